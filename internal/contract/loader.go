@@ -13,15 +13,24 @@ var fs embed.FS
 type ToolContract struct {
 	Name           string   `json:"name"`
 	Description    string   `json:"description"`
+	SchemaVersion  string   `json:"schema_version,omitempty"`
+	Mutating       bool     `json:"mutating,omitempty"`
 	RequiredInputs []string `json:"required_inputs"`
 	OptionalInputs []string `json:"optional_inputs,omitempty"`
 }
 
+type Deprecation struct {
+	Name        string `json:"name"`
+	Replacement string `json:"replacement"`
+	Status      string `json:"status"`
+}
+
 var (
 	loadOnce      sync.Once
 	loadErr       error
 	capabilities  map[string]any
 	toolContracts []ToolContract
+	deprecations  []Deprecation
 )
 
 func load() {
@@ -38,6 +47,23 @@ func load() {
 	}
 	capabilities = parsed
 
+	deprecations = []Deprecation{}
+	for _, entry := range asSlice(parsed["deprecations"]) {
+		obj, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		dep := Deprecation{
+			Name:        asString(obj["name"]),
+			Replacement: asString(obj["replacement"]),
+			Status:      asString(obj["status"]),
+		}
+		if dep.Name == "" {
+			continue
+		}
+		deprecations = append(deprecations, dep)
+	}
+
 	toolsAny, ok := parsed["tools"].([]any)
 	if !ok {
 		toolContracts = []ToolContract{}
@@ -51,9 +77,11 @@ func load() {
 			continue
 		}
 		tc := ToolContract{
-			Name:        asString(obj["name"]),
-			Description: asString(obj["description"]),
+			Name:          asString(obj["name"]),
+			Description:   asString(obj["description"]),
+			SchemaVersion: asString(obj["schema_version"]),
 		}
+		tc.Mutating, _ = obj["mutating"].(bool)
 		tc.RequiredInputs = asStringSlice(obj["required_inputs"])
 		tc.OptionalInputs = asStringSlice(obj["optional_inputs"])
 		if tc.Name == "" {
@@ -69,6 +97,14 @@ func asString(value any) string {
 	return s
 }
 
+func asSlice(value any) []any {
+	values, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	return values
+}
+
 func asStringSlice(value any) []string {
 	values, ok := value.([]any)
 	if !ok {
@@ -112,3 +148,13 @@ func ToolContracts() ([]ToolContract, error) {
 	out = append(out, toolContracts...)
 	return out, nil
 }
+
+func Deprecations() ([]Deprecation, error) {
+	loadOnce.Do(load)
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	out := make([]Deprecation, 0, len(deprecations))
+	out = append(out, deprecations...)
+	return out, nil
+}
@@ -45,3 +45,15 @@ func TestToolContractsIncludeCoreTools(t *testing.T) {
 		}
 	}
 }
+
+func TestToolContractsDeclareSchemaVersion(t *testing.T) {
+	tools, err := ToolContracts()
+	if err != nil {
+		t.Fatalf("ToolContracts returned error: %v", err)
+	}
+	for _, tool := range tools {
+		if tool.SchemaVersion == "" {
+			t.Fatalf("expected tool contract %q to declare schema_version", tool.Name)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package mcp
+
+import (
+	"github.com/neosh11/visa-jobs-mcp/internal/contract"
+	"github.com/neosh11/visa-jobs-mcp/internal/user"
+)
+
+// auditExemptTools are mutating tools that are still not audited.
+// delete_user_data wipes the user's audit trail, and recording the call would
+// immediately recreate it.
+var auditExemptTools = map[string]struct{}{
+	"delete_user_data": {},
+}
+
+// withAudit records every call to a tool marked mutating in the contract.
+func withAudit(tool contract.ToolContract, handler toolHandler) toolHandler {
+	if !tool.Mutating {
+		return handler
+	}
+	if _, exempt := auditExemptTools[tool.Name]; exempt {
+		return handler
+	}
+	return func(input map[string]any) (map[string]any, error) {
+		payload, err := handler(input)
+		_ = user.RecordToolAudit(tool.Name, input, err)
+		return payload, err
+	}
+}
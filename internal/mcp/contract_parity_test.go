@@ -1,9 +1,12 @@
 package mcp
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/neosh11/visa-jobs-mcp/internal/contract"
+	"github.com/neosh11/visa-jobs-mcp/internal/user"
 )
 
 func TestAllContractToolsHaveHandlers(t *testing.T) {
@@ -17,3 +20,47 @@ func TestAllContractToolsHaveHandlers(t *testing.T) {
 		}
 	}
 }
+
+// writePrefixes name the tools that change stored state; each must be marked
+// mutating in the contract so withAudit records it.
+var writePrefixes = []string{
+	"set_", "clear_", "add_", "delete_", "save_", "ignore_", "unignore_",
+	"mark_", "update_", "bulk_", "schedule_", "record_", "log_", "attach_",
+	"merge_", "import_", "start_", "cancel_", "continue_", "rescore_",
+}
+
+func TestMutatingToolsAreAudited(t *testing.T) {
+	t.Setenv("VISA_AUDIT_LOG_PATH", filepath.Join(t.TempDir(), "audit_log.json"))
+
+	tools, err := contract.ToolContracts()
+	if err != nil {
+		t.Fatalf("ToolContracts failed: %v", err)
+	}
+	stub := func(map[string]any) (map[string]any, error) {
+		return map[string]any{}, nil
+	}
+	for _, tc := range tools {
+		for _, prefix := range writePrefixes {
+			if strings.HasPrefix(tc.Name, prefix) && !tc.Mutating {
+				t.Fatalf("expected tool %q to be marked mutating", tc.Name)
+			}
+		}
+		if !tc.Mutating {
+			continue
+		}
+		// delete_user_data is the only exemption; see auditExemptTools.
+		if tc.Name == "delete_user_data" {
+			continue
+		}
+		if _, err := withAudit(tc, stub)(map[string]any{"user_id": "audit-check"}); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.Name, err)
+		}
+		listed, err := user.ListAuditEvents(map[string]any{"user_id": "audit-check", "tool_name": tc.Name})
+		if err != nil {
+			t.Fatalf("ListAuditEvents failed: %v", err)
+		}
+		if events, _ := listed["events"].([]any); len(events) != 1 {
+			t.Fatalf("expected mutating tool %q to be audited, got %#v", tc.Name, listed["events"])
+		}
+	}
+}
@@ -40,6 +40,9 @@ func inputPropertySchema(name string) map[string]any {
 	if schema, ok := arrayStringFields[name]; ok {
 		return schema
 	}
+	if schema, ok := arrayIntegerFields[name]; ok {
+		return schema
+	}
 	if schema, ok := booleanFields[name]; ok {
 		return schema
 	}
@@ -49,68 +52,249 @@ func inputPropertySchema(name string) map[string]any {
 	if schema, ok := stringFields[name]; ok {
 		return schema
 	}
+	if schema, ok := objectFields[name]; ok {
+		return schema
+	}
 	return map[string]any{}
 }
 
 var stringFields = map[string]map[string]any{
-	"applied_at_utc":  {"type": "string"},
-	"command":         {"type": "string"},
-	"company_name":    {"type": "string"},
-	"context":         {"type": "string"},
-	"dataset_path":    {"type": "string"},
-	"job_title":       {"type": "string"},
-	"job_url":         {"type": "string"},
-	"location":        {"type": "string"},
-	"manifest_path":   {"type": "string"},
-	"note":            {"type": "string"},
-	"performance_url": {"type": "string"},
-	"reason":          {"type": "string"},
-	"recipient_email": {"type": "string"},
-	"recipient_name":  {"type": "string"},
-	"recipient_title": {"type": "string"},
-	"result_id":       {"type": "string"},
-	"run_id":          {"type": "string"},
-	"session_id":      {"type": "string"},
-	"site":            {"type": "string"},
-	"source":          {"type": "string"},
-	"stage":           {"type": "string"},
-	"strictness_mode": {"type": "string"},
-	"tone":            {"type": "string"},
-	"user_id":         {"type": "string"},
+	"accept_language":       {"type": "string"},
+	"alias":                 {"type": "string"},
+	"applied_at_utc":        {"type": "string"},
+	"channel":               {"type": "string"},
+	"company_name":          {"type": "string"},
+	"context":               {"type": "string"},
+	"currency":              {"type": "string"},
+	"dataset_path":          {"type": "string"},
+	"decision_deadline":     {"type": "string"},
+	"default_stage":         {"type": "string"},
+	"diff_against_run_id":   {"type": "string"},
+	"direction":             {"type": "string"},
+	"due_at_utc":            {"type": "string"},
+	"e_verify_path":         {"type": "string"},
+	"email":                 {"type": "string"},
+	"format":                {"type": "string"},
+	"geo_id":                {"type": "string"},
+	"interview_type":        {"type": "string"},
+	"interviewer":           {"type": "string"},
+	"job_title":             {"type": "string"},
+	"job_url":               {"type": "string"},
+	"jsessionid":            {"type": "string"},
+	"kind":                  {"type": "string"},
+	"lca_source":            {"type": "string"},
+	"li_at":                 {"type": "string"},
+	"linkedin_host":         {"type": "string"},
+	"linkedin_url":          {"type": "string"},
+	"location":              {"type": "string"},
+	"manifest_path":         {"type": "string"},
+	"name":                  {"type": "string"},
+	"note":                  {"type": "string"},
+	"occurred_at_utc":       {"type": "string"},
+	"outcome":               {"type": "string"},
+	"output_path":           {"type": "string"},
+	"performance_url":       {"type": "string"},
+	"period":                {"type": "string"},
+	"perm_source":           {"type": "string"},
+	"posted_after":          {"type": "string"},
+	"posted_before":         {"type": "string"},
+	"previous_dataset_path": {"type": "string"},
+	"priority":              {"type": "string"},
+	"raw_dir":               {"type": "string"},
+	"reason":                {"type": "string"},
+	"recipient_email":       {"type": "string"},
+	"recipient_name":        {"type": "string"},
+	"recipient_title":       {"type": "string"},
+	"register":              {"type": "string"},
+	"result_id":             {"type": "string"},
+	"role":                  {"type": "string"},
+	"run_id":                {"type": "string"},
+	"salary_interval":       {"type": "string"},
+	"scheduled_at_utc":      {"type": "string"},
+	"session_id":            {"type": "string"},
+	"site":                  {"type": "string"},
+	"soc_code":              {"type": "string"},
+	"sort_by":               {"type": "string"},
+	"source":                {"type": "string"},
+	"sponsorship_terms":     {"type": "string"},
+	"stage":                 {"type": "string"},
+	"start_date":            {"type": "string"},
+	"status":                {"type": "string"},
+	"strictness_mode":       {"type": "string"},
+	"summary":               {"type": "string"},
+	"tag":                   {"type": "string"},
+	"title":                 {"type": "string"},
+	"tone":                  {"type": "string"},
+	"tool_name":             {"type": "string"},
+	"url_or_path":           {"type": "string"},
+	"user_id":               {"type": "string"},
+	"visa_type":             {"type": "string"},
 }
 
 var integerFields = map[string]map[string]any{
-	"cursor":             {"type": "integer"},
-	"days_remaining":     {"type": "integer"},
-	"hours_old":          {"type": "integer"},
-	"ignored_company_id": {"type": "integer"},
-	"ignored_job_id":     {"type": "integer"},
-	"job_id":             {"type": "integer"},
-	"limit":              {"type": "integer"},
-	"line_id":            {"type": "integer"},
-	"max_returned":       {"type": "integer"},
-	"max_scan_results":   {"type": "integer"},
-	"offset":             {"type": "integer"},
-	"results_wanted":     {"type": "integer"},
-	"saved_job_id":       {"type": "integer"},
-	"scan_multiplier":    {"type": "integer"},
+	"artifact_id":                        {"type": "integer"},
+	"base_salary":                        {"type": "integer"},
+	"bonus":                              {"type": "integer"},
+	"contact_id":                         {"type": "integer"},
+	"cursor":                             {"type": "integer"},
+	"days_ahead":                         {"type": "integer"},
+	"days_remaining":                     {"type": "integer"},
+	"due_in_days":                        {"type": "integer"},
+	"equity_annual_value":                {"type": "integer"},
+	"hours_old":                          {"type": "integer"},
+	"ignored_company_id":                 {"type": "integer"},
+	"ignored_job_id":                     {"type": "integer"},
+	"interview_id":                       {"type": "integer"},
+	"job_id":                             {"type": "integer"},
+	"keep_job_id":                        {"type": "integer"},
+	"limit":                              {"type": "integer"},
+	"line_id":                            {"type": "integer"},
+	"max_bytes":                          {"type": "integer"},
+	"max_checks":                         {"type": "integer"},
+	"max_company_page_fetches":           {"type": "integer"},
+	"max_description_fetches":            {"type": "integer"},
+	"max_jobs":                           {"type": "integer"},
+	"max_results_per_company":            {"type": "integer"},
+	"max_returned":                       {"type": "integer"},
+	"max_runtime_seconds":                {"type": "integer"},
+	"max_scan_results":                   {"type": "integer"},
+	"merge_job_id":                       {"type": "integer"},
+	"min_lca_wage":                       {"type": "integer"},
+	"min_salary":                         {"type": "integer"},
+	"offset":                             {"type": "integer"},
+	"rate_limit_initial_backoff_seconds": {"type": "integer"},
+	"rate_limit_max_backoff_seconds":     {"type": "integer"},
+	"rate_limit_retry_window_seconds":    {"type": "integer"},
+	"reminder_id":                        {"type": "integer"},
+	"request_timeout_seconds":            {"type": "integer"},
+	"results_wanted":                     {"type": "integer"},
+	"round":                              {"type": "integer"},
+	"saved_job_id":                       {"type": "integer"},
+	"scan_multiplier":                    {"type": "integer"},
+	"sign_on_bonus":                      {"type": "integer"},
+	"target_count":                       {"type": "integer"},
+	"timeout_seconds":                    {"type": "integer"},
 }
 
 var booleanFields = map[string]map[string]any{
+	"cap_exempt_only":            {"type": "boolean"},
 	"clear_all_for_user":         {"type": "boolean"},
 	"confirm":                    {"type": "boolean"},
+	"create_missing_dirs":        {"type": "boolean"},
+	"diff_against_last_run":      {"type": "boolean"},
+	"dry_run":                    {"type": "boolean"},
+	"enabled":                    {"type": "boolean"},
+	"enforce_constraints":        {"type": "boolean"},
+	"enrich_company_pages":       {"type": "boolean"},
+	"exclude_staffing_agencies":  {"type": "boolean"},
+	"force":                      {"type": "boolean"},
+	"hide_previously_seen":       {"type": "boolean"},
+	"include_decided":            {"type": "boolean"},
+	"overwrite":                  {"type": "boolean"},
+	"probe_linkedin":             {"type": "boolean"},
 	"refresh_session":            {"type": "boolean"},
 	"require_description_signal": {"type": "boolean"},
+	"require_gc_track":           {"type": "boolean"},
+	"reset_to_default":           {"type": "boolean"},
+	"resolve_geo_id":             {"type": "boolean"},
+	"sponsors_visa":              {"type": "boolean"},
+	"strict_validation":          {"type": "boolean"},
 	"willing_to_relocate":        {"type": "boolean"},
 }
 
 var arrayStringFields = map[string]map[string]any{
+	"blocked_transitions": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"company_names": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"company_tiers": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"dataset_paths": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"exclude_keywords": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"exclude_title_keywords": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"job_levels": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"job_types": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"must_include_keywords": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"negative_patterns": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"positive_patterns": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
 	"preferred_visa_types": {
 		"type":  "array",
 		"items": map[string]any{"type": "string"},
 	},
+	"remove_tags": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"result_ids": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"staffing_agency_patterns": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"stages": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"tags": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+	"urls": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
 	"work_modes": {
 		"type":  "array",
 		"items": map[string]any{"type": "string"},
 	},
+	"workplace_types": {
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	},
+}
+
+var arrayIntegerFields = map[string]map[string]any{
+	"job_ids": {
+		"type":  "array",
+		"items": map[string]any{"type": "integer"},
+	},
+}
+
+var objectFields = map[string]map[string]any{
+	"column_mapping":       {"type": "object"},
+	"company_tier_weights": {"type": "object"},
+	"ranking_weights":      {"type": "object"},
+	"visa_type_weights":    {"type": "object"},
 }
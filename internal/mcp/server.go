@@ -26,12 +26,19 @@ var Version = "0.3.1"
 
 var implementedToolHandlers = map[string]toolHandler{
 	"get_mcp_capabilities":                getMCPCapabilities,
+	"run_demo_search":                     user.RunDemoSearch,
 	"set_user_preferences":                user.SetUserPreferences,
 	"set_user_constraints":                user.SetUserConstraints,
+	"set_visa_signal_patterns":            user.SetVisaSignalPatterns,
 	"get_user_preferences":                user.GetUserPreferences,
 	"get_user_readiness":                  user.GetUserReadiness,
+	"doctor_environment":                  user.DoctorEnvironment,
+	"set_linkedin_session":                user.SetLinkedInSession,
+	"clear_linkedin_session":              user.ClearLinkedInSession,
+	"get_server_health":                   user.GetServerHealth,
 	"find_related_titles":                 user.FindRelatedTitles,
 	"get_best_contact_strategy":           user.GetBestContactStrategy,
+	"get_visa_type_info":                  user.GetVisaTypeInfo,
 	"generate_outreach_message":           user.GenerateOutreachMessage,
 	"add_user_memory_line":                user.AddUserMemoryLine,
 	"query_user_memory_blob":              user.QueryUserMemoryBlob,
@@ -41,6 +48,7 @@ var implementedToolHandlers = map[string]toolHandler{
 	"save_job_for_later":                  user.SaveJobForLater,
 	"list_saved_jobs":                     user.ListSavedJobs,
 	"delete_saved_job":                    user.DeleteSavedJob,
+	"rescore_saved_jobs":                  user.RescoreSavedJobs,
 	"ignore_job":                          user.IgnoreJob,
 	"list_ignored_jobs":                   user.ListIgnoredJobs,
 	"unignore_job":                        user.UnignoreJob,
@@ -49,22 +57,57 @@ var implementedToolHandlers = map[string]toolHandler{
 	"unignore_company":                    user.UnignoreCompany,
 	"mark_job_applied":                    user.MarkJobApplied,
 	"update_job_stage":                    user.UpdateJobStage,
+	"bulk_update_job_stage":               user.BulkUpdateJobStage,
 	"list_jobs_by_stage":                  user.ListJobsByStage,
 	"add_job_note":                        user.AddJobNote,
 	"list_recent_job_events":              user.ListRecentJobEvents,
 	"get_job_pipeline_summary":            user.GetJobPipelineSummary,
+	"set_stage_transition_rules":          user.SetStageTransitionRules,
+	"schedule_interview":                  user.ScheduleInterview,
+	"list_upcoming_interviews":            user.ListUpcomingInterviews,
+	"set_followup_reminder":               user.SetFollowupReminder,
+	"list_due_followups":                  user.ListDueFollowups,
+	"record_job_offer":                    user.RecordJobOffer,
+	"compare_offers":                      user.CompareOffers,
+	"add_job_contact":                     user.AddJobContact,
+	"log_contact_interaction":             user.LogContactInteraction,
+	"list_job_contacts":                   user.ListJobContacts,
+	"check_job_still_open":                user.CheckJobStillOpen,
+	"check_pipeline_jobs_still_open":      user.CheckPipelineJobsStillOpen,
+	"add_job_tags":                        user.AddJobTags,
+	"list_jobs_by_tag":                    user.ListJobsByTag,
+	"attach_job_artifact":                 user.AttachJobArtifact,
+	"merge_pipeline_jobs":                 user.MergePipelineJobs,
+	"set_application_goal":                user.SetApplicationGoal,
+	"import_pipeline_csv":                 user.ImportPipelineCSV,
+	"get_job_timeline":                    user.GetJobTimeline,
+	"list_audit_events":                   user.ListAuditEvents,
 	"clear_search_session":                user.ClearSearchSession,
 	"refresh_company_dataset_cache":       user.RefreshCompanyDatasetCache,
+	"validate_company_dataset":            user.ValidateCompanyDataset,
+	"list_company_name_collisions":        user.ListCompanyNameCollisions,
+	"get_dataset_refresh_history":         user.GetDatasetRefreshHistory,
+	"get_dataset_changes":                 user.GetDatasetChanges,
+	"add_company_alias":                   user.AddCompanyAlias,
+	"get_salary_benchmark":                user.GetSalaryBenchmark,
+	"get_company_sponsorship_profile":     user.GetCompanySponsorshipProfile,
+	"check_company_sponsorship":           user.CheckCompanySponsorship,
 	"start_job_search":                    user.StartJobSearch,
 	"get_job_search_status":               user.GetJobSearchStatus,
 	"get_job_search_results":              user.GetJobSearchResults,
 	"cancel_job_search":                   user.CancelJobSearch,
+	"continue_job_search":                 user.ContinueJobSearch,
 	"start_visa_job_search":               user.StartVisaJobSearch,
 	"get_visa_job_search_status":          user.GetVisaJobSearchStatus,
 	"get_visa_job_search_results":         user.GetVisaJobSearchResults,
 	"cancel_visa_job_search":              user.CancelVisaJobSearch,
+	"render_results_report":               user.RenderResultsReport,
+	"export_search_results":               user.ExportSearchResults,
 	"discover_latest_dol_disclosure_urls": user.DiscoverLatestDolDisclosureURLs,
+	"download_dol_disclosures":            user.DownloadDolDisclosures,
 	"run_internal_dol_pipeline":           user.RunInternalDolPipeline,
+	"import_sponsor_register":             user.ImportSponsorRegister,
+	"import_e_verify_employers":           user.ImportEVerifyEmployers,
 }
 
 func Run(in io.Reader, out io.Writer) error {
@@ -72,6 +115,8 @@ func Run(in io.Reader, out io.Writer) error {
 	if err != nil {
 		return err
 	}
+	user.StartDatasetRefresher()
+	user.ResumeSearchRuns()
 	err = server.Run(context.Background(), &mcpSDK.IOTransport{
 		Reader: asReadCloser(in),
 		Writer: asWriteCloser(out),
@@ -110,7 +155,7 @@ func newServer() (*mcpSDK.Server, error) {
 	}
 	for _, tc := range tools {
 		tool := tc
-		handler := resolveToolHandler(tool.Name)
+		handler := withAudit(tool, resolveToolHandler(tool.Name))
 		mcpSDK.AddTool(server, &mcpSDK.Tool{
 			Name:        tool.Name,
 			Description: tool.Description,
@@ -169,9 +214,71 @@ func getMCPCapabilities(_ map[string]any) (map[string]any, error) {
 		return nil, fmt.Errorf("failed to load capabilities: %w", err)
 	}
 	payload["version"] = Version
+	if err := annotateToolLifecycle(payload); err != nil {
+		return nil, fmt.Errorf("failed to load capabilities: %w", err)
+	}
+	payload["layout_health"] = user.LayoutHealth()
 	return payload, nil
 }
 
+func annotateToolLifecycle(payload map[string]any) error {
+	tools, err := contract.ToolContracts()
+	if err != nil {
+		return err
+	}
+	deprecations, err := contract.Deprecations()
+	if err != nil {
+		return err
+	}
+	byName := map[string]contract.Deprecation{}
+	for _, dep := range deprecations {
+		byName[dep.Name] = dep
+	}
+	schemaVersions := map[string]string{}
+	for _, tool := range tools {
+		schemaVersions[tool.Name] = tool.SchemaVersion
+	}
+
+	rawTools, _ := payload["tools"].([]any)
+	for _, raw := range rawTools {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := entry["name"].(string)
+		entry["schema_version"] = schemaVersions[name]
+		dep, deprecated := byName[name]
+		entry["deprecated"] = deprecated
+		if deprecated {
+			entry["deprecation_status"] = dep.Status
+			entry["replacement"] = dep.Replacement
+		} else {
+			entry["deprecation_status"] = nil
+			entry["replacement"] = nil
+		}
+	}
+
+	migrations := map[string]any{}
+	annotated := make([]any, 0, len(deprecations))
+	for _, dep := range deprecations {
+		_, exposed := schemaVersions[dep.Name]
+		_, replacementExposed := schemaVersions[dep.Replacement]
+		annotated = append(annotated, map[string]any{
+			"name":                dep.Name,
+			"replacement":         dep.Replacement,
+			"status":              dep.Status,
+			"still_exposed":       exposed,
+			"replacement_exposed": replacementExposed,
+		})
+		if dep.Replacement != "" {
+			migrations[dep.Name] = dep.Replacement
+		}
+	}
+	payload["deprecations"] = annotated
+	payload["tool_migrations"] = migrations
+	return nil
+}
+
 func asReadCloser(in io.Reader) io.ReadCloser {
 	if rc, ok := in.(io.ReadCloser); ok {
 		return rc
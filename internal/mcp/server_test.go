@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"slices"
@@ -102,6 +103,47 @@ func TestCapabilitiesVersionUsesRuntimeVersion(t *testing.T) {
 	}
 }
 
+func TestCapabilitiesIncludeToolLifecycleMetadata(t *testing.T) {
+	payload, err := getMCPCapabilities(map[string]any{})
+	if err != nil {
+		t.Fatalf("getMCPCapabilities failed: %v", err)
+	}
+	tools, _ := payload["tools"].([]any)
+	if len(tools) == 0 {
+		t.Fatal("expected tools in capabilities payload")
+	}
+	for _, raw := range tools {
+		tool := toMap(raw)
+		if got := getStringFromAnyMap(tool, "schema_version"); got == "" {
+			t.Fatalf("expected schema_version on tool %#v", tool["name"])
+		}
+		if _, ok := tool["deprecated"].(bool); !ok {
+			t.Fatalf("expected deprecated flag on tool %#v", tool["name"])
+		}
+	}
+	migrations := toMap(payload["tool_migrations"])
+	if got := getStringFromAnyMap(migrations, "find_visa_sponsored_jobs"); got != "start_visa_job_search" {
+		t.Fatalf("expected find_visa_sponsored_jobs -> start_visa_job_search migration, got %q", got)
+	}
+	foundLegacy := false
+	for _, raw := range payload["deprecations"].([]any) {
+		dep := toMap(raw)
+		if getStringFromAnyMap(dep, "name") != "find_visa_sponsored_jobs" {
+			continue
+		}
+		foundLegacy = true
+		if exposed, _ := dep["still_exposed"].(bool); exposed {
+			t.Fatalf("legacy tool should not be exposed: %#v", dep)
+		}
+		if exposed, _ := dep["replacement_exposed"].(bool); !exposed {
+			t.Fatalf("replacement tool should be exposed: %#v", dep)
+		}
+	}
+	if !foundLegacy {
+		t.Fatal("expected find_visa_sponsored_jobs deprecation entry")
+	}
+}
+
 func TestCallPortedTools(t *testing.T) {
 	tmpDir := t.TempDir()
 	prefsPath := filepath.Join(tmpDir, "prefs.json")
@@ -179,6 +221,7 @@ func TestCallJobManagementTool(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("VISA_SAVED_JOBS_PATH", filepath.Join(tmpDir, "saved_jobs.json"))
 	t.Setenv("VISA_JOB_DB_PATH", filepath.Join(tmpDir, "job_pipeline.json"))
+	t.Setenv("VISA_AUDIT_LOG_PATH", filepath.Join(tmpDir, "audit_log.json"))
 
 	_, session, cleanup := connectTestSession(t)
 	defer cleanup()
@@ -202,6 +245,22 @@ func TestCallJobManagementTool(t *testing.T) {
 	if got := getStringFromAnyMap(structured, "action"); got != "saved_new" {
 		t.Fatalf("expected action=saved_new, got %q", got)
 	}
+
+	auditResult, err := session.CallTool(context.Background(), &mcpSDK.CallToolParams{
+		Name:      "list_audit_events",
+		Arguments: map[string]any{"user_id": "default"},
+	})
+	if err != nil {
+		t.Fatalf("list_audit_events call failed: %v", err)
+	}
+	audit, _ := auditResult.StructuredContent.(map[string]any)
+	events, _ := audit["events"].([]any)
+	if len(events) != 1 {
+		t.Fatalf("expected one audit event, got %#v", audit)
+	}
+	if got := getStringFromAnyMap(toMap(events[0]), "tool_name"); got != "save_job_for_later" {
+		t.Fatalf("expected audited tool save_job_for_later, got %q", got)
+	}
 }
 
 func TestConcurrentSaveJobForLaterMaintainsAllRows(t *testing.T) {
@@ -346,7 +405,7 @@ func connectTestSession(t *testing.T) (*mcpSDK.Server, *mcpSDK.ClientSession, fu
 		cancel()
 		select {
 		case err := <-serverErr:
-			if err != nil && !errors.Is(err, context.Canceled) && !strings.Contains(strings.ToLower(err.Error()), "closing") {
+			if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, io.ErrClosedPipe) && !strings.Contains(strings.ToLower(err.Error()), "closing") {
 				t.Fatalf("server.Run returned unexpected error: %v", err)
 			}
 		case <-time.After(2 * time.Second):
@@ -367,6 +426,16 @@ func ensureMCPTestPaths(t *testing.T) {
 	setEnvIfUnset(t, "VISA_SEARCH_SESSION_PATH", filepath.Join(root, "search_sessions.json"))
 	setEnvIfUnset(t, "VISA_SEARCH_RUNS_PATH", filepath.Join(root, "search_runs.json"))
 	setEnvIfUnset(t, "VISA_JOB_DB_PATH", filepath.Join(root, "job_pipeline.json"))
+	setEnvIfUnset(t, "VISA_AUDIT_LOG_PATH", filepath.Join(root, "audit_log.json"))
+	setEnvIfUnset(t, "VISA_LAYOUT_BASELINE_PATH", filepath.Join(root, "layout_baseline.json"))
+	setEnvIfUnset(t, "VISA_DESCRIPTION_CACHE_PATH", filepath.Join(root, "description_cache.json"))
+	setEnvIfUnset(t, "VISA_GEO_ID_CACHE_PATH", filepath.Join(root, "geo_id_cache.json"))
+	setEnvIfUnset(t, "VISA_LINKEDIN_SESSION_PATH", filepath.Join(root, "linkedin_session.json"))
+	setEnvIfUnset(t, "VISA_COMPANY_PAGE_CACHE_PATH", filepath.Join(root, "company_page_cache.json"))
+	setEnvIfUnset(t, "VISA_COMPANY_ALIASES_PATH", filepath.Join(root, "company_aliases.json"))
+	setEnvIfUnset(t, "VISA_DATASET_VALIDATION_PATH", filepath.Join(root, "dataset_validations.json"))
+	setEnvIfUnset(t, "VISA_DATASET_REFRESH_HISTORY_PATH", filepath.Join(root, "refresh_history.json"))
+	setEnvIfUnset(t, "VISA_E_VERIFY_PATH", filepath.Join(root, "e_verify.csv"))
 }
 
 func setEnvIfUnset(t *testing.T, key, value string) {
@@ -6,11 +6,21 @@ import (
 )
 
 var visaTypeLabels = map[string]string{
-	"h1b":            "H-1B",
-	"h1b1_chile":     "H-1B1 Chile",
-	"h1b1_singapore": "H-1B1 Singapore",
-	"e3_australian":  "E-3 Australian",
-	"green_card":     "Green Card",
+	"h1b":               "H-1B",
+	"h1b1_chile":        "H-1B1 Chile",
+	"h1b1_singapore":    "H-1B1 Singapore",
+	"e3_australian":     "E-3 Australian",
+	"green_card":        "Green Card",
+	"skilled_worker_uk": "UK Skilled Worker",
+	"au_482":            "AU 482 Skills in Demand",
+	"au_186":            "AU 186 Employer Nomination",
+	"ca_lmia":           "Canada LMIA Work Permit",
+	"ca_lmia_pr":        "Canada LMIA Permanent Residence",
+	"tn":                "TN (USMCA)",
+	"o1":                "O-1 Extraordinary Ability",
+	"f1_opt":            "F-1 OPT/CPT",
+	"stem_opt":          "STEM OPT",
+	"l1":                "L-1 Intracompany Transfer",
 }
 
 var relatedTitleHints = map[string][]string{
@@ -129,18 +139,6 @@ func FindRelatedTitles(args map[string]any) (map[string]any, error) {
 	}, nil
 }
 
-func getFirstContact(resolved map[string]any) map[string]any {
-	contacts := listOrEmpty(resolved["employer_contacts"])
-	if len(contacts) == 0 {
-		return map[string]any{}
-	}
-	first := mapOrNil(contacts[0])
-	if first == nil {
-		return map[string]any{}
-	}
-	return first
-}
-
 func GetBestContactStrategy(args map[string]any) (map[string]any, error) {
 	userID := getString(args, "user_id")
 	if userID == "" {
@@ -151,7 +149,7 @@ func GetBestContactStrategy(args map[string]any) (map[string]any, error) {
 		return nil, err
 	}
 
-	primary := getFirstContact(resolved)
+	primary, quality, flags, emailUsable, phoneUsable := bestContact(listOrEmpty(resolved["employer_contacts"]))
 	primaryName := getString(primary, "name")
 	primaryTitle := getString(primary, "title")
 	primaryEmail := getString(primary, "email")
@@ -163,14 +161,14 @@ func GetBestContactStrategy(args map[string]any) (map[string]any, error) {
 		"Find the recruiter/hiring manager on LinkedIn and send a short intro note.",
 		"Track this role in saved jobs and follow up in 3-5 days.",
 	}
-	if primaryEmail != "" {
+	if emailUsable {
 		channel = "email"
 		strategy = []string{
 			"Send a short intro email referencing role fit and visa type.",
 			"Attach or link a targeted resume with matching skills.",
 			"Follow up once in 48 hours if no response.",
 		}
-	} else if primaryPhone != "" {
+	} else if phoneUsable {
 		channel = "phone"
 		strategy = []string{
 			"Call during business hours and ask for recruiter/hiring manager routing.",
@@ -190,13 +188,15 @@ func GetBestContactStrategy(args map[string]any) (map[string]any, error) {
 		},
 		"recommended_channel": channel,
 		"primary_contact": map[string]any{
-			"name":  primaryName,
-			"title": primaryTitle,
-			"email": primaryEmail,
-			"phone": primaryPhone,
+			"name":            primaryName,
+			"title":           primaryTitle,
+			"email":           primaryEmail,
+			"phone":           primaryPhone,
+			"contact_quality": quality,
+			"quality_flags":   flags,
 		},
 		"strategy_steps":       strategy,
-		"non_legal_disclaimer": "Guidance is informational only and not legal advice.",
+		"non_legal_disclaimer": nonLegalDisclaimer,
 	}, nil
 }
 
@@ -302,7 +302,7 @@ func GenerateOutreachMessage(args map[string]any) (map[string]any, error) {
 }
 
 func RefreshCompanyDatasetCache(args map[string]any) (map[string]any, error) {
-	datasetPath := datasetPathOrDefault(getString(args, "dataset_path"))
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
 	clearDatasetCache(datasetPath)
 	dataset, err := loadCompanyDataset(datasetPath)
 	if err != nil {
@@ -313,6 +313,8 @@ func RefreshCompanyDatasetCache(args map[string]any) (map[string]any, error) {
 		"dataset_path":                  datasetPath,
 		"rows":                          dataset.Rows,
 		"distinct_normalized_companies": len(dataset.ByNormalizedCompany),
+		"companies_by_source":           datasetSourceCounts(dataset),
+		"name_collisions":               len(dataset.NameCollisions),
 		"cache_refreshed":               true,
 	}, nil
 }
@@ -0,0 +1,160 @@
+package user
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultAuditLogPath   = "data/config/audit_log.json"
+	maxAuditEventsPerUser = 1000
+	auditOutcomeSuccess   = "success"
+	auditOutcomeError     = "error"
+	maxAuditErrorLength   = 300
+)
+
+var auditLogMu sync.Mutex
+
+func auditLogPath() string {
+	return envOrDefault("VISA_AUDIT_LOG_PATH", defaultAuditLogPath)
+}
+
+func hashToolArgs(args map[string]any) string {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		raw = []byte(fmt.Sprint(args))
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func ensureAuditEntry(data map[string]any, userID string) map[string]any {
+	users := ensureUsersMap(data)
+	entry := mapOrNil(users[userID])
+	if entry == nil {
+		entry = map[string]any{}
+		users[userID] = entry
+	}
+	events := listOrEmpty(entry["events"])
+	entry["events"] = events
+	nextID, ok := intFromAny(entry["next_id"])
+	if !ok || nextID < 1 {
+		nextID = 1
+	}
+	for _, raw := range events {
+		if id, ok := intFromAny(mapOrNil(raw)["id"]); ok && id >= nextID {
+			nextID = id + 1
+		}
+	}
+	entry["next_id"] = nextID
+	return entry
+}
+
+func RecordToolAudit(toolName string, args map[string]any, callErr error) error {
+	userID := getString(args, "user_id")
+	if userID == "" || toolName == "" {
+		return nil
+	}
+
+	auditLogMu.Lock()
+	defer auditLogMu.Unlock()
+
+	data := loadUserScopedStore(auditLogPath())
+	entry := ensureAuditEntry(data, userID)
+	nextID, _ := intFromAny(entry["next_id"])
+
+	event := map[string]any{
+		"id":             nextID,
+		"tool_name":      toolName,
+		"args_hash":      hashToolArgs(args),
+		"outcome":        auditOutcomeSuccess,
+		"error":          nil,
+		"created_at_utc": utcNowISO(),
+	}
+	if callErr != nil {
+		message := callErr.Error()
+		if len(message) > maxAuditErrorLength {
+			message = message[:maxAuditErrorLength]
+		}
+		event["outcome"] = auditOutcomeError
+		event["error"] = message
+	}
+
+	events := append(listOrEmpty(entry["events"]), event)
+	if len(events) > maxAuditEventsPerUser {
+		events = events[len(events)-maxAuditEventsPerUser:]
+	}
+	entry["events"] = events
+	entry["next_id"] = nextID + 1
+	return saveUserScopedStore(auditLogPath(), data)
+}
+
+func ListAuditEvents(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	limit := 50
+	if parsed, has, err := getOptionalInt(args, "limit"); has {
+		if err != nil {
+			return nil, fmt.Errorf("limit must be an integer when provided")
+		}
+		if parsed < 1 {
+			parsed = 1
+		}
+		if parsed > 200 {
+			parsed = 200
+		}
+		limit = parsed
+	}
+	offset := 0
+	if parsed, has, err := getOptionalInt(args, "offset"); has {
+		if err != nil {
+			return nil, fmt.Errorf("offset must be an integer when provided")
+		}
+		if parsed < 0 {
+			parsed = 0
+		}
+		offset = parsed
+	}
+	toolFilter := getString(args, "tool_name")
+	outcomeFilter := getString(args, "outcome")
+	if outcomeFilter != "" && outcomeFilter != auditOutcomeSuccess && outcomeFilter != auditOutcomeError {
+		return nil, fmt.Errorf("outcome must be one of [error success]")
+	}
+
+	auditLogMu.Lock()
+	stored := getUserList(auditLogPath(), userID, "events")
+	auditLogMu.Unlock()
+
+	matched := make([]any, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		event := mapOrNil(stored[i])
+		if event == nil {
+			continue
+		}
+		if toolFilter != "" && getString(event, "tool_name") != toolFilter {
+			continue
+		}
+		if outcomeFilter != "" && getString(event, "outcome") != outcomeFilter {
+			continue
+		}
+		matched = append(matched, event)
+	}
+
+	start := min(offset, len(matched))
+	end := min(start+limit, len(matched))
+	page := matched[start:end]
+	return map[string]any{
+		"user_id":         userID,
+		"offset":          offset,
+		"limit":           limit,
+		"total_events":    len(matched),
+		"returned_events": len(page),
+		"events":          page,
+		"audit_log_path":  auditLogPath(),
+	}, nil
+}
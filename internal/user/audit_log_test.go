@@ -0,0 +1,67 @@
+package user
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecordToolAuditAndListAuditEvents(t *testing.T) {
+	setupUserToolPaths(t)
+
+	args := map[string]any{"user_id": "u1", "job_id": 3, "stage": "interview"}
+	if err := RecordToolAudit("update_job_stage", args, nil); err != nil {
+		t.Fatalf("RecordToolAudit failed: %v", err)
+	}
+	if err := RecordToolAudit("add_job_note", map[string]any{"user_id": "u1"}, errors.New("note is required")); err != nil {
+		t.Fatalf("RecordToolAudit failed: %v", err)
+	}
+	if err := RecordToolAudit("update_job_stage", map[string]any{"stage": "offer"}, nil); err != nil {
+		t.Fatalf("RecordToolAudit without user_id should be a no-op: %v", err)
+	}
+
+	listed, err := ListAuditEvents(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("ListAuditEvents failed: %v", err)
+	}
+	if got := intOrZero(listed["total_events"]); got != 2 {
+		t.Fatalf("expected 2 audit events, got %d", got)
+	}
+	events := listOrEmpty(listed["events"])
+	newest := mapOrNil(events[0])
+	if getString(newest, "tool_name") != "add_job_note" || getString(newest, "outcome") != "error" {
+		t.Fatalf("expected newest event to be failed add_job_note, got %#v", newest)
+	}
+	oldest := mapOrNil(events[1])
+	if getString(oldest, "args_hash") != hashToolArgs(args) {
+		t.Fatalf("expected args_hash to match, got %#v", oldest)
+	}
+	if _, ok := oldest["stage"]; ok {
+		t.Fatalf("expected raw args not to be stored: %#v", oldest)
+	}
+
+	filtered, err := ListAuditEvents(map[string]any{"user_id": "u1", "outcome": "success"})
+	if err != nil {
+		t.Fatalf("ListAuditEvents with outcome filter failed: %v", err)
+	}
+	if got := intOrZero(filtered["total_events"]); got != 1 {
+		t.Fatalf("expected 1 successful audit event, got %d", got)
+	}
+	if _, err := ListAuditEvents(map[string]any{"user_id": "u1", "outcome": "maybe"}); err == nil {
+		t.Fatal("expected invalid outcome filter to fail")
+	}
+
+	deleted, err := DeleteUserData(map[string]any{"user_id": "u1", "confirm": true})
+	if err != nil {
+		t.Fatalf("DeleteUserData failed: %v", err)
+	}
+	if got := intOrZero(asMap(deleted["deleted"])["audit_events"]); got != 2 {
+		t.Fatalf("expected 2 deleted audit events, got %d", got)
+	}
+	after, err := ListAuditEvents(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("ListAuditEvents after delete failed: %v", err)
+	}
+	if got := intOrZero(after["total_events"]); got != 0 {
+		t.Fatalf("expected audit log to be empty after delete, got %d", got)
+	}
+}
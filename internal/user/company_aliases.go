@@ -0,0 +1,121 @@
+package user
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const defaultCompanyAliasesPath = "data/config/company_aliases.json"
+
+// builtinCompanyAliases maps well-known brand names to the legal entity that
+// files LCA/PERM disclosures. User-added aliases take precedence.
+var builtinCompanyAliases = map[string]string{
+	"AWS":                 "Amazon.com Services LLC",
+	"Amazon Web Services": "Amazon.com Services LLC",
+	"Meta":                "Facebook",
+	"Google":              "Google LLC",
+	"Alphabet":            "Google LLC",
+	"Microsoft":           "Microsoft Corporation",
+}
+
+func companyAliasesPath() string {
+	return envOrDefault("VISA_COMPANY_ALIASES_PATH", defaultCompanyAliasesPath)
+}
+
+func loadCompanyAliasStore() map[string]any {
+	return loadJSONMap(companyAliasesPath(), map[string]any{"aliases": map[string]any{}})
+}
+
+// loadCompanyAliases returns normalized alias -> normalized dataset company,
+// merging the built-in brand list with the user's aliases file.
+func loadCompanyAliases() map[string]string {
+	out := map[string]string{}
+	for alias, company := range builtinCompanyAliases {
+		out[normalizeCompanyName(alias)] = normalizeCompanyName(company)
+	}
+	for alias, company := range asMap(loadCompanyAliasStore()["aliases"]) {
+		key := normalizeCompanyName(alias)
+		target := normalizeCompanyName(fmt.Sprint(company))
+		if key != "" && target != "" {
+			out[key] = target
+		}
+	}
+	return out
+}
+
+// lookup finds the dataset record for a listing's company, falling back to the
+// alias table so brand names resolve to their sponsoring legal entity.
+func (d companyDataset) lookup(company string) (companyDatasetRecord, bool) {
+	normalized := normalizeCompanyName(company)
+	if normalized == "" {
+		return companyDatasetRecord{}, false
+	}
+	if record, ok := d.ByNormalizedCompany[normalized]; ok {
+		return record, true
+	}
+	if target, ok := d.Aliases[normalized]; ok {
+		record, found := d.ByNormalizedCompany[target]
+		return record, found
+	}
+	return companyDatasetRecord{}, false
+}
+
+func AddCompanyAlias(args map[string]any) (map[string]any, error) {
+	alias := normalizeWhitespace(getString(args, "alias"))
+	companyName := normalizeWhitespace(getString(args, "company_name"))
+	if alias == "" || companyName == "" {
+		return nil, fmt.Errorf("alias and company_name are required")
+	}
+	normalizedAlias := normalizeCompanyName(alias)
+	normalizedCompany := normalizeCompanyName(companyName)
+	if normalizedAlias == "" || normalizedCompany == "" {
+		return nil, fmt.Errorf("alias and company_name must contain letters or digits")
+	}
+	if normalizedAlias == normalizedCompany {
+		return nil, fmt.Errorf("alias %q already normalizes to %q; no alias is needed", alias, companyName)
+	}
+
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		return nil, err
+	}
+	record, found := dataset.ByNormalizedCompany[normalizedCompany]
+	if !found {
+		return nil, fmt.Errorf("company_name %q is not in the sponsor dataset; use the employer name as it appears in companies.csv", companyName)
+	}
+
+	store := loadCompanyAliasStore()
+	aliases := asMap(store["aliases"])
+	action := "added"
+	for existing := range aliases {
+		if normalizeCompanyName(existing) == normalizedAlias {
+			delete(aliases, existing)
+			action = "updated"
+		}
+	}
+	aliases[alias] = record.CompanyName
+	store["aliases"] = aliases
+	store["updated_at_utc"] = utcNowISO()
+	if err := saveJSONMap(companyAliasesPath(), store); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+	return map[string]any{
+		"action":              action,
+		"alias":               alias,
+		"company_name":        record.CompanyName,
+		"visa_counts":         visaCountsWithApproval(visaCountsFromRecord(record), record),
+		"aliases_path":        companyAliasesPath(),
+		"user_aliases":        names,
+		"builtin_alias_count": len(builtinCompanyAliases),
+		"dataset_path":        datasetPath,
+		"next_step":           "Searches and rescore_saved_jobs now match listings under this alias to the sponsoring entity.",
+	}, nil
+}
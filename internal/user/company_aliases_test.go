@@ -0,0 +1,74 @@
+package user
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddCompanyAliasMatchesBrandToDatasetEntity(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	t.Setenv("VISA_COMPANY_DATASET_PATH", datasetPath)
+
+	result, err := AddCompanyAlias(map[string]any{"alias": "Acme Cloud", "company_name": "acme"})
+	if err != nil {
+		t.Fatalf("AddCompanyAlias failed: %v", err)
+	}
+	if result["action"] != "added" || result["company_name"] != "Acme Inc" {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("loadCompanyDataset failed: %v", err)
+	}
+	record, ok := dataset.lookup("Acme Cloud, Inc.")
+	if !ok || record.CompanyName != "Acme Inc" {
+		t.Fatalf("expected alias to resolve to Acme Inc, got %#v ok=%v", record, ok)
+	}
+	facts := companyFacts("Acme Cloud", record, dataset.LatestFiscalYear)
+	if !strings.Contains(strings.Join(facts, " "), "sponsoring entity Acme Inc") {
+		t.Fatalf("expected sponsoring entity fact, got %#v", facts)
+	}
+
+	again, err := AddCompanyAlias(map[string]any{"alias": "acme cloud", "company_name": "Acme Inc"})
+	if err != nil {
+		t.Fatalf("AddCompanyAlias update failed: %v", err)
+	}
+	if again["action"] != "updated" || len(getStringList(again, "user_aliases")) != 1 {
+		t.Fatalf("expected alias update in place, got %#v", again)
+	}
+}
+
+func TestAddCompanyAliasRejectsUnknownCompany(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	t.Setenv("VISA_COMPANY_DATASET_PATH", datasetPath)
+
+	if _, err := AddCompanyAlias(map[string]any{"alias": "Gamma", "company_name": "Gamma Corp"}); err == nil {
+		t.Fatalf("expected error for company missing from dataset")
+	}
+	if _, err := AddCompanyAlias(map[string]any{"alias": "Acme", "company_name": "Acme Inc"}); err == nil {
+		t.Fatalf("expected error when alias normalizes to the company itself")
+	}
+}
+
+func TestCompanyDatasetLookupUsesBuiltinAliases(t *testing.T) {
+	setupUserToolPaths(t)
+	dataset := companyDataset{
+		ByNormalizedCompany: map[string]companyDatasetRecord{
+			normalizeCompanyName("Amazon.com Services LLC"): {CompanyName: "Amazon.com Services LLC", H1B: 100, TotalVisas: 100},
+		},
+		Aliases: loadCompanyAliases(),
+	}
+	record, ok := dataset.lookup("AWS")
+	if !ok || record.H1B != 100 {
+		t.Fatalf("expected AWS to resolve via built-in alias, got %#v ok=%v", record, ok)
+	}
+	if _, ok := dataset.lookup("Unknown Brand"); ok {
+		t.Fatalf("expected unknown company to miss")
+	}
+}
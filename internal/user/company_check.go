@@ -0,0 +1,189 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	maxCheckCompanyNames     = 25
+	maxCheckCandidates       = 5
+	minCandidateSimilarity   = 0.5
+	containedNameSimilarity  = 0.9
+	candidateSimilarityScale = 1000
+)
+
+// nameBigrams returns the character bigrams of a normalized company name,
+// ignoring spaces so "face book" and "facebook" compare equal.
+func nameBigrams(name string) map[string]int {
+	compact := strings.ReplaceAll(name, " ", "")
+	out := map[string]int{}
+	for i := 0; i+1 < len(compact); i++ {
+		out[compact[i:i+2]]++
+	}
+	return out
+}
+
+// nameSimilarity is the Dice coefficient of two names' bigrams, raised for
+// names that contain one another ("acme" and "acme robotics").
+func nameSimilarity(query string, queryBigrams map[string]int, candidate string) float64 {
+	if query == candidate {
+		return 1
+	}
+	if strings.Contains(candidate, query) || strings.Contains(query, candidate) {
+		return containedNameSimilarity
+	}
+	other := nameBigrams(candidate)
+	total, shared := 0, 0
+	for gram, count := range queryBigrams {
+		shared += min(count, other[gram])
+		total += count
+	}
+	for _, count := range other {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(2*shared) / float64(total)
+}
+
+// fuzzyCompanyCandidates ranks dataset employers by name similarity, then by
+// filings for the desired visa types.
+func fuzzyCompanyCandidates(dataset companyDataset, normalized string, desired []string, limit int) []map[string]any {
+	type candidate struct {
+		record     companyDatasetRecord
+		similarity float64
+	}
+	queryBigrams := nameBigrams(normalized)
+	matches := []candidate{}
+	for key, record := range dataset.ByNormalizedCompany {
+		if similarity := nameSimilarity(normalized, queryBigrams, key); similarity >= minCandidateSimilarity {
+			matches = append(matches, candidate{record: record, similarity: similarity})
+		}
+	}
+	slices.SortFunc(matches, func(a, b candidate) int {
+		if a.similarity != b.similarity {
+			if a.similarity > b.similarity {
+				return -1
+			}
+			return 1
+		}
+		if countA, countB := desiredVisaCount(a.record, desired), desiredVisaCount(b.record, desired); countA != countB {
+			return countB - countA
+		}
+		return strings.Compare(a.record.CompanyName, b.record.CompanyName)
+	})
+	out := []map[string]any{}
+	for _, match := range matches[:min(len(matches), limit)] {
+		out = append(out, map[string]any{
+			"company_name":       match.record.CompanyName,
+			"similarity":         float64(int(match.similarity*candidateSimilarityScale)) / candidateSimilarityScale,
+			"desired_visa_count": desiredVisaCount(match.record, desired),
+			"total_visas":        match.record.TotalVisas,
+		})
+	}
+	return out
+}
+
+// checkVisaTypes reads preferred_visa_types, falling back to the user's
+// saved preferences.
+func checkVisaTypes(args map[string]any) ([]string, error) {
+	if hasKey(args, "preferred_visa_types") {
+		normalized, err := normalizeVisaTypeList(getStringList(args, "preferred_visa_types"))
+		if err != nil {
+			return nil, err
+		}
+		if len(normalized) > 0 {
+			return normalized, nil
+		}
+	}
+	if getString(args, "user_id") == "" {
+		return nil, fmt.Errorf("user_id or preferred_visa_types is required")
+	}
+	return getRequiredUserVisaTypes(getString(args, "user_id"))
+}
+
+func companySponsorshipCheck(dataset companyDataset, company string, desired []string) map[string]any {
+	normalized := normalizeCompanyName(company)
+	out := map[string]any{
+		"company_name":       company,
+		"normalized_company": normalized,
+	}
+	record, found := dataset.lookup(company)
+	out["matched"] = found
+	if !found {
+		out["sponsors_preferred_visa"] = false
+		out["candidates"] = fuzzyCompanyCandidates(dataset, normalized, desired, maxCheckCandidates)
+		return out
+	}
+	matchType := "exact"
+	if normalizeCompanyName(record.CompanyName) != normalized {
+		matchType = "alias"
+	}
+	visaCounts := visaCountsFromRecord(record)
+	visasSponsored := []string{}
+	for _, visa := range desired {
+		if visaCounts[visa] > 0 {
+			visasSponsored = append(visasSponsored, firstNonEmpty(visaTypeLabels[visa], visa))
+		}
+	}
+	desiredCount := desiredVisaCount(record, desired)
+	out["match_type"] = matchType
+	out["dataset_company_name"] = record.CompanyName
+	out["sponsors_preferred_visa"] = desiredCount > 0
+	out["desired_visa_count"] = desiredCount
+	out["total_visas"] = record.TotalVisas
+	out["visas_sponsored"] = visasSponsored
+	out["visa_counts"] = visaCountsWithApproval(visaCounts, record)
+	out["sponsorship_recency"] = sponsorshipRecency(record, desired, dataset.LatestFiscalYear)
+	out["cap_exempt"] = record.CapExempt
+	out["dataset_source"] = record.DatasetSource
+	return out
+}
+
+func CheckCompanySponsorship(args map[string]any) (map[string]any, error) {
+	companies := []string{}
+	for _, name := range getStringList(args, "company_names") {
+		if name = normalizeWhitespace(name); name != "" && !slices.Contains(companies, name) {
+			companies = append(companies, name)
+		}
+	}
+	if len(companies) == 0 {
+		return nil, fmt.Errorf("company_names is required")
+	}
+	if len(companies) > maxCheckCompanyNames {
+		return nil, fmt.Errorf("company_names accepts at most %d names", maxCheckCompanyNames)
+	}
+	desired, err := checkVisaTypes(args)
+	if err != nil {
+		return nil, err
+	}
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []map[string]any{}
+	matched, sponsoring := 0, 0
+	for _, company := range companies {
+		result := companySponsorshipCheck(dataset, company, desired)
+		if result["matched"] == true {
+			matched++
+		}
+		if result["sponsors_preferred_visa"] == true {
+			sponsoring++
+		}
+		results = append(results, result)
+	}
+	return map[string]any{
+		"desired_visa_types":            desired,
+		"dataset_path":                  datasetPath,
+		"results":                       results,
+		"matched_count":                 matched,
+		"sponsors_preferred_visa_count": sponsoring,
+		"guidance":                      "Unmatched names list fuzzy candidates; rerun with a candidate's exact name, or add_company_alias when a brand files under a different legal entity.",
+	}, nil
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckCompanySponsorshipMatchesAndSuggests(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	if _, err := SetUserPreferences(map[string]any{
+		"user_id":              "u1",
+		"preferred_visa_types": []any{"E3"},
+	}); err != nil {
+		t.Fatalf("SetUserPreferences failed: %v", err)
+	}
+
+	result, err := CheckCompanySponsorship(map[string]any{
+		"user_id":       "u1",
+		"company_names": []any{"ACME, Inc.", "Beta LLC", "Acmee", "Zeta Widgets"},
+		"dataset_path":  datasetPath,
+	})
+	if err != nil {
+		t.Fatalf("CheckCompanySponsorship failed: %v", err)
+	}
+	results := result["results"].([]map[string]any)
+	if len(results) != 4 || intOrZero(result["matched_count"]) != 2 || intOrZero(result["sponsors_preferred_visa_count"]) != 1 {
+		t.Fatalf("unexpected summary: %#v", result)
+	}
+	acme := results[0]
+	if acme["matched"] != true || acme["sponsors_preferred_visa"] != true || intOrZero(acme["desired_visa_count"]) != 5 {
+		t.Fatalf("expected Acme to sponsor E-3, got %#v", acme)
+	}
+	if beta := results[1]; beta["matched"] != true || beta["sponsors_preferred_visa"] != false {
+		t.Fatalf("expected Beta to match without E-3 filings, got %#v", beta)
+	}
+	candidates := results[2]["candidates"].([]map[string]any)
+	if results[2]["matched"] != false || len(candidates) != 1 || getString(candidates[0], "company_name") != "Acme Inc" {
+		t.Fatalf("expected Acme as the fuzzy candidate for a typo, got %#v", results[2])
+	}
+	if zeta := results[3]["candidates"].([]map[string]any); len(zeta) != 0 {
+		t.Fatalf("expected no candidates for an unrelated name, got %#v", zeta)
+	}
+}
+
+func TestCheckCompanySponsorshipRequiresVisaTypes(t *testing.T) {
+	setupUserToolPaths(t)
+	if _, err := CheckCompanySponsorship(map[string]any{"company_names": []any{"Acme"}}); err == nil {
+		t.Fatalf("expected an error without user_id or preferred_visa_types")
+	}
+	if _, err := CheckCompanySponsorship(map[string]any{"preferred_visa_types": []any{"h1b"}}); err == nil {
+		t.Fatalf("expected an error without company_names")
+	}
+}
+
+func TestNameSimilarityRanksContainedNamesHigh(t *testing.T) {
+	if got := nameSimilarity("acme", nameBigrams("acme"), "acme robotics"); got != containedNameSimilarity {
+		t.Fatalf("expected contained names to score %v, got %v", containedNameSimilarity, got)
+	}
+	if got := nameSimilarity("globex", nameBigrams("globex"), "initech"); got >= minCandidateSimilarity {
+		t.Fatalf("expected unrelated names below the cutoff, got %v", got)
+	}
+}
@@ -0,0 +1,159 @@
+package user
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	maxProfileOccupations    = 5
+	maxProfileStates         = 5
+	maxProfileSuggestions    = 5
+	linkedInJobSearchPageURL = "https://" + defaultLinkedInHost + "/jobs/search/"
+)
+
+// companyNameSuggestions lists dataset employers whose normalized name
+// contains the query, busiest sponsors first.
+func companyNameSuggestions(dataset companyDataset, normalized string, limit int) []string {
+	if normalized == "" {
+		return []string{}
+	}
+	matches := []companyDatasetRecord{}
+	for key, record := range dataset.ByNormalizedCompany {
+		if strings.Contains(key, normalized) || strings.Contains(normalized, key) {
+			matches = append(matches, record)
+		}
+	}
+	slices.SortFunc(matches, func(a, b companyDatasetRecord) int {
+		if a.TotalVisas != b.TotalVisas {
+			return b.TotalVisas - a.TotalVisas
+		}
+		return strings.Compare(a.CompanyName, b.CompanyName)
+	})
+	out := []string{}
+	for _, record := range matches[:min(len(matches), limit)] {
+		out = append(out, record.CompanyName)
+	}
+	return out
+}
+
+// sponsorshipTrend compares the two newest fiscal years of a breakdown.
+func sponsorshipTrend(breakdown []map[string]any) string {
+	if len(breakdown) < 2 {
+		return "insufficient_data"
+	}
+	latest, previous := intOrZero(breakdown[0]["total_visas"]), intOrZero(breakdown[1]["total_visas"])
+	switch {
+	case latest*10 > previous*11:
+		return "growing"
+	case latest*10 < previous*9:
+		return "declining"
+	}
+	return "steady"
+}
+
+// topKeyedCounts returns the largest keyed counts as {key: count} rows.
+func topKeyedCounts(counts map[string]int, keyName string, limit int) []map[string]any {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	out := []map[string]any{}
+	for _, key := range keys[:min(len(keys), limit)] {
+		out = append(out, map[string]any{keyName: key, "filings": counts[key]})
+	}
+	return out
+}
+
+// profileOccupations lists the employer's busiest SOC codes, titled from the
+// LCA wage table when it is available.
+func profileOccupations(record companyDatasetRecord, wageRows []lcaWageRow) []map[string]any {
+	titles := map[string]string{}
+	for _, row := range wageRows {
+		if code := normalizeSOCCode(row.SOCCode); code != "" && row.SOCTitle != "" {
+			titles[code] = row.SOCTitle
+		}
+	}
+	occupations := topKeyedCounts(record.SOCCounts, "soc_code", maxProfileOccupations)
+	for _, occupation := range occupations {
+		occupation["soc_title"] = optionalString(titles[getString(occupation, "soc_code")])
+	}
+	return occupations
+}
+
+func linkedInCompanyJobsURL(company, location string) string {
+	params := url.Values{}
+	params.Set("keywords", company)
+	if location = strings.TrimSpace(location); location != "" {
+		params.Set("location", location)
+	}
+	return linkedInJobSearchPageURL + "?" + params.Encode()
+}
+
+func GetCompanySponsorshipProfile(args map[string]any) (map[string]any, error) {
+	company := normalizeWhitespace(getString(args, "company_name"))
+	if company == "" {
+		return nil, fmt.Errorf("company_name is required")
+	}
+	normalized := normalizeCompanyName(company)
+	if normalized == "" {
+		return nil, fmt.Errorf("company_name must contain letters or digits")
+	}
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	record, found := dataset.lookup(company)
+	out := map[string]any{
+		"company_name":       company,
+		"normalized_company": normalized,
+		"found":              found,
+		"dataset_path":       datasetPath,
+		"linkedin_jobs_url":  linkedInCompanyJobsURL(company, getString(args, "location")),
+	}
+	if !found {
+		out["suggestions"] = companyNameSuggestions(dataset, normalized, maxProfileSuggestions)
+		out["guidance"] = "No sponsor filings were found under this name. Try one of the suggestions, or add_company_alias when the brand files under a different legal entity."
+		return out, nil
+	}
+
+	matchType := "exact"
+	if normalizeCompanyName(record.CompanyName) != normalized {
+		matchType = "alias"
+	}
+	wageRows := []lcaWageRow{}
+	if index, err := loadLCAWageIndex(lcaWagesPathFor(datasetPath)); err == nil {
+		wageRows = index.ByCompany[normalizeCompanyName(record.CompanyName)]
+	}
+	breakdown := fiscalYearBreakdown(record)
+	out["match_type"] = matchType
+	out["dataset_company_name"] = record.CompanyName
+	out["dataset_source"] = record.DatasetSource
+	out["company_tier"] = optionalString(record.CompanyTier)
+	out["company_tier_basis"] = optionalString(record.CompanyTierBasis)
+	out["visa_counts"] = visaCountsWithApproval(visaCountsFromRecord(record), record)
+	out["visa_counts_by_fiscal_year"] = breakdown
+	out["sponsorship_trend"] = sponsorshipTrend(breakdown)
+	out["sponsorship_recency"] = sponsorshipRecency(record, nil, dataset.LatestFiscalYear)
+	out["top_occupations"] = profileOccupations(record, wageRows)
+	out["top_worksite_states"] = topKeyedCounts(record.StateCounts, "state", maxProfileStates)
+	out["lca_wage_summary"] = summarizeLCAWages(wageRows)
+	out["employer_contacts"] = record.EmployerContacts
+	out["cap_exempt"] = record.CapExempt
+	out["cap_exempt_basis"] = optionalString(record.CapExemptBasis)
+	out["l1_heavy_company"] = l1HeavyCompany(record)
+	eVerify, _ := loadEVerifyIndex(eVerifyPath())
+	out["e_verify_enrolled"] = eVerify.enrolled(company, record.CompanyName)
+	out["company_facts"] = companyFacts(company, record, dataset.LatestFiscalYear)
+	return out, nil
+}
@@ -0,0 +1,57 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetCompanySponsorshipProfile(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	body := strings.Join([]string{
+		"company_name,h1b,h1b1_chile,h1b1_singapore,e3_australian,green_card,h1b_fy2024,h1b_fy2023,soc_counts,state_counts,contact_1,contact_1_title",
+		"Acme Inc,30,0,0,0,4,20,10,15-1252:25;13-1161:5,TX:20;NY:10,Alice Recruiter,Talent Partner",
+		"Acme Labs LLC,2,0,0,0,0,,,,,,",
+	}, "\n")
+	if err := os.WriteFile(datasetPath, []byte(body), 0o644); err != nil {
+		t.Fatalf("write dataset: %v", err)
+	}
+
+	profile, err := GetCompanySponsorshipProfile(map[string]any{"company_name": "ACME, Inc.", "location": "Austin, TX", "dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("GetCompanySponsorshipProfile failed: %v", err)
+	}
+	if profile["found"] != true || getString(profile, "match_type") != "exact" || getString(profile, "sponsorship_trend") != "growing" {
+		t.Fatalf("unexpected profile: %#v", profile)
+	}
+	if counts := asMap(profile["visa_counts"]); intOrZero(counts["h1b"]) != 30 || intOrZero(counts["green_card"]) != 4 {
+		t.Fatalf("unexpected visa counts: %#v", counts)
+	}
+	occupations := profile["top_occupations"].([]map[string]any)
+	if len(occupations) != 2 || getString(occupations[0], "soc_code") != "15-1252" {
+		t.Fatalf("unexpected occupations: %#v", occupations)
+	}
+	if states := profile["top_worksite_states"].([]map[string]any); getString(states[0], "state") != "TX" {
+		t.Fatalf("unexpected states: %#v", states)
+	}
+	if contacts := profile["employer_contacts"].([]map[string]any); len(contacts) != 1 {
+		t.Fatalf("unexpected contacts: %#v", contacts)
+	}
+	if link := getString(profile, "linkedin_jobs_url"); !strings.Contains(link, "keywords=ACME%2C+Inc.") || !strings.Contains(link, "location=Austin") {
+		t.Fatalf("unexpected link-out: %s", link)
+	}
+
+	missing, err := GetCompanySponsorshipProfile(map[string]any{"company_name": "Acme Labs Research", "dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("GetCompanySponsorshipProfile missing failed: %v", err)
+	}
+	suggestions := missing["suggestions"].([]string)
+	if missing["found"] != false || len(suggestions) != 2 || suggestions[0] != "Acme Inc" {
+		t.Fatalf("unexpected suggestions: %#v", missing)
+	}
+	if _, err := GetCompanySponsorshipProfile(map[string]any{"dataset_path": datasetPath}); err == nil {
+		t.Fatalf("expected error without company_name")
+	}
+}
@@ -0,0 +1,130 @@
+package user
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	contactQualityPersonalEmail = 50
+	contactQualityRoleEmail     = 25
+	contactQualityNamed         = 20
+	contactQualityPhone         = 20
+	contactQualityTitle         = 10
+)
+
+// roleEmailLocalParts are shared mailbox names; they reach a team rather
+// than a person, so they score lower than a named contact's address.
+var roleEmailLocalParts = []string{
+	"admin", "careers", "contact", "hello", "hiring", "hr", "humanresources", "info",
+	"jobs", "office", "people", "recruiter", "recruiting", "recruitment", "support",
+	"talent", "team",
+}
+
+// noReplyEmailLocalParts never reach anyone.
+var noReplyEmailLocalParts = []string{
+	"noreply", "no-reply", "no_reply", "donotreply", "do-not-reply", "do_not_reply",
+	"mailer-daemon", "postmaster",
+}
+
+func phoneDigits(phone string) string {
+	return nonDigitRegex.ReplaceAllString(phone, "")
+}
+
+// contactQuality scores a contact from 0 to 100 and lists what held it back.
+// It also reports whether the email and phone are worth using at all.
+func contactQuality(contact map[string]any) (int, []string, bool, bool) {
+	score, flags := 0, []string{}
+	email := strings.ToLower(getString(contact, "email"))
+	emailUsable := false
+	if email != "" {
+		local, _, _ := strings.Cut(email, "@")
+		switch {
+		case !contactEmailRegex.MatchString(email):
+			flags = append(flags, "malformed_email")
+		case slices.Contains(noReplyEmailLocalParts, local):
+			flags = append(flags, "no_reply_email")
+		case slices.Contains(roleEmailLocalParts, local):
+			flags = append(flags, "role_based_email")
+			score += contactQualityRoleEmail
+			emailUsable = true
+		default:
+			score += contactQualityPersonalEmail
+			emailUsable = true
+		}
+	}
+	phoneUsable := false
+	if phone := getString(contact, "phone"); phone != "" {
+		if len(phoneDigits(phone)) < minContactPhoneDigits {
+			flags = append(flags, "malformed_phone")
+		} else {
+			score += contactQualityPhone
+			phoneUsable = true
+		}
+	}
+	if getString(contact, "name") != "" {
+		score += contactQualityNamed
+	}
+	if getString(contact, "title") != "" {
+		score += contactQualityTitle
+	}
+	return score, flags, emailUsable, phoneUsable
+}
+
+// contactIdentity is the dedupe key across contact_1..3: the email when
+// present, otherwise the name with the phone digits.
+func contactIdentity(contact map[string]any) string {
+	if email := strings.ToLower(getString(contact, "email")); email != "" {
+		return "email:" + email
+	}
+	return "name:" + strings.ToLower(getString(contact, "name")) + "|phone:" + phoneDigits(getString(contact, "phone"))
+}
+
+// cleanDatasetContacts drops repeated contacts (filling blanks in the kept
+// one from its duplicates) and annotates each with contact_quality and
+// quality_flags. Dataset order is kept.
+func cleanDatasetContacts(contacts []map[string]any) []map[string]any {
+	out := []map[string]any{}
+	byIdentity := map[string]map[string]any{}
+	for _, contact := range contacts {
+		identity := contactIdentity(contact)
+		if kept, ok := byIdentity[identity]; ok {
+			for _, field := range []string{"name", "title", "email", "phone"} {
+				if getString(kept, field) == "" {
+					kept[field] = getString(contact, field)
+				}
+			}
+			continue
+		}
+		byIdentity[identity] = contact
+		out = append(out, contact)
+	}
+	for _, contact := range out {
+		score, flags, _, _ := contactQuality(contact)
+		contact["contact_quality"] = score
+		contact["quality_flags"] = flags
+	}
+	return out
+}
+
+// bestContact picks the highest-quality contact from stored results; older
+// results without contact_quality are scored on the fly. Ties keep the
+// dataset order.
+func bestContact(contacts []any) (map[string]any, int, []string, bool, bool) {
+	var best map[string]any
+	bestScore, bestFlags, bestEmail, bestPhone := -1, []string{}, false, false
+	for _, raw := range contacts {
+		contact := mapOrNil(raw)
+		if contact == nil {
+			continue
+		}
+		score, flags, emailUsable, phoneUsable := contactQuality(contact)
+		if score > bestScore {
+			best, bestScore, bestFlags, bestEmail, bestPhone = contact, score, flags, emailUsable, phoneUsable
+		}
+	}
+	if best == nil {
+		return map[string]any{}, 0, []string{}, false, false
+	}
+	return best, bestScore, bestFlags, bestEmail, bestPhone
+}
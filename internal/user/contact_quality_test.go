@@ -0,0 +1,45 @@
+package user
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCleanDatasetContactsDedupesAndScores(t *testing.T) {
+	contacts := cleanDatasetContacts([]map[string]any{
+		{"name": "", "title": "", "email": "Alice@Acme.com", "phone": ""},
+		{"name": "Alice Recruiter", "title": "Talent Partner", "email": "alice@acme.com", "phone": "+1 512 555 0100"},
+		{"name": "", "title": "", "email": "jobs@acme.com", "phone": "12"},
+	})
+	if len(contacts) != 2 {
+		t.Fatalf("expected duplicate emails to collapse, got %#v", contacts)
+	}
+	alice := contacts[0]
+	if getString(alice, "name") != "Alice Recruiter" || getString(alice, "phone") == "" {
+		t.Fatalf("expected the kept contact to absorb the duplicate's fields, got %#v", alice)
+	}
+	if intOrZero(alice["contact_quality"]) != 100 {
+		t.Fatalf("expected a complete personal contact to score 100, got %#v", alice)
+	}
+	jobs := contacts[1]
+	flags := jobs["quality_flags"].([]string)
+	if intOrZero(jobs["contact_quality"]) != contactQualityRoleEmail || !slices.Contains(flags, "role_based_email") || !slices.Contains(flags, "malformed_phone") {
+		t.Fatalf("expected a role-based mailbox with a malformed phone, got %#v", jobs)
+	}
+}
+
+func TestBestContactSkipsDeadEmails(t *testing.T) {
+	best, score, flags, emailUsable, phoneUsable := bestContact([]any{
+		map[string]any{"name": "Do Not Reply", "email": "noreply@acme.com"},
+		map[string]any{"name": "Pat", "email": "pat-at-acme", "phone": "512-555-0101"},
+	})
+	if getString(best, "name") != "Pat" || emailUsable || !phoneUsable || score != contactQualityNamed+contactQualityPhone {
+		t.Fatalf("expected Pat reachable by phone only, got %#v score=%d", best, score)
+	}
+	if !slices.Contains(flags, "malformed_email") {
+		t.Fatalf("expected malformed_email flag, got %v", flags)
+	}
+	if _, _, _, emailUsable, _ := bestContact([]any{map[string]any{"email": "no-reply@acme.com"}}); emailUsable {
+		t.Fatalf("expected a no-reply address to be unusable")
+	}
+}
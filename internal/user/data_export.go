@@ -208,10 +208,15 @@ func ExportUserData(args map[string]any) (map[string]any, error) {
 	ignoredCompanies := getUserList(ignoredCompaniesPath(), userID, "companies")
 	searchSessions := exportSearchSessions(userID)
 	searchRuns := exportSearchRuns(userID)
+	auditEvents := getUserList(auditLogPath(), userID, "events")
 	jobMgmt := getPipelineEntry(loadJobPipeline(), userID)
 	jobMgmtJobs := []any{}
 	jobMgmtApplications := []any{}
 	jobMgmtEvents := []any{}
+	jobMgmtRecords := map[string][]any{}
+	for _, list := range pipelineRecordLists {
+		jobMgmtRecords[list.Key] = []any{}
+	}
 	if jobMgmt != nil {
 		for _, row := range jobMgmt["jobs"].([]map[string]any) {
 			jobMgmtJobs = append(jobMgmtJobs, row)
@@ -222,6 +227,32 @@ func ExportUserData(args map[string]any) (map[string]any, error) {
 		for _, row := range jobMgmt["events"].([]map[string]any) {
 			jobMgmtEvents = append(jobMgmtEvents, row)
 		}
+		for _, list := range pipelineRecordLists {
+			for _, row := range jobMgmt[list.Key].([]map[string]any) {
+				jobMgmtRecords[list.Key] = append(jobMgmtRecords[list.Key], row)
+			}
+		}
+	}
+	jobManagement := map[string]any{
+		"jobs":         jobMgmtJobs,
+		"applications": jobMgmtApplications,
+		"events":       jobMgmtEvents,
+	}
+	counts := map[string]any{
+		"memory_lines":                len(memoryLines),
+		"saved_jobs":                  len(savedJobs),
+		"ignored_jobs":                len(ignoredJobs),
+		"ignored_companies":           len(ignoredCompanies),
+		"search_sessions":             len(searchSessions),
+		"search_runs":                 len(searchRuns),
+		"audit_events":                len(auditEvents),
+		"job_management_jobs":         len(jobMgmtJobs),
+		"job_management_applications": len(jobMgmtApplications),
+		"job_management_events":       len(jobMgmtEvents),
+	}
+	for key, rows := range jobMgmtRecords {
+		jobManagement[key] = rows
+		counts["job_management_"+key] = len(rows)
 	}
 
 	return map[string]any{
@@ -235,23 +266,10 @@ func ExportUserData(args map[string]any) (map[string]any, error) {
 			"ignored_companies": ignoredCompanies,
 			"search_sessions":   searchSessions,
 			"search_runs":       searchRuns,
-			"job_management": map[string]any{
-				"jobs":         jobMgmtJobs,
-				"applications": jobMgmtApplications,
-				"events":       jobMgmtEvents,
-			},
-		},
-		"counts": map[string]any{
-			"memory_lines":                len(memoryLines),
-			"saved_jobs":                  len(savedJobs),
-			"ignored_jobs":                len(ignoredJobs),
-			"ignored_companies":           len(ignoredCompanies),
-			"search_sessions":             len(searchSessions),
-			"search_runs":                 len(searchRuns),
-			"job_management_jobs":         len(jobMgmtJobs),
-			"job_management_applications": len(jobMgmtApplications),
-			"job_management_events":       len(jobMgmtEvents),
+			"audit_events":      auditEvents,
+			"job_management":    jobManagement,
 		},
+		"counts": counts,
 		"paths": map[string]any{
 			"preferences_path":       prefsPath(),
 			"memory_blob_path":       userBlobPath(),
@@ -260,6 +278,7 @@ func ExportUserData(args map[string]any) (map[string]any, error) {
 			"ignored_companies_path": ignoredCompaniesPath(),
 			"search_sessions_path":   searchSessionsPath(),
 			"search_runs_path":       searchRunsPath(),
+			"audit_log_path":         auditLogPath(),
 			"job_db_path":            jobDBPath(),
 		},
 	}, nil
@@ -286,10 +305,14 @@ func DeleteUserData(args map[string]any) (map[string]any, error) {
 		"ignored_companies":           0,
 		"search_sessions":             0,
 		"search_runs":                 0,
+		"audit_events":                0,
 		"job_management_jobs":         0,
 		"job_management_applications": 0,
 		"job_management_events":       0,
 	}
+	for _, list := range pipelineRecordLists {
+		deleted["job_management_"+list.Key] = 0
+	}
 
 	prefsStore, err := loadPrefs()
 	if err != nil {
@@ -333,12 +356,22 @@ func DeleteUserData(args map[string]any) (map[string]any, error) {
 	} else {
 		deleted["search_runs"] = count
 	}
+	auditLogMu.Lock()
+	auditCount, err := removeUserFromStore(auditLogPath(), userID, "events")
+	auditLogMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	deleted["audit_events"] = auditCount
 	pipeline := loadJobPipeline()
 	entry := getPipelineEntry(pipeline, userID)
 	if entry != nil {
 		deleted["job_management_jobs"] = len(entry["jobs"].([]map[string]any))
 		deleted["job_management_applications"] = len(entry["applications"].([]map[string]any))
 		deleted["job_management_events"] = len(entry["events"].([]map[string]any))
+		for _, list := range pipelineRecordLists {
+			deleted["job_management_"+list.Key] = len(entry[list.Key].([]map[string]any))
+		}
 		users := getUsersMap(pipeline)
 		delete(users, userID)
 		pipeline["users"] = users
@@ -358,6 +391,7 @@ func DeleteUserData(args map[string]any) (map[string]any, error) {
 			"ignored_companies_path": ignoredCompaniesPath(),
 			"search_sessions_path":   searchSessionsPath(),
 			"search_runs_path":       searchRunsPath(),
+			"audit_log_path":         auditLogPath(),
 			"job_db_path":            jobDBPath(),
 		},
 	}, nil
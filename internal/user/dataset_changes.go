@@ -0,0 +1,206 @@
+package user
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	defaultDatasetChangesFileName = "dataset_changes.json"
+	maxDatasetChangeReports       = 10
+	maxDatasetChangeEntries       = 200
+	defaultDatasetChangesLimit    = 25
+	// A count change is "big" when it moves by at least datasetChangeMinDelta
+	// filings and by at least datasetChangeMinRatio of the previous count.
+	datasetChangeMinDelta = 10
+	datasetChangeMinRatio = 0.5
+)
+
+// datasetChangesPathFor keeps the change reports next to the pipeline
+// manifest they describe.
+func datasetChangesPathFor(manifestPath string) string {
+	if path := strings.TrimSpace(os.Getenv("VISA_DATASET_CHANGES_PATH")); path != "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(manifestPath), defaultDatasetChangesFileName)
+}
+
+// sponsorTotals maps normalized company names to display name and total
+// filings for one dataset version.
+type sponsorTotals map[string]companyDatasetRecord
+
+// loadSponsorTotals reads the dataset currently at path, reporting false when
+// there is none (first run) or it cannot be loaded.
+func loadSponsorTotals(path string) (sponsorTotals, bool) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, false
+	}
+	dataset, err := loadCompanyDataset(path)
+	if err != nil {
+		return nil, false
+	}
+	return sponsorTotals(dataset.ByNormalizedCompany), true
+}
+
+func datasetChangeEntry(key string, before, after companyDatasetRecord) map[string]any {
+	name := after.CompanyName
+	if name == "" {
+		name = before.CompanyName
+	}
+	return map[string]any{
+		"company_name":         name,
+		"normalized_company":   key,
+		"previous_total_visas": before.TotalVisas,
+		"total_visas":          after.TotalVisas,
+		"delta":                after.TotalVisas - before.TotalVisas,
+	}
+}
+
+func bigCountChange(before, after int) bool {
+	delta := after - before
+	if delta < 0 {
+		delta = -delta
+	}
+	return delta >= datasetChangeMinDelta && float64(delta) >= datasetChangeMinRatio*float64(before)
+}
+
+// sortByAbsDelta puts the largest moves first, then orders by name.
+func sortByAbsDelta(entries []map[string]any) {
+	abs := func(entry map[string]any) int {
+		if delta := intOrZero(entry["delta"]); delta < 0 {
+			return -delta
+		}
+		return intOrZero(entry["delta"])
+	}
+	slices.SortFunc(entries, func(a, b map[string]any) int {
+		if abs(a) != abs(b) {
+			return abs(b) - abs(a)
+		}
+		return strings.Compare(getString(a, "normalized_company"), getString(b, "normalized_company"))
+	})
+}
+
+// diffSponsorTotals lists employers that started sponsoring, stopped, or
+// moved by a large amount between two dataset versions.
+func diffSponsorTotals(before, after sponsorTotals) map[string]any {
+	added, dropped, changed := []map[string]any{}, []map[string]any{}, []map[string]any{}
+	for key, record := range after {
+		previous, existed := before[key]
+		switch {
+		case record.TotalVisas > 0 && (!existed || previous.TotalVisas == 0):
+			added = append(added, datasetChangeEntry(key, previous, record))
+		case existed && previous.TotalVisas > 0 && record.TotalVisas == 0:
+			dropped = append(dropped, datasetChangeEntry(key, previous, record))
+		case existed && bigCountChange(previous.TotalVisas, record.TotalVisas):
+			changed = append(changed, datasetChangeEntry(key, previous, record))
+		}
+	}
+	for key, previous := range before {
+		if _, ok := after[key]; !ok && previous.TotalVisas > 0 {
+			dropped = append(dropped, datasetChangeEntry(key, previous, companyDatasetRecord{}))
+		}
+	}
+	for _, list := range [][]map[string]any{added, dropped, changed} {
+		sortByAbsDelta(list)
+	}
+	return map[string]any{
+		"summary": map[string]any{
+			"new_sponsors":     len(added),
+			"dropped_sponsors": len(dropped),
+			"big_changes":      len(changed),
+			"previous_rows":    len(before),
+			"rows":             len(after),
+		},
+		"new_sponsors":     added[:min(len(added), maxDatasetChangeEntries)],
+		"dropped_sponsors": dropped[:min(len(dropped), maxDatasetChangeEntries)],
+		"big_changes":      changed[:min(len(changed), maxDatasetChangeEntries)],
+	}
+}
+
+// recordDatasetChanges diffs the freshly written dataset against the version
+// it replaced and prepends the report to the change history. A first run
+// records a baseline with no changes.
+func recordDatasetChanges(datasetPath, manifestPath, runAt string, before sponsorTotals, hadPrevious bool) (map[string]any, error) {
+	after, ok := loadSponsorTotals(datasetPath)
+	if !ok {
+		return nil, fmt.Errorf("reload dataset for change report: %s", datasetPath)
+	}
+	if !hadPrevious {
+		before = after
+	}
+	report := diffSponsorTotals(before, after)
+	report["baseline"] = !hadPrevious
+	report["run_at_utc"] = runAt
+	report["dataset_path"] = datasetPath
+
+	path := datasetChangesPathFor(manifestPath)
+	history := loadJSONMap(path, map[string]any{"reports": []any{}})
+	reports := append([]any{report}, listOrEmpty(history["reports"])...)
+	history["reports"] = reports[:min(len(reports), maxDatasetChangeReports)]
+	if err := saveJSONMap(path, history); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// filterDatasetChanges keeps entries for the tracked companies (all when
+// none are given) and caps each list at limit.
+func filterDatasetChanges(entries []any, tracked map[string]bool, limit int) []any {
+	out := []any{}
+	for _, raw := range entries {
+		entry := asMap(raw)
+		if len(tracked) > 0 && !tracked[getString(entry, "normalized_company")] {
+			continue
+		}
+		if len(out) == limit {
+			break
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func GetDatasetChanges(args map[string]any) (map[string]any, error) {
+	limit := defaultDatasetChangesLimit
+	if value, has, err := getOptionalInt(args, "limit"); has {
+		if err != nil || value < 1 {
+			return nil, fmt.Errorf("limit must be a positive integer when provided")
+		}
+		limit = value
+	}
+	tracked := map[string]bool{}
+	for _, name := range getStringList(args, "company_names") {
+		if key := normalizeCompanyName(name); key != "" {
+			tracked[key] = true
+		}
+	}
+	manifestPath := envOrDefault("VISA_DOL_MANIFEST_PATH", defaultManifestPath)
+	if raw := getString(args, "manifest_path"); raw != "" {
+		manifestPath = raw
+	}
+	path := datasetChangesPathFor(manifestPath)
+	reports := listOrEmpty(loadJSONMap(path, map[string]any{"reports": []any{}})["reports"])
+	out := map[string]any{
+		"changes_path":      path,
+		"available_reports": len(reports),
+		"tracked_companies": getStringList(args, "company_names"),
+	}
+	if len(reports) == 0 {
+		out["found"] = false
+		out["guidance"] = "No change reports yet; they are written each time run_internal_dol_pipeline (or the scheduled refresher) rebuilds the dataset."
+		return out, nil
+	}
+	latest := asMap(reports[0])
+	out["found"] = true
+	out["run_at_utc"] = latest["run_at_utc"]
+	out["dataset_path"] = latest["dataset_path"]
+	out["baseline"] = latest["baseline"]
+	out["summary"] = latest["summary"]
+	for _, key := range []string{"new_sponsors", "dropped_sponsors", "big_changes"} {
+		out[key] = filterDatasetChanges(listOrEmpty(latest[key]), tracked, limit)
+	}
+	return out, nil
+}
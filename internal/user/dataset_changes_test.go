@@ -0,0 +1,97 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runChangesPipeline(t *testing.T, dir string, lcaRows []string) map[string]any {
+	t.Helper()
+	lcaPath := filepath.Join(dir, "lca.csv")
+	if err := os.WriteFile(lcaPath, []byte(strings.Join(append([]string{"EMPLOYER_NAME,VISA_CLASS"}, lcaRows...), "\n")), 0o644); err != nil {
+		t.Fatalf("write lca: %v", err)
+	}
+	permPath := filepath.Join(dir, "perm.csv")
+	if err := os.WriteFile(permPath, []byte("EMPLOYER_NAME\nAcme Inc\n"), 0o644); err != nil {
+		t.Fatalf("write perm: %v", err)
+	}
+	result, err := RunInternalDolPipeline(map[string]any{
+		"lca_source":        lcaPath,
+		"perm_source":       permPath,
+		"dataset_path":      filepath.Join(dir, "companies.csv"),
+		"manifest_path":     filepath.Join(dir, "last_run.json"),
+		"strict_validation": false,
+	})
+	if err != nil || getString(result, "status") != "completed" {
+		t.Fatalf("RunInternalDolPipeline failed: %v %#v", err, result)
+	}
+	return result
+}
+
+func changeNames(raw any) []string {
+	names := []string{}
+	for _, entry := range listOrEmpty(raw) {
+		names = append(names, getString(asMap(entry), "normalized_company"))
+	}
+	return names
+}
+
+func TestDatasetChangesReportNewDroppedAndBigChanges(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "last_run.json")
+	first := runChangesPipeline(t, dir, []string{"Acme Inc,H-1B", "Beta LLC,H-1B", "Delta Corp,H-1B"})
+	if summary := asMap(first["dataset_changes_summary"]); intOrZero(summary["new_sponsors"]) != 0 || intOrZero(summary["rows"]) != 3 {
+		t.Fatalf("expected a baseline report on the first run, got %#v", summary)
+	}
+
+	second := []string{"Beta LLC,H-1B", "Gamma Co,H-1B"}
+	for range 14 {
+		second = append(second, "Acme Inc,H-1B")
+	}
+	runChangesPipeline(t, dir, second)
+
+	changes, err := GetDatasetChanges(map[string]any{"manifest_path": manifestPath})
+	if err != nil {
+		t.Fatalf("GetDatasetChanges failed: %v", err)
+	}
+	if changes["found"] != true || changes["baseline"] != false || intOrZero(changes["available_reports"]) != 2 {
+		t.Fatalf("unexpected change report: %#v", changes)
+	}
+	if got := changeNames(changes["new_sponsors"]); len(got) != 1 || got[0] != "gamma" {
+		t.Fatalf("expected gamma as the new sponsor, got %v", got)
+	}
+	if got := changeNames(changes["dropped_sponsors"]); len(got) != 1 || got[0] != "delta" {
+		t.Fatalf("expected delta as the dropped sponsor, got %v", got)
+	}
+	bigChanges := listOrEmpty(changes["big_changes"])
+	if len(bigChanges) != 1 || getString(asMap(bigChanges[0]), "normalized_company") != "acme" || intOrZero(asMap(bigChanges[0])["delta"]) != 13 {
+		t.Fatalf("expected acme's jump as the only big change, got %#v", bigChanges)
+	}
+	manifest := loadJSONMap(manifestPath, nil)
+	if getString(manifest, "dataset_changes_path") != filepath.Join(dir, defaultDatasetChangesFileName) {
+		t.Fatalf("expected the manifest to point at the change report, got %#v", manifest)
+	}
+
+	tracked, err := GetDatasetChanges(map[string]any{"manifest_path": manifestPath, "company_names": []any{"Delta Corporation"}})
+	if err != nil {
+		t.Fatalf("GetDatasetChanges failed: %v", err)
+	}
+	if len(listOrEmpty(tracked["new_sponsors"])) != 0 || len(listOrEmpty(tracked["big_changes"])) != 0 || len(changeNames(tracked["dropped_sponsors"])) != 1 {
+		t.Fatalf("expected only the tracked company's change, got %#v", tracked)
+	}
+}
+
+func TestGetDatasetChangesWithoutReports(t *testing.T) {
+	result, err := GetDatasetChanges(map[string]any{"manifest_path": filepath.Join(t.TempDir(), "last_run.json")})
+	if err != nil {
+		t.Fatalf("GetDatasetChanges failed: %v", err)
+	}
+	if result["found"] != false || getString(result, "guidance") == "" {
+		t.Fatalf("expected a not-found response with guidance, got %#v", result)
+	}
+	if _, err := GetDatasetChanges(map[string]any{"limit": -1}); err == nil {
+		t.Fatalf("expected a negative limit to be rejected")
+	}
+}
@@ -0,0 +1,126 @@
+package user
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const defaultNameCollisionsLimit = 50
+
+// companyNameVariant is one distinct dataset spelling behind a normalized
+// company key.
+type companyNameVariant struct {
+	CompanyName string
+	TotalVisas  int
+}
+
+// sameCompanySpelling treats names differing only in case or spacing as the
+// same row repeated rather than a different employer.
+func sameCompanySpelling(a, b string) bool {
+	return strings.EqualFold(normalizeWhitespace(a), normalizeWhitespace(b))
+}
+
+// noteNameCollision records a row whose distinct company name normalizes to
+// a key already taken by another name ("ABC Inc" and "ABC Corp" both become
+// "abc"). Only the row with the most filings is kept for lookups, so these
+// employers need disambiguating in the dataset.
+func (d *companyDataset) noteNameCollision(normalized string, existing, record companyDatasetRecord) {
+	variants, tracked := d.NameCollisions[normalized]
+	if !tracked {
+		if sameCompanySpelling(existing.CompanyName, record.CompanyName) {
+			return
+		}
+		variants = []companyNameVariant{{CompanyName: existing.CompanyName, TotalVisas: existing.TotalVisas}}
+	}
+	for _, variant := range variants {
+		if sameCompanySpelling(variant.CompanyName, record.CompanyName) {
+			return
+		}
+	}
+	if d.NameCollisions == nil {
+		d.NameCollisions = map[string][]companyNameVariant{}
+	}
+	d.NameCollisions[normalized] = append(variants, companyNameVariant{CompanyName: record.CompanyName, TotalVisas: record.TotalVisas})
+}
+
+// mergeNameCollisions copies a merged file's collisions; a later file's
+// collisions for the same key replace the earlier file's, like its records.
+func (d *companyDataset) mergeNameCollisions(other companyDataset) {
+	if len(other.NameCollisions) == 0 {
+		return
+	}
+	if d.NameCollisions == nil {
+		d.NameCollisions = map[string][]companyNameVariant{}
+	}
+	maps.Copy(d.NameCollisions, other.NameCollisions)
+}
+
+// nameCollisionList reports collisions with the most filings at stake first.
+func nameCollisionList(dataset companyDataset) []map[string]any {
+	type entry struct {
+		normalized string
+		filings    int
+	}
+	entries := []entry{}
+	for normalized, variants := range dataset.NameCollisions {
+		filings := 0
+		for _, variant := range variants {
+			filings += variant.TotalVisas
+		}
+		entries = append(entries, entry{normalized: normalized, filings: filings})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		if a.filings != b.filings {
+			return b.filings - a.filings
+		}
+		return strings.Compare(a.normalized, b.normalized)
+	})
+	out := []map[string]any{}
+	for _, item := range entries {
+		kept := dataset.ByNormalizedCompany[item.normalized]
+		variants := []map[string]any{}
+		for _, variant := range dataset.NameCollisions[item.normalized] {
+			variants = append(variants, map[string]any{
+				"company_name": variant.CompanyName,
+				"total_visas":  variant.TotalVisas,
+				"kept":         variant.CompanyName == kept.CompanyName,
+			})
+		}
+		out = append(out, map[string]any{
+			"normalized_company": item.normalized,
+			"kept_company_name":  kept.CompanyName,
+			"dataset_source":     kept.DatasetSource,
+			"combined_filings":   item.filings,
+			"variants":           variants,
+		})
+	}
+	return out
+}
+
+func ListCompanyNameCollisions(args map[string]any) (map[string]any, error) {
+	limit := defaultNameCollisionsLimit
+	if value, has, err := getOptionalInt(args, "limit"); has {
+		if err != nil || value < 1 {
+			return nil, fmt.Errorf("limit must be a positive integer when provided")
+		}
+		limit = value
+	}
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		return nil, err
+	}
+	collisions := nameCollisionList(dataset)
+	guidance := "No distinct employers share a normalized name."
+	if len(collisions) > 0 {
+		guidance = "Each entry lists employers that normalize to the same name; only the kept row answers lookups. Disambiguate in the dataset by renaming one (for example adding a state or division that normalization keeps) or merging rows that really are the same employer."
+	}
+	return map[string]any{
+		"dataset_path":    datasetPath,
+		"collision_count": len(collisions),
+		"collisions":      collisions[:min(len(collisions), limit)],
+		"guidance":        guidance,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeCollidingDataset(t *testing.T, path string) {
+	t.Helper()
+	body := strings.Join([]string{
+		"company_name,h1b,h1b1_chile,h1b1_singapore,e3_australian,green_card",
+		"ABC Inc,40,0,0,0,0",
+		"ABC Corp,12,0,0,0,0",
+		"abc  inc,3,0,0,0,0",
+		"Acme Inc,10,0,0,0,0",
+		"ACME INC,2,0,0,0,0",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write dataset: %v", err)
+	}
+}
+
+func TestLoadCompanyDatasetRecordsNameCollisions(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeCollidingDataset(t, datasetPath)
+
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("load dataset: %v", err)
+	}
+	if len(dataset.NameCollisions) != 1 || len(dataset.NameCollisions["abc"]) != 2 {
+		t.Fatalf("expected only ABC Inc/ABC Corp to collide, got %#v", dataset.NameCollisions)
+	}
+
+	refreshed, err := RefreshCompanyDatasetCache(map[string]any{"dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("RefreshCompanyDatasetCache failed: %v", err)
+	}
+	if intOrZero(refreshed["name_collisions"]) != 1 {
+		t.Fatalf("expected name_collisions=1, got %#v", refreshed)
+	}
+
+	listed, err := ListCompanyNameCollisions(map[string]any{"dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("ListCompanyNameCollisions failed: %v", err)
+	}
+	collisions := listed["collisions"].([]map[string]any)
+	if len(collisions) != 1 || getString(collisions[0], "kept_company_name") != "ABC Inc" || intOrZero(collisions[0]["combined_filings"]) != 52 {
+		t.Fatalf("unexpected collisions: %#v", listed)
+	}
+	variants := collisions[0]["variants"].([]map[string]any)
+	if getString(variants[1], "company_name") != "ABC Corp" || variants[1]["kept"] != false {
+		t.Fatalf("expected ABC Corp listed as the hidden variant, got %#v", variants)
+	}
+	if _, err := ListCompanyNameCollisions(map[string]any{"dataset_path": datasetPath, "limit": 0}); err == nil {
+		t.Fatalf("expected limit=0 to be rejected")
+	}
+}
+
+func TestValidateCompanyDatasetWarnsOnNameCollisions(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeCollidingDataset(t, datasetPath)
+
+	report, err := ValidateCompanyDataset(map[string]any{"dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("ValidateCompanyDataset failed: %v", err)
+	}
+	if asMap(report["checks"])["name_collisions"] != false || intOrZero(asMap(report["stats"])["name_collisions"]) != 1 {
+		t.Fatalf("expected one name collision warning, got %#v", report)
+	}
+}
@@ -0,0 +1,69 @@
+package user
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// datasetPathFromArgs returns the dataset_paths list joined with the OS
+// path-list separator (":" on macOS/Linux) when given, else dataset_path.
+// The joined form is what VISA_COMPANY_DATASET_PATHS holds, so search runs
+// and cache keys carry a merged dataset as a single string.
+func datasetPathFromArgs(args map[string]any) string {
+	if paths := getStringList(args, "dataset_paths"); len(paths) > 0 {
+		return strings.Join(paths, string(os.PathListSeparator))
+	}
+	return getString(args, "dataset_path")
+}
+
+// splitDatasetPaths splits a possibly joined dataset path into its files.
+func splitDatasetPaths(path string) []string {
+	out := []string{}
+	for _, one := range filepath.SplitList(path) {
+		if one = strings.TrimSpace(one); one != "" {
+			out = append(out, one)
+		}
+	}
+	return out
+}
+
+// primaryDatasetPath is the first (generated) file of a merged dataset; the
+// pipeline writes it and freshness, validation, and LCA wages follow it.
+func primaryDatasetPath(path string) string {
+	if paths := splitDatasetPaths(path); len(paths) > 0 {
+		return paths[0]
+	}
+	return path
+}
+
+// loadMergedCompanyDataset overlays dataset files in order: a company in a
+// later file (such as a personally verified supplement) replaces the record
+// from earlier files, and each record keeps the file it came from in
+// DatasetSource.
+func loadMergedCompanyDataset(paths []string) (companyDataset, error) {
+	out := companyDataset{ByNormalizedCompany: map[string]companyDatasetRecord{}}
+	for _, path := range paths {
+		dataset, err := loadCompanyDataset(path)
+		if err != nil {
+			return companyDataset{}, fmt.Errorf("merge dataset %s: %w", path, err)
+		}
+		maps.Copy(out.ByNormalizedCompany, dataset.ByNormalizedCompany)
+		out.Rows += dataset.Rows
+		out.LatestFiscalYear = max(out.LatestFiscalYear, dataset.LatestFiscalYear)
+		out.Aliases = dataset.Aliases
+		out.mergeNameCollisions(dataset)
+	}
+	return out, nil
+}
+
+// datasetSourceCounts reports how many merged companies each file supplied.
+func datasetSourceCounts(dataset companyDataset) map[string]int {
+	counts := map[string]int{}
+	for _, record := range dataset.ByNormalizedCompany {
+		counts[record.DatasetSource]++
+	}
+	return counts
+}
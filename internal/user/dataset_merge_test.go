@@ -0,0 +1,83 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSupplementDataset(t *testing.T, path string) {
+	t.Helper()
+	body := strings.Join([]string{
+		"company_name,h1b,h1b1_chile,h1b1_singapore,e3_australian,green_card",
+		"Beta LLC,3,0,0,0,1",
+		"Verified Startup Inc,2,0,0,0,0",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write supplement: %v", err)
+	}
+}
+
+func TestMergedDatasetOverlaysLaterFilesWithSource(t *testing.T) {
+	setupUserToolPaths(t)
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "companies.csv")
+	supplementPath := filepath.Join(dir, "verified.csv")
+	writeTestDataset(t, basePath)
+	writeSupplementDataset(t, supplementPath)
+
+	t.Setenv("VISA_COMPANY_DATASET_PATHS", basePath+string(os.PathListSeparator)+supplementPath)
+	dataset, err := loadCompanyDataset("")
+	if err != nil {
+		t.Fatalf("load merged dataset: %v", err)
+	}
+	if dataset.Rows != 4 || len(dataset.ByNormalizedCompany) != 3 {
+		t.Fatalf("expected 4 rows over 3 companies, got rows=%d companies=%d", dataset.Rows, len(dataset.ByNormalizedCompany))
+	}
+	acme, beta := dataset.ByNormalizedCompany["acme"], dataset.ByNormalizedCompany["beta"]
+	if acme.DatasetSource != basePath || acme.H1B != 10 {
+		t.Fatalf("expected acme from the generated dataset, got %+v", acme)
+	}
+	if beta.DatasetSource != supplementPath || beta.H1B != 3 || beta.TotalVisas != 4 {
+		t.Fatalf("expected the supplement to replace beta, got %+v", beta)
+	}
+
+	refreshed, err := RefreshCompanyDatasetCache(map[string]any{})
+	if err != nil {
+		t.Fatalf("RefreshCompanyDatasetCache failed: %v", err)
+	}
+	if bySource := refreshed["companies_by_source"].(map[string]int); bySource[basePath] != 1 || bySource[supplementPath] != 2 {
+		t.Fatalf("unexpected companies_by_source: %#v", bySource)
+	}
+	if lcaWagesPathFor("") != filepath.Join(dir, defaultLCAWagesFileName) {
+		t.Fatalf("expected LCA wages to follow the primary dataset, got %q", lcaWagesPathFor(""))
+	}
+}
+
+func TestDatasetPathsArgumentMergesForProfile(t *testing.T) {
+	setupUserToolPaths(t)
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "companies.csv")
+	supplementPath := filepath.Join(dir, "verified.csv")
+	writeTestDataset(t, basePath)
+	writeSupplementDataset(t, supplementPath)
+
+	profile, err := GetCompanySponsorshipProfile(map[string]any{
+		"company_name":  "Verified Startup",
+		"dataset_paths": []any{basePath, supplementPath},
+	})
+	if err != nil {
+		t.Fatalf("GetCompanySponsorshipProfile failed: %v", err)
+	}
+	if profile["found"] != true || getString(profile, "dataset_source") != supplementPath {
+		t.Fatalf("expected the supplement-only company with its source, got %#v", profile)
+	}
+
+	if _, err := GetCompanySponsorshipProfile(map[string]any{
+		"company_name":  "Acme",
+		"dataset_paths": []any{basePath, filepath.Join(dir, "missing.csv")},
+	}); err == nil || !strings.Contains(err.Error(), "missing.csv") {
+		t.Fatalf("expected a missing merged file to be reported, got %v", err)
+	}
+}
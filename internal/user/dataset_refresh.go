@@ -0,0 +1,164 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDatasetRefreshHistoryPath = "data/pipeline/refresh_history.json"
+	defaultDatasetStaleDays          = 30
+	maxDatasetRefreshHistory         = 50
+	defaultRefreshHistoryLimit       = 10
+)
+
+var (
+	datasetRefresherOnce sync.Once
+	// datasetRefreshRunMu keeps refreshes from overlapping; the history file
+	// has its own lock so it stays readable while a refresh runs.
+	datasetRefreshRunMu     sync.Mutex
+	datasetRefreshHistoryMu sync.Mutex
+)
+
+func datasetRefreshHistoryPath() string {
+	return envOrDefault("VISA_DATASET_REFRESH_HISTORY_PATH", defaultDatasetRefreshHistoryPath)
+}
+
+// datasetRefreshInterval is how often the background refresher checks the
+// manifest; zero (the default) disables scheduled refreshes.
+func datasetRefreshInterval() time.Duration {
+	return time.Duration(max(envInt("VISA_DATASET_REFRESH_INTERVAL_HOURS", 0), 0)) * time.Hour
+}
+
+func datasetStaleDays() int {
+	return max(envInt("VISA_DATASET_STALE_DAYS", defaultDatasetStaleDays), 1)
+}
+
+// scheduledRefreshOptions is what a scheduled refresh runs: discovery from
+// the DOL performance page into the default dataset and manifest, with
+// strict validation so a bad build never replaces the current dataset.
+func scheduledRefreshOptions() dolPipelineOptions {
+	return dolPipelineOptions{
+		PerformanceURL:   dolPerformanceURL(""),
+		RawDir:           envOrDefault("VISA_DOL_RAW_DIR", defaultDOLRawDir),
+		DatasetPath:      primaryDatasetPath(datasetPathOrDefault("")),
+		ManifestPath:     envOrDefault("VISA_DOL_MANIFEST_PATH", defaultManifestPath),
+		StrictValidation: true,
+	}
+}
+
+// StartDatasetRefresher starts the background refresher once per process
+// when VISA_DATASET_REFRESH_INTERVAL_HOURS is set.
+func StartDatasetRefresher() {
+	interval := datasetRefreshInterval()
+	if interval <= 0 {
+		return
+	}
+	datasetRefresherOnce.Do(func() {
+		go func() {
+			refreshDatasetIfStale(scheduledRefreshOptions(), "scheduled")
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for range ticker.C {
+				refreshDatasetIfStale(scheduledRefreshOptions(), "scheduled")
+			}
+		}()
+	})
+}
+
+// refreshDatasetIfStale rebuilds the dataset when its manifest is older than
+// the staleness threshold and records the run. It returns the recorded run,
+// or nil when the dataset was fresh or another refresh was in progress.
+func refreshDatasetIfStale(opts dolPipelineOptions, trigger string) map[string]any {
+	if !datasetRefreshRunMu.TryLock() {
+		return nil
+	}
+	defer datasetRefreshRunMu.Unlock()
+
+	freshness := datasetFreshness(opts.DatasetPath, opts.ManifestPath)
+	if stale, _ := freshness["is_stale"].(bool); !stale {
+		recordDatasetRefresh(nil)
+		return nil
+	}
+
+	timeoutSeconds := dolPipelineTimeoutSeconds()
+	started := utcNow()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+	pipeline, runErr := runDOLPipeline(ctx, opts)
+	completed := utcNow()
+
+	run := map[string]any{
+		"trigger":                         trigger,
+		"status":                          "completed",
+		"started_at_utc":                  toISO(started),
+		"completed_at_utc":                toISO(completed),
+		"duration_seconds":                completed.Sub(started).Seconds(),
+		"days_since_refresh_before":       freshness["days_since_refresh"],
+		"lca_source":                      optionalString(pipeline.LCASource),
+		"perm_source":                     optionalString(pipeline.PERMSource),
+		"rows_written":                    pipeline.RowsWritten,
+		"dataset_path":                    opts.DatasetPath,
+		"validation_passed":               nil,
+		"discovered_from_performance_url": pipeline.Discovered,
+		"error":                           nil,
+	}
+	if runErr != nil {
+		run["status"] = "failed"
+		run["error"] = runErr.Error()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			run["error"] = fmt.Sprintf("Pipeline timed out after %d seconds", timeoutSeconds)
+		}
+	} else if validation, err := ValidateCompanyDataset(map[string]any{"dataset_path": opts.DatasetPath}); err == nil {
+		run["validation_passed"] = validation["passed"]
+	}
+
+	recordDatasetRefresh(run)
+	return run
+}
+
+// recordDatasetRefresh stamps the last check time and, when run is not nil,
+// prepends it to the capped run history.
+func recordDatasetRefresh(run map[string]any) {
+	datasetRefreshHistoryMu.Lock()
+	defer datasetRefreshHistoryMu.Unlock()
+	history := loadJSONMap(datasetRefreshHistoryPath(), map[string]any{"runs": []any{}})
+	history["last_checked_at_utc"] = utcNowISO()
+	if run != nil {
+		runs := append([]any{run}, listOrEmpty(history["runs"])...)
+		history["runs"] = runs[:min(len(runs), maxDatasetRefreshHistory)]
+	}
+	_ = saveJSONMap(datasetRefreshHistoryPath(), history)
+}
+
+func GetDatasetRefreshHistory(args map[string]any) (map[string]any, error) {
+	limit := defaultRefreshHistoryLimit
+	if value, has, err := getOptionalInt(args, "limit"); has {
+		if err != nil || value < 1 {
+			return nil, fmt.Errorf("limit must be a positive integer when provided")
+		}
+		limit = value
+	}
+	datasetRefreshHistoryMu.Lock()
+	history := loadJSONMap(datasetRefreshHistoryPath(), map[string]any{"runs": []any{}})
+	datasetRefreshHistoryMu.Unlock()
+
+	runs := listOrEmpty(history["runs"])
+	opts := scheduledRefreshOptions()
+	interval := datasetRefreshInterval()
+	return map[string]any{
+		"refresher": map[string]any{
+			"enabled":        interval > 0,
+			"interval_hours": int(interval / time.Hour),
+			"stale_days":     datasetStaleDays(),
+		},
+		"last_checked_at_utc": history["last_checked_at_utc"],
+		"dataset_freshness":   datasetFreshness(opts.DatasetPath, opts.ManifestPath),
+		"total_runs":          len(runs),
+		"runs":                runs[:min(len(runs), limit)],
+		"history_path":        datasetRefreshHistoryPath(),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRefreshDatasetIfStaleRebuildsAndRecordsRuns(t *testing.T) {
+	setupUserToolPaths(t)
+	dir := t.TempDir()
+	lcaPath := filepath.Join(dir, "lca.csv")
+	if err := os.WriteFile(lcaPath, []byte("EMPLOYER_NAME,VISA_CLASS\nAcme Inc,H-1B\nBeta LLC,E-3 Australian\n"), 0o644); err != nil {
+		t.Fatalf("write lca: %v", err)
+	}
+	permPath := filepath.Join(dir, "perm.csv")
+	if err := os.WriteFile(permPath, []byte("EMPLOYER_NAME\nAcme Inc\n"), 0o644); err != nil {
+		t.Fatalf("write perm: %v", err)
+	}
+	opts := dolPipelineOptions{
+		LCASource:        lcaPath,
+		PERMSource:       permPath,
+		RawDir:           filepath.Join(dir, "raw"),
+		DatasetPath:      filepath.Join(dir, "companies.csv"),
+		ManifestPath:     filepath.Join(dir, "last_run.json"),
+		StrictValidation: false,
+	}
+
+	run := refreshDatasetIfStale(opts, "scheduled")
+	if run == nil || getString(run, "status") != "completed" || intOrZero(run["rows_written"]) != 2 {
+		t.Fatalf("expected a completed refresh of the missing dataset, got %#v", run)
+	}
+	if passed, _ := run["validation_passed"].(bool); !passed {
+		t.Fatalf("expected the rebuilt dataset to pass validation, got %#v", run)
+	}
+	if again := refreshDatasetIfStale(opts, "scheduled"); again != nil {
+		t.Fatalf("expected a fresh manifest to skip the refresh, got %#v", again)
+	}
+
+	opts.LCASource = filepath.Join(dir, "missing.csv")
+	opts.DatasetPath = filepath.Join(dir, "other", "companies.csv")
+	opts.ManifestPath = filepath.Join(dir, "other", "last_run.json")
+	failed := refreshDatasetIfStale(opts, "scheduled")
+	if failed == nil || getString(failed, "status") != "failed" || getString(failed, "error") == "" {
+		t.Fatalf("expected a failed refresh for a missing source, got %#v", failed)
+	}
+
+	history, err := GetDatasetRefreshHistory(map[string]any{"limit": 1})
+	if err != nil {
+		t.Fatalf("GetDatasetRefreshHistory failed: %v", err)
+	}
+	runs := listOrEmpty(history["runs"])
+	if intOrZero(history["total_runs"]) != 2 || len(runs) != 1 || getString(asMap(runs[0]), "status") != "failed" {
+		t.Fatalf("expected two recorded runs with the failure first, got %#v", history)
+	}
+	if getString(history, "last_checked_at_utc") == "" {
+		t.Fatalf("expected last_checked_at_utc, got %#v", history)
+	}
+	if refresher := asMap(history["refresher"]); refresher["enabled"] != false || intOrZero(refresher["stale_days"]) != defaultDatasetStaleDays {
+		t.Fatalf("expected the refresher to be off by default, got %#v", refresher)
+	}
+}
+
+func TestDatasetStaleDaysDrivesFreshness(t *testing.T) {
+	dir := t.TempDir()
+	datasetPath := filepath.Join(dir, "companies.csv")
+	manifestPath := filepath.Join(dir, "last_run.json")
+	if err := os.WriteFile(manifestPath, []byte(`{"run_at_utc":"`+toISO(utcNow().AddDate(0, 0, -10))+`"}`), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	if stale, _ := datasetFreshness(datasetPath, manifestPath)["is_stale"].(bool); stale {
+		t.Fatalf("expected a 10-day-old manifest to be fresh with the default threshold")
+	}
+	t.Setenv("VISA_DATASET_STALE_DAYS", "7")
+	if stale, _ := datasetFreshness(datasetPath, manifestPath)["is_stale"].(bool); !stale {
+		t.Fatalf("expected VISA_DATASET_STALE_DAYS=7 to mark a 10-day-old manifest stale")
+	}
+	if _, err := GetDatasetRefreshHistory(map[string]any{"limit": 0}); err == nil {
+		t.Fatalf("expected limit=0 to be rejected")
+	}
+}
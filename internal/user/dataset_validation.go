@@ -0,0 +1,338 @@
+package user
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultDatasetValidationPath = "data/pipeline/dataset_validations.json"
+	maxValidationExamples        = 5
+	// maxPlausibleEmployerFilings is well above the largest single-employer
+	// yearly LCA volume; bigger counts are parsing or merge errors.
+	maxPlausibleEmployerFilings = 250000
+	// rowDropErrorRatio fails validation when a new dataset version loses
+	// more than this share of the previous version's rows.
+	rowDropErrorRatio     = 0.2
+	rowGrowthWarnRatio    = 1.0
+	minContactPhoneDigits = 7
+)
+
+var (
+	datasetRequiredColumns = []string{"company_name", "h1b", "h1b1_chile", "h1b1_singapore", "e3_australian", "green_card"}
+	contactEmailRegex      = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[A-Za-z]{2,}$`)
+	nonDigitRegex          = regexp.MustCompile(`\D`)
+)
+
+func datasetValidationPath() string {
+	return envOrDefault("VISA_DATASET_VALIDATION_PATH", defaultDatasetValidationPath)
+}
+
+// validationIssue collects one kind of problem with a few example rows.
+type validationIssue struct {
+	Check    string
+	Severity string
+	Message  string
+	Count    int
+	Examples []string
+}
+
+func (i *validationIssue) add(example string) {
+	i.Count++
+	if len(i.Examples) < maxValidationExamples {
+		i.Examples = append(i.Examples, example)
+	}
+}
+
+func (i *validationIssue) toMap() map[string]any {
+	return map[string]any{
+		"check":    i.Check,
+		"severity": i.Severity,
+		"message":  i.Message,
+		"count":    i.Count,
+		"examples": i.Examples,
+	}
+}
+
+// datasetStats is what a validation run remembers about a dataset version.
+type datasetStats struct {
+	Rows           int
+	Companies      int
+	TotalVisas     int
+	NameCollisions int
+	ModifiedAt     string
+	ValidatedAt    string
+}
+
+func (s datasetStats) toMap() map[string]any {
+	return map[string]any{
+		"rows":                          s.Rows,
+		"distinct_normalized_companies": s.Companies,
+		"total_visas":                   s.TotalVisas,
+		"name_collisions":               s.NameCollisions,
+		"modified_at_utc":               s.ModifiedAt,
+		"validated_at_utc":              s.ValidatedAt,
+	}
+}
+
+func datasetStatsFromMap(raw map[string]any) (datasetStats, bool) {
+	if len(raw) == 0 {
+		return datasetStats{}, false
+	}
+	return datasetStats{
+		Rows:           intOrZero(raw["rows"]),
+		Companies:      intOrZero(raw["distinct_normalized_companies"]),
+		TotalVisas:     intOrZero(raw["total_visas"]),
+		NameCollisions: intOrZero(raw["name_collisions"]),
+		ModifiedAt:     getString(raw, "modified_at_utc"),
+		ValidatedAt:    getString(raw, "validated_at_utc"),
+	}, true
+}
+
+// scanDatasetFile runs the per-row checks on a companies.csv-shaped file.
+func scanDatasetFile(path string) (datasetStats, []*validationIssue, error) {
+	stats := datasetStats{}
+	file, err := os.Open(path)
+	if err != nil {
+		return stats, nil, fmt.Errorf("dataset not found at '%s': %w", path, err)
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return stats, nil, fmt.Errorf("read dataset header: %w", err)
+	}
+	headerIndex := normalizedHeaderMap(header)
+	canonicalIndex := map[string]int{}
+	for canonical, aliases := range datasetColumnAliases {
+		canonicalIndex[canonical] = findColumnIndex(headerIndex, aliases)
+	}
+
+	missing := &validationIssue{Check: "required_columns", Severity: "error", Message: "Required columns are missing; the dataset cannot be loaded."}
+	for _, column := range datasetRequiredColumns {
+		if canonicalIndex[column] < 0 {
+			missing.add(column)
+		}
+	}
+	if missing.Count > 0 {
+		return stats, []*validationIssue{missing}, nil
+	}
+
+	countColumns := append([]string{}, datasetVisaColumns...)
+	countIndex := map[string]int{}
+	for _, column := range datasetVisaColumns {
+		countIndex[column] = canonicalIndex[column]
+	}
+	for _, column := range registerVisaColumns() {
+		if idx := findColumnIndex(headerIndex, []string{column}); idx >= 0 {
+			countColumns = append(countColumns, column)
+			countIndex[column] = idx
+		}
+	}
+	visaColumnCount := len(countColumns)
+	for _, column := range fiscalYearColumnsFromHeader(headerIndex) {
+		name := fiscalYearColumnName(column.Visa, column.Year)
+		countColumns = append(countColumns, name)
+		countIndex[name] = column.Index
+	}
+
+	blank := &validationIssue{Check: "blank_company_names", Severity: "error", Message: "Rows without a usable company name are dropped at load time."}
+	duplicates := &validationIssue{Check: "duplicate_normalized_names", Severity: "warning", Message: "Several rows normalize to the same company; only the row with the most filings is used."}
+	impossible := &validationIssue{Check: "impossible_counts", Severity: "error", Message: fmt.Sprintf("Visa count cells must be whole numbers between 0 and %d.", maxPlausibleEmployerFilings)}
+	collisions := &validationIssue{Check: "name_collisions", Severity: "warning", Message: "Distinct company names normalize to the same key, so all but one employer are hidden from lookups; see list_company_name_collisions."}
+	contacts := &validationIssue{Check: "malformed_contacts", Severity: "warning", Message: "Contact emails or phone numbers look malformed."}
+	firstRow := map[string]int{}
+	firstName := map[string]string{}
+	collided := map[string]bool{}
+	line := 1
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return stats, nil, fmt.Errorf("read dataset line %d: %w", line, err)
+		}
+		stats.Rows++
+		name := readCSVColumn(row, canonicalIndex["company_name"])
+		normalized := normalizeCompanyName(name)
+		if normalized == "" {
+			blank.add(fmt.Sprintf("line %d", line))
+			continue
+		}
+		if first, seen := firstRow[normalized]; seen {
+			duplicates.add(fmt.Sprintf("line %d %q duplicates line %d", line, name, first))
+			if !collided[normalized] && !sameCompanySpelling(name, firstName[normalized]) {
+				collided[normalized] = true
+				collisions.add(fmt.Sprintf("%q and %q both normalize to %q", firstName[normalized], name, normalized))
+			}
+		} else {
+			firstRow[normalized], firstName[normalized] = line, name
+		}
+		for i, column := range countColumns {
+			raw := readCSVColumn(row, countIndex[column])
+			if raw == "" {
+				continue
+			}
+			value, err := strconv.Atoi(strings.TrimSuffix(strings.ReplaceAll(raw, ",", ""), ".0"))
+			if err != nil || value < 0 || value > maxPlausibleEmployerFilings {
+				impossible.add(fmt.Sprintf("line %d %s=%q", line, column, raw))
+				continue
+			}
+			if i < visaColumnCount {
+				stats.TotalVisas += value
+			}
+		}
+		for _, n := range []string{"1", "2", "3"} {
+			email := readCSVColumn(row, canonicalIndex["email_"+n])
+			if email != "" && !contactEmailRegex.MatchString(email) {
+				contacts.add(fmt.Sprintf("line %d email_%s=%q", line, n, email))
+			}
+			phone := readCSVColumn(row, canonicalIndex["contact_"+n+"_phone"])
+			if phone != "" && len(nonDigitRegex.ReplaceAllString(phone, "")) < minContactPhoneDigits {
+				contacts.add(fmt.Sprintf("line %d contact_%s_phone=%q", line, n, phone))
+			}
+		}
+	}
+	stats.Companies = len(firstRow)
+	stats.NameCollisions = collisions.Count
+	if stats.Rows == 0 {
+		empty := &validationIssue{Check: "no_rows", Severity: "error", Message: "The dataset has a header but no rows."}
+		empty.add(path)
+		return stats, []*validationIssue{empty}, nil
+	}
+	return stats, []*validationIssue{blank, duplicates, collisions, impossible, contacts}, nil
+}
+
+// rowCountDelta compares a dataset version with the previous one and flags
+// large drops, which usually mean a truncated download or a column rename.
+func rowCountDelta(current, previous datasetStats) (map[string]any, *validationIssue) {
+	delta := map[string]any{
+		"previous":         previous.toMap(),
+		"row_delta":        current.Rows - previous.Rows,
+		"company_delta":    current.Companies - previous.Companies,
+		"total_visa_delta": current.TotalVisas - previous.TotalVisas,
+		"row_change_ratio": nil,
+	}
+	if previous.Rows == 0 {
+		return delta, nil
+	}
+	ratio := float64(current.Rows-previous.Rows) / float64(previous.Rows)
+	delta["row_change_ratio"] = float64(int(ratio*1000)) / 1000
+	switch {
+	case -ratio > rowDropErrorRatio:
+		issue := &validationIssue{Check: "row_count_delta", Severity: "error", Message: fmt.Sprintf("Row count dropped by more than %d%% since the previous version.", int(rowDropErrorRatio*100))}
+		issue.add(fmt.Sprintf("%d -> %d rows", previous.Rows, current.Rows))
+		return delta, issue
+	case ratio > rowGrowthWarnRatio:
+		issue := &validationIssue{Check: "row_count_delta", Severity: "warning", Message: "Row count more than doubled since the previous version."}
+		issue.add(fmt.Sprintf("%d -> %d rows", previous.Rows, current.Rows))
+		return delta, issue
+	}
+	return delta, nil
+}
+
+// previousDatasetStats returns the stats of the last validated version of
+// path that differs from the file on disk now.
+func previousDatasetStats(entry map[string]any, modifiedAt string) (datasetStats, bool) {
+	if current, ok := datasetStatsFromMap(asMap(entry["current"])); ok && current.ModifiedAt != modifiedAt {
+		return current, true
+	}
+	return datasetStatsFromMap(asMap(entry["previous"]))
+}
+
+func ValidateCompanyDataset(args map[string]any) (map[string]any, error) {
+	datasetPath := primaryDatasetPath(datasetPathOrDefault(getString(args, "dataset_path")))
+	info, err := os.Stat(datasetPath)
+	if err != nil {
+		return nil, fmt.Errorf("dataset not found at '%s': %w", datasetPath, err)
+	}
+	stats, issues, err := scanDatasetFile(datasetPath)
+	if err != nil {
+		return nil, err
+	}
+	stats.ModifiedAt = toISO(info.ModTime().UTC())
+	stats.ValidatedAt = utcNowISO()
+
+	history := loadJSONMap(datasetValidationPath(), map[string]any{"datasets": map[string]any{}})
+	datasets := asMap(history["datasets"])
+	key := datasetPath
+	if abs, err := filepath.Abs(datasetPath); err == nil {
+		key = abs
+	}
+	entry := asMap(datasets[key])
+
+	var previous datasetStats
+	hasPrevious := false
+	comparedWith := "none"
+	if path := getString(args, "previous_dataset_path"); path != "" {
+		if previous, _, err = scanDatasetFile(path); err != nil {
+			return nil, fmt.Errorf("previous_dataset_path: %w", err)
+		}
+		hasPrevious, comparedWith = true, "previous_dataset_path"
+	} else if previous, hasPrevious = previousDatasetStats(entry, stats.ModifiedAt); hasPrevious {
+		comparedWith = "validation_history"
+	}
+	var delta map[string]any
+	if hasPrevious {
+		var deltaIssue *validationIssue
+		if delta, deltaIssue = rowCountDelta(stats, previous); deltaIssue != nil {
+			issues = append(issues, deltaIssue)
+		}
+	}
+
+	errorsFound, warnings := []any{}, []any{}
+	checks := map[string]any{}
+	for _, issue := range issues {
+		checks[issue.Check] = issue.Count == 0
+		if issue.Count == 0 {
+			continue
+		}
+		if issue.Severity == "error" {
+			errorsFound = append(errorsFound, issue.toMap())
+		} else {
+			warnings = append(warnings, issue.toMap())
+		}
+	}
+	if _, checked := checks["row_count_delta"]; hasPrevious && !checked {
+		checks["row_count_delta"] = true
+	}
+	passed := len(errorsFound) == 0
+
+	if passed {
+		if current, ok := datasetStatsFromMap(asMap(entry["current"])); ok && current.ModifiedAt != stats.ModifiedAt {
+			entry["previous"] = current.toMap()
+		}
+		entry["current"] = stats.toMap()
+		datasets[key] = entry
+		history["datasets"] = datasets
+		if err := saveJSONMap(datasetValidationPath(), history); err != nil {
+			return nil, err
+		}
+	}
+
+	guidance := "Dataset passed validation."
+	if !passed {
+		guidance = "Do not switch searches to this dataset until the errors are fixed; rerun run_internal_dol_pipeline or restore the previous file."
+	}
+	return map[string]any{
+		"dataset_path":    datasetPath,
+		"passed":          passed,
+		"stats":           stats.toMap(),
+		"checks":          checks,
+		"errors":          errorsFound,
+		"warnings":        warnings,
+		"compared_with":   comparedWith,
+		"row_count_delta": delta,
+		"history_path":    datasetValidationPath(),
+		"guidance":        guidance,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeValidationDataset(t *testing.T, path string, rows []string) {
+	t.Helper()
+	header := "company_name,h1b,h1b1_chile,h1b1_singapore,e3_australian,green_card,contact_1,email_1,contact_1_phone"
+	if err := os.WriteFile(path, []byte(strings.Join(append([]string{header}, rows...), "\n")), 0o644); err != nil {
+		t.Fatalf("write dataset: %v", err)
+	}
+}
+
+func validationChecks(result map[string]any) map[string]int {
+	out := map[string]int{}
+	for _, group := range []string{"errors", "warnings"} {
+		for _, raw := range listOrEmpty(result[group]) {
+			issue := asMap(raw)
+			out[getString(issue, "check")] = intOrZero(issue["count"])
+		}
+	}
+	return out
+}
+
+func TestValidateCompanyDatasetReportsAnomalies(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeValidationDataset(t, datasetPath, []string{
+		"Acme Inc,10,0,0,0,2,Alice,alice@acme.com,+1 512 555 0100",
+		"ACME LLC,3,0,0,0,0,,,",
+		"Beta LLC,-4,0,0,0,abc,Bob,bob-at-beta,12",
+		",1,0,0,0,0,,,",
+		"Gamma Corp,1,0,0,0,0,,,",
+	})
+	result, err := ValidateCompanyDataset(map[string]any{"dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("ValidateCompanyDataset failed: %v", err)
+	}
+	checks := validationChecks(result)
+	if result["passed"] != false || checks["impossible_counts"] != 2 || checks["blank_company_names"] != 1 || checks["duplicate_normalized_names"] != 1 || checks["malformed_contacts"] != 2 {
+		t.Fatalf("unexpected validation report: %#v", result)
+	}
+
+	writeValidationDataset(t, datasetPath, []string{
+		"Acme Inc,10,0,0,0,2,,,",
+		"Beta LLC,4,0,0,0,0,,,",
+		"Gamma Corp,1,0,0,0,0,,,",
+		"Delta Co,1,0,0,0,0,,,",
+		"Epsilon Inc,1,0,0,0,0,,,",
+	})
+	first, err := ValidateCompanyDataset(map[string]any{"dataset_path": datasetPath})
+	if err != nil || first["passed"] != true || getString(first, "compared_with") != "none" {
+		t.Fatalf("expected clean dataset to pass: %#v %v", first, err)
+	}
+
+	writeValidationDataset(t, datasetPath, []string{"Acme Inc,10,0,0,0,2,,,", "Beta LLC,4,0,0,0,0,,,"})
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(datasetPath, later, later); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	dropped, err := ValidateCompanyDataset(map[string]any{"dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("ValidateCompanyDataset failed: %v", err)
+	}
+	delta := asMap(dropped["row_count_delta"])
+	if dropped["passed"] != false || getString(dropped, "compared_with") != "validation_history" || intOrZero(delta["row_delta"]) != -3 {
+		t.Fatalf("expected row drop to fail validation: %#v", dropped)
+	}
+
+	missing := filepath.Join(t.TempDir(), "broken.csv")
+	if err := os.WriteFile(missing, []byte("company_name,h1b\nAcme,1\n"), 0o644); err != nil {
+		t.Fatalf("write broken: %v", err)
+	}
+	broken, err := ValidateCompanyDataset(map[string]any{"dataset_path": missing})
+	if err != nil || validationChecks(broken)["required_columns"] != 4 {
+		t.Fatalf("expected missing required columns: %#v %v", broken, err)
+	}
+}
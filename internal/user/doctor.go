@@ -0,0 +1,237 @@
+package user
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type configuredPath struct {
+	Name     string
+	EnvVar   string
+	Path     string
+	Writable bool
+	Required bool
+}
+
+var readOnlyInstallMarkers = []string{
+	"/Cellar/",
+	"/nix/store/",
+	"/snap/",
+	"/Applications/",
+}
+
+func configuredPaths() []configuredPath {
+	return []configuredPath{
+		{Name: "dataset", EnvVar: "VISA_COMPANY_DATASET_PATH", Path: datasetPathOrDefault(""), Writable: false, Required: false},
+		{Name: "manifest", EnvVar: "VISA_DOL_MANIFEST_PATH", Path: envOrDefault("VISA_DOL_MANIFEST_PATH", defaultManifestPath), Writable: false, Required: false},
+		{Name: "preferences", EnvVar: "VISA_USER_PREFS_PATH", Path: prefsPath(), Writable: true, Required: true},
+		{Name: "memory_blob", EnvVar: "VISA_USER_BLOB_PATH", Path: userBlobPath(), Writable: true, Required: true},
+		{Name: "saved_jobs", EnvVar: "VISA_SAVED_JOBS_PATH", Path: savedJobsPath(), Writable: true, Required: true},
+		{Name: "ignored_jobs", EnvVar: "VISA_IGNORED_JOBS_PATH", Path: ignoredJobsPath(), Writable: true, Required: true},
+		{Name: "ignored_companies", EnvVar: "VISA_IGNORED_COMPANIES_PATH", Path: ignoredCompaniesPath(), Writable: true, Required: true},
+		{Name: "search_sessions", EnvVar: "VISA_SEARCH_SESSION_PATH", Path: searchSessionsPath(), Writable: true, Required: true},
+		{Name: "search_runs", EnvVar: "VISA_SEARCH_RUNS_PATH", Path: searchRunsPath(), Writable: true, Required: true},
+		{Name: "job_db", EnvVar: "VISA_JOB_DB_PATH", Path: jobDBPath(), Writable: true, Required: true},
+		{Name: "audit_log", EnvVar: "VISA_AUDIT_LOG_PATH", Path: auditLogPath(), Writable: true, Required: true},
+		{Name: "layout_baseline", EnvVar: "VISA_LAYOUT_BASELINE_PATH", Path: layoutBaselinePath(), Writable: true, Required: false},
+		{Name: "description_cache", EnvVar: "VISA_DESCRIPTION_CACHE_PATH", Path: descriptionCachePath(), Writable: true, Required: false},
+		{Name: "geo_id_cache", EnvVar: "VISA_GEO_ID_CACHE_PATH", Path: geoIDCachePath(), Writable: true, Required: false},
+		{Name: "linkedin_session", EnvVar: "VISA_LINKEDIN_SESSION_PATH", Path: linkedInSessionPath(), Writable: true, Required: false},
+		{Name: "company_page_cache", EnvVar: "VISA_COMPANY_PAGE_CACHE_PATH", Path: companyPageCachePath(), Writable: true, Required: false},
+		{Name: "company_aliases", EnvVar: "VISA_COMPANY_ALIASES_PATH", Path: companyAliasesPath(), Writable: true, Required: false},
+		{Name: "dataset_validations", EnvVar: "VISA_DATASET_VALIDATION_PATH", Path: datasetValidationPath(), Writable: true, Required: false},
+		{Name: "dataset_refresh_history", EnvVar: "VISA_DATASET_REFRESH_HISTORY_PATH", Path: datasetRefreshHistoryPath(), Writable: true, Required: false},
+		{Name: "e_verify_employers", EnvVar: "VISA_E_VERIFY_PATH", Path: eVerifyPath(), Writable: true, Required: false},
+		{Name: "dataset_changes", EnvVar: "VISA_DATASET_CHANGES_PATH", Path: datasetChangesPathFor(envOrDefault("VISA_DOL_MANIFEST_PATH", defaultManifestPath)), Writable: true, Required: false},
+	}
+}
+
+func looksLikeReadOnlyInstall(absPath string) bool {
+	slashed := filepath.ToSlash(absPath)
+	for _, marker := range readOnlyInstallMarkers {
+		if strings.Contains(slashed, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func dirIsWritable(dir string) bool {
+	probe, err := os.CreateTemp(dir, ".visa-jobs-mcp-doctor-*")
+	if err != nil {
+		return false
+	}
+	name := probe.Name()
+	_ = probe.Close()
+	_ = os.Remove(name)
+	return true
+}
+
+func fileIsWritable(path string) bool {
+	handle, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return false
+	}
+	_ = handle.Close()
+	return true
+}
+
+func suggestedEnvFix(cp configuredPath) string {
+	base := filepath.Base(cp.Path)
+	return fmt.Sprintf("export %s=\"$HOME/.visa-jobs-mcp/%s\"", cp.EnvVar, base)
+}
+
+func diagnosePath(cp configuredPath, createMissingDirs bool) map[string]any {
+	absPath, err := filepath.Abs(cp.Path)
+	if err != nil {
+		absPath = cp.Path
+	}
+	parent := filepath.Dir(absPath)
+	envValue := strings.TrimSpace(os.Getenv(cp.EnvVar))
+	issues := []string{}
+	createdDir := false
+
+	exists := false
+	isDir := false
+	if info, err := os.Stat(absPath); err == nil {
+		exists = true
+		isDir = info.IsDir()
+	}
+	parentExists := false
+	if info, err := os.Stat(parent); err == nil && info.IsDir() {
+		parentExists = true
+	}
+	if !parentExists && createMissingDirs && cp.Writable {
+		if err := os.MkdirAll(parent, 0o755); err == nil {
+			parentExists = true
+			createdDir = true
+		} else {
+			issues = append(issues, fmt.Sprintf("could not create parent directory: %v", err))
+		}
+	}
+
+	readable := false
+	if exists && !isDir {
+		if handle, err := os.Open(absPath); err == nil {
+			readable = true
+			_ = handle.Close()
+		}
+	}
+	writable := false
+	if exists && !isDir {
+		writable = fileIsWritable(absPath)
+	} else if !exists && parentExists {
+		writable = dirIsWritable(parent)
+	}
+	readOnlyInstall := looksLikeReadOnlyInstall(absPath)
+
+	if isDir {
+		issues = append(issues, "path points to a directory, expected a file")
+	}
+	if cp.Writable {
+		if !parentExists {
+			issues = append(issues, "parent directory does not exist")
+		} else if !writable {
+			issues = append(issues, "path is not writable")
+		}
+		if readOnlyInstall {
+			issues = append(issues, "path is inside a package install directory that is replaced on upgrade")
+		}
+		if envValue == "" && !filepath.IsAbs(cp.Path) {
+			issues = append(issues, "relative default path resolves against the MCP client's working directory")
+		}
+	} else if cp.Name == "dataset" && !exists {
+		issues = append(issues, "dataset CSV not found; company visa enrichment will be reduced")
+	}
+
+	status := "ok"
+	if len(issues) > 0 {
+		status = "warning"
+		if cp.Required && (!parentExists || !writable || isDir) {
+			status = "error"
+		}
+	}
+	var suggestion any = nil
+	if status != "ok" {
+		if cp.Writable {
+			suggestion = suggestedEnvFix(cp)
+		} else if cp.Name == "dataset" {
+			suggestion = "export VISA_COMPANY_DATASET_PATH=\"/absolute/path/to/companies.csv\""
+		}
+	}
+
+	return map[string]any{
+		"name":               cp.Name,
+		"env_var":            cp.EnvVar,
+		"env_override":       envValue != "",
+		"configured_path":    cp.Path,
+		"absolute_path":      absPath,
+		"exists":             exists,
+		"parent_exists":      parentExists,
+		"readable":           readable,
+		"writable":           writable,
+		"needs_write":        cp.Writable,
+		"read_only_install":  readOnlyInstall,
+		"created_parent_dir": createdDir,
+		"status":             status,
+		"issues":             issues,
+		"suggested_env_fix":  suggestion,
+	}
+}
+
+func DoctorEnvironment(args map[string]any) (map[string]any, error) {
+	createMissingDirs := false
+	if value, has, err := getOptionalBool(args, "create_missing_dirs"); has {
+		if err != nil {
+			return nil, fmt.Errorf("create_missing_dirs must be a boolean when provided")
+		}
+		createMissingDirs = value
+	}
+
+	cwd, _ := os.Getwd()
+	checks := []any{}
+	errorCount := 0
+	warningCount := 0
+	suggestions := []string{}
+	for _, cp := range configuredPaths() {
+		check := diagnosePath(cp, createMissingDirs)
+		switch getString(check, "status") {
+		case "error":
+			errorCount++
+		case "warning":
+			warningCount++
+		}
+		if fix, ok := check["suggested_env_fix"].(string); ok && fix != "" {
+			suggestions = append(suggestions, fix)
+		}
+		checks = append(checks, check)
+	}
+
+	overall := "ok"
+	if warningCount > 0 {
+		overall = "warning"
+	}
+	if errorCount > 0 {
+		overall = "error"
+	}
+	nextActions := []string{}
+	if errorCount > 0 && !createMissingDirs {
+		nextActions = append(nextActions, "Re-run doctor_environment with create_missing_dirs=true to create missing store directories.")
+	}
+	if len(suggestions) > 0 {
+		nextActions = append(nextActions, "Set the suggested environment variables in your MCP client config and restart the server.")
+	}
+
+	return map[string]any{
+		"status":              overall,
+		"working_directory":   cwd,
+		"create_missing_dirs": createMissingDirs,
+		"error_count":         errorCount,
+		"warning_count":       warningCount,
+		"checks":              checks,
+		"suggested_env_fixes": suggestions,
+		"next_actions":        nextActions,
+	}, nil
+}
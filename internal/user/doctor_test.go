@@ -0,0 +1,77 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDoctorEnvironmentReportsAndCreatesMissingDirs(t *testing.T) {
+	setupUserToolPaths(t)
+	root := t.TempDir()
+	nested := filepath.Join(root, "missing", "nested", "saved_jobs.json")
+	t.Setenv("VISA_SAVED_JOBS_PATH", nested)
+	t.Setenv("VISA_COMPANY_DATASET_PATH", filepath.Join(root, "companies.csv"))
+
+	report, err := DoctorEnvironment(map[string]any{})
+	if err != nil {
+		t.Fatalf("DoctorEnvironment failed: %v", err)
+	}
+	if got := getString(report, "status"); got != "error" {
+		t.Fatalf("expected error status for missing parent dir, got %q", got)
+	}
+	check := doctorCheckByName(t, report, "saved_jobs")
+	if parentExists, _ := check["parent_exists"].(bool); parentExists {
+		t.Fatalf("expected missing parent dir, got %#v", check)
+	}
+	if getString(check, "suggested_env_fix") == "" {
+		t.Fatalf("expected suggested env fix, got %#v", check)
+	}
+	dataset := doctorCheckByName(t, report, "dataset")
+	if got := getString(dataset, "status"); got != "warning" {
+		t.Fatalf("expected dataset warning, got %q", got)
+	}
+
+	fixed, err := DoctorEnvironment(map[string]any{"create_missing_dirs": true})
+	if err != nil {
+		t.Fatalf("DoctorEnvironment with create_missing_dirs failed: %v", err)
+	}
+	check = doctorCheckByName(t, fixed, "saved_jobs")
+	if got := getString(check, "status"); got != "ok" {
+		t.Fatalf("expected saved_jobs ok after creating dirs, got %#v", check)
+	}
+	if created, _ := check["created_parent_dir"].(bool); !created {
+		t.Fatalf("expected created_parent_dir=true, got %#v", check)
+	}
+	if _, err := os.Stat(filepath.Dir(nested)); err != nil {
+		t.Fatalf("expected parent directory to exist: %v", err)
+	}
+	if got := intOrZero(fixed["error_count"]); got != 0 {
+		t.Fatalf("expected no errors after fix, got %d", got)
+	}
+
+	if _, err := DoctorEnvironment(map[string]any{"create_missing_dirs": "sometimes"}); err == nil {
+		t.Fatal("expected invalid create_missing_dirs to fail")
+	}
+}
+
+func TestLooksLikeReadOnlyInstall(t *testing.T) {
+	if !looksLikeReadOnlyInstall("/opt/homebrew/Cellar/visa-jobs-mcp/0.3.1/data/config/saved_jobs.json") {
+		t.Fatal("expected Homebrew cellar path to be flagged")
+	}
+	if looksLikeReadOnlyInstall("/Users/me/.visa-jobs-mcp/saved_jobs.json") {
+		t.Fatal("expected home directory path not to be flagged")
+	}
+}
+
+func doctorCheckByName(t *testing.T, report map[string]any, name string) map[string]any {
+	t.Helper()
+	for _, raw := range listOrEmpty(report["checks"]) {
+		check := mapOrNil(raw)
+		if getString(check, "name") == name {
+			return check
+		}
+	}
+	t.Fatalf("missing doctor check %q", name)
+	return nil
+}
@@ -0,0 +1,216 @@
+package user
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultEVerifyPath = "data/e_verify/employers.csv"
+
+var (
+	eVerifyEmployerCols = []string{"Employer Name", "Employer", "Company Name", "Business Name"}
+	eVerifyDBACols      = []string{"Doing Business As", "Doing Business As (DBA) Name", "DBA Name", "DBA"}
+	eVerifyStatusCols   = []string{"Account Status", "Status", "MOU Status"}
+	eVerifyDateCols     = []string{"Enrollment Date", "Date Enrolled", "MOU Date"}
+
+	eVerifyCacheMu sync.Mutex
+	eVerifyCache   = map[string]eVerifyCacheEntry{}
+)
+
+type eVerifyEmployer struct {
+	CompanyName    string
+	EnrollmentDate string
+	HiringSites    int
+}
+
+// eVerifyIndex holds E-Verify participants by normalized company name.
+// Available is false until import_e_verify_employers has run, so callers can
+// tell "not enrolled" from "unknown".
+type eVerifyIndex struct {
+	Available  bool
+	ByCompany  map[string]eVerifyEmployer
+	ImportedAt string
+}
+
+type eVerifyCacheEntry struct {
+	ModTime time.Time
+	Data    eVerifyIndex
+}
+
+func eVerifyPath() string {
+	return envOrDefault("VISA_E_VERIFY_PATH", defaultEVerifyPath)
+}
+
+func loadEVerifyIndex(path string) (eVerifyIndex, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return eVerifyIndex{ByCompany: map[string]eVerifyEmployer{}}, fmt.Errorf("e-verify list not found at '%s': %w", path, err)
+	}
+	eVerifyCacheMu.Lock()
+	if cached, ok := eVerifyCache[path]; ok && cached.ModTime.Equal(info.ModTime().UTC()) {
+		eVerifyCacheMu.Unlock()
+		return cached.Data, nil
+	}
+	eVerifyCacheMu.Unlock()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return eVerifyIndex{ByCompany: map[string]eVerifyEmployer{}}, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return eVerifyIndex{ByCompany: map[string]eVerifyEmployer{}}, fmt.Errorf("read e-verify header: %w", err)
+	}
+	index := normalizedHeaderMap(header)
+	column := func(row []string, name string) string {
+		return readCSVColumn(row, findColumnIndex(index, []string{name}))
+	}
+	out := eVerifyIndex{Available: true, ByCompany: map[string]eVerifyEmployer{}, ImportedAt: toISO(info.ModTime().UTC())}
+	for {
+		row, err := reader.Read()
+		if err != nil {
+			break
+		}
+		if key := normalizeCompanyName(column(row, "company_name")); key != "" {
+			out.ByCompany[key] = eVerifyEmployer{
+				CompanyName:    column(row, "company_name"),
+				EnrollmentDate: column(row, "enrollment_date"),
+				HiringSites:    parseIntCSV(column(row, "hiring_sites")),
+			}
+		}
+	}
+
+	eVerifyCacheMu.Lock()
+	eVerifyCache[path] = eVerifyCacheEntry{ModTime: info.ModTime().UTC(), Data: out}
+	eVerifyCacheMu.Unlock()
+	return out, nil
+}
+
+// enrolled checks the listing's company name and the dataset name it
+// matched. It returns nil when no E-Verify list has been imported.
+func (i eVerifyIndex) enrolled(names ...string) any {
+	if !i.Available {
+		return nil
+	}
+	for _, name := range names {
+		if _, ok := i.ByCompany[normalizeCompanyName(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// eVerifyRows collapses participant rows (one per hiring site) to one row per
+// employer; DBA names get their own rows so listings under a trade name
+// match. Terminated accounts are skipped.
+func eVerifyRows(ctx context.Context, sourcePath string) ([][]string, map[string]any, error) {
+	type employer struct {
+		name, date string
+		sites      int
+	}
+	employers := map[string]*employer{}
+	order := []string{}
+	employerCol, dbaCol, statusCol, dateCol := "", "", "", ""
+	rowsRead := 0
+	skipped := map[string]int{}
+	add := func(name, date string) {
+		key := normalizeCompanyName(name)
+		if key == "" {
+			return
+		}
+		entry := employers[key]
+		if entry == nil {
+			entry = &employer{name: name, date: date}
+			employers[key] = entry
+			order = append(order, key)
+		}
+		entry.sites++
+		if entry.date == "" {
+			entry.date = date
+		}
+	}
+	err := streamDisclosureTable(ctx, sourcePath, func(table *disclosureTable) error {
+		if employerCol = table.pick(eVerifyEmployerCols); employerCol == "" {
+			return fmt.Errorf("e-verify list is missing an employer column (expected one of %s)", strings.Join(eVerifyEmployerCols, ", "))
+		}
+		dbaCol, statusCol, dateCol = table.pick(eVerifyDBACols), table.pick(eVerifyStatusCols), table.pick(eVerifyDateCols)
+		return nil
+	}, func(table *disclosureTable, row []string) error {
+		rowsRead++
+		status := strings.ToLower(table.value(row, statusCol))
+		if strings.Contains(status, "terminat") || strings.Contains(status, "inactive") {
+			skipped["terminated"]++
+			return nil
+		}
+		name := table.value(row, employerCol)
+		if normalizeCompanyName(name) == "" {
+			skipped["blank_employer"]++
+			return nil
+		}
+		date := table.value(row, dateCol)
+		add(name, date)
+		if dba := table.value(row, dbaCol); dba != "" && normalizeCompanyName(dba) != normalizeCompanyName(name) {
+			add(dba, date)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([][]string, 0, len(order))
+	for _, key := range order {
+		entry := employers[key]
+		rows = append(rows, []string{entry.name, entry.date, strconv.Itoa(entry.sites)})
+	}
+	return rows, map[string]any{
+		"employer_col": employerCol,
+		"dba_col":      optionalString(dbaCol),
+		"rows_read":    rowsRead,
+		"rows_skipped": skipped,
+	}, nil
+}
+
+func ImportEVerifyEmployers(args map[string]any) (map[string]any, error) {
+	source := strings.TrimSpace(getString(args, "source"))
+	if source == "" {
+		return nil, fmt.Errorf("source is required (a local CSV/XLSX path or download URL of the E-Verify participating employers list)")
+	}
+	if !supportedDisclosureSource(source) {
+		return nil, fmt.Errorf("source must be a .csv or .xlsx file")
+	}
+	path := firstNonEmpty(strings.TrimSpace(getString(args, "e_verify_path")), eVerifyPath())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(dolPipelineTimeoutSeconds())*time.Second)
+	defer cancel()
+	localPath, err := downloadDOLSource(ctx, source, envOrDefault("VISA_DOL_RAW_DIR", defaultDOLRawDir))
+	if err != nil {
+		return nil, fmt.Errorf("fetch e-verify list: %w", err)
+	}
+	rows, summary, err := eVerifyRows(ctx, localPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no active E-Verify employers found in %s", source)
+	}
+	if err := writeCSVAtomic(path, ".e-verify-*.csv", []string{"company_name", "enrollment_date", "hiring_sites"}, rows); err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"source":            source,
+		"e_verify_path":     path,
+		"employers_written": len(rows),
+		"import":            summary,
+		"imported_at":       utcNowISO(),
+		"data_boundary":     "E-Verify enrollment is required for STEM OPT extensions; it says nothing about visa sponsorship.",
+	}, nil
+}
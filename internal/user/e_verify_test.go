@@ -0,0 +1,91 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func importTestEVerifyList(t *testing.T) map[string]any {
+	t.Helper()
+	sourcePath := filepath.Join(t.TempDir(), "e_verify_participants.csv")
+	body := strings.Join([]string{
+		"Employer Name,Doing Business As,Account Status,Enrollment Date,Hiring Site State",
+		"Acme Inc,Acme Robotics,Active,01/15/2012,NY",
+		"Acme Inc,,Active,01/15/2012,CA",
+		"Globex Corporation,,Terminated,03/01/2010,TX",
+		",Nameless,Active,,WA",
+	}, "\n")
+	if err := os.WriteFile(sourcePath, []byte(body), 0o644); err != nil {
+		t.Fatalf("write e-verify list: %v", err)
+	}
+	result, err := ImportEVerifyEmployers(map[string]any{"source": sourcePath})
+	if err != nil {
+		t.Fatalf("ImportEVerifyEmployers failed: %v", err)
+	}
+	return result
+}
+
+func TestImportEVerifyEmployersCollapsesSitesAndSkipsTerminated(t *testing.T) {
+	setupUserToolPaths(t)
+	result := importTestEVerifyList(t)
+	if intOrZero(result["employers_written"]) != 2 {
+		t.Fatalf("expected Acme and its DBA, got %#v", result)
+	}
+	skipped := asMap(result["import"])["rows_skipped"].(map[string]int)
+	if skipped["terminated"] != 1 || skipped["blank_employer"] != 1 {
+		t.Fatalf("unexpected skipped rows: %#v", skipped)
+	}
+	index, err := loadEVerifyIndex(eVerifyPath())
+	if err != nil {
+		t.Fatalf("load e-verify index: %v", err)
+	}
+	if index.ByCompany["acme"].HiringSites != 2 || index.enrolled("Acme Robotics") != true || index.enrolled("Globex") != false {
+		t.Fatalf("unexpected e-verify index: %#v", index.ByCompany)
+	}
+	if (eVerifyIndex{}).enrolled("Acme") != nil {
+		t.Fatalf("expected unknown enrollment without an imported list")
+	}
+	if _, err := ImportEVerifyEmployers(map[string]any{"source": "list.pdf"}); err == nil {
+		t.Fatalf("expected unsupported sources to be rejected")
+	}
+}
+
+func TestEVerifyEnrollmentInProfileAndSTEMOPTSearch(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	importTestEVerifyList(t)
+
+	profile, err := GetCompanySponsorshipProfile(map[string]any{"company_name": "Acme", "dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("GetCompanySponsorshipProfile failed: %v", err)
+	}
+	if profile["e_verify_enrolled"] != true {
+		t.Fatalf("expected Acme to be E-Verify enrolled, got %#v", profile["e_verify_enrolled"])
+	}
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {{JobURL: "https://www.linkedin.com/jobs/view/acme-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"}},
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":              "u1",
+		"location":             "United States",
+		"job_title":            "Software Engineer",
+		"dataset_path":         datasetPath,
+		"results_wanted":       5,
+		"preferred_visa_types": []any{"stem_opt"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 {
+		t.Fatalf("expected the Acme job, got %#v", jobs)
+	}
+	job := asMap(jobs[0])
+	if job["e_verify_enrolled"] != true || !slices.Contains(getStringList(job, "visa_heuristic_flags"), "e_verify_participant") {
+		t.Fatalf("expected E-Verify enrollment to support STEM OPT, got %#v", job)
+	}
+}
@@ -0,0 +1,220 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHealthProbeTimeoutSec = 5
+	defaultStuckRunAfterSeconds  = 900
+	lowDiskSpaceBytes            = 100 * 1024 * 1024
+)
+
+var linkedInReachabilityProbe = func(timeout time.Duration) (int, error) {
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{Proxy: nil},
+	}
+	req, err := http.NewRequest(http.MethodHead, linkedInSearchURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func stuckRunAfterSeconds() int {
+	value := envInt("VISA_STUCK_RUN_AFTER_SECONDS", defaultStuckRunAfterSeconds)
+	if value < 60 {
+		return 60
+	}
+	return value
+}
+
+func countStuckSearchRuns() (int, int) {
+	active := 0
+	stuck := 0
+	cutoff := utcNow().Add(-time.Duration(stuckRunAfterSeconds()) * time.Second)
+	_ = withSearchRunStore(false, func(store map[string]any) error {
+		for _, raw := range mapOrNil(store["runs"]) {
+			run := mapOrNil(raw)
+			if run == nil {
+				continue
+			}
+			status := strings.ToLower(getString(run, "status"))
+			if status != "pending" && status != "running" && status != "cancelling" {
+				continue
+			}
+			active++
+			updated := parseISOTime(run["updated_at_utc"])
+			if updated.IsZero() {
+				updated = parseISOTime(run["created_at_utc"])
+			}
+			if !updated.IsZero() && updated.Before(cutoff) {
+				stuck++
+			}
+		}
+		return nil
+	})
+	return active, stuck
+}
+
+func nearestExistingDir(path string) string {
+	dir := path
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+func probeLinkedIn(timeout time.Duration) map[string]any {
+	started := time.Now()
+	statusCode, err := linkedInReachabilityProbe(timeout)
+	elapsed := time.Since(started).Milliseconds()
+	out := map[string]any{
+		"url":             linkedInSearchURL,
+		"method":          http.MethodHead,
+		"timeout_seconds": int(timeout.Seconds()),
+		"latency_ms":      elapsed,
+		"status_code":     nil,
+		"reachable":       false,
+		"rate_limited":    false,
+		"error":           nil,
+	}
+	if err != nil {
+		out["error"] = err.Error()
+		return out
+	}
+	out["status_code"] = statusCode
+	out["reachable"] = statusCode > 0 && statusCode < 500
+	out["rate_limited"] = isRateLimitStatus(statusCode)
+	return out
+}
+
+func GetServerHealth(args map[string]any) (map[string]any, error) {
+	probeNetwork := true
+	if value, has, err := getOptionalBool(args, "probe_linkedin"); has {
+		if err != nil {
+			return nil, fmt.Errorf("probe_linkedin must be a boolean when provided")
+		}
+		probeNetwork = value
+	}
+
+	issues := []string{}
+
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
+	datasetCheck := map[string]any{
+		"path":      datasetPath,
+		"available": false,
+		"rows":      0,
+		"error":     nil,
+	}
+	if dataset, err := loadCompanyDataset(datasetPath); err != nil {
+		datasetCheck["error"] = err.Error()
+		issues = append(issues, "Company dataset is unavailable; visa enrichment will be reduced.")
+	} else {
+		datasetCheck["available"] = true
+		datasetCheck["rows"] = dataset.Rows
+	}
+	freshness := datasetFreshness(datasetPath, envOrDefault("VISA_DOL_MANIFEST_PATH", defaultManifestPath))
+	datasetCheck["freshness"] = freshness
+
+	stores := []any{}
+	storesHealthy := true
+	for _, cp := range configuredPaths() {
+		if !cp.Writable {
+			continue
+		}
+		check := diagnosePath(cp, false)
+		ok := getString(check, "status") != "error"
+		if !ok {
+			storesHealthy = false
+		}
+		stores = append(stores, map[string]any{
+			"name":     cp.Name,
+			"path":     check["absolute_path"],
+			"readable": check["readable"],
+			"writable": check["writable"],
+			"exists":   check["exists"],
+			"ok":       ok,
+		})
+	}
+	if !storesHealthy {
+		issues = append(issues, "One or more local stores are not writable; run doctor_environment for fixes.")
+	}
+
+	dataDir := nearestExistingDir(filepath.Dir(searchRunsPath()))
+	if abs, err := filepath.Abs(dataDir); err == nil {
+		dataDir = abs
+	}
+	disk := map[string]any{
+		"path":            dataDir,
+		"free_bytes":      nil,
+		"low_disk_space":  false,
+		"threshold_bytes": lowDiskSpaceBytes,
+		"error":           nil,
+	}
+	if free, err := freeDiskBytes(dataDir); err != nil {
+		disk["error"] = err.Error()
+	} else {
+		disk["free_bytes"] = free
+		if free < lowDiskSpaceBytes {
+			disk["low_disk_space"] = true
+			issues = append(issues, "Data directory is low on disk space.")
+		}
+	}
+
+	activeRuns, stuckRuns := countStuckSearchRuns()
+	if stuckRuns > 0 {
+		issues = append(issues, fmt.Sprintf("%d search run(s) have not progressed in over %d seconds; consider cancelling and restarting them.", stuckRuns, stuckRunAfterSeconds()))
+	}
+
+	linkedIn := map[string]any{"skipped": true}
+	if probeNetwork {
+		linkedIn = probeLinkedIn(time.Duration(defaultHealthProbeTimeoutSec) * time.Second)
+		linkedIn["skipped"] = false
+		if reachable, _ := linkedIn["reachable"].(bool); !reachable {
+			issues = append(issues, "LinkedIn is not reachable; searches will fail until connectivity returns.")
+		} else if limited, _ := linkedIn["rate_limited"].(bool); limited {
+			issues = append(issues, "LinkedIn is currently rate limiting this host; wait before starting long searches.")
+		}
+	}
+
+	status := "healthy"
+	if len(issues) > 0 {
+		status = "degraded"
+	}
+	if !storesHealthy {
+		status = "unhealthy"
+	}
+	return map[string]any{
+		"status":              status,
+		"checked_at_utc":      utcNowISO(),
+		"dataset":             datasetCheck,
+		"stores":              stores,
+		"stores_healthy":      storesHealthy,
+		"linkedin_probe":      linkedIn,
+		"upstream_limiter":    sharedUpstreamLimiter().snapshot(),
+		"circuit_breaker":     sharedCircuitBreaker.snapshot(),
+		"disk":                disk,
+		"active_runs":         activeRuns,
+		"stuck_runs":          stuckRuns,
+		"stuck_after_seconds": stuckRunAfterSeconds(),
+		"issues":              issues,
+	}, nil
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package user
+
+import "fmt"
+
+func freeDiskBytes(_ string) (uint64, error) {
+	return 0, fmt.Errorf("disk space check is not supported on this platform")
+}
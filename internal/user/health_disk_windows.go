@@ -0,0 +1,28 @@
+//go:build windows
+
+package user
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var procGetDiskFreeSpaceEx = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDiskFreeSpaceExW")
+
+func freeDiskBytes(path string) (uint64, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	var freeBytes uint64
+	ok, _, callErr := procGetDiskFreeSpaceEx.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(&freeBytes)),
+		0,
+		0,
+	)
+	if ok == 0 {
+		return 0, callErr
+	}
+	return freeBytes, nil
+}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetServerHealthReportsStuckRunsAndProbe(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	t.Setenv("VISA_COMPANY_DATASET_PATH", datasetPath)
+
+	originalProbe := linkedInReachabilityProbe
+	defer func() {
+		linkedInReachabilityProbe = originalProbe
+	}()
+	probeCalls := 0
+	linkedInReachabilityProbe = func(timeout time.Duration) (int, error) {
+		probeCalls++
+		return 0, errors.New("dial tcp: i/o timeout")
+	}
+
+	stale := toISO(utcNow().Add(-2 * time.Hour))
+	if err := withSearchRunStore(true, func(store map[string]any) error {
+		store["runs"] = map[string]any{
+			"stuck-run": map[string]any{
+				"run_id":         "stuck-run",
+				"status":         "running",
+				"created_at_utc": stale,
+				"updated_at_utc": stale,
+				"expires_at_utc": futureISO(3600),
+				"query":          map[string]any{"user_id": "u1"},
+			},
+			"fresh-run": map[string]any{
+				"run_id":         "fresh-run",
+				"status":         "running",
+				"created_at_utc": utcNowISO(),
+				"updated_at_utc": utcNowISO(),
+				"expires_at_utc": futureISO(3600),
+				"query":          map[string]any{"user_id": "u1"},
+			},
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed runs failed: %v", err)
+	}
+
+	health, err := GetServerHealth(map[string]any{})
+	if err != nil {
+		t.Fatalf("GetServerHealth failed: %v", err)
+	}
+	if probeCalls != 1 {
+		t.Fatalf("expected one LinkedIn probe, got %d", probeCalls)
+	}
+	if got := intOrZero(health["stuck_runs"]); got != 1 {
+		t.Fatalf("expected 1 stuck run, got %d", got)
+	}
+	if got := intOrZero(health["active_runs"]); got != 2 {
+		t.Fatalf("expected 2 active runs, got %d", got)
+	}
+	if available, _ := asMap(health["dataset"])["available"].(bool); !available {
+		t.Fatalf("expected dataset available, got %#v", health["dataset"])
+	}
+	if reachable, _ := asMap(health["linkedin_probe"])["reachable"].(bool); reachable {
+		t.Fatalf("expected LinkedIn unreachable, got %#v", health["linkedin_probe"])
+	}
+	if got := getString(health, "status"); got != "degraded" {
+		t.Fatalf("expected degraded status, got %q", got)
+	}
+	if healthy, _ := health["stores_healthy"].(bool); !healthy {
+		t.Fatalf("expected stores healthy, got %#v", health["stores"])
+	}
+
+	skipped, err := GetServerHealth(map[string]any{"probe_linkedin": false})
+	if err != nil {
+		t.Fatalf("GetServerHealth without probe failed: %v", err)
+	}
+	if probeCalls != 1 {
+		t.Fatalf("expected probe to be skipped, got %d calls", probeCalls)
+	}
+	if isSkipped, _ := asMap(skipped["linkedin_probe"])["skipped"].(bool); !isSkipped {
+		t.Fatalf("expected skipped probe, got %#v", skipped["linkedin_probe"])
+	}
+}
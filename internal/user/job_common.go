@@ -3,6 +3,7 @@ package user
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -25,6 +26,8 @@ var companyLegalSuffixes = map[string]struct{}{
 	"ltd":          {},
 	"lp":           {},
 	"plc":          {},
+	"pty":          {},
+	"limited":      {},
 	"pc":           {},
 	"holdings":     {},
 	"holding":      {},
@@ -64,3 +67,31 @@ func normalizeCompanyName(name string) string {
 	}
 	return strings.Join(tokens, " ")
 }
+
+// getJobIDList reads a list of pipeline job ids such as job_ids.
+func getJobIDList(args map[string]any, key string) ([]int, error) {
+	out := []int{}
+	for _, raw := range getStringList(args, key) {
+		id, ok := intFromAny(raw)
+		if !ok || id < 1 {
+			return nil, fmt.Errorf("%s must be a list of positive integer job ids", key)
+		}
+		if !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+// validateEnumValue lowercases value and checks it against allowed; an empty
+// value becomes fallback.
+func validateEnumValue(field, value, fallback string, allowed []string) (string, error) {
+	clean := strings.ToLower(strings.TrimSpace(value))
+	if clean == "" {
+		return fallback, nil
+	}
+	if !slices.Contains(allowed, clean) {
+		return "", fmt.Errorf("%s must be one of %v", field, allowed)
+	}
+	return clean, nil
+}
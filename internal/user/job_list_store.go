@@ -49,7 +49,7 @@ func normalizeSavedJob(raw any) (map[string]any, bool) {
 	if value, ok := boolFromAny(item["is_remote"]); ok {
 		isRemote = value
 	}
-	return map[string]any{
+	job := map[string]any{
 		"id":                  id,
 		"job_url":             getString(item, "job_url"),
 		"title":               getString(item, "title"),
@@ -74,7 +74,12 @@ func normalizeSavedJob(raw any) (map[string]any, bool) {
 		"source_session_id":   getString(item, "source_session_id"),
 		"saved_at_utc":        getString(item, "saved_at_utc"),
 		"updated_at_utc":      getString(item, "updated_at_utc"),
-	}, true
+		"tags":                jobTags(item),
+	}
+	if score := mapOrNil(item["visa_score"]); score != nil {
+		job["visa_score"] = score
+	}
+	return job, true
 }
 
 func normalizeIgnoredJob(raw any) (map[string]any, bool) {
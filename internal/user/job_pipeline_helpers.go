@@ -205,6 +205,25 @@ func appendJobNote(entry map[string]any, userID string, jobID int, note string)
 	return existing, event, nil
 }
 
+// appendPipelineEvent records a pipeline event that does not go through
+// setJobStage, such as an interview update that leaves the stage alone.
+func appendPipelineEvent(entry map[string]any, userID string, jobID int, fromStage, toStage, reason, note string) map[string]any {
+	nextEventID, _ := intFromAny(entry["next_event_id"])
+	event := map[string]any{
+		"id":             nextEventID,
+		"user_id":        userID,
+		"job_id":         jobID,
+		"from_stage":     fromStage,
+		"to_stage":       toStage,
+		"reason":         reason,
+		"note":           strings.TrimSpace(note),
+		"created_at_utc": utcNowISO(),
+	}
+	entry["events"] = append(entry["events"].([]map[string]any), event)
+	entry["next_event_id"] = nextEventID + 1
+	return event
+}
+
 func jobSnapshot(entry map[string]any, userID string, jobID int) (map[string]any, error) {
 	job := getJobByID(entry, jobID)
 	if job == nil {
@@ -213,39 +232,47 @@ func jobSnapshot(entry map[string]any, userID string, jobID int) (map[string]any
 	_, app := findApplicationIndex(entry, jobID)
 	if app == nil {
 		return map[string]any{
-			"job_id":               jobID,
-			"user_id":              userID,
-			"result_id":            getString(job, "result_id"),
-			"job_url":              getString(job, "job_url"),
-			"title":                getString(job, "title"),
-			"company":              getString(job, "company"),
-			"location":             getString(job, "location"),
-			"site":                 getString(job, "site"),
-			"created_at_utc":       getString(job, "created_at_utc"),
-			"updated_at_utc":       getString(job, "updated_at_utc"),
-			"stage":                "new",
-			"applied_at_utc":       "",
-			"source_session_id":    "",
-			"note":                 "",
-			"stage_updated_at_utc": nil,
+			"job_id":                 jobID,
+			"user_id":                userID,
+			"result_id":              getString(job, "result_id"),
+			"job_url":                getString(job, "job_url"),
+			"title":                  getString(job, "title"),
+			"company":                getString(job, "company"),
+			"location":               getString(job, "location"),
+			"site":                   getString(job, "site"),
+			"created_at_utc":         getString(job, "created_at_utc"),
+			"updated_at_utc":         getString(job, "updated_at_utc"),
+			"posting_status":         getString(job, "posting_status"),
+			"posting_checked_at_utc": getString(job, "posting_checked_at_utc"),
+			"tags":                   jobTags(job),
+			"artifacts":              jobArtifacts(entry, jobID),
+			"stage":                  "new",
+			"applied_at_utc":         "",
+			"source_session_id":      "",
+			"note":                   "",
+			"stage_updated_at_utc":   nil,
 		}, nil
 	}
 	return map[string]any{
-		"job_id":               jobID,
-		"user_id":              userID,
-		"result_id":            getString(job, "result_id"),
-		"job_url":              getString(job, "job_url"),
-		"title":                getString(job, "title"),
-		"company":              getString(job, "company"),
-		"location":             getString(job, "location"),
-		"site":                 getString(job, "site"),
-		"created_at_utc":       getString(job, "created_at_utc"),
-		"updated_at_utc":       getString(job, "updated_at_utc"),
-		"stage":                getString(app, "stage"),
-		"applied_at_utc":       getString(app, "applied_at_utc"),
-		"source_session_id":    getString(app, "source_session_id"),
-		"note":                 getString(app, "note"),
-		"stage_updated_at_utc": app["updated_at_utc"],
+		"job_id":                 jobID,
+		"user_id":                userID,
+		"result_id":              getString(job, "result_id"),
+		"job_url":                getString(job, "job_url"),
+		"title":                  getString(job, "title"),
+		"company":                getString(job, "company"),
+		"location":               getString(job, "location"),
+		"site":                   getString(job, "site"),
+		"created_at_utc":         getString(job, "created_at_utc"),
+		"updated_at_utc":         getString(job, "updated_at_utc"),
+		"posting_status":         getString(job, "posting_status"),
+		"posting_checked_at_utc": getString(job, "posting_checked_at_utc"),
+		"tags":                   jobTags(job),
+		"artifacts":              jobArtifacts(entry, jobID),
+		"stage":                  getString(app, "stage"),
+		"applied_at_utc":         getString(app, "applied_at_utc"),
+		"source_session_id":      getString(app, "source_session_id"),
+		"note":                   getString(app, "note"),
+		"stage_updated_at_utc":   app["updated_at_utc"],
 	}, nil
 }
 
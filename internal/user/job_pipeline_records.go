@@ -0,0 +1,239 @@
+package user
+
+// pipelineRecordList is a per-job record list kept next to applications in
+// the pipeline store, with its id counter. Normalizers drop rows without a
+// positive id and job_id and reset unknown enum values to their defaults.
+type pipelineRecordList struct {
+	Key       string
+	NextIDKey string
+	Normalize func(any, string) (map[string]any, bool)
+}
+
+// pipelineRecordLists are normalized with every pipeline load and exported
+// and deleted with the rest of the user's job management data.
+var pipelineRecordLists = []pipelineRecordList{
+	{Key: "interviews", NextIDKey: "next_interview_id", Normalize: normalizePipelineInterview},
+	{Key: "followups", NextIDKey: "next_followup_id", Normalize: normalizePipelineFollowup},
+	{Key: "offers", NextIDKey: "next_offer_id", Normalize: normalizePipelineOffer},
+	{Key: "contacts", NextIDKey: "next_contact_id", Normalize: normalizePipelineContact},
+	{Key: "contact_interactions", NextIDKey: "next_contact_interaction_id", Normalize: normalizePipelineContactInteraction},
+	{Key: "artifacts", NextIDKey: "next_artifact_id", Normalize: normalizePipelineArtifact},
+}
+
+func normalizePipelineInterview(raw any, userID string) (map[string]any, bool) {
+	item := mapOrNil(raw)
+	if item == nil {
+		return nil, false
+	}
+	id, ok := intFromAny(item["id"])
+	if !ok || id < 1 {
+		return nil, false
+	}
+	jobID, ok := intFromAny(item["job_id"])
+	if !ok || jobID < 1 {
+		return nil, false
+	}
+	round, ok := intFromAny(item["round"])
+	if !ok || round < 1 {
+		round = 1
+	}
+	interviewType, err := validateInterviewType(getString(item, "interview_type"))
+	if err != nil {
+		interviewType = "other"
+	}
+	outcome, err := validateInterviewOutcome(getString(item, "outcome"))
+	if err != nil {
+		outcome = "pending"
+	}
+	return map[string]any{
+		"id":               id,
+		"user_id":          userID,
+		"job_id":           jobID,
+		"round":            round,
+		"interview_type":   interviewType,
+		"scheduled_at_utc": getString(item, "scheduled_at_utc"),
+		"interviewer":      getString(item, "interviewer"),
+		"outcome":          outcome,
+		"note":             getString(item, "note"),
+		"created_at_utc":   getString(item, "created_at_utc"),
+		"updated_at_utc":   getString(item, "updated_at_utc"),
+	}, true
+}
+
+func normalizePipelineFollowup(raw any, userID string) (map[string]any, bool) {
+	item := mapOrNil(raw)
+	if item == nil {
+		return nil, false
+	}
+	id, ok := intFromAny(item["id"])
+	if !ok || id < 1 {
+		return nil, false
+	}
+	jobID, ok := intFromAny(item["job_id"])
+	if !ok || jobID < 1 {
+		return nil, false
+	}
+	kind, err := validateFollowupKind(getString(item, "kind"))
+	if err != nil {
+		kind = "other"
+	}
+	status, err := validateFollowupStatus(getString(item, "status"))
+	if err != nil {
+		status = "pending"
+	}
+	return map[string]any{
+		"id":               id,
+		"user_id":          userID,
+		"job_id":           jobID,
+		"kind":             kind,
+		"due_at_utc":       getString(item, "due_at_utc"),
+		"status":           status,
+		"note":             getString(item, "note"),
+		"completed_at_utc": getString(item, "completed_at_utc"),
+		"created_at_utc":   getString(item, "created_at_utc"),
+		"updated_at_utc":   getString(item, "updated_at_utc"),
+	}, true
+}
+
+func normalizePipelineOffer(raw any, userID string) (map[string]any, bool) {
+	item := mapOrNil(raw)
+	if item == nil {
+		return nil, false
+	}
+	id, ok := intFromAny(item["id"])
+	if !ok || id < 1 {
+		return nil, false
+	}
+	jobID, ok := intFromAny(item["job_id"])
+	if !ok || jobID < 1 {
+		return nil, false
+	}
+	status, err := validateOfferStatus(getString(item, "status"))
+	if err != nil {
+		status = "pending"
+	}
+	var sponsorsVisa any
+	if value, ok := item["sponsors_visa"].(bool); ok {
+		sponsorsVisa = value
+	}
+	row := map[string]any{
+		"id":                    id,
+		"user_id":               userID,
+		"job_id":                jobID,
+		"currency":              firstNonEmpty(getString(item, "currency"), "USD"),
+		"start_date":            getString(item, "start_date"),
+		"sponsorship_terms":     getString(item, "sponsorship_terms"),
+		"sponsors_visa":         sponsorsVisa,
+		"decision_deadline_utc": getString(item, "decision_deadline_utc"),
+		"status":                status,
+		"note":                  getString(item, "note"),
+		"created_at_utc":        getString(item, "created_at_utc"),
+		"updated_at_utc":        getString(item, "updated_at_utc"),
+	}
+	for _, field := range offerAmountFields {
+		row[field] = intOrZero(item[field])
+	}
+	return row, true
+}
+
+func normalizePipelineContact(raw any, userID string) (map[string]any, bool) {
+	item := mapOrNil(raw)
+	if item == nil {
+		return nil, false
+	}
+	id, ok := intFromAny(item["id"])
+	if !ok || id < 1 {
+		return nil, false
+	}
+	jobID, ok := intFromAny(item["job_id"])
+	if !ok || jobID < 1 {
+		return nil, false
+	}
+	role, err := validateContactRole(getString(item, "role"))
+	if err != nil {
+		role = "other"
+	}
+	return map[string]any{
+		"id":             id,
+		"user_id":        userID,
+		"job_id":         jobID,
+		"name":           getString(item, "name"),
+		"email":          getString(item, "email"),
+		"linkedin_url":   getString(item, "linkedin_url"),
+		"role":           role,
+		"source":         getString(item, "source"),
+		"note":           getString(item, "note"),
+		"created_at_utc": getString(item, "created_at_utc"),
+		"updated_at_utc": getString(item, "updated_at_utc"),
+	}, true
+}
+
+func normalizePipelineContactInteraction(raw any, userID string) (map[string]any, bool) {
+	item := mapOrNil(raw)
+	if item == nil {
+		return nil, false
+	}
+	id, ok := intFromAny(item["id"])
+	if !ok || id < 1 {
+		return nil, false
+	}
+	jobID, ok := intFromAny(item["job_id"])
+	if !ok || jobID < 1 {
+		return nil, false
+	}
+	contactID, ok := intFromAny(item["contact_id"])
+	if !ok || contactID < 1 {
+		return nil, false
+	}
+	channel, err := validateContactChannel(getString(item, "channel"))
+	if err != nil {
+		channel = "other"
+	}
+	direction, err := validateContactDirection(getString(item, "direction"))
+	if err != nil {
+		direction = "outbound"
+	}
+	return map[string]any{
+		"id":              id,
+		"user_id":         userID,
+		"job_id":          jobID,
+		"contact_id":      contactID,
+		"channel":         channel,
+		"direction":       direction,
+		"summary":         getString(item, "summary"),
+		"occurred_at_utc": getString(item, "occurred_at_utc"),
+		"created_at_utc":  getString(item, "created_at_utc"),
+	}, true
+}
+
+func normalizePipelineArtifact(raw any, userID string) (map[string]any, bool) {
+	item := mapOrNil(raw)
+	if item == nil {
+		return nil, false
+	}
+	id, ok := intFromAny(item["id"])
+	if !ok || id < 1 {
+		return nil, false
+	}
+	jobID, ok := intFromAny(item["job_id"])
+	if !ok || jobID < 1 {
+		return nil, false
+	}
+	kind, err := validateArtifactKind(getString(item, "kind"))
+	if err != nil {
+		kind = "other"
+	}
+	urlOrPath := getString(item, "url_or_path")
+	return map[string]any{
+		"id":             id,
+		"user_id":        userID,
+		"job_id":         jobID,
+		"name":           getString(item, "name"),
+		"kind":           kind,
+		"url_or_path":    urlOrPath,
+		"link_type":      artifactLinkType(urlOrPath),
+		"note":           getString(item, "note"),
+		"created_at_utc": getString(item, "created_at_utc"),
+		"updated_at_utc": getString(item, "updated_at_utc"),
+	}, true
+}
@@ -23,16 +23,21 @@ func normalizePipelineJob(raw any, userID string) (map[string]any, bool) {
 		return nil, false
 	}
 	return map[string]any{
-		"id":             id,
-		"user_id":        userID,
-		"result_id":      getString(item, "result_id"),
-		"job_url":        getString(item, "job_url"),
-		"title":          getString(item, "title"),
-		"company":        getString(item, "company"),
-		"location":       getString(item, "location"),
-		"site":           getString(item, "site"),
-		"created_at_utc": getString(item, "created_at_utc"),
-		"updated_at_utc": getString(item, "updated_at_utc"),
+		"id":                     id,
+		"user_id":                userID,
+		"result_id":              getString(item, "result_id"),
+		"job_url":                getString(item, "job_url"),
+		"title":                  getString(item, "title"),
+		"company":                getString(item, "company"),
+		"location":               getString(item, "location"),
+		"site":                   getString(item, "site"),
+		"created_at_utc":         getString(item, "created_at_utc"),
+		"updated_at_utc":         getString(item, "updated_at_utc"),
+		"posting_status":         getString(item, "posting_status"),
+		"posting_closed_reason":  getString(item, "posting_closed_reason"),
+		"posting_checked_at_utc": getString(item, "posting_checked_at_utc"),
+		"tags":                   jobTags(item),
+		"merged_job_urls":        append([]string{}, getStringList(item, "merged_job_urls")...),
 	}, true
 }
 
@@ -142,6 +147,39 @@ func normalizePipelineEvents(list []any, userID string) []map[string]any {
 	return out
 }
 
+// normalizePipelineRows normalizes one of the pipelineRecordLists and sorts
+// it by id.
+func normalizePipelineRows(list []any, userID string, normalize func(any, string) (map[string]any, bool)) []map[string]any {
+	out := make([]map[string]any, 0, len(list))
+	for _, raw := range list {
+		row, ok := normalize(raw, userID)
+		if ok {
+			out = append(out, row)
+		}
+	}
+	slices.SortFunc(out, func(a, b map[string]any) int {
+		ai, _ := intFromAny(a["id"])
+		bi, _ := intFromAny(b["id"])
+		return ai - bi
+	})
+	return out
+}
+
+// ensureNextPipelineID keeps entry[key] ahead of every id already in rows.
+func ensureNextPipelineID(entry map[string]any, key string, rows []map[string]any) {
+	maxID := 0
+	for _, row := range rows {
+		if id, ok := intFromAny(row["id"]); ok && id > maxID {
+			maxID = id
+		}
+	}
+	nextID, ok := intFromAny(entry[key])
+	if !ok || nextID <= maxID {
+		nextID = maxID + 1
+	}
+	entry[key] = nextID
+}
+
 func ensurePipelineEntry(data map[string]any, userID string) map[string]any {
 	users := ensureUsersMap(data)
 	entry := mapOrNil(users[userID])
@@ -156,6 +194,11 @@ func ensurePipelineEntry(data map[string]any, userID string) map[string]any {
 	entry["jobs"] = jobs
 	entry["applications"] = apps
 	entry["events"] = events
+	for _, list := range pipelineRecordLists {
+		rows := normalizePipelineRows(listOrEmpty(entry[list.Key]), userID, list.Normalize)
+		entry[list.Key] = rows
+		ensureNextPipelineID(entry, list.NextIDKey, rows)
+	}
 
 	maxJobID := 0
 	for _, row := range jobs {
@@ -214,6 +257,9 @@ func getPipelineEntry(data map[string]any, userID string) map[string]any {
 	entry["jobs"] = normalizePipelineJobs(listOrEmpty(entry["jobs"]), userID)
 	entry["applications"] = normalizePipelineApplications(listOrEmpty(entry["applications"]), userID)
 	entry["events"] = normalizePipelineEvents(listOrEmpty(entry["events"]), userID)
+	for _, list := range pipelineRecordLists {
+		entry[list.Key] = normalizePipelineRows(listOrEmpty(entry[list.Key]), userID, list.Normalize)
+	}
 	return entry
 }
 
@@ -227,6 +273,8 @@ func getJobByID(entry map[string]any, jobID int) map[string]any {
 	return nil
 }
 
+// getJobByURL matches a job's own URL or, after merge_pipeline_jobs, the URL
+// of a duplicate merged into it.
 func getJobByURL(entry map[string]any, jobURL string) map[string]any {
 	clean := strings.ToLower(strings.TrimSpace(jobURL))
 	for _, row := range entry["jobs"].([]map[string]any) {
@@ -234,5 +282,12 @@ func getJobByURL(entry map[string]any, jobURL string) map[string]any {
 			return row
 		}
 	}
+	for _, row := range entry["jobs"].([]map[string]any) {
+		for _, merged := range getStringList(row, "merged_job_urls") {
+			if strings.ToLower(merged) == clean {
+				return row
+			}
+		}
+	}
 	return nil
 }
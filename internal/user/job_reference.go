@@ -34,7 +34,11 @@ func resolveJobReference(args map[string]any, userID string) (map[string]any, er
 			"job_function":             "",
 			"job_url_direct":           "",
 			"is_remote":                nil,
+			"workplace_type":           nil,
+			"constraint_effects":       []any{},
+			"constraint_mismatch":      false,
 			"employer_contacts":        []any{},
+			"company_facts":            []any{},
 			"visa_counts":              map[string]any{},
 			"visas_sponsored":          []any{},
 			"eligibility_reasons":      []any{},
@@ -94,7 +98,11 @@ func resolveJobReference(args map[string]any, userID string) (map[string]any, er
 				"job_function":             getString(item, "job_function"),
 				"job_url_direct":           getString(item, "job_url_direct"),
 				"is_remote":                item["is_remote"],
+				"workplace_type":           item["workplace_type"],
+				"constraint_effects":       listOrEmpty(item["constraint_effects"]),
+				"constraint_mismatch":      boolOrFalse(item["constraint_mismatch"]),
 				"employer_contacts":        listOrEmpty(item["employer_contacts"]),
+				"company_facts":            listOrEmpty(item["company_facts"]),
 				"visa_counts":              asMap(item["visa_counts"]),
 				"visas_sponsored":          listOrEmpty(item["visas_sponsored"]),
 				"visa_match_strength":      getString(item, "visa_match_strength"),
@@ -0,0 +1,129 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+)
+
+var validArtifactKinds = []string{"resume", "cover_letter", "take_home", "portfolio", "offer_letter", "other"}
+
+func validateArtifactKind(value string) (string, error) {
+	return validateEnumValue("kind", value, "other", validArtifactKinds)
+}
+
+// artifactLinkType reports whether an artifact points at a web URL or a
+// local file path.
+func artifactLinkType(urlOrPath string) string {
+	lower := strings.ToLower(urlOrPath)
+	if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") {
+		return "url"
+	}
+	return "path"
+}
+
+// jobArtifacts returns the artifacts attached to jobID in the order they were
+// attached.
+func jobArtifacts(entry map[string]any, jobID int) []map[string]any {
+	out := []map[string]any{}
+	rows, _ := entry["artifacts"].([]map[string]any)
+	for _, row := range rows {
+		if id, _ := intFromAny(row["job_id"]); id == jobID {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// AttachJobArtifact records a named link or local path (the resume version
+// sent, a cover letter, a take-home repo) on a pipeline job, or updates the
+// artifact named by artifact_id. Only the reference is stored; files are
+// never copied or read.
+func AttachJobArtifact(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+
+	var artifact map[string]any
+	created := false
+	artifactID, hasArtifactID, err := getOptionalInt(args, "artifact_id")
+	if hasArtifactID {
+		if err != nil {
+			return nil, fmt.Errorf("artifact_id must be an integer when provided")
+		}
+		for _, row := range entry["artifacts"].([]map[string]any) {
+			if id, _ := intFromAny(row["id"]); id == artifactID {
+				artifact = row
+				break
+			}
+		}
+		if artifact == nil {
+			return nil, fmt.Errorf("artifact_id=%d not found for user_id='%s'", artifactID, userID)
+		}
+	} else {
+		jobID, _, err := resolveJobManagementTarget(entry, args, userID)
+		if err != nil {
+			return nil, err
+		}
+		nextID, _ := intFromAny(entry["next_artifact_id"])
+		artifact = map[string]any{
+			"id":             nextID,
+			"user_id":        userID,
+			"job_id":         jobID,
+			"name":           "",
+			"kind":           "other",
+			"url_or_path":    "",
+			"note":           "",
+			"created_at_utc": utcNowISO(),
+		}
+		created = true
+	}
+
+	if hasKey(args, "name") {
+		artifact["name"] = normalizeWhitespace(getString(args, "name"))
+	}
+	if hasKey(args, "kind") {
+		kind, err := validateArtifactKind(getString(args, "kind"))
+		if err != nil {
+			return nil, err
+		}
+		artifact["kind"] = kind
+	}
+	if hasKey(args, "url_or_path") {
+		artifact["url_or_path"] = getString(args, "url_or_path")
+	}
+	if hasKey(args, "note") {
+		artifact["note"] = getString(args, "note")
+	}
+	if getString(artifact, "name") == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if getString(artifact, "url_or_path") == "" {
+		return nil, fmt.Errorf("url_or_path is required")
+	}
+	artifact["link_type"] = artifactLinkType(getString(artifact, "url_or_path"))
+	artifact["updated_at_utc"] = utcNowISO()
+	if created {
+		nextID, _ := intFromAny(artifact["id"])
+		entry["artifacts"] = append(entry["artifacts"].([]map[string]any), artifact)
+		entry["next_artifact_id"] = nextID + 1
+	}
+
+	if err := saveJobPipeline(pipeline); err != nil {
+		return nil, err
+	}
+	jobID, _ := intFromAny(artifact["job_id"])
+	snapshot, err := jobSnapshot(entry, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":     userID,
+		"job":         snapshot,
+		"artifact":    artifact,
+		"created":     created,
+		"job_db_path": jobDBPath(),
+	}, nil
+}
@@ -0,0 +1,62 @@
+package user
+
+import "testing"
+
+func TestAttachJobArtifact(t *testing.T) {
+	setupUserToolPaths(t)
+
+	attached, err := AttachJobArtifact(map[string]any{
+		"user_id":     "u1",
+		"job_url":     "https://example.com/jobs/artifact-1",
+		"name":        "Resume v3 (backend)",
+		"kind":        "resume",
+		"url_or_path": "~/Documents/resume-v3.pdf",
+	})
+	if err != nil {
+		t.Fatalf("AttachJobArtifact failed: %v", err)
+	}
+	artifact := asMap(attached["artifact"])
+	if got := getString(artifact, "link_type"); got != "path" {
+		t.Fatalf("expected a path artifact, got %q", got)
+	}
+	artifactID, _ := intFromAny(artifact["id"])
+
+	if _, err := AttachJobArtifact(map[string]any{
+		"user_id":     "u1",
+		"job_url":     "https://example.com/jobs/artifact-1",
+		"name":        "Take-home",
+		"kind":        "take_home",
+		"url_or_path": "https://github.com/example/take-home",
+	}); err != nil {
+		t.Fatalf("AttachJobArtifact failed: %v", err)
+	}
+	updated, err := AttachJobArtifact(map[string]any{"user_id": "u1", "artifact_id": artifactID, "note": "sent with the application"})
+	if err != nil {
+		t.Fatalf("updating the artifact failed: %v", err)
+	}
+	if updated["created"] != false {
+		t.Fatalf("expected an update, got %#v", updated)
+	}
+
+	stage, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/artifact-1", "stage": "applied"})
+	if err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+	artifacts := listOrEmpty(asMap(stage["job"])["artifacts"])
+	if len(artifacts) != 2 {
+		t.Fatalf("expected 2 artifacts on the job snapshot, got %#v", asMap(stage["job"])["artifacts"])
+	}
+	if got := getString(asMap(artifacts[0]), "note"); got != "sent with the application" {
+		t.Fatalf("expected the updated note, got %q", got)
+	}
+	if got := getString(asMap(artifacts[1]), "link_type"); got != "url" {
+		t.Fatalf("expected a url artifact, got %q", got)
+	}
+
+	if _, err := AttachJobArtifact(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/artifact-1", "name": "No link"}); err == nil {
+		t.Fatalf("expected url_or_path to be required")
+	}
+	if _, err := AttachJobArtifact(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/artifact-1", "name": "x", "url_or_path": "x", "kind": "selfie"}); err == nil {
+		t.Fatalf("expected an invalid kind to fail")
+	}
+}
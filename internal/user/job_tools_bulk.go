@@ -0,0 +1,114 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+)
+
+const maxBulkStageUpdates = 100
+
+// BulkUpdateJobStage moves many pipeline jobs to one stage in a single load
+// and save. Each job gets one event; jobs that cannot be resolved or that
+// break a transition rule are reported in failed and left unchanged.
+func BulkUpdateJobStage(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	cleanStage, err := validateJobStage(getString(args, "stage"))
+	if err != nil {
+		return nil, err
+	}
+	force, err := parseForce(args)
+	if err != nil {
+		return nil, err
+	}
+	jobIDs, err := getJobIDList(args, "job_ids")
+	if err != nil {
+		return nil, err
+	}
+	resultIDs := getStringList(args, "result_ids")
+	if len(jobIDs)+len(resultIDs) == 0 {
+		return nil, fmt.Errorf("job_ids or result_ids is required")
+	}
+	if len(jobIDs)+len(resultIDs) > maxBulkStageUpdates {
+		return nil, fmt.Errorf("bulk_update_job_stage accepts at most %d jobs per call", maxBulkStageUpdates)
+	}
+
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+	targets := []map[string]any{}
+	for _, jobID := range jobIDs {
+		targets = append(targets, map[string]any{"job_id": jobID})
+	}
+	sourceSessionID := getString(args, "session_id")
+	for _, resultID := range resultIDs {
+		target := map[string]any{"result_id": resultID}
+		if sourceSessionID != "" {
+			target["session_id"] = sourceSessionID
+		}
+		targets = append(targets, target)
+	}
+
+	note := getString(args, "note")
+	reason := "bulk_update_job_stage"
+	updated := []any{}
+	failed := []any{}
+	seen := map[int]bool{}
+	for _, target := range targets {
+		jobCount := len(entry["jobs"].([]map[string]any))
+		nextJobID, _ := intFromAny(entry["next_job_id"])
+		jobID, _, err := resolveJobManagementTarget(entry, target, userID)
+		if err == nil && seen[jobID] {
+			continue
+		}
+		var overridden string
+		if err == nil {
+			overridden, err = checkStageTransition(entry, userID, jobID, cleanStage, force)
+		}
+		if err != nil {
+			// Resolving a result_id creates its pipeline job; drop it so a
+			// failed target is not saved alongside the successful ones.
+			entry["jobs"] = entry["jobs"].([]map[string]any)[:jobCount]
+			entry["next_job_id"] = nextJobID
+			target["error"] = err.Error()
+			failed = append(failed, target)
+			continue
+		}
+		seen[jobID] = true
+		source := sourceSessionID
+		if resultID := getString(target, "result_id"); source == "" && strings.Contains(resultID, ":") {
+			source = strings.TrimSpace(strings.SplitN(resultID, ":", 2)[0])
+		}
+		_, event, err := setJobStage(entry, userID, jobID, cleanStage, note, source, "", forcedReason(reason, overridden))
+		if err != nil {
+			target["error"] = err.Error()
+			failed = append(failed, target)
+			continue
+		}
+		snapshot, err := jobSnapshot(entry, userID, jobID)
+		if err != nil {
+			return nil, err
+		}
+		updated = append(updated, map[string]any{
+			"job":             snapshot,
+			"event":           event,
+			"overridden_rule": optionalString(overridden),
+		})
+	}
+
+	if len(updated) > 0 {
+		if err := saveJobPipeline(pipeline); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]any{
+		"user_id":       userID,
+		"stage":         cleanStage,
+		"updated_count": len(updated),
+		"failed_count":  len(failed),
+		"updated":       updated,
+		"failed":        failed,
+		"job_db_path":   jobDBPath(),
+	}, nil
+}
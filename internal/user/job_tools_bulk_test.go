@@ -0,0 +1,101 @@
+package user
+
+import "testing"
+
+func TestBulkUpdateJobStage(t *testing.T) {
+	setupUserToolPaths(t)
+
+	jobIDs := []any{}
+	for _, url := range []string{"https://example.com/jobs/bulk-1", "https://example.com/jobs/bulk-2", "https://example.com/jobs/bulk-3"} {
+		saved, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": url, "stage": "saved"})
+		if err != nil {
+			t.Fatalf("UpdateJobStage failed: %v", err)
+		}
+		jobIDs = append(jobIDs, asMap(saved["job"])["job_id"])
+	}
+
+	result, err := BulkUpdateJobStage(map[string]any{
+		"user_id":    "u1",
+		"stage":      "applied",
+		"job_ids":    append(jobIDs, 999, jobIDs[0]),
+		"result_ids": []any{"no-session-prefix"},
+		"note":       "batch session",
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdateJobStage failed: %v", err)
+	}
+	if got, _ := result["updated_count"].(int); got != 3 {
+		t.Fatalf("expected 3 updated jobs (duplicates skipped), got %#v", result["updated_count"])
+	}
+	if got, _ := result["failed_count"].(int); got != 2 {
+		t.Fatalf("expected the unknown job_id and bare result_id to fail, got %#v", result["failed"])
+	}
+
+	applied, _ := ListJobsByStage(map[string]any{"user_id": "u1", "stage": "applied"})
+	if got, _ := applied["total_jobs"].(int); got != 3 {
+		t.Fatalf("expected 3 applied jobs, got %#v", applied["total_jobs"])
+	}
+	events, _ := ListRecentJobEvents(map[string]any{"user_id": "u1"})
+	bulkEvents := 0
+	for _, raw := range listOrEmpty(events["events"]) {
+		if getString(asMap(raw), "reason") == "bulk_update_job_stage" {
+			bulkEvents++
+		}
+	}
+	if bulkEvents != 3 {
+		t.Fatalf("expected one bulk event per job, got %d", bulkEvents)
+	}
+
+	if _, err := BulkUpdateJobStage(map[string]any{"user_id": "u1", "stage": "applied"}); err == nil {
+		t.Fatalf("expected job_ids or result_ids to be required")
+	}
+}
+
+func TestBulkUpdateJobStageDropsJobsCreatedForFailedTargets(t *testing.T) {
+	setupUserToolPaths(t)
+
+	store := map[string]any{
+		"sessions": map[string]any{
+			"s1": map[string]any{
+				"query": map[string]any{"user_id": "u1"},
+				"accepted_jobs": []any{
+					map[string]any{"job_url": "https://example.com/jobs/bulk-blocked", "title": "Backend Engineer", "company": "Acme"},
+				},
+			},
+		},
+	}
+	if err := saveSearchSessions(store); err != nil {
+		t.Fatalf("saveSearchSessions failed: %v", err)
+	}
+	if _, err := SetStageTransitionRules(map[string]any{"user_id": "u1", "blocked_transitions": []any{"new -> offer"}}); err != nil {
+		t.Fatalf("SetStageTransitionRules failed: %v", err)
+	}
+	saved, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/bulk-valid", "stage": "interview"})
+	if err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+
+	result, err := BulkUpdateJobStage(map[string]any{
+		"user_id":    "u1",
+		"stage":      "offer",
+		"job_ids":    []any{asMap(saved["job"])["job_id"]},
+		"result_ids": []any{"s1:1"},
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdateJobStage failed: %v", err)
+	}
+	if got, _ := result["updated_count"].(int); got != 1 {
+		t.Fatalf("expected the valid job to move, got %#v", result)
+	}
+	if got, _ := result["failed_count"].(int); got != 1 {
+		t.Fatalf("expected the blocked result_id to fail, got %#v", result["failed"])
+	}
+
+	entry := getPipelineEntry(loadJobPipeline(), "u1")
+	if getJobByURL(entry, "https://example.com/jobs/bulk-blocked") != nil {
+		t.Fatalf("expected no pipeline job for the blocked result_id, got %#v", entry["jobs"])
+	}
+	if jobs := entry["jobs"].([]map[string]any); len(jobs) != 1 {
+		t.Fatalf("expected only the valid job to be saved, got %#v", jobs)
+	}
+}
@@ -0,0 +1,281 @@
+package user
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+var validContactRoles = []string{"recruiter", "hiring_manager", "referral", "interviewer", "other"}
+
+var validContactChannels = []string{"email", "linkedin", "phone", "video", "in_person", "other"}
+
+var validContactDirections = []string{"outbound", "inbound"}
+
+func validateContactRole(value string) (string, error) {
+	return validateEnumValue("role", value, "other", validContactRoles)
+}
+
+func validateContactChannel(value string) (string, error) {
+	return validateEnumValue("channel", value, "other", validContactChannels)
+}
+
+func validateContactDirection(value string) (string, error) {
+	return validateEnumValue("direction", value, "outbound", validContactDirections)
+}
+
+func findContact(entry map[string]any, contactID int) map[string]any {
+	for _, row := range entry["contacts"].([]map[string]any) {
+		id, _ := intFromAny(row["id"])
+		if id == contactID {
+			return row
+		}
+	}
+	return nil
+}
+
+// applyContactFields copies the contact fields present in args onto record.
+func applyContactFields(record map[string]any, args map[string]any) error {
+	if hasKey(args, "name") {
+		record["name"] = normalizeWhitespace(getString(args, "name"))
+	}
+	if hasKey(args, "email") {
+		email := strings.ToLower(getString(args, "email"))
+		if email != "" && !contactEmailRegex.MatchString(email) {
+			return fmt.Errorf("email %q is not a valid email address", email)
+		}
+		record["email"] = email
+	}
+	if hasKey(args, "linkedin_url") {
+		linkedinURL := getString(args, "linkedin_url")
+		if linkedinURL != "" && !strings.Contains(strings.ToLower(linkedinURL), "linkedin.com/") {
+			return fmt.Errorf("linkedin_url must be a linkedin.com profile URL")
+		}
+		record["linkedin_url"] = linkedinURL
+	}
+	if hasKey(args, "role") {
+		role, err := validateContactRole(getString(args, "role"))
+		if err != nil {
+			return err
+		}
+		record["role"] = role
+	}
+	if hasKey(args, "source") {
+		record["source"] = normalizeWhitespace(getString(args, "source"))
+	}
+	if hasKey(args, "note") {
+		record["note"] = getString(args, "note")
+	}
+	return nil
+}
+
+// AddJobContact attaches a recruiter or other contact to a pipeline job, or
+// updates the one named by contact_id.
+func AddJobContact(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+
+	var contact map[string]any
+	created := false
+	contactID, hasContactID, err := getOptionalInt(args, "contact_id")
+	if hasContactID {
+		if err != nil {
+			return nil, fmt.Errorf("contact_id must be an integer when provided")
+		}
+		contact = findContact(entry, contactID)
+		if contact == nil {
+			return nil, fmt.Errorf("contact_id=%d not found for user_id='%s'", contactID, userID)
+		}
+	} else {
+		jobID, _, err := resolveJobManagementTarget(entry, args, userID)
+		if err != nil {
+			return nil, err
+		}
+		nextID, _ := intFromAny(entry["next_contact_id"])
+		contact = map[string]any{
+			"id":             nextID,
+			"user_id":        userID,
+			"job_id":         jobID,
+			"name":           "",
+			"email":          "",
+			"linkedin_url":   "",
+			"role":           "recruiter",
+			"source":         "",
+			"note":           "",
+			"created_at_utc": utcNowISO(),
+		}
+		created = true
+	}
+	if err := applyContactFields(contact, args); err != nil {
+		return nil, err
+	}
+	if getString(contact, "name") == "" && getString(contact, "email") == "" && getString(contact, "linkedin_url") == "" {
+		return nil, fmt.Errorf("a contact needs at least one of name, email, or linkedin_url")
+	}
+	contact["updated_at_utc"] = utcNowISO()
+	if created {
+		nextID, _ := intFromAny(contact["id"])
+		entry["contacts"] = append(entry["contacts"].([]map[string]any), contact)
+		entry["next_contact_id"] = nextID + 1
+	}
+
+	if err := saveJobPipeline(pipeline); err != nil {
+		return nil, err
+	}
+	jobID, _ := intFromAny(contact["job_id"])
+	snapshot, err := jobSnapshot(entry, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":     userID,
+		"job":         snapshot,
+		"contact":     contact,
+		"created":     created,
+		"job_db_path": jobDBPath(),
+	}, nil
+}
+
+// LogContactInteraction records an email, message, or call with a job
+// contact. It also adds a pipeline event so the outreach shows up in the
+// job's history.
+func LogContactInteraction(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	contactID, hasContactID, err := getOptionalInt(args, "contact_id")
+	if !hasContactID || err != nil || contactID < 1 {
+		return nil, fmt.Errorf("contact_id is required and must be a positive integer")
+	}
+	channel, err := validateContactChannel(getString(args, "channel"))
+	if err != nil {
+		return nil, err
+	}
+	direction, err := validateContactDirection(getString(args, "direction"))
+	if err != nil {
+		return nil, err
+	}
+	summary := getString(args, "summary")
+	if summary == "" {
+		return nil, fmt.Errorf("summary is required")
+	}
+	occurredAt := utcNowISO()
+	if raw := getString(args, "occurred_at_utc"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("occurred_at_utc must be an RFC3339 timestamp")
+		}
+		occurredAt = toISO(parsed)
+	}
+
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+	contact := findContact(entry, contactID)
+	if contact == nil {
+		return nil, fmt.Errorf("contact_id=%d not found for user_id='%s'", contactID, userID)
+	}
+	jobID, _ := intFromAny(contact["job_id"])
+	nextID, _ := intFromAny(entry["next_contact_interaction_id"])
+	interaction := map[string]any{
+		"id":              nextID,
+		"user_id":         userID,
+		"job_id":          jobID,
+		"contact_id":      contactID,
+		"channel":         channel,
+		"direction":       direction,
+		"summary":         summary,
+		"occurred_at_utc": occurredAt,
+		"created_at_utc":  utcNowISO(),
+	}
+	entry["contact_interactions"] = append(entry["contact_interactions"].([]map[string]any), interaction)
+	entry["next_contact_interaction_id"] = nextID + 1
+
+	stage := "new"
+	if _, app := findApplicationIndex(entry, jobID); app != nil {
+		stage = getString(app, "stage")
+	}
+	who := firstNonEmpty(getString(contact, "name"), getString(contact, "email"), getString(contact, "linkedin_url"))
+	event := appendPipelineEvent(entry, userID, jobID, stage, stage, "contact_interaction", fmt.Sprintf("%s %s with %s: %s", direction, channel, who, summary))
+
+	if err := saveJobPipeline(pipeline); err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":     userID,
+		"contact":     contactWithInteractions(entry, contact),
+		"interaction": interaction,
+		"event":       event,
+		"job_db_path": jobDBPath(),
+	}, nil
+}
+
+// contactWithInteractions returns contact with its interactions, newest
+// first.
+func contactWithInteractions(entry map[string]any, contact map[string]any) map[string]any {
+	contactID, _ := intFromAny(contact["id"])
+	interactions := []map[string]any{}
+	for _, row := range entry["contact_interactions"].([]map[string]any) {
+		if id, _ := intFromAny(row["contact_id"]); id == contactID {
+			interactions = append(interactions, row)
+		}
+	}
+	slices.SortStableFunc(interactions, func(a, b map[string]any) int {
+		return strings.Compare(getString(b, "occurred_at_utc"), getString(a, "occurred_at_utc"))
+	})
+	out := maps.Clone(contact)
+	out["interactions"] = interactions
+	out["interaction_count"] = len(interactions)
+	out["last_interaction_at_utc"] = nil
+	if len(interactions) > 0 {
+		out["last_interaction_at_utc"] = interactions[0]["occurred_at_utc"]
+	}
+	return out
+}
+
+// ListJobContacts lists contacts with their interaction history, for one
+// job when a job reference is given or across the whole pipeline otherwise.
+func ListJobContacts(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	entry := getPipelineEntry(loadJobPipeline(), userID)
+	filterJobID := 0
+	if entry != nil && (hasKey(args, "job_id") || getString(args, "job_url") != "" || getString(args, "result_id") != "") {
+		jobID, _, err := resolveJobManagementTarget(entry, args, userID)
+		if err != nil {
+			return nil, err
+		}
+		filterJobID = jobID
+	}
+	contacts := []any{}
+	if entry != nil {
+		for _, contact := range entry["contacts"].([]map[string]any) {
+			jobID, _ := intFromAny(contact["job_id"])
+			if filterJobID > 0 && jobID != filterJobID {
+				continue
+			}
+			row := contactWithInteractions(entry, contact)
+			if job := getJobByID(entry, jobID); job != nil {
+				row["job_title"] = getString(job, "title")
+				row["company"] = getString(job, "company")
+				row["job_url"] = getString(job, "job_url")
+			}
+			contacts = append(contacts, row)
+		}
+	}
+	return map[string]any{
+		"user_id":        userID,
+		"job_id":         optionalPositiveInt(filterJobID),
+		"total_contacts": len(contacts),
+		"contacts":       contacts,
+		"job_db_path":    jobDBPath(),
+	}, nil
+}
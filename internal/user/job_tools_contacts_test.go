@@ -0,0 +1,90 @@
+package user
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJobContactsAndInteractions(t *testing.T) {
+	setupUserToolPaths(t)
+
+	added, err := AddJobContact(map[string]any{
+		"user_id":      "u1",
+		"job_url":      "https://example.com/jobs/contact-1",
+		"name":         "Priya Recruiter",
+		"email":        "Priya@Example.com",
+		"linkedin_url": "https://www.linkedin.com/in/priya",
+		"source":       "LinkedIn InMail",
+	})
+	if err != nil {
+		t.Fatalf("AddJobContact failed: %v", err)
+	}
+	contact, _ := added["contact"].(map[string]any)
+	if got := getString(contact, "email"); got != "priya@example.com" {
+		t.Fatalf("expected a lowercased email, got %q", got)
+	}
+	contactID, _ := intFromAny(contact["id"])
+
+	for _, summary := range []string{"Sent intro note", "Replied with interview slots"} {
+		direction := "outbound"
+		if strings.HasPrefix(summary, "Replied") {
+			direction = "inbound"
+		}
+		if _, err := LogContactInteraction(map[string]any{
+			"user_id":    "u1",
+			"contact_id": contactID,
+			"channel":    "email",
+			"direction":  direction,
+			"summary":    summary,
+		}); err != nil {
+			t.Fatalf("LogContactInteraction failed: %v", err)
+		}
+	}
+
+	listed, err := ListJobContacts(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/contact-1"})
+	if err != nil {
+		t.Fatalf("ListJobContacts failed: %v", err)
+	}
+	contacts := listOrEmpty(listed["contacts"])
+	if len(contacts) != 1 {
+		t.Fatalf("expected 1 contact, got %d", len(contacts))
+	}
+	if got := intOrZero(asMap(contacts[0])["interaction_count"]); got != 2 {
+		t.Fatalf("expected 2 interactions, got %d", got)
+	}
+
+	events, _ := ListRecentJobEvents(map[string]any{"user_id": "u1"})
+	found := false
+	for _, raw := range listOrEmpty(events["events"]) {
+		event := asMap(raw)
+		if getString(event, "reason") == "contact_interaction" && strings.Contains(getString(event, "note"), "Priya Recruiter") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a contact_interaction event naming the contact")
+	}
+
+	exported, _ := ExportUserData(map[string]any{"user_id": "u1"})
+	counts := asMap(exported["counts"])
+	if got := intOrZero(counts["job_management_contact_interactions"]); got != 2 {
+		t.Fatalf("expected 2 exported interactions, got %#v", counts["job_management_contact_interactions"])
+	}
+}
+
+func TestJobContactValidation(t *testing.T) {
+	setupUserToolPaths(t)
+
+	if _, err := AddJobContact(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/contact-2"}); err == nil {
+		t.Fatalf("expected a contact without name, email, or linkedin_url to fail")
+	}
+	if _, err := AddJobContact(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/contact-2", "email": "not-an-email"}); err == nil {
+		t.Fatalf("expected invalid email to fail")
+	}
+	if _, err := AddJobContact(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/contact-2", "linkedin_url": "https://example.com/me"}); err == nil {
+		t.Fatalf("expected a non-LinkedIn profile URL to fail")
+	}
+	if _, err := LogContactInteraction(map[string]any{"user_id": "u1", "contact_id": 4, "summary": "hi"}); err == nil {
+		t.Fatalf("expected unknown contact_id to fail")
+	}
+}
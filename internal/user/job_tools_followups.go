@@ -0,0 +1,285 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var validFollowupKinds = []string{"application_followup", "thank_you", "check_in", "other"}
+
+var validFollowupStatuses = []string{"pending", "done", "dismissed"}
+
+// followupDefaultDelays is how long after its anchor a reminder falls due
+// when neither due_at_utc nor due_in_days is given: application follow-ups
+// count from applied_at_utc, thank-you notes from the latest interview, and
+// check-ins from now.
+var followupDefaultDelays = map[string]time.Duration{
+	"application_followup": 7 * 24 * time.Hour,
+	"thank_you":            24 * time.Hour,
+	"check_in":             14 * 24 * time.Hour,
+}
+
+func validateFollowupKind(value string) (string, error) {
+	return validateEnumValue("kind", value, "other", validFollowupKinds)
+}
+
+func validateFollowupStatus(value string) (string, error) {
+	return validateEnumValue("status", value, "pending", validFollowupStatuses)
+}
+
+func findFollowup(entry map[string]any, reminderID int) map[string]any {
+	for _, row := range entry["followups"].([]map[string]any) {
+		id, _ := intFromAny(row["id"])
+		if id == reminderID {
+			return row
+		}
+	}
+	return nil
+}
+
+// followupAnchor is the time a default reminder delay counts from.
+func followupAnchor(entry map[string]any, jobID int, kind string, now time.Time) time.Time {
+	switch kind {
+	case "application_followup":
+		if _, app := findApplicationIndex(entry, jobID); app != nil {
+			if applied := parseISOTime(app["applied_at_utc"]); !applied.IsZero() {
+				return applied
+			}
+		}
+	case "thank_you":
+		latest := time.Time{}
+		for _, interview := range jobInterviews(entry, jobID) {
+			scheduled := parseISOTime(interview["scheduled_at_utc"])
+			if scheduled.After(latest) && !scheduled.After(now) {
+				latest = scheduled
+			}
+		}
+		if !latest.IsZero() {
+			return latest
+		}
+	}
+	return now
+}
+
+// followupDueAt resolves due_at_utc or due_in_days from args, falling back
+// to the kind's default delay. ok is false when args set neither and the
+// kind has no default.
+func followupDueAt(entry map[string]any, jobID int, kind string, args map[string]any, now time.Time) (string, bool, error) {
+	if hasKey(args, "due_at_utc") {
+		due, err := time.Parse(time.RFC3339, getString(args, "due_at_utc"))
+		if err != nil {
+			return "", false, fmt.Errorf("due_at_utc must be an RFC3339 timestamp")
+		}
+		return toISO(due), true, nil
+	}
+	if days, has, err := getOptionalInt(args, "due_in_days"); has {
+		if err != nil || days < 0 {
+			return "", false, fmt.Errorf("due_in_days must be a non-negative integer when provided")
+		}
+		return toISO(now.Add(time.Duration(days) * 24 * time.Hour)), true, nil
+	}
+	delay, ok := followupDefaultDelays[kind]
+	if !ok {
+		return "", false, nil
+	}
+	return toISO(followupAnchor(entry, jobID, kind, now).Add(delay)), true, nil
+}
+
+// SetFollowupReminder adds a reminder to a pipeline job, or updates the one
+// named by reminder_id (status=done once the follow-up is sent).
+func SetFollowupReminder(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+	now := utcNow()
+
+	var reminder map[string]any
+	reminderID, hasReminderID, err := getOptionalInt(args, "reminder_id")
+	if hasReminderID {
+		if err != nil {
+			return nil, fmt.Errorf("reminder_id must be an integer when provided")
+		}
+		reminder = findFollowup(entry, reminderID)
+		if reminder == nil {
+			return nil, fmt.Errorf("reminder_id=%d not found for user_id='%s'", reminderID, userID)
+		}
+		if hasKey(args, "kind") {
+			kind, err := validateFollowupKind(getString(args, "kind"))
+			if err != nil {
+				return nil, err
+			}
+			reminder["kind"] = kind
+		}
+		if hasKey(args, "due_at_utc") || hasKey(args, "due_in_days") {
+			jobID, _ := intFromAny(reminder["job_id"])
+			due, _, err := followupDueAt(entry, jobID, getString(reminder, "kind"), args, now)
+			if err != nil {
+				return nil, err
+			}
+			reminder["due_at_utc"] = due
+		}
+	} else {
+		kind, err := validateFollowupKind(getString(args, "kind"))
+		if err != nil {
+			return nil, err
+		}
+		jobID, _, err := resolveJobManagementTarget(entry, args, userID)
+		if err != nil {
+			return nil, err
+		}
+		due, ok, err := followupDueAt(entry, jobID, kind, args, now)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("due_at_utc or due_in_days is required for kind=%s", kind)
+		}
+		nextID, _ := intFromAny(entry["next_followup_id"])
+		reminder = map[string]any{
+			"id":               nextID,
+			"user_id":          userID,
+			"job_id":           jobID,
+			"kind":             kind,
+			"due_at_utc":       due,
+			"status":           "pending",
+			"note":             "",
+			"completed_at_utc": "",
+			"created_at_utc":   toISO(now),
+		}
+		entry["followups"] = append(entry["followups"].([]map[string]any), reminder)
+		entry["next_followup_id"] = nextID + 1
+	}
+	if hasKey(args, "status") {
+		status, err := validateFollowupStatus(getString(args, "status"))
+		if err != nil {
+			return nil, err
+		}
+		reminder["status"] = status
+		reminder["completed_at_utc"] = ""
+		if status != "pending" {
+			reminder["completed_at_utc"] = toISO(now)
+		}
+	}
+	if hasKey(args, "note") {
+		reminder["note"] = getString(args, "note")
+	}
+	reminder["updated_at_utc"] = toISO(now)
+
+	if err := saveJobPipeline(pipeline); err != nil {
+		return nil, err
+	}
+	jobID, _ := intFromAny(reminder["job_id"])
+	snapshot, err := jobSnapshot(entry, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":     userID,
+		"job":         snapshot,
+		"reminder":    reminder,
+		"job_db_path": jobDBPath(),
+	}, nil
+}
+
+// dueFollowups returns pending reminders due at or before until, oldest
+// first, with the job they belong to.
+func dueFollowups(entry map[string]any, now, until time.Time) []map[string]any {
+	out := []map[string]any{}
+	for _, reminder := range entry["followups"].([]map[string]any) {
+		if getString(reminder, "status") != "pending" {
+			continue
+		}
+		due := parseISOTime(reminder["due_at_utc"])
+		if due.IsZero() || due.After(until) {
+			continue
+		}
+		jobID, _ := intFromAny(reminder["job_id"])
+		job := getJobByID(entry, jobID)
+		if job == nil {
+			continue
+		}
+		reminderID, _ := intFromAny(reminder["id"])
+		stage := "new"
+		if _, app := findApplicationIndex(entry, jobID); app != nil {
+			stage = getString(app, "stage")
+		}
+		out = append(out, map[string]any{
+			"reminder_id": reminderID,
+			"job_id":      jobID,
+			"result_id":   getString(job, "result_id"),
+			"job_url":     getString(job, "job_url"),
+			"title":       getString(job, "title"),
+			"company":     getString(job, "company"),
+			"stage":       stage,
+			"kind":        reminder["kind"],
+			"due_at_utc":  reminder["due_at_utc"],
+			"overdue":     due.Before(now),
+			"note":        reminder["note"],
+		})
+	}
+	slices.SortFunc(out, func(a, b map[string]any) int {
+		return strings.Compare(getString(a, "due_at_utc"), getString(b, "due_at_utc"))
+	})
+	return out
+}
+
+// endOfUTCDay is the last second of now's UTC day plus extraDays days.
+func endOfUTCDay(now time.Time, extraDays int) time.Time {
+	day := now.UTC().Truncate(24 * time.Hour)
+	return day.Add(time.Duration(extraDays+1)*24*time.Hour - time.Second)
+}
+
+func ListDueFollowups(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	days := 0
+	if parsed, has, err := getOptionalInt(args, "days_ahead"); has {
+		if err != nil {
+			return nil, fmt.Errorf("days_ahead must be an integer when provided")
+		}
+		days = max(0, min(parsed, 365))
+	}
+	limit := 50
+	if parsed, has, err := getOptionalInt(args, "limit"); has {
+		if err != nil {
+			return nil, fmt.Errorf("limit must be an integer when provided")
+		}
+		limit = max(1, min(parsed, 200))
+	}
+
+	now := utcNow()
+	until := endOfUTCDay(now, days)
+	rows := []map[string]any{}
+	if entry := getPipelineEntry(loadJobPipeline(), userID); entry != nil {
+		rows = dueFollowups(entry, now, until)
+	}
+	overdue := 0
+	for _, row := range rows {
+		if boolOrFalse(row["overdue"]) {
+			overdue++
+		}
+	}
+	page := rows[:min(limit, len(rows))]
+	pageAny := make([]any, 0, len(page))
+	for _, row := range page {
+		pageAny = append(pageAny, row)
+	}
+	return map[string]any{
+		"user_id":            userID,
+		"days_ahead":         days,
+		"due_by_utc":         toISO(until),
+		"limit":              limit,
+		"total_followups":    len(rows),
+		"overdue_followups":  overdue,
+		"returned_followups": len(page),
+		"followups":          pageAny,
+		"job_db_path":        jobDBPath(),
+	}, nil
+}
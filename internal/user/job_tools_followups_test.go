@@ -0,0 +1,90 @@
+package user
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFollowupRemindersDueToday(t *testing.T) {
+	setupUserToolPaths(t)
+
+	appliedAt := toISO(time.Now().Add(-8 * 24 * time.Hour))
+	if _, err := MarkJobApplied(map[string]any{
+		"user_id":        "u1",
+		"job_url":        "https://example.com/jobs/followup-1",
+		"applied_at_utc": appliedAt,
+	}); err != nil {
+		t.Fatalf("MarkJobApplied failed: %v", err)
+	}
+	overdue, err := SetFollowupReminder(map[string]any{
+		"user_id": "u1",
+		"job_url": "https://example.com/jobs/followup-1",
+		"kind":    "application_followup",
+	})
+	if err != nil {
+		t.Fatalf("SetFollowupReminder failed: %v", err)
+	}
+	reminder, _ := overdue["reminder"].(map[string]any)
+	wantDue := toISO(parseISOTime(appliedAt).Add(7 * 24 * time.Hour))
+	if got := getString(reminder, "due_at_utc"); got != wantDue {
+		t.Fatalf("expected due_at_utc=%s (7 days after applying), got %s", wantDue, got)
+	}
+
+	if _, err := SetFollowupReminder(map[string]any{
+		"user_id":     "u1",
+		"job_url":     "https://example.com/jobs/followup-2",
+		"kind":        "check_in",
+		"due_in_days": 3,
+	}); err != nil {
+		t.Fatalf("SetFollowupReminder with due_in_days failed: %v", err)
+	}
+
+	today, err := ListDueFollowups(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("ListDueFollowups failed: %v", err)
+	}
+	if got, _ := today["total_followups"].(int); got != 1 {
+		t.Fatalf("expected 1 follow-up due today, got %#v", today["total_followups"])
+	}
+	if got, _ := today["overdue_followups"].(int); got != 1 {
+		t.Fatalf("expected the application follow-up to be overdue, got %#v", today["overdue_followups"])
+	}
+	week, _ := ListDueFollowups(map[string]any{"user_id": "u1", "days_ahead": 7})
+	if got, _ := week["total_followups"].(int); got != 2 {
+		t.Fatalf("expected 2 follow-ups due this week, got %#v", week["total_followups"])
+	}
+
+	reminderID, _ := intFromAny(reminder["id"])
+	done, err := SetFollowupReminder(map[string]any{"user_id": "u1", "reminder_id": reminderID, "status": "done"})
+	if err != nil {
+		t.Fatalf("marking reminder done failed: %v", err)
+	}
+	if got := getString(asMap(done["reminder"]), "completed_at_utc"); got == "" {
+		t.Fatalf("expected completed_at_utc once done")
+	}
+	summary, _ := GetJobPipelineSummary(map[string]any{"user_id": "u1"})
+	if got, _ := summary["due_followups_today"].(int); got != 0 {
+		t.Fatalf("expected no follow-ups due after completing, got %#v", summary["due_followups_today"])
+	}
+}
+
+func TestFollowupReminderValidation(t *testing.T) {
+	setupUserToolPaths(t)
+
+	if _, err := SetFollowupReminder(map[string]any{
+		"user_id": "u1",
+		"job_url": "https://example.com/jobs/followup-3",
+	}); err == nil {
+		t.Fatalf("expected kind=other without a due time to fail")
+	}
+	if _, err := SetFollowupReminder(map[string]any{
+		"user_id": "u1",
+		"job_url": "https://example.com/jobs/followup-3",
+		"kind":    "fax",
+	}); err == nil {
+		t.Fatalf("expected invalid kind error")
+	}
+	if _, err := SetFollowupReminder(map[string]any{"user_id": "u1", "reminder_id": 5, "status": "done"}); err == nil {
+		t.Fatalf("expected unknown reminder_id error")
+	}
+}
@@ -0,0 +1,112 @@
+package user
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+var validGoalPeriods = []string{"week", "month"}
+
+func validateGoalPeriod(value string) (string, error) {
+	return validateEnumValue("period", value, "week", validGoalPeriods)
+}
+
+// goalPeriodBounds returns the UTC calendar week (Monday start) or month that
+// contains now.
+func goalPeriodBounds(period string, now time.Time) (time.Time, time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if period == "month" {
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+	offset := (int(day.Weekday()) + 6) % 7
+	start := day.AddDate(0, 0, -offset)
+	return start, start.AddDate(0, 0, 7)
+}
+
+// applicationGoalProgress compares applications submitted in the goal's
+// current period against its target. It returns nil when the user has no
+// goal. on_track means the count keeps pace with the share of the period that
+// has elapsed.
+func applicationGoalProgress(userID string, entry map[string]any, now time.Time) any {
+	prefs, err := loadPrefs()
+	if err != nil {
+		return nil
+	}
+	goal := asMap(prefs[userID]["application_goal"])
+	target := intOrZero(goal["target_count"])
+	if target < 1 {
+		return nil
+	}
+	period := getString(goal, "period")
+	start, end := goalPeriodBounds(period, now)
+	applied := 0
+	if entry != nil {
+		for _, app := range entry["applications"].([]map[string]any) {
+			at := parseISOTime(app["applied_at_utc"])
+			if !at.IsZero() && !at.Before(start) && at.Before(end) {
+				applied++
+			}
+		}
+	}
+	elapsed := now.Sub(start).Seconds() / end.Sub(start).Seconds()
+	expected := int(math.Ceil(float64(target) * elapsed))
+	return map[string]any{
+		"target_count":          target,
+		"period":                period,
+		"period_start_utc":      toISO(start),
+		"period_end_utc":        toISO(end),
+		"applied_count":         applied,
+		"remaining_count":       max(0, target-applied),
+		"percent_complete":      math.Round(float64(applied)/float64(target)*1000) / 10,
+		"expected_count_so_far": expected,
+		"on_track":              applied >= expected,
+		"goal_met":              applied >= target,
+	}
+}
+
+// SetApplicationGoal saves a target number of applications per week or
+// month; target_count=0 clears it. Progress is reported here and in
+// get_job_pipeline_summary.
+func SetApplicationGoal(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	target, hasTarget, err := getOptionalInt(args, "target_count")
+	if !hasTarget || err != nil || target < 0 {
+		return nil, fmt.Errorf("target_count is required and must be a non-negative integer")
+	}
+	period, err := validateGoalPeriod(getString(args, "period"))
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := loadPrefs()
+	if err != nil {
+		return nil, err
+	}
+	user := prefs[userID]
+	if user == nil {
+		user = map[string]any{}
+	}
+	if target == 0 {
+		delete(user, "application_goal")
+	} else {
+		user["application_goal"] = map[string]any{
+			"target_count":   target,
+			"period":         period,
+			"updated_at_utc": utcNowISO(),
+		}
+	}
+	prefs[userID] = user
+	if err := savePrefs(prefs); err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":          userID,
+		"application_goal": user["application_goal"],
+		"progress":         applicationGoalProgress(userID, getPipelineEntry(loadJobPipeline(), userID), utcNow()),
+		"path":             prefsPath(),
+	}, nil
+}
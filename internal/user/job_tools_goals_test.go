@@ -0,0 +1,58 @@
+package user
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplicationGoalProgress(t *testing.T) {
+	setupUserToolPaths(t)
+
+	summary, _ := GetJobPipelineSummary(map[string]any{"user_id": "u1"})
+	if summary["application_goal"] != nil {
+		t.Fatalf("expected no goal before one is set, got %#v", summary["application_goal"])
+	}
+	if _, err := SetApplicationGoal(map[string]any{"user_id": "u1", "target_count": 10}); err != nil {
+		t.Fatalf("SetApplicationGoal failed: %v", err)
+	}
+	for _, url := range []string{"https://example.com/jobs/goal-1", "https://example.com/jobs/goal-2"} {
+		if _, err := MarkJobApplied(map[string]any{"user_id": "u1", "job_url": url}); err != nil {
+			t.Fatalf("MarkJobApplied failed: %v", err)
+		}
+	}
+
+	summary, err := GetJobPipelineSummary(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("GetJobPipelineSummary failed: %v", err)
+	}
+	progress := asMap(summary["application_goal"])
+	if intOrZero(progress["applied_count"]) != 2 || intOrZero(progress["remaining_count"]) != 8 {
+		t.Fatalf("expected 2 of 10 applications, got %#v", progress)
+	}
+	if getString(progress, "period") != "week" {
+		t.Fatalf("expected a weekly goal by default, got %#v", progress["period"])
+	}
+
+	if _, err := SetApplicationGoal(map[string]any{"user_id": "u1", "target_count": 0}); err != nil {
+		t.Fatalf("clearing the goal failed: %v", err)
+	}
+	summary, _ = GetJobPipelineSummary(map[string]any{"user_id": "u1"})
+	if summary["application_goal"] != nil {
+		t.Fatalf("expected the goal to be cleared, got %#v", summary["application_goal"])
+	}
+	if _, err := SetApplicationGoal(map[string]any{"user_id": "u1", "target_count": 5, "period": "fortnight"}); err == nil {
+		t.Fatalf("expected an invalid period to fail")
+	}
+}
+
+func TestGoalPeriodBounds(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC) // a Saturday
+	start, end := goalPeriodBounds("week", now)
+	if !start.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)) || !end.Equal(start.AddDate(0, 0, 7)) {
+		t.Fatalf("unexpected week bounds %s - %s", start, end)
+	}
+	start, end = goalPeriodBounds("month", now)
+	if !start.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected month bounds %s - %s", start, end)
+	}
+}
@@ -0,0 +1,244 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxPipelineImportRows = 2000
+
+// pipelineImportColumns lists, per pipeline field, the spreadsheet headers
+// recognized without a column_mapping. Headers match case-insensitively with
+// underscores treated as spaces.
+var pipelineImportColumns = map[string][]string{
+	"job_url":    {"job url", "url", "link", "job link", "posting url", "linkedin url"},
+	"title":      {"title", "job title", "role", "position"},
+	"company":    {"company", "company name", "employer", "organization"},
+	"location":   {"location", "city"},
+	"stage":      {"stage", "status", "application status"},
+	"applied_at": {"applied at", "applied at utc", "date applied", "applied on", "application date", "applied"},
+	"note":       {"note", "notes", "comments"},
+	"tags":       {"tags", "labels"},
+}
+
+// pipelineImportStageAliases maps common tracker statuses onto pipeline
+// stages.
+var pipelineImportStageAliases = map[string]string{
+	"wishlist":     "saved",
+	"bookmarked":   "saved",
+	"interested":   "saved",
+	"to apply":     "saved",
+	"submitted":    "applied",
+	"in review":    "applied",
+	"screening":    "interview",
+	"phone screen": "interview",
+	"interviewing": "interview",
+	"onsite":       "interview",
+	"offered":      "offer",
+	"declined":     "rejected",
+	"not selected": "rejected",
+	"archived":     "ignored",
+	"withdrawn":    "ignored",
+}
+
+var pipelineImportDateLayouts = []string{time.RFC3339, "2006-01-02", "2006/01/02", "01/02/2006", "1/2/2006", "Jan 2, 2006", "January 2, 2006", "2 Jan 2006"}
+
+func importHeaderKey(name string) string {
+	return normalizeJobTag(strings.ReplaceAll(name, "_", " "))
+}
+
+// resolvePipelineImportColumns maps each pipeline field to a header in table,
+// preferring column_mapping over the recognized header names.
+func resolvePipelineImportColumns(table *disclosureTable, mapping map[string]any) (map[string]string, error) {
+	headers := map[string]string{}
+	for name := range table.index {
+		if _, exists := headers[importHeaderKey(name)]; !exists {
+			headers[importHeaderKey(name)] = name
+		}
+	}
+	columns := map[string]string{}
+	for field, raw := range mapping {
+		if _, ok := pipelineImportColumns[field]; !ok {
+			return nil, fmt.Errorf("column_mapping field %q is not one of [applied_at company job_url location note stage tags title]", field)
+		}
+		header, ok := headers[importHeaderKey(fmt.Sprint(raw))]
+		if !ok {
+			return nil, fmt.Errorf("column_mapping.%s names column %q, which is not in the header row", field, raw)
+		}
+		columns[field] = header
+	}
+	for field, candidates := range pipelineImportColumns {
+		if _, mapped := columns[field]; mapped {
+			continue
+		}
+		for _, candidate := range candidates {
+			if header, ok := headers[candidate]; ok {
+				columns[field] = header
+				break
+			}
+		}
+	}
+	if columns["job_url"] == "" {
+		return nil, fmt.Errorf("no job URL column found; name it in column_mapping.job_url")
+	}
+	return columns, nil
+}
+
+func parseImportStage(raw string) (string, error) {
+	clean := normalizeJobTag(raw)
+	if alias, ok := pipelineImportStageAliases[clean]; ok {
+		return alias, nil
+	}
+	if stage, err := validateJobStage(clean); err == nil {
+		return stage, nil
+	}
+	return "", fmt.Errorf("unrecognized stage %q", raw)
+}
+
+// parseImportDate reads the date formats trackers usually export, including
+// the serial day numbers XLSX stores for date cells.
+func parseImportDate(raw string) (string, error) {
+	for _, layout := range pipelineImportDateLayouts {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return toISO(parsed), nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil && serial > 20000 && serial < 80000 {
+		return toISO(time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).Add(time.Duration(serial * float64(24*time.Hour)))), nil
+	}
+	return "", fmt.Errorf("unrecognized applied date %q", raw)
+}
+
+// ImportPipelineCSV loads an existing application tracker (a local CSV or
+// XLSX file) into the pipeline. Rows are matched to pipeline jobs by URL, so
+// re-running an import updates jobs instead of duplicating them. Stage
+// transition rules do not apply to imported rows.
+func ImportPipelineCSV(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	source := strings.TrimSpace(getString(args, "source"))
+	if source == "" {
+		return nil, fmt.Errorf("source is required (a local .csv or .xlsx file)")
+	}
+	if strings.Contains(source, "://") || !supportedDisclosureSource(source) {
+		return nil, fmt.Errorf("source must be a local .csv or .xlsx file")
+	}
+	if _, err := os.Stat(source); err != nil {
+		return nil, fmt.Errorf("source not found at '%s'", source)
+	}
+	defaultStage, err := validateJobStage(firstNonEmpty(getString(args, "default_stage"), "applied"))
+	if err != nil {
+		return nil, fmt.Errorf("default_stage must be a pipeline stage")
+	}
+	dryRun := false
+	if parsed, has, err := getOptionalBool(args, "dry_run"); has {
+		if err != nil {
+			return nil, fmt.Errorf("dry_run must be a boolean when provided")
+		}
+		dryRun = parsed
+	}
+
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+	var columns map[string]string
+	imported, unchanged, created, rowNumber := 0, 0, 0, 1
+	skipped := []any{}
+	skip := func(reason string) {
+		skipped = append(skipped, map[string]any{"row": rowNumber, "reason": reason})
+	}
+	err = streamDisclosureTable(context.Background(), source, func(table *disclosureTable) error {
+		var err error
+		columns, err = resolvePipelineImportColumns(table, asMap(args["column_mapping"]))
+		return err
+	}, func(table *disclosureTable, row []string) error {
+		rowNumber++
+		if rowNumber-1 > maxPipelineImportRows {
+			return fmt.Errorf("source has more than %d rows; split it into smaller files", maxPipelineImportRows)
+		}
+		value := func(field string) string { return table.value(row, columns[field]) }
+		jobURL := value("job_url")
+		if jobURL == "" {
+			skip("missing job URL")
+			return nil
+		}
+		stage := defaultStage
+		if raw := value("stage"); raw != "" {
+			parsed, err := parseImportStage(raw)
+			if err != nil {
+				skip(err.Error())
+				return nil
+			}
+			stage = parsed
+		}
+		appliedAt := ""
+		if raw := value("applied_at"); raw != "" {
+			parsed, err := parseImportDate(raw)
+			if err != nil {
+				skip(err.Error())
+				return nil
+			}
+			appliedAt = parsed
+		}
+		isNew := getJobByURL(entry, jobURL) == nil
+		jobID, job, err := upsertJob(entry, userID, map[string]any{"job_url": jobURL}, value("title"), value("company"), value("location"), "")
+		if err != nil {
+			skip(err.Error())
+			return nil
+		}
+		if isNew {
+			created++
+		}
+		if raw := value("tags"); raw != "" {
+			tags := strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == ';' })
+			if _, err := applyJobTagChanges(job, jobTags(map[string]any{"tags": tags}), nil); err != nil {
+				skip(err.Error())
+				return nil
+			}
+		}
+		// Re-importing the same sheet leaves matching rows alone instead of
+		// adding events and repeating notes.
+		note := value("note")
+		_, app := findApplicationIndex(entry, jobID)
+		if app != nil && getString(app, "stage") == stage && strings.Contains(getString(app, "note"), note) {
+			unchanged++
+		} else {
+			app, _, err = setJobStage(entry, userID, jobID, stage, note, "", appliedAt, "csv_import")
+			if err != nil {
+				skip(err.Error())
+				return nil
+			}
+			imported++
+		}
+		if appliedAt != "" && slices.Contains([]string{"applied", "interview", "offer", "rejected"}, stage) {
+			app["applied_at_utc"] = appliedAt
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		if err := saveJobPipeline(pipeline); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]any{
+		"user_id":        userID,
+		"source":         source,
+		"dry_run":        dryRun,
+		"columns":        columns,
+		"rows_read":      rowNumber - 1,
+		"imported_rows":  imported,
+		"unchanged_rows": unchanged,
+		"created_jobs":   created,
+		"skipped_rows":   skipped,
+		"job_db_path":    jobDBPath(),
+	}, nil
+}
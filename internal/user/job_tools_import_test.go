@@ -0,0 +1,81 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportPipelineCSV(t *testing.T) {
+	setupUserToolPaths(t)
+
+	source := filepath.Join(t.TempDir(), "tracker.csv")
+	body := strings.Join([]string{
+		"Company,Job Title,Link,Status,Date Applied,Notes,Labels",
+		"Acme Inc,Backend Engineer,https://www.linkedin.com/jobs/view/1/,Interviewing,2026-03-02,Referred by Sam,\"dream company, referral available\"",
+		"Beta LLC,Data Engineer,https://www.linkedin.com/jobs/view/2/,,03/05/2026,,",
+		"Gamma Corp,SRE,https://www.linkedin.com/jobs/view/3/,Ghosted,,,",
+		"Delta Co,Analyst,,Applied,,,",
+	}, "\n")
+	if err := os.WriteFile(source, []byte(body), 0o644); err != nil {
+		t.Fatalf("write tracker: %v", err)
+	}
+
+	preview, err := ImportPipelineCSV(map[string]any{"user_id": "u1", "source": source, "dry_run": true})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if got := intOrZero(preview["imported_rows"]); got != 2 {
+		t.Fatalf("expected 2 importable rows, got %#v", preview)
+	}
+	if summary, _ := GetJobPipelineSummary(map[string]any{"user_id": "u1"}); intOrZero(summary["total_tracked_jobs"]) != 0 {
+		t.Fatalf("expected a dry run to save nothing, got %#v", summary["total_tracked_jobs"])
+	}
+
+	result, err := ImportPipelineCSV(map[string]any{"user_id": "u1", "source": source})
+	if err != nil {
+		t.Fatalf("ImportPipelineCSV failed: %v", err)
+	}
+	if got := len(listOrEmpty(result["skipped_rows"])); got != 2 {
+		t.Fatalf("expected the unknown status and missing URL rows to be skipped, got %#v", result["skipped_rows"])
+	}
+	interview, _ := ListJobsByStage(map[string]any{"user_id": "u1", "stage": "interview"})
+	jobs := listOrEmpty(interview["jobs"])
+	if len(jobs) != 1 {
+		t.Fatalf("expected one interviewing job, got %#v", interview["jobs"])
+	}
+	job := asMap(jobs[0])
+	if getString(job, "applied_at_utc") != "2026-03-02T00:00:00Z" || getString(job, "note") != "Referred by Sam" {
+		t.Fatalf("expected the applied date and note to carry over, got %#v", job)
+	}
+	tagged, _ := ListJobsByTag(map[string]any{"user_id": "u1", "tag": "referral available"})
+	if got := len(listOrEmpty(tagged["pipeline_jobs"])); got != 1 {
+		t.Fatalf("expected the imported labels to become tags, got %d tagged jobs", got)
+	}
+
+	again, err := ImportPipelineCSV(map[string]any{"user_id": "u1", "source": source})
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if intOrZero(again["unchanged_rows"]) != 2 || intOrZero(again["created_jobs"]) != 0 {
+		t.Fatalf("expected a re-import to change nothing, got %#v", again)
+	}
+
+	if _, err := ImportPipelineCSV(map[string]any{"user_id": "u1", "source": source, "column_mapping": map[string]any{"job_url": "Posting"}}); err == nil {
+		t.Fatalf("expected a mapping to a missing column to fail")
+	}
+}
+
+func TestParseImportDate(t *testing.T) {
+	for raw, want := range map[string]string{
+		"2026-03-02":  "2026-03-02T00:00:00Z",
+		"3/2/2026":    "2026-03-02T00:00:00Z",
+		"Mar 2, 2026": "2026-03-02T00:00:00Z",
+		"46083":       "2026-03-02T00:00:00Z",
+	} {
+		if got, err := parseImportDate(raw); err != nil || got != want {
+			t.Fatalf("parseImportDate(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var validInterviewTypes = []string{"phone", "video", "onsite", "technical", "behavioral", "other"}
+
+var validInterviewOutcomes = []string{"pending", "passed", "failed", "cancelled", "no_show"}
+
+// preInterviewStages move to "interview" when an interview is scheduled;
+// later stages (offer, rejected, ignored) are left as they are.
+var preInterviewStages = []string{"new", "saved", "applied"}
+
+const defaultUpcomingInterviewDays = 14
+
+func validateInterviewType(value string) (string, error) {
+	return validateEnumValue("interview_type", value, "other", validInterviewTypes)
+}
+
+func validateInterviewOutcome(value string) (string, error) {
+	return validateEnumValue("outcome", value, "pending", validInterviewOutcomes)
+}
+
+func findInterview(entry map[string]any, interviewID int) map[string]any {
+	for _, row := range entry["interviews"].([]map[string]any) {
+		id, _ := intFromAny(row["id"])
+		if id == interviewID {
+			return row
+		}
+	}
+	return nil
+}
+
+func jobInterviews(entry map[string]any, jobID int) []map[string]any {
+	out := []map[string]any{}
+	for _, row := range entry["interviews"].([]map[string]any) {
+		id, _ := intFromAny(row["job_id"])
+		if id == jobID {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+// applyInterviewFields copies the optional interview fields present in args
+// onto record.
+func applyInterviewFields(record map[string]any, args map[string]any) error {
+	if round, has, err := getOptionalInt(args, "round"); has {
+		if err != nil || round < 1 {
+			return fmt.Errorf("round must be a positive integer when provided")
+		}
+		record["round"] = round
+	}
+	if hasKey(args, "interview_type") {
+		interviewType, err := validateInterviewType(getString(args, "interview_type"))
+		if err != nil {
+			return err
+		}
+		record["interview_type"] = interviewType
+	}
+	if hasKey(args, "scheduled_at_utc") {
+		scheduled, err := time.Parse(time.RFC3339, getString(args, "scheduled_at_utc"))
+		if err != nil {
+			return fmt.Errorf("scheduled_at_utc must be an RFC3339 timestamp")
+		}
+		record["scheduled_at_utc"] = toISO(scheduled)
+	}
+	if hasKey(args, "interviewer") {
+		record["interviewer"] = getString(args, "interviewer")
+	}
+	if hasKey(args, "outcome") {
+		outcome, err := validateInterviewOutcome(getString(args, "outcome"))
+		if err != nil {
+			return err
+		}
+		record["outcome"] = outcome
+	}
+	if hasKey(args, "note") {
+		record["note"] = getString(args, "note")
+	}
+	return nil
+}
+
+// ScheduleInterview adds an interview round to a pipeline job, or updates
+// the one named by interview_id (for example to record its outcome).
+func ScheduleInterview(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	force, err := parseForce(args)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+
+	overridden := ""
+	var interview map[string]any
+	var jobID int
+	var event map[string]any
+	interviewID, hasInterviewID, err := getOptionalInt(args, "interview_id")
+	if hasInterviewID {
+		if err != nil {
+			return nil, fmt.Errorf("interview_id must be an integer when provided")
+		}
+		interview = findInterview(entry, interviewID)
+		if interview == nil {
+			return nil, fmt.Errorf("interview_id=%d not found for user_id='%s'", interviewID, userID)
+		}
+		if err := applyInterviewFields(interview, args); err != nil {
+			return nil, err
+		}
+		interview["updated_at_utc"] = utcNowISO()
+		jobID, _ = intFromAny(interview["job_id"])
+		stage := "new"
+		if _, app := findApplicationIndex(entry, jobID); app != nil {
+			stage = getString(app, "stage")
+		}
+		event = appendPipelineEvent(entry, userID, jobID, stage, stage, "interview_updated", getString(args, "note"))
+	} else {
+		if getString(args, "scheduled_at_utc") == "" {
+			return nil, fmt.Errorf("scheduled_at_utc is required when scheduling a new interview")
+		}
+		jobID, _, err = resolveJobManagementTarget(entry, args, userID)
+		if err != nil {
+			return nil, err
+		}
+		now := utcNowISO()
+		nextID, _ := intFromAny(entry["next_interview_id"])
+		interview = map[string]any{
+			"id":               nextID,
+			"user_id":          userID,
+			"job_id":           jobID,
+			"round":            len(jobInterviews(entry, jobID)) + 1,
+			"interview_type":   "other",
+			"scheduled_at_utc": "",
+			"interviewer":      "",
+			"outcome":          "pending",
+			"note":             "",
+			"created_at_utc":   now,
+			"updated_at_utc":   now,
+		}
+		if err := applyInterviewFields(interview, args); err != nil {
+			return nil, err
+		}
+		entry["interviews"] = append(entry["interviews"].([]map[string]any), interview)
+		entry["next_interview_id"] = nextID + 1
+
+		stage := "new"
+		if _, app := findApplicationIndex(entry, jobID); app != nil {
+			stage = getString(app, "stage")
+		}
+		if slices.Contains(preInterviewStages, stage) {
+			overridden, err = checkStageTransition(entry, userID, jobID, "interview", force)
+			if err != nil {
+				return nil, err
+			}
+			_, event, err = setJobStage(entry, userID, jobID, "interview", getString(args, "note"), "", "", forcedReason("interview_scheduled", overridden))
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			event = appendPipelineEvent(entry, userID, jobID, stage, stage, "interview_scheduled", getString(args, "note"))
+		}
+	}
+
+	if err := saveJobPipeline(pipeline); err != nil {
+		return nil, err
+	}
+	snapshot, err := jobSnapshot(entry, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":         userID,
+		"job":             snapshot,
+		"interview":       interview,
+		"event":           event,
+		"overridden_rule": optionalString(overridden),
+		"job_db_path":     jobDBPath(),
+	}, nil
+}
+
+// upcomingInterviews returns pending interviews scheduled between now and
+// until, soonest first, with the job they belong to.
+func upcomingInterviews(entry map[string]any, now, until time.Time) []map[string]any {
+	out := []map[string]any{}
+	for _, interview := range entry["interviews"].([]map[string]any) {
+		if getString(interview, "outcome") != "pending" {
+			continue
+		}
+		scheduled := parseISOTime(interview["scheduled_at_utc"])
+		if scheduled.IsZero() || scheduled.Before(now) || scheduled.After(until) {
+			continue
+		}
+		jobID, _ := intFromAny(interview["job_id"])
+		job := getJobByID(entry, jobID)
+		if job == nil {
+			continue
+		}
+		interviewID, _ := intFromAny(interview["id"])
+		stage := "new"
+		if _, app := findApplicationIndex(entry, jobID); app != nil {
+			stage = getString(app, "stage")
+		}
+		out = append(out, map[string]any{
+			"interview_id":     interviewID,
+			"job_id":           jobID,
+			"result_id":        getString(job, "result_id"),
+			"job_url":          getString(job, "job_url"),
+			"title":            getString(job, "title"),
+			"company":          getString(job, "company"),
+			"stage":            stage,
+			"round":            interview["round"],
+			"interview_type":   interview["interview_type"],
+			"scheduled_at_utc": interview["scheduled_at_utc"],
+			"interviewer":      interview["interviewer"],
+			"note":             interview["note"],
+		})
+	}
+	slices.SortFunc(out, func(a, b map[string]any) int {
+		return strings.Compare(getString(a, "scheduled_at_utc"), getString(b, "scheduled_at_utc"))
+	})
+	return out
+}
+
+func ListUpcomingInterviews(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	days := defaultUpcomingInterviewDays
+	if parsed, has, err := getOptionalInt(args, "days_ahead"); has {
+		if err != nil {
+			return nil, fmt.Errorf("days_ahead must be an integer when provided")
+		}
+		days = max(1, min(parsed, 365))
+	}
+	limit := 50
+	if parsed, has, err := getOptionalInt(args, "limit"); has {
+		if err != nil {
+			return nil, fmt.Errorf("limit must be an integer when provided")
+		}
+		limit = max(1, min(parsed, 200))
+	}
+
+	now := utcNow()
+	until := now.Add(time.Duration(days) * 24 * time.Hour)
+	rows := []map[string]any{}
+	if entry := getPipelineEntry(loadJobPipeline(), userID); entry != nil {
+		rows = upcomingInterviews(entry, now, until)
+	}
+	page := rows[:min(limit, len(rows))]
+	pageAny := make([]any, 0, len(page))
+	for _, row := range page {
+		pageAny = append(pageAny, row)
+	}
+	return map[string]any{
+		"user_id":             userID,
+		"days_ahead":          days,
+		"window_end_utc":      toISO(until),
+		"limit":               limit,
+		"total_interviews":    len(rows),
+		"returned_interviews": len(page),
+		"interviews":          pageAny,
+		"job_db_path":         jobDBPath(),
+	}, nil
+}
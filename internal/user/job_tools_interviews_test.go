@@ -0,0 +1,119 @@
+package user
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleInterviewMovesStageAndListsUpcoming(t *testing.T) {
+	setupUserToolPaths(t)
+
+	if _, err := MarkJobApplied(map[string]any{
+		"user_id": "u1",
+		"job_url": "https://example.com/jobs/interview-1",
+	}); err != nil {
+		t.Fatalf("MarkJobApplied failed: %v", err)
+	}
+	soon := toISO(time.Now().Add(48 * time.Hour))
+	scheduled, err := ScheduleInterview(map[string]any{
+		"user_id":          "u1",
+		"job_url":          "https://example.com/jobs/interview-1",
+		"interview_type":   "phone",
+		"scheduled_at_utc": soon,
+		"interviewer":      "Recruiter",
+	})
+	if err != nil {
+		t.Fatalf("ScheduleInterview failed: %v", err)
+	}
+	job, _ := scheduled["job"].(map[string]any)
+	if got := getString(job, "stage"); got != "interview" {
+		t.Fatalf("expected stage=interview, got %q", got)
+	}
+	interview, _ := scheduled["interview"].(map[string]any)
+	if got, _ := intFromAny(interview["round"]); got != 1 {
+		t.Fatalf("expected round=1, got %#v", interview["round"])
+	}
+
+	second, err := ScheduleInterview(map[string]any{
+		"user_id":          "u1",
+		"job_url":          "https://example.com/jobs/interview-1",
+		"interview_type":   "onsite",
+		"scheduled_at_utc": toISO(time.Now().Add(30 * 24 * time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf("second ScheduleInterview failed: %v", err)
+	}
+	secondInterview, _ := second["interview"].(map[string]any)
+	if got, _ := intFromAny(secondInterview["round"]); got != 2 {
+		t.Fatalf("expected round=2, got %#v", secondInterview["round"])
+	}
+
+	upcoming, err := ListUpcomingInterviews(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("ListUpcomingInterviews failed: %v", err)
+	}
+	if got, _ := upcoming["total_interviews"].(int); got != 1 {
+		t.Fatalf("expected only the interview inside 14 days, got %#v", upcoming["total_interviews"])
+	}
+	wide, _ := ListUpcomingInterviews(map[string]any{"user_id": "u1", "days_ahead": 60})
+	if got, _ := wide["total_interviews"].(int); got != 2 {
+		t.Fatalf("expected 2 interviews inside 60 days, got %#v", wide["total_interviews"])
+	}
+
+	firstID, _ := intFromAny(interview["id"])
+	if _, err := ScheduleInterview(map[string]any{
+		"user_id":      "u1",
+		"interview_id": firstID,
+		"outcome":      "passed",
+	}); err != nil {
+		t.Fatalf("updating outcome failed: %v", err)
+	}
+	summary, err := GetJobPipelineSummary(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("GetJobPipelineSummary failed: %v", err)
+	}
+	if got, _ := summary["interview_count"].(int); got != 2 {
+		t.Fatalf("expected interview_count=2, got %#v", summary["interview_count"])
+	}
+	if got := len(listOrEmpty(summary["upcoming_interviews"])); got != 0 {
+		t.Fatalf("expected no pending interviews inside 14 days, got %d", got)
+	}
+
+	exported, err := ExportUserData(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("ExportUserData failed: %v", err)
+	}
+	counts, _ := exported["counts"].(map[string]any)
+	if got, _ := counts["job_management_interviews"].(int); got != 2 {
+		t.Fatalf("expected 2 exported interviews, got %#v", counts["job_management_interviews"])
+	}
+}
+
+func TestScheduleInterviewValidation(t *testing.T) {
+	setupUserToolPaths(t)
+
+	if _, err := ScheduleInterview(map[string]any{
+		"user_id": "u1",
+		"job_url": "https://example.com/jobs/interview-2",
+	}); err == nil {
+		t.Fatalf("expected scheduled_at_utc to be required")
+	}
+	if _, err := ScheduleInterview(map[string]any{
+		"user_id":          "u1",
+		"job_url":          "https://example.com/jobs/interview-2",
+		"scheduled_at_utc": "next tuesday",
+	}); err == nil {
+		t.Fatalf("expected invalid timestamp error")
+	}
+	if _, err := ScheduleInterview(map[string]any{
+		"user_id":          "u1",
+		"job_url":          "https://example.com/jobs/interview-2",
+		"scheduled_at_utc": "2030-01-02T15:00:00Z",
+		"interview_type":   "lunch",
+	}); err == nil {
+		t.Fatalf("expected invalid interview_type error")
+	}
+	if _, err := ScheduleInterview(map[string]any{"user_id": "u1", "interview_id": 99}); err == nil {
+		t.Fatalf("expected unknown interview_id error")
+	}
+}
@@ -0,0 +1,163 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	defaultLivenessChecks = 10
+	maxLivenessChecks     = 50
+)
+
+var defaultLivenessStages = []string{"saved", "applied"}
+
+// checkPipelineJobPosting re-fetches one pipeline job's LinkedIn page and
+// stores the result on the job. Only a definitive open or closed result
+// replaces the stored posting_status; a page with neither a closed banner nor
+// a description keeps the previous one. The first time a job is seen closed
+// it gets a posting_closed event; its stage is left for the user to decide.
+func checkPipelineJobPosting(client linkedInClient, entry map[string]any, userID string, job map[string]any) map[string]any {
+	jobID, _ := intFromAny(job["id"])
+	result := map[string]any{
+		"job_id":  jobID,
+		"job_url": getString(job, "job_url"),
+		"title":   getString(job, "title"),
+		"company": getString(job, "company"),
+	}
+	if !savedJobDescriptionFetchable(job) {
+		result["posting_status"] = "unknown"
+		result["error"] = "only LinkedIn job URLs can be checked"
+		return result
+	}
+	details, err := client.FetchJobDetails(getString(job, "job_url"), getString(job, "title"), getString(job, "location"), nil)
+	if err != nil {
+		result["posting_status"] = "unknown"
+		result["error"] = err.Error()
+		return result
+	}
+	status := jobPostingStatus(details)
+	wasClosed := getString(job, "posting_status") == "closed"
+	now := utcNowISO()
+	if status != "unknown" {
+		job["posting_status"] = status
+		job["posting_closed_reason"] = details.ClosedReason
+	}
+	job["posting_checked_at_utc"] = now
+	result["posting_status"] = status
+	result["closed_reason"] = optionalString(details.ClosedReason)
+	result["checked_at_utc"] = now
+	result["newly_closed"] = false
+	if status == "closed" && !wasClosed {
+		stage := "new"
+		if _, app := findApplicationIndex(entry, jobID); app != nil {
+			stage = getString(app, "stage")
+		}
+		result["event"] = appendPipelineEvent(entry, userID, jobID, stage, stage, "posting_closed", details.ClosedReason)
+		result["newly_closed"] = true
+	}
+	return result
+}
+
+// CheckJobStillOpen re-fetches one pipeline job's posting and flags it when
+// LinkedIn says it no longer accepts applications.
+func CheckJobStillOpen(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+	jobID, job, err := resolveJobManagementTarget(entry, args, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := checkPipelineJobPosting(linkedInClientFactory(), entry, userID, job)
+	if err := saveJobPipeline(pipeline); err != nil {
+		return nil, err
+	}
+	snapshot, err := jobSnapshot(entry, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	result["user_id"] = userID
+	result["job"] = snapshot
+	result["job_db_path"] = jobDBPath()
+	return result, nil
+}
+
+// CheckPipelineJobsStillOpen runs check_job_still_open over the jobs in the
+// given stages (saved and applied by default), least recently checked first.
+// Jobs already known to be closed are skipped.
+func CheckPipelineJobsStillOpen(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	stages := defaultLivenessStages
+	if hasKey(args, "stages") {
+		stages = []string{}
+		for _, value := range getStringList(args, "stages") {
+			stage, err := validateJobStage(value)
+			if err != nil {
+				return nil, err
+			}
+			stages = append(stages, stage)
+		}
+	}
+	maxChecks := defaultLivenessChecks
+	if parsed, has, err := getOptionalInt(args, "max_checks"); has {
+		if err != nil {
+			return nil, fmt.Errorf("max_checks must be an integer when provided")
+		}
+		maxChecks = max(1, min(parsed, maxLivenessChecks))
+	}
+
+	pipeline := loadJobPipeline()
+	entry := getPipelineEntry(pipeline, userID)
+	candidates := []map[string]any{}
+	if entry != nil {
+		for _, app := range entry["applications"].([]map[string]any) {
+			if !slices.Contains(stages, getString(app, "stage")) {
+				continue
+			}
+			jobID, _ := intFromAny(app["job_id"])
+			job := getJobByID(entry, jobID)
+			if job == nil || getString(job, "posting_status") == "closed" {
+				continue
+			}
+			candidates = append(candidates, job)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b map[string]any) int {
+		return strings.Compare(getString(a, "posting_checked_at_utc"), getString(b, "posting_checked_at_utc"))
+	})
+
+	results := []any{}
+	closed := 0
+	if len(candidates) > 0 {
+		client := linkedInClientFactory()
+		for _, job := range candidates[:min(maxChecks, len(candidates))] {
+			result := checkPipelineJobPosting(client, entry, userID, job)
+			if boolOrFalse(result["newly_closed"]) {
+				closed++
+			}
+			results = append(results, result)
+		}
+		if err := saveJobPipeline(pipeline); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]any{
+		"user_id":          userID,
+		"stages":           stages,
+		"max_checks":       maxChecks,
+		"eligible_jobs":    len(candidates),
+		"checked_jobs":     len(results),
+		"newly_closed":     closed,
+		"remaining_checks": max(0, len(candidates)-len(results)),
+		"results":          results,
+		"job_db_path":      jobDBPath(),
+	}, nil
+}
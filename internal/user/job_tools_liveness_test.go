@@ -0,0 +1,106 @@
+package user
+
+import "testing"
+
+func TestCheckJobsStillOpen(t *testing.T) {
+	setupUserToolPaths(t)
+
+	openURL := "https://www.linkedin.com/jobs/view/open-1/"
+	closedURL := "https://www.linkedin.com/jobs/view/closed-1/"
+	for _, url := range []string{openURL, closedURL} {
+		if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": url, "stage": "saved"}); err != nil {
+			t.Fatalf("UpdateJobStage failed: %v", err)
+		}
+	}
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/offsite", "stage": "applied"}); err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+	client := &fakeLinkedInClient{
+		descriptions: map[string]string{openURL: "We sponsor H-1B visas."},
+		closed:       map[string]string{closedURL: "No longer accepting applications"},
+	}
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient { return client }
+
+	single, err := CheckJobStillOpen(map[string]any{"user_id": "u1", "job_url": closedURL})
+	if err != nil {
+		t.Fatalf("CheckJobStillOpen failed: %v", err)
+	}
+	if got := getString(single, "posting_status"); got != "closed" || single["newly_closed"] != true {
+		t.Fatalf("expected a newly closed posting, got %#v", single)
+	}
+	if got := getString(asMap(single["job"]), "stage"); got != "saved" {
+		t.Fatalf("expected the stage to stay saved, got %q", got)
+	}
+
+	bulk, err := CheckPipelineJobsStillOpen(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("CheckPipelineJobsStillOpen failed: %v", err)
+	}
+	if got := intOrZero(bulk["checked_jobs"]); got != 2 {
+		t.Fatalf("expected the open and off-site jobs to be checked, got %#v", bulk["results"])
+	}
+	if got := intOrZero(bulk["newly_closed"]); got != 0 {
+		t.Fatalf("expected no new closures, got %d", got)
+	}
+
+	events, _ := ListRecentJobEvents(map[string]any{"user_id": "u1"})
+	closedEvents := 0
+	for _, raw := range listOrEmpty(events["events"]) {
+		if getString(asMap(raw), "reason") == "posting_closed" {
+			closedEvents++
+		}
+	}
+	if closedEvents != 1 {
+		t.Fatalf("expected one posting_closed event, got %d", closedEvents)
+	}
+}
+
+func TestCheckJobStillOpenKeepsClosedStatusOnUnknownResult(t *testing.T) {
+	setupUserToolPaths(t)
+
+	jobURL := "https://www.linkedin.com/jobs/view/flaky-1/"
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": jobURL, "stage": "applied"}); err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+	client := &fakeLinkedInClient{closed: map[string]string{jobURL: "No longer accepting applications"}}
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient { return client }
+
+	if _, err := CheckJobStillOpen(map[string]any{"user_id": "u1", "job_url": jobURL}); err != nil {
+		t.Fatalf("CheckJobStillOpen failed: %v", err)
+	}
+	// LinkedIn serves a page with neither a closed banner nor a description.
+	delete(client.closed, jobURL)
+	unknown, err := CheckJobStillOpen(map[string]any{"user_id": "u1", "job_url": jobURL})
+	if err != nil {
+		t.Fatalf("CheckJobStillOpen failed: %v", err)
+	}
+	if got := getString(unknown, "posting_status"); got != "unknown" {
+		t.Fatalf("expected the check itself to report unknown, got %q", got)
+	}
+	if got := getString(asMap(unknown["job"]), "posting_status"); got != "closed" {
+		t.Fatalf("expected the stored status to stay closed, got %q", got)
+	}
+
+	client.closed[jobURL] = "No longer accepting applications"
+	again, err := CheckJobStillOpen(map[string]any{"user_id": "u1", "job_url": jobURL})
+	if err != nil {
+		t.Fatalf("CheckJobStillOpen failed: %v", err)
+	}
+	if again["newly_closed"] != false {
+		t.Fatalf("expected the closure to already be known, got %#v", again)
+	}
+	events, _ := ListRecentJobEvents(map[string]any{"user_id": "u1"})
+	closedEvents := 0
+	for _, raw := range listOrEmpty(events["events"]) {
+		if getString(asMap(raw), "reason") == "posting_closed" {
+			closedEvents++
+		}
+	}
+	if closedEvents != 1 {
+		t.Fatalf("expected one posting_closed event, got %d", closedEvents)
+	}
+}
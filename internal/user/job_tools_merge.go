@@ -0,0 +1,189 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// mergedJobFields are filled on the kept job from the duplicate when the kept
+// job has no value of its own.
+var mergedJobFields = []string{"result_id", "title", "company", "location", "site", "posting_status", "posting_closed_reason", "posting_checked_at_utc"}
+
+// mergeStageOrder ranks stages by how far along the pipeline they are.
+// Rejected is final, so it outranks active stages; ignored only outranks new,
+// since a duplicate the user ignored should not hide one they applied to.
+var mergeStageOrder = []string{"new", "ignored", "saved", "applied", "interview", "offer", "rejected"}
+
+// mergeJobApplications leaves keepJobID with a single application. When both
+// jobs have one, the application further along the pipeline wins (the more
+// recently updated one on a tie) and blank applied_at_utc, source_session_id,
+// and note values are filled from the other.
+func mergeJobApplications(entry map[string]any, keepJobID, mergeJobID int) {
+	_, keepApp := findApplicationIndex(entry, keepJobID)
+	mergeIdx, mergeApp := findApplicationIndex(entry, mergeJobID)
+	if mergeApp == nil {
+		return
+	}
+	if keepApp == nil {
+		mergeApp["job_id"] = keepJobID
+		return
+	}
+	winner, loser := keepApp, mergeApp
+	keepRank := slices.Index(mergeStageOrder, getString(keepApp, "stage"))
+	mergeRank := slices.Index(mergeStageOrder, getString(mergeApp, "stage"))
+	if mergeRank > keepRank || (mergeRank == keepRank && getString(mergeApp, "updated_at_utc") > getString(keepApp, "updated_at_utc")) {
+		winner, loser = mergeApp, keepApp
+	}
+	for _, field := range []string{"applied_at_utc", "source_session_id", "note"} {
+		if getString(winner, field) == "" {
+			winner[field] = getString(loser, field)
+		}
+	}
+	keepApp["stage"] = winner["stage"]
+	for _, field := range []string{"applied_at_utc", "source_session_id", "note", "updated_at_utc"} {
+		keepApp[field] = winner[field]
+	}
+	entry["applications"] = slices.Delete(entry["applications"].([]map[string]any), mergeIdx, mergeIdx+1)
+}
+
+// MergePipelineJobs folds a duplicate pipeline job (the same role saved under
+// another URL, or a repost) into the job it duplicates. Blank metadata is
+// filled from the duplicate, tags are combined, events and per-job records
+// move to the kept job, and the duplicate's URL keeps resolving to it. An
+// explicit stage replaces the stage picked from the two applications.
+func MergePipelineJobs(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	keepJobID, hasKeep, err := getOptionalInt(args, "keep_job_id")
+	if !hasKeep || err != nil || keepJobID < 1 {
+		return nil, fmt.Errorf("keep_job_id is required and must be a positive integer")
+	}
+	mergeJobID, hasMerge, err := getOptionalInt(args, "merge_job_id")
+	if !hasMerge || err != nil || mergeJobID < 1 {
+		return nil, fmt.Errorf("merge_job_id is required and must be a positive integer")
+	}
+	if keepJobID == mergeJobID {
+		return nil, fmt.Errorf("keep_job_id and merge_job_id must be different jobs")
+	}
+	stageOverride := ""
+	if hasKey(args, "stage") {
+		if stageOverride, err = validateJobStage(getString(args, "stage")); err != nil {
+			return nil, err
+		}
+	}
+
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+	keepJob := getJobByID(entry, keepJobID)
+	if keepJob == nil {
+		return nil, fmt.Errorf("job_id=%d not found for user_id='%s'", keepJobID, userID)
+	}
+	mergeJob := getJobByID(entry, mergeJobID)
+	if mergeJob == nil {
+		return nil, fmt.Errorf("job_id=%d not found for user_id='%s'", mergeJobID, userID)
+	}
+
+	filled := []string{}
+	for _, field := range mergedJobFields {
+		if getString(keepJob, field) == "" && getString(mergeJob, field) != "" {
+			keepJob[field] = getString(mergeJob, field)
+			filled = append(filled, field)
+		}
+	}
+	tags := jobTags(keepJob)
+	for _, tag := range jobTags(mergeJob) {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	keepJob["tags"] = tags
+	mergedURLs := getStringList(keepJob, "merged_job_urls")
+	for _, url := range append([]string{getString(mergeJob, "job_url")}, getStringList(mergeJob, "merged_job_urls")...) {
+		if url != "" && !strings.EqualFold(url, getString(keepJob, "job_url")) && !slices.Contains(mergedURLs, url) {
+			mergedURLs = append(mergedURLs, url)
+		}
+	}
+	keepJob["merged_job_urls"] = mergedURLs
+	if created := getString(mergeJob, "created_at_utc"); created != "" && created < getString(keepJob, "created_at_utc") {
+		keepJob["created_at_utc"] = created
+	}
+	keepJob["updated_at_utc"] = utcNowISO()
+
+	mergeJobApplications(entry, keepJobID, mergeJobID)
+
+	// One offer per job: if both jobs have an offer, the older one is dropped.
+	droppedOfferID := 0
+	if keepOffer, mergeOffer := findOfferForJob(entry, keepJobID), findOfferForJob(entry, mergeJobID); keepOffer != nil && mergeOffer != nil {
+		drop := mergeOffer
+		if getString(mergeOffer, "updated_at_utc") > getString(keepOffer, "updated_at_utc") {
+			drop = keepOffer
+		}
+		droppedOfferID, _ = intFromAny(drop["id"])
+		entry["offers"] = slices.DeleteFunc(entry["offers"].([]map[string]any), func(row map[string]any) bool {
+			id, _ := intFromAny(row["id"])
+			return id == droppedOfferID
+		})
+	}
+
+	moved := map[string]any{}
+	movedEvents := 0
+	for _, event := range entry["events"].([]map[string]any) {
+		if id, _ := intFromAny(event["job_id"]); id == mergeJobID {
+			event["job_id"] = keepJobID
+			movedEvents++
+		}
+	}
+	moved["events"] = movedEvents
+	for _, list := range pipelineRecordLists {
+		count := 0
+		for _, row := range entry[list.Key].([]map[string]any) {
+			if id, _ := intFromAny(row["job_id"]); id == mergeJobID {
+				row["job_id"] = keepJobID
+				count++
+			}
+		}
+		moved[list.Key] = count
+	}
+	entry["jobs"] = slices.DeleteFunc(entry["jobs"].([]map[string]any), func(row map[string]any) bool {
+		id, _ := intFromAny(row["id"])
+		return id == mergeJobID
+	})
+
+	stage := "new"
+	if _, app := findApplicationIndex(entry, keepJobID); app != nil {
+		stage = getString(app, "stage")
+	}
+	if stageOverride != "" && stageOverride != stage {
+		if _, _, err := setJobStage(entry, userID, keepJobID, stageOverride, "", "", "", "merge_stage_override"); err != nil {
+			return nil, err
+		}
+		stage = stageOverride
+	}
+	note := fmt.Sprintf("merged job_id=%d (%s)", mergeJobID, getString(mergeJob, "job_url"))
+	if droppedOfferID > 0 {
+		note += fmt.Sprintf("; dropped offer_id=%d", droppedOfferID)
+	}
+	event := appendPipelineEvent(entry, userID, keepJobID, stage, stage, "jobs_merged", note)
+
+	if err := saveJobPipeline(pipeline); err != nil {
+		return nil, err
+	}
+	snapshot, err := jobSnapshot(entry, userID, keepJobID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":          userID,
+		"job":              snapshot,
+		"merged_job_id":    mergeJobID,
+		"merged_job_url":   getString(mergeJob, "job_url"),
+		"filled_fields":    filled,
+		"moved_records":    moved,
+		"dropped_offer_id": optionalPositiveInt(droppedOfferID),
+		"event":            event,
+		"job_db_path":      jobDBPath(),
+	}, nil
+}
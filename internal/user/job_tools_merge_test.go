@@ -0,0 +1,113 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMergePipelineJobs(t *testing.T) {
+	setupUserToolPaths(t)
+
+	keepURL := "https://www.linkedin.com/jobs/view/100/"
+	dupURL := "https://www.linkedin.com/jobs/view/200/"
+	kept, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": keepURL, "stage": "saved", "title": "Backend Engineer"})
+	if err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+	keepJobID, _ := intFromAny(asMap(kept["job"])["job_id"])
+	dup, err := MarkJobApplied(map[string]any{"user_id": "u1", "job_url": dupURL, "company": "Acme Inc"})
+	if err != nil {
+		t.Fatalf("MarkJobApplied failed: %v", err)
+	}
+	dupJobID, _ := intFromAny(asMap(dup["job"])["job_id"])
+	if _, err := AddJobTags(map[string]any{"user_id": "u1", "job_id": dupJobID, "tags": []any{"referral available"}}); err != nil {
+		t.Fatalf("AddJobTags failed: %v", err)
+	}
+	if _, err := AttachJobArtifact(map[string]any{"user_id": "u1", "job_id": dupJobID, "name": "Resume", "url_or_path": "resume.pdf"}); err != nil {
+		t.Fatalf("AttachJobArtifact failed: %v", err)
+	}
+
+	merged, err := MergePipelineJobs(map[string]any{"user_id": "u1", "keep_job_id": keepJobID, "merge_job_id": dupJobID})
+	if err != nil {
+		t.Fatalf("MergePipelineJobs failed: %v", err)
+	}
+	job := asMap(merged["job"])
+	if getString(job, "title") != "Backend Engineer" || getString(job, "company") != "Acme Inc" {
+		t.Fatalf("expected metadata from both jobs, got %#v", job)
+	}
+	if got := getString(job, "stage"); got != "applied" {
+		t.Fatalf("expected the newer applied application to win, got %q", got)
+	}
+	if len(listOrEmpty(job["artifacts"])) != 1 || len(jobTags(job)) != 1 {
+		t.Fatalf("expected the artifact and tag to move, got %#v", job)
+	}
+
+	again, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": dupURL, "stage": "interview"})
+	if err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+	if id, _ := intFromAny(asMap(again["job"])["job_id"]); id != keepJobID {
+		t.Fatalf("expected the merged URL to resolve to job %d, got %d", keepJobID, id)
+	}
+	applied, _ := ListJobsByStage(map[string]any{"user_id": "u1", "stage": "interview"})
+	if got, _ := applied["total_jobs"].(int); got != 1 {
+		t.Fatalf("expected a single job after the merge, got %#v", applied["total_jobs"])
+	}
+
+	if _, err := MergePipelineJobs(map[string]any{"user_id": "u1", "keep_job_id": keepJobID, "merge_job_id": dupJobID}); err == nil {
+		t.Fatalf("expected merging a removed job to fail")
+	}
+	if _, err := MergePipelineJobs(map[string]any{"user_id": "u1", "keep_job_id": keepJobID, "merge_job_id": keepJobID}); err == nil {
+		t.Fatalf("expected merging a job into itself to fail")
+	}
+}
+
+func TestMergePipelineJobsPrefersActiveStage(t *testing.T) {
+	setupUserToolPaths(t)
+
+	keepURL := "https://example.com/jobs/offer-keep"
+	dupURL := "https://example.com/jobs/offer-dup"
+	kept, err := RecordJobOffer(map[string]any{"user_id": "u1", "job_url": keepURL, "base_salary": 150000})
+	if err != nil {
+		t.Fatalf("RecordJobOffer failed: %v", err)
+	}
+	keepJobID, _ := intFromAny(asMap(kept["job"])["job_id"])
+	dup, err := RecordJobOffer(map[string]any{"user_id": "u1", "job_url": dupURL, "base_salary": 160000})
+	if err != nil {
+		t.Fatalf("RecordJobOffer failed: %v", err)
+	}
+	dupJobID, _ := intFromAny(asMap(dup["job"])["job_id"])
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_id": dupJobID, "stage": "ignored", "force": true}); err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+
+	merged, err := MergePipelineJobs(map[string]any{"user_id": "u1", "keep_job_id": keepJobID, "merge_job_id": dupJobID})
+	if err != nil {
+		t.Fatalf("MergePipelineJobs failed: %v", err)
+	}
+	if got := getString(asMap(merged["job"]), "stage"); got != "offer" {
+		t.Fatalf("expected the offer stage to beat ignored, got %q", got)
+	}
+	droppedOfferID := intOrZero(merged["dropped_offer_id"])
+	if droppedOfferID == 0 || !strings.Contains(getString(asMap(merged["event"]), "note"), fmt.Sprintf("dropped offer_id=%d", droppedOfferID)) {
+		t.Fatalf("expected the merge event to record the dropped offer, got %#v", merged["event"])
+	}
+
+	savedURL := "https://example.com/jobs/saved-keep"
+	appliedURL := "https://example.com/jobs/applied-dup"
+	saved, _ := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": savedURL, "stage": "saved"})
+	applied, _ := MarkJobApplied(map[string]any{"user_id": "u1", "job_url": appliedURL})
+	savedJobID, _ := intFromAny(asMap(saved["job"])["job_id"])
+	appliedJobID, _ := intFromAny(asMap(applied["job"])["job_id"])
+	overridden, err := MergePipelineJobs(map[string]any{"user_id": "u1", "keep_job_id": savedJobID, "merge_job_id": appliedJobID, "stage": "ignored"})
+	if err != nil {
+		t.Fatalf("MergePipelineJobs failed: %v", err)
+	}
+	if got := getString(asMap(overridden["job"]), "stage"); got != "ignored" {
+		t.Fatalf("expected the explicit stage to win, got %q", got)
+	}
+	if _, err := MergePipelineJobs(map[string]any{"user_id": "u1", "keep_job_id": keepJobID, "merge_job_id": savedJobID, "stage": "maybe"}); err == nil {
+		t.Fatalf("expected an invalid stage to be rejected")
+	}
+}
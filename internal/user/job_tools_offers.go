@@ -0,0 +1,295 @@
+package user
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// offerAmountFields are whole-currency annual amounts, except sign_on_bonus
+// which is paid once.
+var offerAmountFields = []string{"base_salary", "bonus", "sign_on_bonus", "equity_annual_value"}
+
+var validOfferStatuses = []string{"pending", "accepted", "declined", "expired"}
+
+func validateOfferStatus(value string) (string, error) {
+	return validateEnumValue("status", value, "pending", validOfferStatuses)
+}
+
+func findOfferForJob(entry map[string]any, jobID int) map[string]any {
+	for _, row := range entry["offers"].([]map[string]any) {
+		id, _ := intFromAny(row["job_id"])
+		if id == jobID {
+			return row
+		}
+	}
+	return nil
+}
+
+// applyOfferFields copies the offer fields present in args onto record.
+func applyOfferFields(record map[string]any, args map[string]any) error {
+	for _, field := range offerAmountFields {
+		if amount, has, err := getOptionalInt(args, field); has {
+			if err != nil || amount < 0 {
+				return fmt.Errorf("%s must be a non-negative integer when provided", field)
+			}
+			record[field] = amount
+		}
+	}
+	if hasKey(args, "currency") {
+		record["currency"] = firstNonEmpty(strings.ToUpper(getString(args, "currency")), "USD")
+	}
+	if hasKey(args, "start_date") {
+		start := getString(args, "start_date")
+		if _, err := time.Parse(postedDateLayout, start); start != "" && err != nil {
+			return fmt.Errorf("start_date must be a YYYY-MM-DD date")
+		}
+		record["start_date"] = start
+	}
+	if hasKey(args, "decision_deadline") {
+		deadline := getString(args, "decision_deadline")
+		record["decision_deadline_utc"] = ""
+		if deadline != "" {
+			parsed, err := parsePostedBound(deadline, true)
+			if err != nil {
+				return fmt.Errorf("decision_deadline must be a YYYY-MM-DD date or RFC3339 timestamp")
+			}
+			record["decision_deadline_utc"] = toISO(parsed)
+		}
+	}
+	if hasKey(args, "sponsorship_terms") {
+		record["sponsorship_terms"] = getString(args, "sponsorship_terms")
+	}
+	if value, has, err := getOptionalBool(args, "sponsors_visa"); has {
+		if err != nil {
+			return fmt.Errorf("sponsors_visa must be a boolean when provided")
+		}
+		record["sponsors_visa"] = value
+	}
+	if hasKey(args, "status") {
+		status, err := validateOfferStatus(getString(args, "status"))
+		if err != nil {
+			return err
+		}
+		record["status"] = status
+	}
+	if hasKey(args, "note") {
+		record["note"] = getString(args, "note")
+	}
+	return nil
+}
+
+// RecordJobOffer stores the offer details for a pipeline job (one offer per
+// job; later calls update it) and moves the job to the offer stage.
+func RecordJobOffer(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	force, err := parseForce(args)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+	jobID, _, err := resolveJobManagementTarget(entry, args, userID)
+	if err != nil {
+		return nil, err
+	}
+	overridden, err := checkStageTransition(entry, userID, jobID, "offer", force)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utcNowISO()
+	offer := findOfferForJob(entry, jobID)
+	created := offer == nil
+	if created {
+		nextID, _ := intFromAny(entry["next_offer_id"])
+		offer = map[string]any{
+			"id":                    nextID,
+			"user_id":               userID,
+			"job_id":                jobID,
+			"currency":              "USD",
+			"start_date":            "",
+			"sponsorship_terms":     "",
+			"sponsors_visa":         nil,
+			"decision_deadline_utc": "",
+			"status":                "pending",
+			"note":                  "",
+			"created_at_utc":        now,
+		}
+		for _, field := range offerAmountFields {
+			offer[field] = 0
+		}
+	}
+	if err := applyOfferFields(offer, args); err != nil {
+		return nil, err
+	}
+	offer["updated_at_utc"] = now
+	if created {
+		nextID, _ := intFromAny(offer["id"])
+		entry["offers"] = append(entry["offers"].([]map[string]any), offer)
+		entry["next_offer_id"] = nextID + 1
+	}
+
+	stage := "new"
+	if _, app := findApplicationIndex(entry, jobID); app != nil {
+		stage = getString(app, "stage")
+	}
+	var event map[string]any
+	if stage != "offer" {
+		_, event, err = setJobStage(entry, userID, jobID, "offer", getString(args, "note"), "", "", forcedReason("offer_recorded", overridden))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		event = appendPipelineEvent(entry, userID, jobID, stage, stage, "offer_updated", getString(args, "note"))
+	}
+
+	if err := saveJobPipeline(pipeline); err != nil {
+		return nil, err
+	}
+	snapshot, err := jobSnapshot(entry, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":         userID,
+		"job":             snapshot,
+		"offer":           offer,
+		"created":         created,
+		"event":           event,
+		"overridden_rule": optionalString(overridden),
+		"job_db_path":     jobDBPath(),
+	}, nil
+}
+
+// offerComparisonRow flattens an offer with its job and the derived totals:
+// first_year_total counts the sign-on bonus, annual_recurring_total does not.
+func offerComparisonRow(entry map[string]any, offer map[string]any, now time.Time) map[string]any {
+	jobID, _ := intFromAny(offer["job_id"])
+	job := getJobByID(entry, jobID)
+	recurring := intOrZero(offer["base_salary"]) + intOrZero(offer["bonus"]) + intOrZero(offer["equity_annual_value"])
+	var daysToDecision any
+	deadlinePassed := false
+	if deadline := parseISOTime(offer["decision_deadline_utc"]); !deadline.IsZero() {
+		daysToDecision = int(math.Ceil(deadline.Sub(now).Hours() / 24))
+		deadlinePassed = deadline.Before(now)
+	}
+	return map[string]any{
+		"offer_id":               offer["id"],
+		"job_id":                 jobID,
+		"job_url":                getString(job, "job_url"),
+		"title":                  getString(job, "title"),
+		"company":                getString(job, "company"),
+		"currency":               offer["currency"],
+		"base_salary":            offer["base_salary"],
+		"bonus":                  offer["bonus"],
+		"sign_on_bonus":          offer["sign_on_bonus"],
+		"equity_annual_value":    offer["equity_annual_value"],
+		"annual_recurring_total": recurring,
+		"first_year_total":       recurring + intOrZero(offer["sign_on_bonus"]),
+		"start_date":             offer["start_date"],
+		"sponsors_visa":          offer["sponsors_visa"],
+		"sponsorship_terms":      offer["sponsorship_terms"],
+		"decision_deadline_utc":  offer["decision_deadline_utc"],
+		"days_to_decision":       daysToDecision,
+		"deadline_passed":        deadlinePassed,
+		"status":                 offer["status"],
+		"note":                   offer["note"],
+	}
+}
+
+// CompareOffers lines up recorded offers side by side, highest first-year
+// total first. Totals are only compared within one currency.
+func CompareOffers(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	jobIDs, err := getJobIDList(args, "job_ids")
+	if err != nil {
+		return nil, err
+	}
+	includeDecided := false
+	if parsed, has, err := getOptionalBool(args, "include_decided"); has {
+		if err != nil {
+			return nil, fmt.Errorf("include_decided must be a boolean when provided")
+		}
+		includeDecided = parsed
+	}
+
+	now := utcNow()
+	rows := []map[string]any{}
+	if entry := getPipelineEntry(loadJobPipeline(), userID); entry != nil {
+		for _, offer := range entry["offers"].([]map[string]any) {
+			jobID, _ := intFromAny(offer["job_id"])
+			if len(jobIDs) > 0 && !slices.Contains(jobIDs, jobID) {
+				continue
+			}
+			if len(jobIDs) == 0 && !includeDecided && getString(offer, "status") != "pending" {
+				continue
+			}
+			rows = append(rows, offerComparisonRow(entry, offer, now))
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b map[string]any) int {
+		return intOrZero(b["first_year_total"]) - intOrZero(a["first_year_total"])
+	})
+
+	currencies := []string{}
+	for _, row := range rows {
+		if currency := getString(row, "currency"); !slices.Contains(currencies, currency) {
+			currencies = append(currencies, currency)
+		}
+	}
+	bestBy := map[string]any{}
+	warnings := []string{}
+	if len(currencies) > 1 {
+		warnings = append(warnings, fmt.Sprintf("Offers use different currencies %v; totals are not converted, so best_by is left empty.", currencies))
+	} else if len(rows) > 0 {
+		for _, field := range []string{"first_year_total", "annual_recurring_total", "base_salary"} {
+			best := rows[0]
+			for _, row := range rows[1:] {
+				if intOrZero(row[field]) > intOrZero(best[field]) {
+					best = row
+				}
+			}
+			bestBy[field] = best["job_id"]
+		}
+	}
+	var earliest map[string]any
+	for _, row := range rows {
+		if row["days_to_decision"] == nil || boolOrFalse(row["deadline_passed"]) {
+			continue
+		}
+		if earliest == nil || getString(row, "decision_deadline_utc") < getString(earliest, "decision_deadline_utc") {
+			earliest = row
+		}
+	}
+	if earliest != nil {
+		bestBy["earliest_deadline"] = earliest["job_id"]
+	}
+	for _, row := range rows {
+		if row["sponsors_visa"] == false {
+			warnings = append(warnings, fmt.Sprintf("Offer for job_id=%v does not include visa sponsorship.", row["job_id"]))
+		}
+	}
+
+	rowsAny := make([]any, 0, len(rows))
+	for _, row := range rows {
+		rowsAny = append(rowsAny, row)
+	}
+	return map[string]any{
+		"user_id":     userID,
+		"total":       len(rows),
+		"offers":      rowsAny,
+		"currencies":  currencies,
+		"best_by":     bestBy,
+		"warnings":    warnings,
+		"job_db_path": jobDBPath(),
+	}, nil
+}
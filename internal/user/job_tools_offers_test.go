@@ -0,0 +1,99 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordJobOfferAndCompare(t *testing.T) {
+	setupUserToolPaths(t)
+
+	first, err := RecordJobOffer(map[string]any{
+		"user_id":           "u1",
+		"job_url":           "https://example.com/jobs/offer-1",
+		"base_salary":       180000,
+		"bonus":             20000,
+		"sign_on_bonus":     30000,
+		"sponsors_visa":     true,
+		"sponsorship_terms": "H-1B transfer plus green card after 1 year",
+		"decision_deadline": time.Now().Add(5 * 24 * time.Hour).Format(postedDateLayout),
+	})
+	if err != nil {
+		t.Fatalf("RecordJobOffer failed: %v", err)
+	}
+	job, _ := first["job"].(map[string]any)
+	if got := getString(job, "stage"); got != "offer" {
+		t.Fatalf("expected stage=offer, got %q", got)
+	}
+	if _, err := RecordJobOffer(map[string]any{
+		"user_id":             "u1",
+		"job_url":             "https://example.com/jobs/offer-2",
+		"base_salary":         200000,
+		"equity_annual_value": 25000,
+		"sponsors_visa":       false,
+	}); err != nil {
+		t.Fatalf("second RecordJobOffer failed: %v", err)
+	}
+	updated, err := RecordJobOffer(map[string]any{
+		"user_id":    "u1",
+		"job_url":    "https://example.com/jobs/offer-1",
+		"bonus":      25000,
+		"start_date": "2026-01-05",
+	})
+	if err != nil {
+		t.Fatalf("updating offer failed: %v", err)
+	}
+	if created, _ := updated["created"].(bool); created {
+		t.Fatalf("expected the second call for a job to update its offer")
+	}
+
+	comparison, err := CompareOffers(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("CompareOffers failed: %v", err)
+	}
+	offers := listOrEmpty(comparison["offers"])
+	if len(offers) != 2 {
+		t.Fatalf("expected 2 offers, got %d", len(offers))
+	}
+	top := asMap(offers[0])
+	if got := intOrZero(top["first_year_total"]); got != 235000 {
+		t.Fatalf("expected first_year_total=235000 for the top offer, got %d", got)
+	}
+	if got := intOrZero(top["annual_recurring_total"]); got != 205000 {
+		t.Fatalf("expected annual_recurring_total=205000, got %d", got)
+	}
+	bestBy := asMap(comparison["best_by"])
+	if intOrZero(bestBy["base_salary"]) == intOrZero(bestBy["first_year_total"]) {
+		t.Fatalf("expected different winners for base_salary and first_year_total, got %#v", bestBy)
+	}
+	if _, ok := bestBy["earliest_deadline"]; !ok {
+		t.Fatalf("expected earliest_deadline in best_by, got %#v", bestBy)
+	}
+	warnings, _ := comparison["warnings"].([]string)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "does not include visa sponsorship") {
+		t.Fatalf("expected a no-sponsorship warning, got %#v", warnings)
+	}
+}
+
+func TestCompareOffersValidation(t *testing.T) {
+	setupUserToolPaths(t)
+
+	if _, err := RecordJobOffer(map[string]any{
+		"user_id":     "u1",
+		"job_url":     "https://example.com/jobs/offer-3",
+		"base_salary": -1,
+	}); err == nil {
+		t.Fatalf("expected negative base_salary to fail")
+	}
+	if _, err := RecordJobOffer(map[string]any{
+		"user_id":    "u1",
+		"job_url":    "https://example.com/jobs/offer-3",
+		"start_date": "soon",
+	}); err == nil {
+		t.Fatalf("expected invalid start_date to fail")
+	}
+	if _, err := CompareOffers(map[string]any{"user_id": "u1", "job_ids": []any{"x"}}); err == nil {
+		t.Fatalf("expected invalid job_ids to fail")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 )
 
 func MarkJobApplied(args map[string]any) (map[string]any, error) {
@@ -11,12 +12,20 @@ func MarkJobApplied(args map[string]any) (map[string]any, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("user_id is required")
 	}
+	force, err := parseForce(args)
+	if err != nil {
+		return nil, err
+	}
 	pipeline := loadJobPipeline()
 	entry := ensurePipelineEntry(pipeline, userID)
 	jobID, _, err := resolveJobManagementTarget(entry, args, userID)
 	if err != nil {
 		return nil, err
 	}
+	overridden, err := checkStageTransition(entry, userID, jobID, "applied", force)
+	if err != nil {
+		return nil, err
+	}
 
 	sourceSessionID := getString(args, "session_id")
 	resultID := getString(args, "result_id")
@@ -31,7 +40,7 @@ func MarkJobApplied(args map[string]any) (map[string]any, error) {
 		getString(args, "note"),
 		sourceSessionID,
 		getString(args, "applied_at_utc"),
-		"mark_job_applied",
+		forcedReason("mark_job_applied", overridden),
 	)
 	if err != nil {
 		return nil, err
@@ -44,11 +53,12 @@ func MarkJobApplied(args map[string]any) (map[string]any, error) {
 		return nil, err
 	}
 	return map[string]any{
-		"user_id":     userID,
-		"job":         snapshot,
-		"application": application,
-		"event":       event,
-		"job_db_path": jobDBPath(),
+		"user_id":         userID,
+		"job":             snapshot,
+		"application":     application,
+		"event":           event,
+		"overridden_rule": optionalString(overridden),
+		"job_db_path":     jobDBPath(),
 	}, nil
 }
 
@@ -61,12 +71,20 @@ func UpdateJobStage(args map[string]any) (map[string]any, error) {
 	if err != nil {
 		return nil, err
 	}
+	force, err := parseForce(args)
+	if err != nil {
+		return nil, err
+	}
 	pipeline := loadJobPipeline()
 	entry := ensurePipelineEntry(pipeline, userID)
 	jobID, _, err := resolveJobManagementTarget(entry, args, userID)
 	if err != nil {
 		return nil, err
 	}
+	overridden, err := checkStageTransition(entry, userID, jobID, cleanStage, force)
+	if err != nil {
+		return nil, err
+	}
 
 	sourceSessionID := getString(args, "session_id")
 	resultID := getString(args, "result_id")
@@ -81,7 +99,7 @@ func UpdateJobStage(args map[string]any) (map[string]any, error) {
 		getString(args, "note"),
 		sourceSessionID,
 		"",
-		"update_job_stage",
+		forcedReason("update_job_stage", overridden),
 	)
 	if err != nil {
 		return nil, err
@@ -94,11 +112,12 @@ func UpdateJobStage(args map[string]any) (map[string]any, error) {
 		return nil, err
 	}
 	return map[string]any{
-		"user_id":     userID,
-		"job":         snapshot,
-		"application": application,
-		"event":       event,
-		"job_db_path": jobDBPath(),
+		"user_id":         userID,
+		"job":             snapshot,
+		"application":     application,
+		"event":           event,
+		"overridden_rule": optionalString(overridden),
+		"job_db_path":     jobDBPath(),
 	}, nil
 }
 
@@ -351,6 +370,10 @@ func GetJobPipelineSummary(args map[string]any) (map[string]any, error) {
 		"new": 0, "saved": 0, "applied": 0, "interview": 0, "offer": 0, "rejected": 0, "ignored": 0,
 	}
 	recentEvents := []any{}
+	upcoming := []any{}
+	interviewCount := 0
+	dueFollowupCount := 0
+	pendingOffers := 0
 	totalTrackedJobs := 0
 	pipeline := loadJobPipeline()
 	entry := getPipelineEntry(pipeline, userID)
@@ -370,14 +393,30 @@ func GetJobPipelineSummary(args map[string]any) (map[string]any, error) {
 		if err == nil {
 			recentEvents = listOrEmpty(eventsResult["events"])
 		}
+		interviewCount = len(entry["interviews"].([]map[string]any))
+		now := utcNow()
+		for _, row := range upcomingInterviews(entry, now, now.Add(defaultUpcomingInterviewDays*24*time.Hour)) {
+			upcoming = append(upcoming, row)
+		}
+		dueFollowupCount = len(dueFollowups(entry, now, endOfUTCDay(now, 0)))
+		for _, offer := range entry["offers"].([]map[string]any) {
+			if getString(offer, "status") == "pending" {
+				pendingOffers++
+			}
+		}
 	}
 	return map[string]any{
-		"user_id":            userID,
-		"stage_counts":       stageCounts,
-		"applied_jobs_count": stageCounts["applied"],
-		"total_tracked_jobs": totalTrackedJobs,
-		"recent_events":      recentEvents,
-		"job_db_path":        jobDBPath(),
+		"user_id":             userID,
+		"stage_counts":        stageCounts,
+		"applied_jobs_count":  stageCounts["applied"],
+		"total_tracked_jobs":  totalTrackedJobs,
+		"recent_events":       recentEvents,
+		"interview_count":     interviewCount,
+		"upcoming_interviews": upcoming,
+		"due_followups_today": dueFollowupCount,
+		"pending_offers":      pendingOffers,
+		"application_goal":    applicationGoalProgress(userID, entry, utcNow()),
+		"job_db_path":         jobDBPath(),
 	}, nil
 }
 
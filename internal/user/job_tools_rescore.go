@@ -0,0 +1,224 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const defaultRescoreDescriptionFetches = 10
+
+func savedJobDescriptionFetchable(job map[string]any) bool {
+	return strings.Contains(strings.ToLower(getString(job, "job_url")), "linkedin.com/")
+}
+
+// scoreSavedJob runs the same dataset lookup, description signals, and
+// confidence model as a live search against a saved job.
+func scoreSavedJob(
+	job map[string]any,
+	dataset companyDataset,
+	desiredVisaTypes []string,
+	weights rankingWeights,
+	signalPatterns visaSignalPatterns,
+	priority visaPriority,
+) map[string]any {
+	desiredCount := 0
+	totalCount := 0
+	visaCounts := map[string]int{}
+	recency, localityFactor, approvalFactor := 1.0, 1.0, 1.0
+	var locality map[string]any
+	fiscalYears := []map[string]any{}
+	record, hasCompany := dataset.lookup(getString(job, "company"))
+	if hasCompany {
+		recency = sponsorshipRecency(record, desiredVisaTypes, dataset.LatestFiscalYear)
+		localityFactor, locality = sponsorshipLocality(record, getString(job, "location"), "")
+		approvalFactor = approvalRateFactor(record)
+		fiscalYears = fiscalYearBreakdown(record)
+		desiredCount = occupationVisaCount(record, desiredVisaTypes, socPrefixesForTitle(getString(job, "title")))
+		totalCount = record.TotalVisas
+		visaCounts = visaCountsFromRecord(record)
+	}
+	capExempt, _ := jobCapExempt(record, hasCompany, getString(job, "company"))
+	description := getString(job, "description")
+	positive, negative, mentioned := detectDescriptionSignals(description)
+	custom := signalPatterns.match(description)
+	positive = positive || custom.Positive
+	negative = negative || custom.Negative
+	desiredMention := hasDesiredMention(mentioned, desiredVisaTypes) || custom.Positive
+	eVerify, _ := loadEVerifyIndex(eVerifyPath())
+	eVerifyEnrolled := eVerify.enrolled(getString(job, "company"), record.CompanyName)
+	heuristics := evaluateVisaHeuristics(desiredVisaTypes, getString(job, "title"), description, record, hasCompany, eVerifyEnrolled == true)
+	negative = negative && !heuristics.WaivesNegative
+	benefits := extractBenefits(description)
+	visasSponsored := []string{}
+	matchedVisas := []string{}
+	for _, visa := range desiredVisaTypes {
+		if visaCounts[visa] > 0 || (desiredMention && slices.Contains(mentioned, visa)) || slices.Contains(heuristics.Eligible, visa) {
+			matchedVisas = append(matchedVisas, visa)
+			if label, ok := visaTypeLabels[visa]; ok {
+				visasSponsored = append(visasSponsored, label)
+			} else {
+				visasSponsored = append(visasSponsored, visa)
+			}
+		}
+	}
+	priorityVisa, priorityWeight := priority.best(matchedVisas)
+	// Saved jobs do not keep the searched title, so title_match gets full
+	// credit rather than penalizing every rescored job.
+	score := scoreConfidence(confidenceFeatures{
+		DesiredCount:        desiredCount,
+		TotalCount:          totalCount,
+		Recency:             recency,
+		ApprovalRate:        approvalFactor,
+		LocationMatch:       localityFactor,
+		TitleMatch:          1,
+		DescriptionPositive: positive,
+		DescriptionNegative: negative,
+		DesiredMention:      desiredMention,
+		MobilityBenefit:     hasMobilityBenefit(benefits),
+		HeuristicMatch:      len(heuristics.Eligible) > 0,
+		DatasetFactor:       priorityWeight,
+	}, weights)
+	return map[string]any{
+		"confidence_score":           score.Score,
+		"confidence_model_version":   weights.modelVersion(),
+		"confidence_breakdown":       score.toMap(),
+		"visa_match_strength":        priority.strength(heuristics.strength(visaMatchStrength(desiredCount, desiredMention, positive)), priorityVisa),
+		"eligibility_reasons":        append(buildEligibilityReasons(desiredCount, positive, negative, desiredMention, desiredVisaTypes), heuristics.Reasons...),
+		"visa_heuristic_flags":       heuristics.Flags,
+		"custom_signal_matches":      custom.Matched,
+		"visa_priority_match":        optionalString(priorityVisa),
+		"visa_priority_weight":       priorityWeight,
+		"gc_track":                   gcTrackListing(description),
+		"e_verify_enrolled":          eVerifyEnrolled,
+		"visas_sponsored":            visasSponsored,
+		"visa_counts":                visaCountsWithApproval(visaCounts, record),
+		"visa_counts_by_fiscal_year": fiscalYears,
+		"sponsorship_recency":        recency,
+		"sponsorship_locality":       locality,
+		"cap_exempt":                 capExempt,
+		"benefits":                   benefits,
+		"company_in_dataset":         hasCompany,
+		"description_available":      normalizeWhitespace(description) != "",
+		"desired_visa_types":         desiredVisaTypes,
+		"rescored_at_utc":            utcNowISO(),
+	}
+}
+
+func RescoreSavedJobs(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	desiredVisaTypes := []string{}
+	if hasKey(args, "preferred_visa_types") {
+		normalized, err := normalizeVisaTypeList(getStringList(args, "preferred_visa_types"))
+		if err != nil {
+			return nil, err
+		}
+		desiredVisaTypes = normalized
+	}
+	if len(desiredVisaTypes) == 0 {
+		stored, err := getRequiredUserVisaTypes(userID)
+		if err != nil {
+			return nil, err
+		}
+		desiredVisaTypes = stored
+	}
+	fetchBudget := defaultRescoreDescriptionFetches
+	if parsed, has, err := getOptionalInt(args, "max_description_fetches"); has {
+		if err != nil {
+			return nil, fmt.Errorf("max_description_fetches must be an integer when provided")
+		}
+		if parsed < 0 {
+			return nil, fmt.Errorf("max_description_fetches must be >= 0")
+		}
+		fetchBudget = parsed
+	}
+	weights := defaultRankingWeights
+	if hasKey(args, "ranking_weights") {
+		normalized, err := normalizeRankingWeights(args["ranking_weights"])
+		if err != nil {
+			return nil, err
+		}
+		weights = rankingWeightsFromMap(normalized)
+	}
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	signalPatterns := loadVisaSignalPatterns(userID)
+	priority := newVisaPriority(desiredVisaTypes, visaTypeWeightsFor(userID, nil))
+
+	store := loadSavedJobs()
+	entry := getUserListEntry(store, userID, "jobs", normalizeSavedJob)
+	if entry == nil {
+		return map[string]any{
+			"user_id":             userID,
+			"rescored_jobs":       0,
+			"description_fetches": 0,
+			"jobs":                []any{},
+			"path":                savedJobsPath(),
+		}, nil
+	}
+
+	var client linkedInClient
+	fetches := 0
+	fetchErrors := 0
+	changes := []any{}
+	jobs := entry["jobs"].([]map[string]any)
+	for _, job := range jobs {
+		// As in a live search, only jobs the dataset cannot vouch for spend
+		// the description budget.
+		record, _ := dataset.lookup(getString(job, "company"))
+		needsDescription := desiredVisaCount(record, desiredVisaTypes) == 0
+		if needsDescription && normalizeWhitespace(getString(job, "description")) == "" && fetches < fetchBudget && savedJobDescriptionFetchable(job) {
+			if client == nil {
+				client = linkedInClientFactory()
+			}
+			fetches++
+			details, err := client.FetchJobDetails(getString(job, "job_url"), getString(job, "title"), getString(job, "location"), nil)
+			if err != nil {
+				fetchErrors++
+			} else if text := normalizeWhitespace(details.Description); text != "" {
+				job["description"] = details.Description
+				job["description_excerpt"] = text[:min(len(text), 280)]
+			}
+		}
+		previous := mapOrNil(job["visa_score"])
+		score := scoreSavedJob(job, dataset, desiredVisaTypes, weights, signalPatterns, priority)
+		job["visa_score"] = score
+		change := map[string]any{
+			"saved_job_id":              job["id"],
+			"job_url":                   getString(job, "job_url"),
+			"title":                     getString(job, "title"),
+			"company":                   getString(job, "company"),
+			"previous_confidence_score": nil,
+			"confidence_score":          score["confidence_score"],
+			"visa_match_strength":       score["visa_match_strength"],
+			"visas_sponsored":           score["visas_sponsored"],
+		}
+		if previous != nil {
+			change["previous_confidence_score"] = previous["confidence_score"]
+		}
+		changes = append(changes, change)
+	}
+	entry["updated_at_utc"] = utcNowISO()
+	if err := saveSavedJobs(store); err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":                  userID,
+		"rescored_jobs":            len(changes),
+		"desired_visa_types":       desiredVisaTypes,
+		"dataset_path":             datasetPath,
+		"confidence_model_version": weights.modelVersion(),
+		"description_fetches":      fetches,
+		"description_fetch_errors": fetchErrors,
+		"max_description_fetches":  fetchBudget,
+		"jobs":                     changes,
+		"path":                     savedJobsPath(),
+	}, nil
+}
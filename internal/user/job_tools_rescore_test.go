@@ -0,0 +1,81 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRescoreSavedJobsUsesCurrentPreferencesAndFetchesDescriptions(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	acmeURL := "https://www.linkedin.com/jobs/view/acme-1/"
+	gammaURL := "https://www.linkedin.com/jobs/view/gamma-1/"
+	for _, job := range []map[string]any{
+		{"user_id": "u1", "job_url": acmeURL, "title": "Software Engineer", "company": "Acme Inc"},
+		{"user_id": "u1", "job_url": gammaURL, "title": "Data Engineer", "company": "Gamma Corp"},
+	} {
+		if _, err := SaveJobForLater(job); err != nil {
+			t.Fatalf("SaveJobForLater failed: %v", err)
+		}
+	}
+	client := &fakeLinkedInClient{descriptions: map[string]string{
+		gammaURL: "We sponsor H-1B visas for this role.",
+	}}
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient { return client }
+
+	if _, err := RescoreSavedJobs(map[string]any{"user_id": "u1", "dataset_path": datasetPath}); err == nil {
+		t.Fatal("expected an error without visa preferences")
+	}
+
+	out, err := RescoreSavedJobs(map[string]any{
+		"user_id":                 "u1",
+		"dataset_path":            datasetPath,
+		"preferred_visa_types":    []any{"H1B"},
+		"max_description_fetches": 1,
+	})
+	if err != nil {
+		t.Fatalf("RescoreSavedJobs failed: %v", err)
+	}
+	if intOrZero(out["rescored_jobs"]) != 2 || intOrZero(out["description_fetches"]) != 1 {
+		t.Fatalf("unexpected rescore summary: %#v", out)
+	}
+	strengths := map[string]string{}
+	for _, raw := range listOrEmpty(out["jobs"]) {
+		job := mapOrNil(raw)
+		strengths[getString(job, "job_url")] = getString(job, "visa_match_strength")
+	}
+	if strengths[acmeURL] != "company_dataset" || strengths[gammaURL] != "description_signal" {
+		t.Fatalf("unexpected match strengths: %#v", strengths)
+	}
+
+	listed, err := ListSavedJobs(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("ListSavedJobs failed: %v", err)
+	}
+	for _, raw := range listOrEmpty(listed["jobs"]) {
+		job := mapOrNil(raw)
+		if mapOrNil(job["visa_score"]) == nil {
+			t.Fatalf("expected visa_score to persist on saved job %#v", job)
+		}
+		if getString(job, "job_url") == gammaURL && getString(job, "description") == "" {
+			t.Fatal("expected the fetched description to be saved")
+		}
+	}
+
+	again, err := RescoreSavedJobs(map[string]any{"user_id": "u1", "dataset_path": datasetPath, "preferred_visa_types": []any{"E3"}})
+	if err != nil {
+		t.Fatalf("second RescoreSavedJobs failed: %v", err)
+	}
+	if intOrZero(again["description_fetches"]) != 0 {
+		t.Fatalf("expected saved descriptions to be reused, got %#v", again["description_fetches"])
+	}
+	for _, raw := range listOrEmpty(again["jobs"]) {
+		if mapOrNil(raw)["previous_confidence_score"] == nil {
+			t.Fatalf("expected previous_confidence_score after a second rescore, got %#v", raw)
+		}
+	}
+}
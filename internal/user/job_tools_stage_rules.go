@@ -0,0 +1,197 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// stageTransitionRule blocks one from -> to move unless the caller passes
+// force=true.
+type stageTransitionRule struct {
+	From   string
+	To     string
+	Reason string
+}
+
+func (r stageTransitionRule) id() string {
+	return r.From + "->" + r.To
+}
+
+// defaultStageTransitionRules are the jumps that usually mean an agent
+// picked the wrong job or misread the user, not a real change in status.
+var defaultStageTransitionRules = []stageTransitionRule{
+	{From: "rejected", To: "applied", Reason: "a rejected application does not move forward again"},
+	{From: "rejected", To: "interview", Reason: "a rejected application does not move forward again"},
+	{From: "rejected", To: "offer", Reason: "a rejected application does not move forward again"},
+	{From: "ignored", To: "interview", Reason: "an ignored job was never applied to"},
+	{From: "ignored", To: "offer", Reason: "an ignored job was never applied to"},
+	{From: "new", To: "offer", Reason: "an offer needs an application first"},
+	{From: "saved", To: "offer", Reason: "an offer needs an application first"},
+	{From: "offer", To: "new", Reason: "an offer does not move back to the start of the funnel"},
+	{From: "offer", To: "saved", Reason: "an offer does not move back to the start of the funnel"},
+	{From: "offer", To: "applied", Reason: "an offer does not move back to the start of the funnel"},
+	{From: "interview", To: "new", Reason: "an interviewing job does not move back before applied"},
+	{From: "interview", To: "saved", Reason: "an interviewing job does not move back before applied"},
+}
+
+func defaultStageRuleIDs() []string {
+	out := make([]string, 0, len(defaultStageTransitionRules))
+	for _, rule := range defaultStageTransitionRules {
+		out = append(out, rule.id())
+	}
+	return out
+}
+
+// parseStageTransitionRule accepts "from->to" with valid stages on both sides.
+func parseStageTransitionRule(value string) (stageTransitionRule, error) {
+	from, to, ok := strings.Cut(strings.ReplaceAll(value, " ", ""), "->")
+	if !ok {
+		return stageTransitionRule{}, fmt.Errorf("blocked_transitions entries must look like 'rejected->offer', got %q", value)
+	}
+	cleanFrom, err := validateJobStage(from)
+	if err != nil {
+		return stageTransitionRule{}, fmt.Errorf("blocked_transitions %q: %w", value, err)
+	}
+	cleanTo, err := validateJobStage(to)
+	if err != nil {
+		return stageTransitionRule{}, fmt.Errorf("blocked_transitions %q: %w", value, err)
+	}
+	if cleanFrom == cleanTo {
+		return stageTransitionRule{}, fmt.Errorf("blocked_transitions %q must name two different stages", value)
+	}
+	rule := stageTransitionRule{From: cleanFrom, To: cleanTo, Reason: "blocked by the user's transition rules"}
+	for _, builtin := range defaultStageTransitionRules {
+		if builtin.id() == rule.id() {
+			rule.Reason = builtin.Reason
+		}
+	}
+	return rule, nil
+}
+
+// stageTransitionRulesFor returns the user's active rules. Rules are opt-in:
+// nothing is enforced until set_stage_transition_rules enables them, and an
+// enabled user without a custom list gets defaultStageTransitionRules.
+func stageTransitionRulesFor(userID string) []stageTransitionRule {
+	prefs, err := loadPrefs()
+	if err != nil {
+		return nil
+	}
+	stored := asMap(asMap(prefs[userID])["stage_transition_rules"])
+	if !boolOrFalse(stored["enabled"]) {
+		return nil
+	}
+	if _, custom := stored["blocked_transitions"]; !custom {
+		return defaultStageTransitionRules
+	}
+	out := []stageTransitionRule{}
+	for _, value := range getStringList(stored, "blocked_transitions") {
+		if rule, err := parseStageTransitionRule(value); err == nil {
+			out = append(out, rule)
+		}
+	}
+	return out
+}
+
+// checkStageTransition fails when moving jobID to toStage breaks one of the
+// user's rules. With force it returns the overridden rule id instead, so the
+// event can record that the move was forced.
+func checkStageTransition(entry map[string]any, userID string, jobID int, toStage string, force bool) (string, error) {
+	fromStage := "new"
+	if _, app := findApplicationIndex(entry, jobID); app != nil {
+		fromStage = getString(app, "stage")
+	}
+	for _, rule := range stageTransitionRulesFor(userID) {
+		if rule.From != fromStage || rule.To != toStage {
+			continue
+		}
+		if force {
+			return rule.id(), nil
+		}
+		return "", fmt.Errorf("stage transition %s violates rule '%s' (%s); pass force=true to override", rule.id(), rule.id(), rule.Reason)
+	}
+	return "", nil
+}
+
+// parseForce reads the optional force flag shared by the stage-changing tools.
+func parseForce(args map[string]any) (bool, error) {
+	force, has, err := getOptionalBool(args, "force")
+	if has && err != nil {
+		return false, fmt.Errorf("force must be a boolean when provided")
+	}
+	return force, nil
+}
+
+// forcedReason tags the event reason of a move that overrode a rule.
+func forcedReason(reason, overridden string) string {
+	if overridden == "" {
+		return reason
+	}
+	return reason + ":forced"
+}
+
+func SetStageTransitionRules(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	prefs, err := loadPrefs()
+	if err != nil {
+		return nil, err
+	}
+	user := prefs[userID]
+	if user == nil {
+		user = map[string]any{}
+	}
+	stored := asMap(user["stage_transition_rules"])
+	if _, ok := stored["enabled"]; !ok {
+		stored["enabled"] = true
+	}
+	if enabled, has, err := getOptionalBool(args, "enabled"); has {
+		if err != nil {
+			return nil, fmt.Errorf("enabled must be a boolean when provided")
+		}
+		stored["enabled"] = enabled
+	}
+	if reset, has, err := getOptionalBool(args, "reset_to_default"); has {
+		if err != nil {
+			return nil, fmt.Errorf("reset_to_default must be a boolean when provided")
+		}
+		if reset {
+			delete(stored, "blocked_transitions")
+		}
+	}
+	if hasKey(args, "blocked_transitions") {
+		blocked := []string{}
+		for _, value := range getStringList(args, "blocked_transitions") {
+			rule, err := parseStageTransitionRule(value)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(blocked, rule.id()) {
+				blocked = append(blocked, rule.id())
+			}
+		}
+		stored["blocked_transitions"] = blocked
+	}
+	stored["updated_at_utc"] = utcNowISO()
+	user["stage_transition_rules"] = stored
+	prefs[userID] = user
+	if err := savePrefs(prefs); err != nil {
+		return nil, err
+	}
+
+	active := []map[string]any{}
+	for _, rule := range stageTransitionRulesFor(userID) {
+		active = append(active, map[string]any{"rule": rule.id(), "from": rule.From, "to": rule.To, "reason": rule.Reason})
+	}
+	return map[string]any{
+		"user_id":                userID,
+		"enabled":                stored["enabled"],
+		"using_default_rules":    stored["blocked_transitions"] == nil,
+		"default_rules":          defaultStageRuleIDs(),
+		"active_rules":           active,
+		"stage_transition_rules": stored,
+		"path":                   prefsPath(),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package user
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStageTransitionRulesBlockUnlessForced(t *testing.T) {
+	setupUserToolPaths(t)
+
+	jobURL := "https://example.com/jobs/rules-1"
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": jobURL, "stage": "rejected"}); err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+	// Rules are opt-in, so this jump is allowed before they are enabled.
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": jobURL, "stage": "applied"}); err != nil {
+		t.Fatalf("expected no rules before enabling, got %v", err)
+	}
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": jobURL, "stage": "rejected"}); err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+
+	rules, err := SetStageTransitionRules(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("SetStageTransitionRules failed: %v", err)
+	}
+	if got, _ := rules["using_default_rules"].(bool); !got {
+		t.Fatalf("expected default rules, got %#v", rules)
+	}
+
+	_, err = RecordJobOffer(map[string]any{"user_id": "u1", "job_url": jobURL, "base_salary": 150000})
+	if err == nil || !strings.Contains(err.Error(), "rejected->offer") {
+		t.Fatalf("expected the rejected->offer rule in the error, got %v", err)
+	}
+	forced, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": jobURL, "stage": "offer", "force": true})
+	if err != nil {
+		t.Fatalf("forced UpdateJobStage failed: %v", err)
+	}
+	if got := forced["overridden_rule"]; got != "rejected->offer" {
+		t.Fatalf("expected overridden_rule=rejected->offer, got %#v", got)
+	}
+	if got := getString(asMap(forced["event"]), "reason"); got != "update_job_stage:forced" {
+		t.Fatalf("expected a forced event reason, got %q", got)
+	}
+}
+
+func TestStageTransitionRulesCustomList(t *testing.T) {
+	setupUserToolPaths(t)
+
+	if _, err := SetStageTransitionRules(map[string]any{"user_id": "u1", "blocked_transitions": []any{"offer -> ignored"}}); err != nil {
+		t.Fatalf("SetStageTransitionRules failed: %v", err)
+	}
+	jobURL := "https://example.com/jobs/rules-2"
+	// new->offer is a default rule, but the custom list replaces the defaults.
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": jobURL, "stage": "offer"}); err != nil {
+		t.Fatalf("expected new->offer to be allowed by the custom list, got %v", err)
+	}
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": jobURL, "stage": "ignored"}); err == nil {
+		t.Fatalf("expected offer->ignored to be blocked")
+	}
+	if _, err := SetStageTransitionRules(map[string]any{"user_id": "u1", "enabled": false}); err != nil {
+		t.Fatalf("disabling rules failed: %v", err)
+	}
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": jobURL, "stage": "ignored"}); err != nil {
+		t.Fatalf("expected no rules once disabled, got %v", err)
+	}
+	if _, err := SetStageTransitionRules(map[string]any{"user_id": "u1", "blocked_transitions": []any{"offer->hired"}}); err == nil {
+		t.Fatalf("expected an unknown stage to be rejected")
+	}
+}
@@ -0,0 +1,199 @@
+package user
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	maxJobTags      = 20
+	maxJobTagLength = 40
+)
+
+// normalizeJobTag lowercases a tag and collapses its whitespace, so "Dream
+// Company" and "dream  company" are the same tag.
+func normalizeJobTag(raw string) string {
+	return strings.ToLower(normalizeWhitespace(raw))
+}
+
+// jobTags returns the normalized, de-duplicated tags stored on a pipeline or
+// saved job row, in the order they were added.
+func jobTags(row map[string]any) []string {
+	out := []string{}
+	for _, raw := range getStringList(row, "tags") {
+		tag := normalizeJobTag(raw)
+		if tag != "" && !slices.Contains(out, tag) {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+func parseJobTagArgs(args map[string]any, key string) ([]string, error) {
+	tags := jobTags(map[string]any{"tags": getStringList(args, key)})
+	for _, tag := range tags {
+		if len(tag) > maxJobTagLength {
+			return nil, fmt.Errorf("%s entries must be at most %d characters", key, maxJobTagLength)
+		}
+	}
+	return tags, nil
+}
+
+// applyJobTagChanges adds and removes tags on row and returns the result.
+func applyJobTagChanges(row map[string]any, add []string, remove []string) ([]string, error) {
+	tags := []string{}
+	for _, tag := range jobTags(row) {
+		if !slices.Contains(remove, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	for _, tag := range add {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > maxJobTags {
+		return nil, fmt.Errorf("a job can have at most %d tags", maxJobTags)
+	}
+	row["tags"] = tags
+	row["updated_at_utc"] = utcNowISO()
+	return tags, nil
+}
+
+// AddJobTags adds (and optionally removes) free-form tags on a pipeline job,
+// or on a saved job when saved_job_id is given. Tags are independent of the
+// pipeline stage.
+func AddJobTags(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	add, err := parseJobTagArgs(args, "tags")
+	if err != nil {
+		return nil, err
+	}
+	remove, err := parseJobTagArgs(args, "remove_tags")
+	if err != nil {
+		return nil, err
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, fmt.Errorf("tags or remove_tags is required")
+	}
+
+	savedJobID, hasSavedJobID, err := getOptionalInt(args, "saved_job_id")
+	if hasSavedJobID {
+		if err != nil || savedJobID < 1 {
+			return nil, fmt.Errorf("saved_job_id must be a positive integer")
+		}
+		store := loadSavedJobs()
+		entry := getUserListEntry(store, userID, "jobs", normalizeSavedJob)
+		var savedJob map[string]any
+		if entry != nil {
+			for _, row := range entry["jobs"].([]map[string]any) {
+				if id, _ := intFromAny(row["id"]); id == savedJobID {
+					savedJob = row
+					break
+				}
+			}
+		}
+		if savedJob == nil {
+			return nil, fmt.Errorf("saved_job_id=%d not found for user_id='%s'", savedJobID, userID)
+		}
+		tags, err := applyJobTagChanges(savedJob, add, remove)
+		if err != nil {
+			return nil, err
+		}
+		if err := saveSavedJobs(store); err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"user_id":         userID,
+			"saved_job":       savedJob,
+			"tags":            tags,
+			"saved_jobs_path": savedJobsPath(),
+		}, nil
+	}
+
+	pipeline := loadJobPipeline()
+	entry := ensurePipelineEntry(pipeline, userID)
+	jobID, job, err := resolveJobManagementTarget(entry, args, userID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := applyJobTagChanges(job, add, remove)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveJobPipeline(pipeline); err != nil {
+		return nil, err
+	}
+	snapshot, err := jobSnapshot(entry, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"user_id":     userID,
+		"job":         snapshot,
+		"tags":        tags,
+		"job_db_path": jobDBPath(),
+	}, nil
+}
+
+// ListJobsByTag lists pipeline and saved jobs carrying tag. Without a tag it
+// only reports how many jobs use each tag.
+func ListJobsByTag(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	tag := normalizeJobTag(getString(args, "tag"))
+	counts := map[string]int{}
+
+	pipelineJobs := []any{}
+	if entry := getPipelineEntry(loadJobPipeline(), userID); entry != nil {
+		for _, job := range entry["jobs"].([]map[string]any) {
+			tags := jobTags(job)
+			for _, t := range tags {
+				counts[t]++
+			}
+			if tag == "" || !slices.Contains(tags, tag) {
+				continue
+			}
+			jobID, _ := intFromAny(job["id"])
+			snapshot, err := jobSnapshot(entry, userID, jobID)
+			if err != nil {
+				return nil, err
+			}
+			pipelineJobs = append(pipelineJobs, snapshot)
+		}
+	}
+	savedJobs := []any{}
+	if entry := getUserListEntry(loadSavedJobs(), userID, "jobs", normalizeSavedJob); entry != nil {
+		for _, row := range entry["jobs"].([]map[string]any) {
+			tags := jobTags(row)
+			for _, t := range tags {
+				counts[t]++
+			}
+			if tag != "" && slices.Contains(tags, tag) {
+				savedJobs = append(savedJobs, row)
+			}
+		}
+	}
+
+	tagCounts := []any{}
+	for _, name := range slices.Sorted(maps.Keys(counts)) {
+		tagCounts = append(tagCounts, map[string]any{"tag": name, "job_count": counts[name]})
+	}
+	return map[string]any{
+		"user_id":         userID,
+		"tag":             optionalString(tag),
+		"pipeline_jobs":   pipelineJobs,
+		"saved_jobs":      savedJobs,
+		"total_jobs":      len(pipelineJobs) + len(savedJobs),
+		"tag_counts":      tagCounts,
+		"job_db_path":     jobDBPath(),
+		"saved_jobs_path": savedJobsPath(),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestJobTagsOnPipelineAndSavedJobs(t *testing.T) {
+	setupUserToolPaths(t)
+
+	tagged, err := AddJobTags(map[string]any{
+		"user_id": "u1",
+		"job_url": "https://example.com/jobs/tag-1",
+		"tags":    []any{"Dream  Company", "referral available", "dream company"},
+	})
+	if err != nil {
+		t.Fatalf("AddJobTags failed: %v", err)
+	}
+	if got := jobTags(asMap(tagged["job"])); !slices.Equal(got, []string{"dream company", "referral available"}) {
+		t.Fatalf("unexpected pipeline tags %v", got)
+	}
+
+	saved, err := SaveJobForLater(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/tag-2", "title": "Backend Engineer"})
+	if err != nil {
+		t.Fatalf("SaveJobForLater failed: %v", err)
+	}
+	savedJobID, _ := intFromAny(asMap(saved["saved_job"])["id"])
+	if _, err := AddJobTags(map[string]any{"user_id": "u1", "saved_job_id": savedJobID, "tags": []any{"dream company", "backup"}}); err != nil {
+		t.Fatalf("AddJobTags on a saved job failed: %v", err)
+	}
+	if _, err := AddJobTags(map[string]any{"user_id": "u1", "saved_job_id": savedJobID, "remove_tags": []any{"backup"}}); err != nil {
+		t.Fatalf("removing a tag failed: %v", err)
+	}
+
+	listed, err := ListJobsByTag(map[string]any{"user_id": "u1", "tag": "Dream Company"})
+	if err != nil {
+		t.Fatalf("ListJobsByTag failed: %v", err)
+	}
+	if len(listOrEmpty(listed["pipeline_jobs"])) != 1 || len(listOrEmpty(listed["saved_jobs"])) != 1 {
+		t.Fatalf("expected one pipeline and one saved job, got %#v", listed)
+	}
+	counts := listOrEmpty(listed["tag_counts"])
+	if len(counts) != 2 || intOrZero(asMap(counts[0])["job_count"]) != 2 {
+		t.Fatalf("expected dream company on 2 jobs and backup removed, got %#v", counts)
+	}
+
+	if _, err := AddJobTags(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/tag-1"}); err == nil {
+		t.Fatalf("expected tags or remove_tags to be required")
+	}
+	if _, err := AddJobTags(map[string]any{"user_id": "u1", "saved_job_id": 99, "tags": []any{"x"}}); err == nil {
+		t.Fatalf("expected unknown saved_job_id to fail")
+	}
+}
@@ -0,0 +1,135 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+func timelineItem(at, kind, summary string, record map[string]any) map[string]any {
+	return map[string]any{
+		"at_utc":  at,
+		"kind":    kind,
+		"summary": strings.TrimSpace(summary),
+		"record":  record,
+	}
+}
+
+// jobEventTimelineItem describes a pipeline event as a stage change, a note,
+// or another event (an interview booking, a closed posting, a merge).
+func jobEventTimelineItem(event map[string]any) map[string]any {
+	from, to := getString(event, "from_stage"), getString(event, "to_stage")
+	reason, note := getString(event, "reason"), getString(event, "note")
+	switch {
+	case reason == "note_added":
+		return timelineItem(getString(event, "created_at_utc"), "note", note, event)
+	case from != to:
+		summary := fmt.Sprintf("%s -> %s (%s)", firstNonEmpty(from, "none"), to, reason)
+		if note != "" {
+			summary += ": " + note
+		}
+		return timelineItem(getString(event, "created_at_utc"), "stage_change", summary, event)
+	}
+	summary := reason
+	if note != "" {
+		summary += ": " + note
+	}
+	return timelineItem(getString(event, "created_at_utc"), "event", summary, event)
+}
+
+// GetJobTimeline returns everything recorded for one pipeline job in
+// chronological order: stage changes, notes, and other events, interviews,
+// follow-ups, offers, contacts and outreach, and attached artifacts.
+// Interviews and follow-ups sit at their scheduled or due time, so upcoming
+// items come last.
+func GetJobTimeline(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	entry := getPipelineEntry(loadJobPipeline(), userID)
+	if entry == nil {
+		return nil, fmt.Errorf("no pipeline jobs found for user_id='%s'", userID)
+	}
+	trackedJobs := len(entry["jobs"].([]map[string]any))
+	jobID, job, err := resolveJobManagementTarget(entry, args, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entry["jobs"].([]map[string]any)) != trackedJobs {
+		return nil, fmt.Errorf("job is not in the pipeline for user_id='%s'", userID)
+	}
+	forJob := func(key string) []map[string]any {
+		out := []map[string]any{}
+		for _, row := range entry[key].([]map[string]any) {
+			if id, _ := intFromAny(row["job_id"]); id == jobID {
+				out = append(out, row)
+			}
+		}
+		return out
+	}
+
+	items := []map[string]any{
+		timelineItem(getString(job, "created_at_utc"), "job_added", fmt.Sprintf("Started tracking %s at %s", firstNonEmpty(getString(job, "title"), "job"), firstNonEmpty(getString(job, "company"), "unknown company")), job),
+	}
+	for _, event := range forJob("events") {
+		// Outreach is listed from the interaction records, which carry
+		// more detail than the event LogContactInteraction adds.
+		if getString(event, "reason") == "contact_interaction" {
+			continue
+		}
+		items = append(items, jobEventTimelineItem(event))
+	}
+	for _, row := range forJob("interviews") {
+		summary := fmt.Sprintf("Round %d %s interview (%s)", intOrZero(row["round"]), getString(row, "interview_type"), getString(row, "outcome"))
+		if interviewer := getString(row, "interviewer"); interviewer != "" {
+			summary += " with " + interviewer
+		}
+		items = append(items, timelineItem(firstNonEmpty(getString(row, "scheduled_at_utc"), getString(row, "created_at_utc")), "interview", summary, row))
+	}
+	for _, row := range forJob("followups") {
+		summary := fmt.Sprintf("%s follow-up (%s)", getString(row, "kind"), getString(row, "status"))
+		items = append(items, timelineItem(getString(row, "due_at_utc"), "followup", summary, row))
+	}
+	for _, row := range forJob("offers") {
+		items = append(items, timelineItem(getString(row, "created_at_utc"), "offer", fmt.Sprintf("Offer recorded (%s)", getString(row, "status")), row))
+		if deadline := getString(row, "decision_deadline_utc"); deadline != "" {
+			items = append(items, timelineItem(deadline, "offer_deadline", "Offer decision deadline", row))
+		}
+	}
+	contactNames := map[int]string{}
+	for _, row := range forJob("contacts") {
+		id, _ := intFromAny(row["id"])
+		name := firstNonEmpty(getString(row, "name"), getString(row, "email"), getString(row, "linkedin_url"))
+		contactNames[id] = name
+		items = append(items, timelineItem(getString(row, "created_at_utc"), "contact", fmt.Sprintf("Added %s %s", strings.ReplaceAll(getString(row, "role"), "_", " "), name), row))
+	}
+	for _, row := range forJob("contact_interactions") {
+		contactID, _ := intFromAny(row["contact_id"])
+		summary := fmt.Sprintf("%s %s with %s: %s", getString(row, "direction"), getString(row, "channel"), contactNames[contactID], getString(row, "summary"))
+		items = append(items, timelineItem(getString(row, "occurred_at_utc"), "outreach", summary, row))
+	}
+	for _, row := range forJob("artifacts") {
+		summary := fmt.Sprintf("Attached %s %q", strings.ReplaceAll(getString(row, "kind"), "_", " "), getString(row, "name"))
+		items = append(items, timelineItem(getString(row, "created_at_utc"), "artifact", summary, row))
+	}
+	slices.SortStableFunc(items, func(a, b map[string]any) int {
+		return strings.Compare(getString(a, "at_utc"), getString(b, "at_utc"))
+	})
+
+	snapshot, err := jobSnapshot(entry, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	timeline := make([]any, 0, len(items))
+	for _, item := range items {
+		timeline = append(timeline, item)
+	}
+	return map[string]any{
+		"user_id":     userID,
+		"job":         snapshot,
+		"total_items": len(timeline),
+		"timeline":    timeline,
+		"job_db_path": jobDBPath(),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package user
+
+import "testing"
+
+func TestGetJobTimeline(t *testing.T) {
+	setupUserToolPaths(t)
+
+	jobURL := "https://example.com/jobs/timeline-1"
+	if _, err := UpdateJobStage(map[string]any{"user_id": "u1", "job_url": jobURL, "stage": "saved", "title": "Backend Engineer", "company": "Acme Inc"}); err != nil {
+		t.Fatalf("UpdateJobStage failed: %v", err)
+	}
+	if _, err := MarkJobApplied(map[string]any{"user_id": "u1", "job_url": jobURL}); err != nil {
+		t.Fatalf("MarkJobApplied failed: %v", err)
+	}
+	if _, err := AddJobNote(map[string]any{"user_id": "u1", "job_url": jobURL, "note": "Tailored the resume"}); err != nil {
+		t.Fatalf("AddJobNote failed: %v", err)
+	}
+	added, err := AddJobContact(map[string]any{"user_id": "u1", "job_url": jobURL, "name": "Priya"})
+	if err != nil {
+		t.Fatalf("AddJobContact failed: %v", err)
+	}
+	contactID, _ := intFromAny(asMap(added["contact"])["id"])
+	if _, err := LogContactInteraction(map[string]any{"user_id": "u1", "contact_id": contactID, "summary": "Sent intro", "occurred_at_utc": "2000-01-01T00:00:00Z"}); err != nil {
+		t.Fatalf("LogContactInteraction failed: %v", err)
+	}
+	if _, err := ScheduleInterview(map[string]any{"user_id": "u1", "job_url": jobURL, "scheduled_at_utc": "2999-01-01T15:00:00Z"}); err != nil {
+		t.Fatalf("ScheduleInterview failed: %v", err)
+	}
+
+	result, err := GetJobTimeline(map[string]any{"user_id": "u1", "job_url": jobURL})
+	if err != nil {
+		t.Fatalf("GetJobTimeline failed: %v", err)
+	}
+	kinds := []string{}
+	for _, raw := range listOrEmpty(result["timeline"]) {
+		kinds = append(kinds, getString(asMap(raw), "kind"))
+	}
+	if len(kinds) < 7 || kinds[0] != "outreach" || kinds[len(kinds)-1] != "interview" {
+		t.Fatalf("expected outreach first and the future interview last, got %v", kinds)
+	}
+	counts := map[string]int{}
+	for _, kind := range kinds {
+		counts[kind]++
+	}
+	if counts["outreach"] != 1 || counts["note"] != 1 || counts["stage_change"] < 3 || counts["contact"] != 1 || counts["job_added"] != 1 {
+		t.Fatalf("unexpected timeline kinds %v", kinds)
+	}
+
+	if _, err := GetJobTimeline(map[string]any{"user_id": "u1", "job_url": "https://example.com/jobs/untracked"}); err == nil {
+		t.Fatalf("expected an untracked job to fail")
+	}
+}
@@ -259,4 +259,14 @@ func setupUserToolPaths(t *testing.T) {
 	t.Setenv("VISA_SEARCH_SESSION_PATH", filepath.Join(root, "search_sessions.json"))
 	t.Setenv("VISA_SEARCH_RUNS_PATH", filepath.Join(root, "search_runs.json"))
 	t.Setenv("VISA_JOB_DB_PATH", filepath.Join(root, "job_pipeline.json"))
+	t.Setenv("VISA_AUDIT_LOG_PATH", filepath.Join(root, "audit_log.json"))
+	t.Setenv("VISA_LAYOUT_BASELINE_PATH", filepath.Join(root, "layout_baseline.json"))
+	t.Setenv("VISA_DESCRIPTION_CACHE_PATH", filepath.Join(root, "description_cache.json"))
+	t.Setenv("VISA_GEO_ID_CACHE_PATH", filepath.Join(root, "geo_id_cache.json"))
+	t.Setenv("VISA_LINKEDIN_SESSION_PATH", filepath.Join(root, "linkedin_session.json"))
+	t.Setenv("VISA_COMPANY_PAGE_CACHE_PATH", filepath.Join(root, "company_page_cache.json"))
+	t.Setenv("VISA_COMPANY_ALIASES_PATH", filepath.Join(root, "company_aliases.json"))
+	t.Setenv("VISA_DATASET_VALIDATION_PATH", filepath.Join(root, "dataset_validations.json"))
+	t.Setenv("VISA_DATASET_REFRESH_HISTORY_PATH", filepath.Join(root, "refresh_history.json"))
+	t.Setenv("VISA_E_VERIFY_PATH", filepath.Join(root, "e_verify.csv"))
 }
@@ -0,0 +1,245 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDOLRawDir                   = "data/raw/dol"
+	defaultDOLPipelineTimeoutSeconds   = 1800
+	minDOLPipelineTimeoutSeconds       = 60
+	dolPipelineSourceSupportedPatterns = ".xlsx, .csv"
+)
+
+// dolHTTPClient talks to dol.gov without any proxy, matching the LinkedIn
+// client's no-proxy rule.
+func dolHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy: nil,
+		},
+	}
+}
+
+func isRemoteSource(source string) bool {
+	lower := strings.ToLower(strings.TrimSpace(source))
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+func supportedDisclosureSource(source string) bool {
+	clean := strings.ToLower(source)
+	if parsed, err := url.Parse(source); err == nil && parsed.Path != "" {
+		clean = strings.ToLower(parsed.Path)
+	}
+	return strings.HasSuffix(clean, ".xlsx") || strings.HasSuffix(clean, ".csv")
+}
+
+func firstSupportedDisclosure(urls []string) string {
+	for _, one := range urls {
+		if supportedDisclosureSource(one) {
+			return one
+		}
+	}
+	return ""
+}
+
+// downloadDOLSource returns a local path for source, downloading remote
+// files into rawDir (or reusing a recorded download) first.
+func downloadDOLSource(ctx context.Context, source, rawDir string) (string, error) {
+	if !isRemoteSource(source) {
+		return source, nil
+	}
+	download, err := downloadDOLFile(ctx, source, rawDir, dolDownloadMaxBytes(), false)
+	if err != nil {
+		return "", err
+	}
+	return download.LocalPath, nil
+}
+
+type dolPipelineOptions struct {
+	LCASource        string
+	PERMSource       string
+	PerformanceURL   string
+	RawDir           string
+	DatasetPath      string
+	ManifestPath     string
+	StrictValidation bool
+}
+
+type dolPipelineResult struct {
+	RowsWritten     int
+	WageRowsWritten int
+	WagesPath       string
+	LCASource       string
+	PERMSource      string
+	LCAEmployerCol  string
+	LCAVisaCol      string
+	PERMEmployerCol string
+	Discovered      bool
+	QualitySummary  map[string]any
+	ChangesSummary  map[string]any
+	RunAt           time.Time
+}
+
+// runDOLPipeline discovers (when needed), downloads, and aggregates the LCA
+// and PERM disclosure files into companies.csv plus the pipeline manifest.
+// A strict run that fails validation leaves the current dataset untouched.
+func runDOLPipeline(ctx context.Context, opts dolPipelineOptions) (dolPipelineResult, error) {
+	result := dolPipelineResult{LCASource: opts.LCASource, PERMSource: opts.PERMSource}
+	if result.LCASource == "" || result.PERMSource == "" {
+		discovered, err := discoverDOLDisclosures(opts.PerformanceURL)
+		if err != nil {
+			return result, err
+		}
+		if getString(discovered, "status") == "failed" {
+			return result, fmt.Errorf("discover disclosures: %s", getString(discovered, "error"))
+		}
+		if result.LCASource == "" {
+			result.LCASource = firstSupportedDisclosure(getStringList(discovered, "lca_disclosure_urls"))
+		}
+		if result.PERMSource == "" {
+			result.PERMSource = firstSupportedDisclosure(getStringList(discovered, "perm_disclosure_urls"))
+		}
+		if result.LCASource == "" || result.PERMSource == "" {
+			return result, fmt.Errorf("could not discover LCA/PERM disclosure files (%s) on %s", dolPipelineSourceSupportedPatterns, opts.PerformanceURL)
+		}
+		result.Discovered = true
+	}
+
+	lcaPath, err := downloadDOLSource(ctx, result.LCASource, opts.RawDir)
+	if err != nil {
+		return result, fmt.Errorf("download LCA disclosure: %w", err)
+	}
+	permPath, err := downloadDOLSource(ctx, result.PERMSource, opts.RawDir)
+	if err != nil {
+		return result, fmt.Errorf("download PERM disclosure: %w", err)
+	}
+	lca, err := tallyDisclosureFile(ctx, lcaPath, lcaEmployerColumns, lcaVisaColumns, lcaContactSpecs)
+	if err != nil {
+		return result, fmt.Errorf("read LCA disclosure: %w", err)
+	}
+	perm, err := tallyDisclosureFile(ctx, permPath, permEmployerColumns, nil, permContactSpecs)
+	if err != nil {
+		return result, fmt.Errorf("read PERM disclosure: %w", err)
+	}
+	result.LCAEmployerCol, result.LCAVisaCol, result.PERMEmployerCol = lca.EmployerCol, lca.VisaCol, perm.EmployerCol
+
+	header, rows := buildDOLDatasetRows(lca, perm)
+	result.RowsWritten = len(rows)
+	result.QualitySummary = dolQualitySummary(rows)
+	validation := asMap(result.QualitySummary["validation"])
+	if opts.StrictValidation && !boolOrFalse(validation["passed"]) {
+		return result, fmt.Errorf("pipeline validation failed: %s", strings.Join(getStringList(validation, "errors"), "; "))
+	}
+	previous, hadPrevious := loadSponsorTotals(opts.DatasetPath)
+	if err := writeDatasetCSV(opts.DatasetPath, header, rows); err != nil {
+		return result, fmt.Errorf("write dataset: %w", err)
+	}
+	clearDatasetCache(opts.DatasetPath)
+	result.WagesPath = lcaWagesPathFor(opts.DatasetPath)
+	if result.WageRowsWritten, err = writeLCAWagesCSV(result.WagesPath, lca.wages, lca); err != nil {
+		return result, fmt.Errorf("write lca wages: %w", err)
+	}
+
+	result.RunAt = utcNow()
+	manifest := map[string]any{
+		"run_at_utc":                      toISO(result.RunAt),
+		"output_path":                     opts.DatasetPath,
+		"rows_written":                    result.RowsWritten,
+		"lca_wage_rows_written":           result.WageRowsWritten,
+		"lca_source":                      result.LCASource,
+		"perm_source":                     result.PERMSource,
+		"lca_employer_col":                result.LCAEmployerCol,
+		"lca_visa_col":                    optionalString(result.LCAVisaCol),
+		"perm_employer_col":               result.PERMEmployerCol,
+		"discovered_from_performance_url": result.Discovered,
+		"quality_summary":                 result.QualitySummary,
+	}
+	changes, err := recordDatasetChanges(opts.DatasetPath, opts.ManifestPath, toISO(result.RunAt), previous, hadPrevious)
+	if err != nil {
+		return result, fmt.Errorf("write dataset changes: %w", err)
+	}
+	result.ChangesSummary = asMap(changes["summary"])
+	manifest["dataset_changes_path"] = datasetChangesPathFor(opts.ManifestPath)
+	manifest["dataset_changes_summary"] = result.ChangesSummary
+	if err := saveJSONMap(opts.ManifestPath, manifest); err != nil {
+		return result, fmt.Errorf("write manifest: %w", err)
+	}
+	return result, nil
+}
+
+func dolPipelineTimeoutSeconds() int {
+	return max(envInt("VISA_DOL_PIPELINE_TIMEOUT_SECONDS", defaultDOLPipelineTimeoutSeconds), minDOLPipelineTimeoutSeconds)
+}
+
+func RunInternalDolPipeline(args map[string]any) (map[string]any, error) {
+	strict := true
+	if value, has, err := getOptionalBool(args, "strict_validation"); has {
+		if err != nil {
+			return nil, fmt.Errorf("strict_validation must be a boolean when provided")
+		}
+		strict = value
+	}
+	opts := dolPipelineOptions{
+		LCASource:        strings.TrimSpace(getString(args, "lca_source")),
+		PERMSource:       strings.TrimSpace(getString(args, "perm_source")),
+		PerformanceURL:   dolPerformanceURL(getString(args, "performance_url")),
+		RawDir:           envOrDefault("VISA_DOL_RAW_DIR", defaultDOLRawDir),
+		DatasetPath:      primaryDatasetPath(datasetPathOrDefault(getString(args, "dataset_path"))),
+		ManifestPath:     envOrDefault("VISA_DOL_MANIFEST_PATH", defaultManifestPath),
+		StrictValidation: strict,
+	}
+	if raw := getString(args, "raw_dir"); raw != "" {
+		opts.RawDir = raw
+	}
+	if raw := getString(args, "manifest_path"); raw != "" {
+		opts.ManifestPath = raw
+	}
+
+	timeoutSeconds := dolPipelineTimeoutSeconds()
+	started := utcNow()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+	pipeline, runErr := runDOLPipeline(ctx, opts)
+	completed := utcNow()
+	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+
+	result := map[string]any{
+		"status":                          "completed",
+		"started_at_utc":                  toISO(started),
+		"completed_at_utc":                toISO(completed),
+		"duration_seconds":                completed.Sub(started).Seconds(),
+		"timed_out":                       timedOut,
+		"lca_source":                      optionalString(pipeline.LCASource),
+		"perm_source":                     optionalString(pipeline.PERMSource),
+		"lca_employer_col":                optionalString(pipeline.LCAEmployerCol),
+		"lca_visa_col":                    optionalString(pipeline.LCAVisaCol),
+		"perm_employer_col":               optionalString(pipeline.PERMEmployerCol),
+		"discovered_from_performance_url": pipeline.Discovered,
+		"rows_written":                    pipeline.RowsWritten,
+		"lca_wage_rows_written":           pipeline.WageRowsWritten,
+		"lca_wages_path":                  optionalString(pipeline.WagesPath),
+		"quality_summary":                 pipeline.QualitySummary,
+		"dataset_changes_summary":         pipeline.ChangesSummary,
+		"strict_validation":               opts.StrictValidation,
+		"dataset_path":                    opts.DatasetPath,
+		"manifest_path":                   opts.ManifestPath,
+		"dataset_freshness":               datasetFreshness(opts.DatasetPath, opts.ManifestPath),
+	}
+	if runErr != nil {
+		result["status"] = "failed"
+		result["error"] = runErr.Error()
+		result["guidance"] = "Pipeline failed. Pass lca_source/perm_source to use already-downloaded files, or strict_validation=false to write a dataset that fails validation checks."
+		if timedOut {
+			result["error"] = fmt.Sprintf("Pipeline timed out after %d seconds", timeoutSeconds)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,501 @@
+package user
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const maxDOLContactsPerCompany = 3
+
+var (
+	lcaEmployerColumns  = []string{"EMPLOYER_NAME", "EMPLOYER", "EMPLOYER BUSINESS NAME", "Employer Name"}
+	lcaVisaColumns      = []string{"VISA_CLASS", "CASE_VISA_CLASS", "VISA CLASS", "Visa Class"}
+	permEmployerColumns = []string{"EMPLOYER_NAME", "EMP_BUSINESS_NAME", "EMPLOYER", "EMPLOYER BUSINESS NAME", "Employer Name"}
+)
+
+// lcaVisaClassColumns maps LCA visa class labels onto dataset columns.
+var lcaVisaClassColumns = map[string]string{
+	"h-1b":            "h1b",
+	"h-1b1 chile":     "h1b1_chile",
+	"h-1b1 singapore": "h1b1_singapore",
+	"e-3 australian":  "e3_australian",
+}
+
+var datasetVisaColumns = []string{"h1b", "h1b1_chile", "h1b1_singapore", "e3_australian", "green_card"}
+
+var datasetOutputColumns = []string{
+	"company_tier", "company_name", "h1b", "h1b1_chile", "h1b1_singapore", "e3_australian", "green_card",
+	"email_1", "email_1_date", "contact_1", "contact_1_title", "contact_1_phone",
+	"email_2", "email_2_date", "contact_2", "contact_2_title", "contact_2_phone",
+	"email_3", "email_3_date", "contact_3", "contact_3_title", "contact_3_phone",
+	"soc_counts", "state_counts", "city_counts", "cap_exempt", "h1b_denied", "h1b_withdrawn",
+}
+
+// datasetKeyedCountColumns are the "key:count;..." breakdown columns, in
+// output order.
+var datasetKeyedCountColumns = []string{"soc_counts", "state_counts", "city_counts"}
+
+type dolContactSpec struct {
+	NameCols     []string
+	TitleCol     string
+	EmailCol     string
+	PhoneCol     string
+	PhoneExtCol  string
+	DefaultTitle string
+	Source       string
+}
+
+var lcaContactSpecs = []dolContactSpec{
+	{NameCols: []string{"EMPLOYER_POC_FIRST_NAME", "EMPLOYER_POC_MIDDLE_NAME", "EMPLOYER_POC_LAST_NAME"}, TitleCol: "EMPLOYER_POC_JOB_TITLE", EmailCol: "EMPLOYER_POC_EMAIL", PhoneCol: "EMPLOYER_POC_PHONE", PhoneExtCol: "EMPLOYER_POC_PHONE_EXT", Source: "lca_employer_poc"},
+	{NameCols: []string{"AGENT_ATTORNEY_FIRST_NAME", "AGENT_ATTORNEY_MIDDLE_NAME", "AGENT_ATTORNEY_LAST_NAME"}, EmailCol: "AGENT_ATTORNEY_EMAIL_ADDRESS", PhoneCol: "AGENT_ATTORNEY_PHONE", PhoneExtCol: "AGENT_ATTORNEY_PHONE_EXT", DefaultTitle: "Attorney/Agent", Source: "lca_attorney"},
+	{NameCols: []string{"PREPARER_FIRST_NAME", "PREPARER_LAST_NAME"}, EmailCol: "PREPARER_EMAIL", DefaultTitle: "Preparer", Source: "lca_preparer"},
+}
+
+var permContactSpecs = []dolContactSpec{
+	{NameCols: []string{"EMP_POC_FIRST_NAME", "EMP_POC_MIDDLE_NAME", "EMP_POC_LAST_NAME"}, TitleCol: "EMP_POC_JOB_TITLE", EmailCol: "EMP_POC_EMAIL", PhoneCol: "EMP_POC_PHONE", PhoneExtCol: "EMP_POC_PHONEEXT", Source: "perm_employer_poc"},
+	{NameCols: []string{"ATTY_AG_FIRST_NAME", "ATTY_AG_MIDDLE_NAME", "ATTY_AG_LAST_NAME"}, EmailCol: "ATTY_AG_EMAIL", PhoneCol: "ATTY_AG_PHONE", PhoneExtCol: "ATTY_AG_PHONE_EXT", DefaultTitle: "Attorney/Agent", Source: "perm_attorney"},
+	{NameCols: []string{"DECL_PREP_FIRST_NAME", "DECL_PREP_MIDDLE_NAME", "DECL_PREP_LAST_NAME"}, EmailCol: "DECL_PREP_EMAIL", DefaultTitle: "Preparer", Source: "perm_preparer"},
+}
+
+type dolContact struct {
+	Name   string
+	Title  string
+	Email  string
+	Phone  string
+	Source string
+	rank   int
+}
+
+type dolEmployerTally struct {
+	names      map[string]int
+	total      int
+	visaCounts map[string]int
+	yearCounts map[int]map[string]int
+	keyed      map[string]map[string]int
+	outcomes   map[string]int
+	contacts   []dolContact
+}
+
+// dolDisclosureTally aggregates one disclosure file (LCA or PERM) per
+// normalized employer name.
+type dolDisclosureTally struct {
+	EmployerCol string
+	VisaCol     string
+	DateCol     string
+	SOCCol      string
+	CityCol     string
+	StateCol    string
+	StatusCol   string
+	employers   map[string]*dolEmployerTally
+	wages       *lcaWageTally
+}
+
+type disclosureTable struct {
+	index map[string]int
+}
+
+func newDisclosureTable(header []string) *disclosureTable {
+	table := &disclosureTable{index: map[string]int{}}
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, exists := table.index[name]; !exists {
+			table.index[name] = i
+		}
+	}
+	return table
+}
+
+func (t *disclosureTable) pick(candidates []string) string {
+	for _, name := range candidates {
+		if _, ok := t.index[name]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func (t *disclosureTable) value(row []string, column string) string {
+	index, ok := t.index[column]
+	if !ok || column == "" || index >= len(row) {
+		return ""
+	}
+	return cleanDisclosureText(row[index])
+}
+
+func cleanDisclosureText(raw string) string {
+	text := strings.TrimSpace(raw)
+	switch strings.ToLower(text) {
+	case "nan", "none", "null", "na", "n/a":
+		return ""
+	}
+	return text
+}
+
+// streamDisclosureTable reads a CSV or XLSX disclosure file row by row. The
+// first row is treated as the header and handed to onHeader.
+func streamDisclosureTable(ctx context.Context, filePath string, onHeader func(table *disclosureTable) error, onRow func(table *disclosureTable, row []string) error) error {
+	var table *disclosureTable
+	rows := 0
+	handle := func(row []string) error {
+		rows++
+		if rows%1000 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if table == nil {
+			table = newDisclosureTable(row)
+			return onHeader(table)
+		}
+		return onRow(table, row)
+	}
+	if strings.HasSuffix(strings.ToLower(filePath), ".csv") {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		reader.ReuseRecord = true
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("read %s: %w", filepath.Base(filePath), err)
+			}
+			if err := handle(record); err != nil {
+				return err
+			}
+		}
+	} else if err := streamXLSXRows(filePath, handle); err != nil {
+		return err
+	}
+	if table == nil {
+		return fmt.Errorf("%s has no header row", filepath.Base(filePath))
+	}
+	return nil
+}
+
+func tallyDisclosureFile(ctx context.Context, filePath string, employerCols, visaCols []string, specs []dolContactSpec) (*dolDisclosureTally, error) {
+	tally := &dolDisclosureTally{employers: map[string]*dolEmployerTally{}}
+	fileFiscalYear := fiscalYearFromFileName(filePath)
+	onHeader := func(table *disclosureTable) error {
+		tally.EmployerCol = table.pick(employerCols)
+		tally.VisaCol = table.pick(visaCols)
+		tally.DateCol = table.pick(dolDecisionDateColumns)
+		tally.SOCCol = table.pick(dolSOCColumns)
+		tally.CityCol = table.pick(dolWorksiteCityColumns)
+		tally.StateCol = table.pick(dolWorksiteStateColumns)
+		tally.StatusCol = table.pick(lcaCaseStatusColumns)
+		tally.wages = newLCAWageTally(table)
+		if tally.EmployerCol == "" {
+			return fmt.Errorf("%s is missing an employer column", filepath.Base(filePath))
+		}
+		return nil
+	}
+	err := streamDisclosureTable(ctx, filePath, onHeader, func(table *disclosureTable, row []string) error {
+		employer := ""
+		if index := table.index[tally.EmployerCol]; index < len(row) {
+			employer = strings.TrimSpace(row[index])
+		}
+		normalized := normalizeCompanyName(employer)
+		if normalized == "" {
+			return nil
+		}
+		entry := tally.employers[normalized]
+		if entry == nil {
+			entry = &dolEmployerTally{names: map[string]int{}, visaCounts: map[string]int{}}
+			tally.employers[normalized] = entry
+		}
+		entry.total++
+		entry.names[employer]++
+		visa := ""
+		if tally.VisaCol != "" {
+			if column, ok := lcaVisaClassColumns[strings.ToLower(table.value(row, tally.VisaCol))]; ok {
+				entry.visaCounts[column]++
+				visa = column
+			}
+		}
+		if visa == "h1b" || tally.VisaCol == "" {
+			entry.addOutcome(table.value(row, tally.StatusCol))
+		}
+		entry.addFiscalYear(disclosureFiscalYear(table.value(row, tally.DateCol), fileFiscalYear), visa)
+		tally.wages.add(table, row, normalized)
+		state := normalizeUSState(table.value(row, tally.StateCol))
+		entry.addKeyed("soc_counts", normalizeSOCCode(table.value(row, tally.SOCCol)))
+		entry.addKeyed("state_counts", state)
+		entry.addKeyed("city_counts", worksiteCityKey(table.value(row, tally.CityCol), state))
+		for specIndex, spec := range specs {
+			if contact, ok := disclosureContact(table, row, spec, specIndex); ok {
+				entry.contacts = addDOLContact(entry.contacts, contact)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tally, nil
+}
+
+func disclosureContact(table *disclosureTable, row []string, spec dolContactSpec, specIndex int) (dolContact, bool) {
+	parts := []string{}
+	for _, column := range spec.NameCols {
+		if value := table.value(row, column); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	contact := dolContact{
+		Name:   normalizeWhitespace(strings.Join(parts, " ")),
+		Title:  table.value(row, spec.TitleCol),
+		Email:  table.value(row, spec.EmailCol),
+		Phone:  table.value(row, spec.PhoneCol),
+		Source: spec.Source,
+	}
+	if contact.Title == "" {
+		contact.Title = spec.DefaultTitle
+	}
+	if ext := table.value(row, spec.PhoneExtCol); ext != "" && contact.Phone != "" {
+		contact.Phone += " x" + ext
+	}
+	if contact.Name == "" && contact.Email == "" && contact.Phone == "" {
+		return dolContact{}, false
+	}
+	// Contacts with an email rank first, then those with a phone; earlier
+	// specs (employer point of contact) win ties.
+	if contact.Email != "" {
+		contact.rank += 200
+	}
+	if contact.Phone != "" {
+		contact.rank += 100
+	}
+	contact.rank -= specIndex
+	return contact, true
+}
+
+// addDOLContact keeps the best few distinct contacts per employer so memory
+// stays bounded on national disclosure files.
+func addDOLContact(contacts []dolContact, contact dolContact) []dolContact {
+	for _, existing := range contacts {
+		if existing.sameAs(contact) {
+			return contacts
+		}
+	}
+	position := len(contacts)
+	for i, existing := range contacts {
+		if contact.rank > existing.rank {
+			position = i
+			break
+		}
+	}
+	if position >= maxDOLContactsPerCompany {
+		return contacts
+	}
+	contacts = slices.Insert(contacts, position, contact)
+	if len(contacts) > maxDOLContactsPerCompany {
+		contacts = contacts[:maxDOLContactsPerCompany]
+	}
+	return contacts
+}
+
+func (c dolContact) sameAs(other dolContact) bool {
+	return c.Name == other.Name && c.Title == other.Title && c.Email == other.Email && c.Phone == other.Phone && c.Source == other.Source
+}
+
+func (t *dolEmployerTally) displayName() string {
+	best, bestCount := "", -1
+	for name, count := range t.names {
+		if count > bestCount || (count == bestCount && name < best) {
+			best, bestCount = name, count
+		}
+	}
+	return best
+}
+
+// buildDOLDatasetRows merges LCA and PERM tallies into companies.csv rows
+// and returns the header, which adds per-fiscal-year columns when the
+// disclosures carry decision dates or fiscal-year file names.
+// Without a visa class column every LCA row counts as H-1B.
+func buildDOLDatasetRows(lca, perm *dolDisclosureTally) ([]string, [][]string) {
+	type datasetRow struct {
+		name   string
+		counts map[string]int
+		years  map[int]map[string]int
+		values []string
+	}
+	keys := map[string]struct{}{}
+	for key := range lca.employers {
+		keys[key] = struct{}{}
+	}
+	for key := range perm.employers {
+		keys[key] = struct{}{}
+	}
+	rows := []datasetRow{}
+	for key := range keys {
+		lcaEntry, permEntry := lca.employers[key], perm.employers[key]
+		counts := map[string]int{}
+		keyed := map[string]map[string]int{}
+		name := ""
+		contacts := []dolContact{}
+		if permEntry != nil {
+			counts["green_card"] = permEntry.total
+			name = permEntry.displayName()
+			mergeKeyedCounts(keyed, permEntry.keyed)
+			contacts = append(contacts, permEntry.contacts...)
+		}
+		if lcaEntry != nil {
+			name = lcaEntry.displayName()
+			mergeKeyedCounts(keyed, lcaEntry.keyed)
+			if lca.VisaCol == "" {
+				counts["h1b"] = lcaEntry.total
+			} else {
+				for column, count := range lcaEntry.visaCounts {
+					counts[column] = count
+				}
+			}
+			for _, contact := range lcaEntry.contacts {
+				if !slices.ContainsFunc(contacts, contact.sameAs) {
+					contacts = append(contacts, contact)
+				}
+			}
+		}
+		total := 0
+		for _, column := range datasetVisaColumns {
+			total += counts[column]
+		}
+		if total == 0 {
+			continue
+		}
+		values := []string{"dol", name}
+		for _, column := range datasetVisaColumns {
+			values = append(values, strconv.Itoa(counts[column]))
+		}
+		for i := 0; i < maxDOLContactsPerCompany; i++ {
+			contact := dolContact{}
+			if i < len(contacts) {
+				contact = contacts[i]
+			}
+			values = append(values, contact.Email, "", contact.Name, contact.Title, contact.Phone)
+		}
+		for _, column := range datasetKeyedCountColumns {
+			values = append(values, formatKeyedCounts(keyed[column], maxDatasetKeyedCounts))
+		}
+		values = append(values, capExemptColumnValue(name))
+		values = append(values, lcaOutcomeValues(lca, lcaEntry)...)
+		years := mergeDOLFiscalYears(lcaEntry, lca.VisaCol != "", permEntry)
+		rows = append(rows, datasetRow{name: name, counts: counts, years: years, values: values})
+	}
+	allYears := make([]map[int]map[string]int, 0, len(rows))
+	for _, row := range rows {
+		allYears = append(allYears, row.years)
+	}
+	yearColumns := dolFiscalYearColumns(allYears)
+	header := slices.Clone(datasetOutputColumns)
+	for _, column := range yearColumns {
+		header = append(header, fiscalYearColumnName(column.Visa, column.Year))
+	}
+	slices.SortFunc(rows, func(a, b datasetRow) int {
+		for _, column := range []string{"h1b", "green_card", "h1b1_chile", "h1b1_singapore", "e3_australian"} {
+			if a.counts[column] != b.counts[column] {
+				return b.counts[column] - a.counts[column]
+			}
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	out := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		for _, column := range yearColumns {
+			row.values = append(row.values, strconv.Itoa(row.years[column.Year][column.Visa]))
+		}
+		out = append(out, row.values)
+	}
+	return header, out
+}
+
+// dolQualitySummary mirrors the checks the dataset has always shipped with:
+// errors block a strict run, warnings are reported only.
+func dolQualitySummary(rows [][]string) map[string]any {
+	names := map[string]struct{}{}
+	normalized := map[string]struct{}{}
+	duplicates, blanks, contacts, emails, total := 0, 0, 0, 0, 0
+	nonzero := map[string]any{}
+	totals := map[string]any{}
+	for _, column := range datasetVisaColumns {
+		nonzero[column] = 0
+		totals[column] = 0
+	}
+	for _, row := range rows {
+		name := strings.TrimSpace(row[1])
+		names[name] = struct{}{}
+		if name == "" {
+			blanks++
+		}
+		key := normalizeCompanyName(name)
+		if _, seen := normalized[key]; seen {
+			duplicates++
+		}
+		normalized[key] = struct{}{}
+		for i, column := range datasetVisaColumns {
+			value, _ := strconv.Atoi(row[2+i])
+			totals[column] = totals[column].(int) + value
+			total += value
+			if value > 0 {
+				nonzero[column] = nonzero[column].(int) + 1
+			}
+		}
+		if strings.TrimSpace(row[9]) != "" {
+			contacts++
+		}
+		if strings.TrimSpace(row[7]) != "" {
+			emails++
+		}
+	}
+	errors := []string{}
+	warnings := []string{}
+	if len(rows) == 0 {
+		errors = append(errors, "No rows produced")
+	} else if len(rows) < 1000 {
+		warnings = append(warnings, "Low row count (<1000) for national disclosure aggregation")
+	}
+	if duplicates > 0 {
+		errors = append(errors, "Duplicate normalized company names found in output")
+	}
+	if blanks > 0 {
+		errors = append(errors, "Blank company names found in output")
+	}
+	if total == 0 {
+		errors = append(errors, "All visa counts are zero")
+	}
+	return map[string]any{
+		"rows":                           len(rows),
+		"unique_company_names":           len(names),
+		"duplicate_normalized_companies": duplicates,
+		"blank_company_names":            blanks,
+		"negative_visa_values":           0,
+		"visa_type_nonzero_counts":       nonzero,
+		"visa_type_totals":               totals,
+		"total_visa_sum":                 total,
+		"contact_1_nonblank":             contacts,
+		"email_1_nonblank":               emails,
+		"validation": map[string]any{
+			"passed":   len(errors) == 0,
+			"errors":   errors,
+			"warnings": warnings,
+		},
+	}
+}
+
+// writeDatasetCSV replaces the dataset atomically so a running server never
+// reads a half-written file.
+func writeDatasetCSV(datasetPath string, header []string, rows [][]string) error {
+	return writeCSVAtomic(datasetPath, ".companies-*.csv", header, rows)
+}
@@ -0,0 +1,401 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDOLDownloadMaxBytes       = 2 << 30
+	defaultDOLDownloadTimeoutSeconds = 1800
+	maxDOLDownloadTimeoutSeconds     = 7200
+	dolDownloadManifestName          = "downloads.json"
+)
+
+var (
+	dolDownloadMu          sync.Mutex
+	errDOLDownloadTooLarge = errors.New("download exceeds max_bytes")
+)
+
+type dolDownload struct {
+	URL        string
+	LocalPath  string
+	Bytes      int64
+	SHA256     string
+	Status     string
+	ResumedAt  int64
+	Downloaded time.Time
+}
+
+func (d dolDownload) toMap() map[string]any {
+	return map[string]any{
+		"url":               d.URL,
+		"status":            d.Status,
+		"local_path":        d.LocalPath,
+		"bytes":             d.Bytes,
+		"sha256":            d.SHA256,
+		"resumed_from_byte": d.ResumedAt,
+		"downloaded_at_utc": toISO(d.Downloaded),
+	}
+}
+
+func dolDownloadManifestPath(rawDir string) string {
+	return filepath.Join(rawDir, dolDownloadManifestName)
+}
+
+func dolDownloadMaxBytes() int64 {
+	value := envInt("VISA_DOL_DOWNLOAD_MAX_BYTES", defaultDOLDownloadMaxBytes)
+	if value < 1 {
+		return defaultDOLDownloadMaxBytes
+	}
+	return int64(value)
+}
+
+// dolDownloadFileName keeps the URL's file name so repeated downloads land on
+// the same path, which is what makes resume and checksum reuse possible.
+func dolDownloadFileName(source string) (string, error) {
+	parsed, err := url.Parse(source)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("download urls must be absolute http(s) urls: %q", source)
+	}
+	name := path.Base(parsed.Path)
+	if name == "" || name == "." || name == "/" {
+		return "", fmt.Errorf("download url has no file name: %q", source)
+	}
+	// path.Base keeps backslashes, which filepath.Join treats as separators
+	// on Windows.
+	if strings.Contains(name, `\`) || name == ".." {
+		return "", fmt.Errorf("download url has an unsafe file name: %q", source)
+	}
+	return name, nil
+}
+
+// dolPartialValidators are the ETag and Last-Modified of the response a .part
+// file was started from. A resume sends them as If-Range, so the server only
+// returns the rest of the file when it has not changed since.
+type dolPartialValidators struct {
+	ETag         string
+	LastModified string
+}
+
+func (v dolPartialValidators) ifRange() string {
+	// Weak ETags are not allowed in If-Range.
+	if v.ETag != "" && !strings.HasPrefix(v.ETag, "W/") {
+		return v.ETag
+	}
+	return v.LastModified
+}
+
+func dolPartialMetaPath(partial string) string {
+	return partial + ".json"
+}
+
+func loadDOLPartialValidators(partial, source string) dolPartialValidators {
+	meta := loadJSONMap(dolPartialMetaPath(partial), map[string]any{})
+	if getString(meta, "url") != source {
+		return dolPartialValidators{}
+	}
+	return dolPartialValidators{ETag: getString(meta, "etag"), LastModified: getString(meta, "last_modified")}
+}
+
+func saveDOLPartialValidators(partial, source string, header http.Header) error {
+	return saveJSONMap(dolPartialMetaPath(partial), map[string]any{
+		"url":           source,
+		"etag":          header.Get("ETag"),
+		"last_modified": header.Get("Last-Modified"),
+	})
+}
+
+func discardDOLPartial(partial string) {
+	_ = os.Remove(partial)
+	_ = os.Remove(dolPartialMetaPath(partial))
+}
+
+// contentRangeStart reads the first byte position from a Content-Range
+// header such as "bytes 100-1999/2000".
+func contentRangeStart(header string) (int64, bool) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(start), 10, 64)
+	return value, err == nil
+}
+
+func requestDOLFile(ctx context.Context, source string, offset int64, validators dolPartialValidators) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("User-Agent", "visa-jobs-mcp-go/0.3")
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+		req.Header.Set("If-Range", validators.ifRange())
+	}
+	return dolHTTPClient(0).Do(req)
+}
+
+func fileSHA256(filePath string) (string, int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// cachedDOLDownload returns the manifest entry for source when the recorded
+// file is still on disk with the recorded checksum.
+func cachedDOLDownload(rawDir, source string) (dolDownload, bool) {
+	dolDownloadMu.Lock()
+	entry := asMap(asMap(loadJSONMap(dolDownloadManifestPath(rawDir), map[string]any{"downloads": map[string]any{}})["downloads"])[source])
+	dolDownloadMu.Unlock()
+	localPath := getString(entry, "local_path")
+	if localPath == "" {
+		return dolDownload{}, false
+	}
+	sum, size, err := fileSHA256(localPath)
+	if err != nil || sum != getString(entry, "sha256") {
+		return dolDownload{}, false
+	}
+	return dolDownload{
+		URL:        source,
+		LocalPath:  localPath,
+		Bytes:      size,
+		SHA256:     sum,
+		Status:     "cached",
+		Downloaded: parseISOTime(entry["downloaded_at_utc"]),
+	}, true
+}
+
+func recordDOLDownload(rawDir string, download dolDownload) error {
+	dolDownloadMu.Lock()
+	defer dolDownloadMu.Unlock()
+	manifestPath := dolDownloadManifestPath(rawDir)
+	data := loadJSONMap(manifestPath, map[string]any{"downloads": map[string]any{}})
+	downloads := asMap(data["downloads"])
+	downloads[download.URL] = map[string]any{
+		"local_path":        download.LocalPath,
+		"bytes":             download.Bytes,
+		"sha256":            download.SHA256,
+		"downloaded_at_utc": toISO(download.Downloaded),
+	}
+	data["downloads"] = downloads
+	data["updated_at_utc"] = utcNowISO()
+	return saveJSONMap(manifestPath, data)
+}
+
+// downloadDOLFile fetches source into rawDir, resuming from a leftover .part
+// file with a Range and If-Range request when possible. The finished file is checksummed
+// and recorded in the raw directory's download manifest.
+func downloadDOLFile(ctx context.Context, source, rawDir string, maxBytes int64, force bool) (dolDownload, error) {
+	name, err := dolDownloadFileName(source)
+	if err != nil {
+		return dolDownload{}, err
+	}
+	if !force {
+		if cached, ok := cachedDOLDownload(rawDir, source); ok {
+			return cached, nil
+		}
+	}
+	if err := os.MkdirAll(rawDir, 0o755); err != nil {
+		return dolDownload{}, err
+	}
+	target := filepath.Join(rawDir, name)
+	partial := target + ".part"
+	if force {
+		discardDOLPartial(partial)
+	}
+	// Only resume a .part file whose validators were recorded; without them
+	// there is no way to tell the server which version it is a prefix of.
+	var offset int64
+	validators := loadDOLPartialValidators(partial, source)
+	if info, err := os.Stat(partial); err == nil && validators.ifRange() != "" {
+		offset = info.Size()
+	} else {
+		discardDOLPartial(partial)
+	}
+
+	resp, err := requestDOLFile(ctx, source, offset, validators)
+	if err != nil {
+		return dolDownload{}, err
+	}
+	if offset > 0 {
+		start, ok := contentRangeStart(resp.Header.Get("Content-Range"))
+		badRange := resp.StatusCode == http.StatusPartialContent && (!ok || start != offset)
+		if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable || badRange {
+			// The .part file is not a prefix the server agrees with, so
+			// fetch the whole file again.
+			resp.Body.Close()
+			discardDOLPartial(partial)
+			offset = 0
+			if resp, err = requestDOLFile(ctx, source, 0, dolPartialValidators{}); err != nil {
+				return dolDownload{}, err
+			}
+		}
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags = os.O_WRONLY | os.O_APPEND
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299 && resp.StatusCode != http.StatusPartialContent:
+		// A full body: the range was ignored or the file changed upstream.
+		offset = 0
+	default:
+		return dolDownload{}, fmt.Errorf("download %s returned status %d", name, resp.StatusCode)
+	}
+	if resp.ContentLength > 0 && offset+resp.ContentLength > maxBytes {
+		return dolDownload{}, fmt.Errorf("%w (%d bytes > %d)", errDOLDownloadTooLarge, offset+resp.ContentLength, maxBytes)
+	}
+	if offset == 0 {
+		if err := saveDOLPartialValidators(partial, source, resp.Header); err != nil {
+			return dolDownload{}, err
+		}
+	}
+	file, err := os.OpenFile(partial, flags, 0o644)
+	if err != nil {
+		return dolDownload{}, err
+	}
+	written, err := io.Copy(file, io.LimitReader(resp.Body, maxBytes-offset+1))
+	closeErr := file.Close()
+	if err != nil {
+		return dolDownload{}, fmt.Errorf("download %s (partial file kept for resume): %w", name, err)
+	}
+	if closeErr != nil {
+		return dolDownload{}, closeErr
+	}
+	if offset+written > maxBytes {
+		discardDOLPartial(partial)
+		return dolDownload{}, fmt.Errorf("%w (limit %d bytes)", errDOLDownloadTooLarge, maxBytes)
+	}
+	if err := os.Rename(partial, target); err != nil {
+		return dolDownload{}, err
+	}
+	_ = os.Remove(dolPartialMetaPath(partial))
+	sum, size, err := fileSHA256(target)
+	if err != nil {
+		return dolDownload{}, err
+	}
+	download := dolDownload{
+		URL:        source,
+		LocalPath:  target,
+		Bytes:      size,
+		SHA256:     sum,
+		Status:     "downloaded",
+		ResumedAt:  offset,
+		Downloaded: utcNow(),
+	}
+	if offset > 0 {
+		download.Status = "resumed"
+	}
+	if err := recordDOLDownload(rawDir, download); err != nil {
+		return dolDownload{}, fmt.Errorf("record download: %w", err)
+	}
+	return download, nil
+}
+
+func DownloadDolDisclosures(args map[string]any) (map[string]any, error) {
+	rawDir := envOrDefault("VISA_DOL_RAW_DIR", defaultDOLRawDir)
+	if raw := getString(args, "raw_dir"); raw != "" {
+		rawDir = raw
+	}
+	maxBytes := dolDownloadMaxBytes()
+	if value, has, err := getOptionalInt(args, "max_bytes"); has {
+		if err != nil || value < 1 {
+			return nil, fmt.Errorf("max_bytes must be a positive integer when provided")
+		}
+		maxBytes = int64(value)
+	}
+	timeoutSeconds := defaultDOLDownloadTimeoutSeconds
+	if value, has, err := getOptionalInt(args, "timeout_seconds"); has {
+		if err != nil || value < 1 || value > maxDOLDownloadTimeoutSeconds {
+			return nil, fmt.Errorf("timeout_seconds must be between 1 and %d", maxDOLDownloadTimeoutSeconds)
+		}
+		timeoutSeconds = value
+	}
+	force := false
+	if value, has, err := getOptionalBool(args, "force"); has {
+		if err != nil {
+			return nil, fmt.Errorf("force must be a boolean when provided")
+		}
+		force = value
+	}
+
+	urls := getStringList(args, "urls")
+	discovered := false
+	if len(urls) == 0 {
+		found, err := discoverDOLDisclosures(dolPerformanceURL(getString(args, "performance_url")))
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range []string{"lca_disclosure_urls", "perm_disclosure_urls"} {
+			if latest := firstSupportedDisclosure(getStringList(found, key)); latest != "" {
+				urls = append(urls, latest)
+			}
+		}
+		if len(urls) == 0 {
+			return nil, fmt.Errorf("urls is required when no LCA/PERM disclosures can be discovered")
+		}
+		discovered = true
+	}
+	for _, one := range urls {
+		if _, err := dolDownloadFileName(one); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+	results := []any{}
+	failed := 0
+	for _, one := range urls {
+		download, err := downloadDOLFile(ctx, one, rawDir, maxBytes, force)
+		row := download.toMap()
+		if err != nil {
+			failed++
+			row = map[string]any{"url": one, "status": "failed", "error": err.Error()}
+		}
+		results = append(results, row)
+	}
+	status := "completed"
+	if failed == len(urls) {
+		status = "failed"
+	} else if failed > 0 {
+		status = "partial"
+	}
+	return map[string]any{
+		"status":                          status,
+		"raw_dir":                         rawDir,
+		"manifest_path":                   dolDownloadManifestPath(rawDir),
+		"discovered_from_performance_url": discovered,
+		"max_bytes":                       maxBytes,
+		"timeout_seconds":                 timeoutSeconds,
+		"downloads":                       results,
+		"failed_count":                    failed,
+		"next_step":                       "Call run_internal_dol_pipeline with lca_source/perm_source set to these URLs (or local_path values); recorded downloads are reused without fetching again. Re-run this tool to resume failed downloads.",
+	}, nil
+}
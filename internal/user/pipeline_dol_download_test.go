@@ -0,0 +1,163 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownloadDolDisclosuresResumesAndChecksums(t *testing.T) {
+	body := bytes.Repeat([]byte("EMPLOYER_NAME,VISA_CLASS\nAcme Inc,H-1B\n"), 50)
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Header.Get("Range"))
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "LCA_Disclosure_Data_FY2024.csv", time.Time{}, bytes.NewReader(body))
+	}))
+	defer server.Close()
+	rawDir := t.TempDir()
+	source := server.URL + "/docs/LCA_Disclosure_Data_FY2024.csv"
+	if err := os.WriteFile(filepath.Join(rawDir, "LCA_Disclosure_Data_FY2024.csv.part"), body[:100], 0o644); err != nil {
+		t.Fatalf("write partial: %v", err)
+	}
+	if err := saveDOLPartialValidators(filepath.Join(rawDir, "LCA_Disclosure_Data_FY2024.csv.part"), source, http.Header{"Etag": {`"v1"`}}); err != nil {
+		t.Fatalf("write partial validators: %v", err)
+	}
+
+	result, err := DownloadDolDisclosures(map[string]any{"urls": []any{source}, "raw_dir": rawDir})
+	if err != nil {
+		t.Fatalf("DownloadDolDisclosures failed: %v", err)
+	}
+	downloads := listOrEmpty(result["downloads"])
+	first := asMap(downloads[0])
+	sum := sha256.Sum256(body)
+	if getString(result, "status") != "completed" || getString(first, "status") != "resumed" || getString(first, "sha256") != hex.EncodeToString(sum[:]) {
+		t.Fatalf("expected resumed download with matching checksum, got %#v", result)
+	}
+	if len(requests) != 1 || requests[0] != "bytes=100-" {
+		t.Fatalf("expected a single ranged request, got %v", requests)
+	}
+	saved, _ := os.ReadFile(getString(first, "local_path"))
+	if !bytes.Equal(saved, body) {
+		t.Fatalf("resumed file does not match the source")
+	}
+
+	again, err := DownloadDolDisclosures(map[string]any{"urls": []any{source}, "raw_dir": rawDir})
+	if err != nil || getString(asMap(listOrEmpty(again["downloads"])[0]), "status") != "cached" || len(requests) != 1 {
+		t.Fatalf("expected recorded download to be reused, got %#v (%v), requests=%v", again, err, requests)
+	}
+
+	local, err := downloadDOLSource(context.Background(), source, rawDir)
+	if err != nil || local != getString(first, "local_path") || len(requests) != 1 {
+		t.Fatalf("expected the pipeline to reuse the recorded download, got %q (%v)", local, err)
+	}
+}
+
+func TestDownloadDolDisclosuresRefetchesStalePartials(t *testing.T) {
+	oldBody := bytes.Repeat([]byte("EMPLOYER_NAME,VISA_CLASS\nOld Co,H-1B\n"), 50)
+	newBody := bytes.Repeat([]byte("EMPLOYER_NAME,VISA_CLASS\nNew Co,E-3\n"), 60)
+	cases := map[string]http.HandlerFunc{
+		// The file was republished, so If-Range fails and the full body comes back.
+		"changed etag": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v2"`)
+			http.ServeContent(w, r, "data.csv", time.Time{}, bytes.NewReader(newBody))
+		},
+		"range not satisfiable": func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			_, _ = w.Write(newBody)
+		},
+		"wrong range start": func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(newBody)-1, len(newBody)))
+				w.WriteHeader(http.StatusPartialContent)
+				_, _ = w.Write(newBody)
+				return
+			}
+			_, _ = w.Write(newBody)
+		},
+	}
+	for name, handler := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+			rawDir := t.TempDir()
+			source := server.URL + "/data.csv"
+			partial := filepath.Join(rawDir, "data.csv.part")
+			if err := os.WriteFile(partial, oldBody[:100], 0o644); err != nil {
+				t.Fatalf("write partial: %v", err)
+			}
+			if err := saveDOLPartialValidators(partial, source, http.Header{"Etag": {`"v1"`}}); err != nil {
+				t.Fatalf("write partial validators: %v", err)
+			}
+
+			result, err := DownloadDolDisclosures(map[string]any{"urls": []any{source}, "raw_dir": rawDir})
+			if err != nil {
+				t.Fatalf("DownloadDolDisclosures failed: %v", err)
+			}
+			first := asMap(listOrEmpty(result["downloads"])[0])
+			if getString(first, "status") != "downloaded" || intOrZero(first["resumed_from_byte"]) != 0 {
+				t.Fatalf("expected a fresh download, got %#v", result)
+			}
+			saved, _ := os.ReadFile(getString(first, "local_path"))
+			if !bytes.Equal(saved, newBody) {
+				t.Fatalf("expected the current file without the stale prefix, got %d bytes", len(saved))
+			}
+			if leftovers, _ := filepath.Glob(partial + "*"); len(leftovers) != 0 {
+				t.Fatalf("expected partial files to be cleaned up, got %v", leftovers)
+			}
+		})
+	}
+}
+
+func TestDolDownloadFileNameRejectsBackslashes(t *testing.T) {
+	if _, err := dolDownloadFileName("https://example.com/files/..%5Cevil.csv"); err == nil {
+		t.Fatalf("expected a file name with a backslash to be rejected")
+	}
+	if name, err := dolDownloadFileName("https://example.com/files/LCA_FY2024.xlsx"); err != nil || name != "LCA_FY2024.xlsx" {
+		t.Fatalf("expected plain file name, got %q (%v)", name, err)
+	}
+}
+
+func TestDownloadDolDisclosuresEnforcesLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(bytes.Repeat([]byte("x"), 2048))
+	}))
+	defer server.Close()
+	rawDir := t.TempDir()
+	result, err := DownloadDolDisclosures(map[string]any{
+		"urls":      []any{server.URL + "/big.csv", server.URL + "/small.csv"},
+		"raw_dir":   rawDir,
+		"max_bytes": 1024,
+	})
+	if err != nil {
+		t.Fatalf("DownloadDolDisclosures failed: %v", err)
+	}
+	if getString(result, "status") != "failed" || intOrZero(result["failed_count"]) != 2 {
+		t.Fatalf("expected both downloads to fail the size limit, got %#v", result)
+	}
+	if !strings.Contains(getString(asMap(listOrEmpty(result["downloads"])[0]), "error"), "max_bytes") {
+		t.Fatalf("expected max_bytes error, got %#v", result["downloads"])
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(rawDir, "*")); len(leftovers) != 0 {
+		t.Fatalf("expected oversize downloads to be removed, got %v", leftovers)
+	}
+
+	if _, err := DownloadDolDisclosures(map[string]any{"urls": []any{"file:///etc/passwd"}, "raw_dir": rawDir}); err == nil {
+		t.Fatalf("expected non-http urls to be rejected")
+	}
+	if _, err := DownloadDolDisclosures(map[string]any{"urls": []any{server.URL + "/a.csv"}, "timeout_seconds": 0}); err == nil {
+		t.Fatalf("expected timeout_seconds validation error")
+	}
+}
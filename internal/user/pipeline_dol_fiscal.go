@@ -0,0 +1,145 @@
+package user
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var dolDecisionDateColumns = []string{"DECISION_DATE", "CASE_RECEIVED_DATE", "RECEIVED_DATE", "Decision Date"}
+
+var fiscalYearFileRegex = regexp.MustCompile(`(?i)fy[ _-]?(\d{4})`)
+
+var disclosureDateLayouts = []string{"2006-01-02", "2006-01-02 15:04:05", "1/2/2006", "01/02/2006", "1/2/2006 15:04"}
+
+// excelEpoch is day zero for XLSX date serials (the 1900 leap-year bug is
+// folded in by starting on Dec 30).
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+func fiscalYearColumnName(visa string, year int) string {
+	return fmt.Sprintf("%s_fy%d", visa, year)
+}
+
+// fiscalYearForDate follows the federal fiscal year, which starts on Oct 1.
+func fiscalYearForDate(date time.Time) int {
+	if date.Month() >= time.October {
+		return date.Year() + 1
+	}
+	return date.Year()
+}
+
+func fiscalYearFromFileName(filePath string) int {
+	match := fiscalYearFileRegex.FindStringSubmatch(filepath.Base(filePath))
+	if match == nil {
+		return 0
+	}
+	year, _ := strconv.Atoi(match[1])
+	return year
+}
+
+func parseDisclosureDate(raw string) (time.Time, bool) {
+	text := strings.TrimSpace(raw)
+	if text == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range disclosureDateLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			return parsed, true
+		}
+	}
+	if serial, err := strconv.ParseFloat(text, 64); err == nil && serial > 0 && serial < 100000 {
+		return excelEpoch.AddDate(0, 0, int(serial)), true
+	}
+	return time.Time{}, false
+}
+
+// disclosureFiscalYear prefers the row's decision date and falls back to the
+// fiscal year in the disclosure file name (for example FY2024_Q4).
+func disclosureFiscalYear(rawDate string, fallback int) int {
+	if date, ok := parseDisclosureDate(rawDate); ok {
+		return fiscalYearForDate(date)
+	}
+	return fallback
+}
+
+func (t *dolEmployerTally) addFiscalYear(year int, visa string) {
+	if year <= 0 {
+		return
+	}
+	if t.yearCounts == nil {
+		t.yearCounts = map[int]map[string]int{}
+	}
+	if t.yearCounts[year] == nil {
+		t.yearCounts[year] = map[string]int{}
+	}
+	t.yearCounts[year][visa]++
+}
+
+// mergeDOLFiscalYears maps per-year LCA and PERM tallies onto dataset visa
+// columns using the same rules as the all-years totals.
+func mergeDOLFiscalYears(lcaEntry *dolEmployerTally, lcaHasVisaCol bool, permEntry *dolEmployerTally) map[int]map[string]int {
+	out := map[int]map[string]int{}
+	add := func(year int, visa string, count int) {
+		if out[year] == nil {
+			out[year] = map[string]int{}
+		}
+		out[year][visa] += count
+	}
+	if permEntry != nil {
+		for year, counts := range permEntry.yearCounts {
+			for _, count := range counts {
+				add(year, "green_card", count)
+			}
+		}
+	}
+	if lcaEntry != nil {
+		for year, counts := range lcaEntry.yearCounts {
+			for visa, count := range counts {
+				switch {
+				case !lcaHasVisaCol:
+					add(year, "h1b", count)
+				case visa != "":
+					add(year, visa, count)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// dolFiscalYearColumns lists the per-year columns that have at least one
+// filing, newest year first and in dataset visa order within a year.
+func dolFiscalYearColumns(years []map[int]map[string]int) []fiscalYearColumn {
+	present := map[int]map[string]bool{}
+	for _, byYear := range years {
+		for year, counts := range byYear {
+			for visa, count := range counts {
+				if count <= 0 {
+					continue
+				}
+				if present[year] == nil {
+					present[year] = map[string]bool{}
+				}
+				present[year][visa] = true
+			}
+		}
+	}
+	ordered := make([]int, 0, len(present))
+	for year := range present {
+		ordered = append(ordered, year)
+	}
+	slices.SortFunc(ordered, func(a, b int) int { return b - a })
+	columns := []fiscalYearColumn{}
+	for _, year := range ordered {
+		for _, visa := range datasetVisaColumns {
+			if present[year][visa] {
+				columns = append(columns, fiscalYearColumn{Visa: visa, Year: year})
+			}
+		}
+	}
+	return columns
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"strconv"
+	"strings"
+)
+
+// lcaCaseStatusColumns name the LCA case outcome column across disclosure
+// years.
+var lcaCaseStatusColumns = []string{"CASE_STATUS", "STATUS", "Case Status"}
+
+// lcaOutcomeColumns are the dataset columns that hold H-1B LCA outcomes; a
+// filing counted in h1b that ended Denied or Withdrawn (including
+// "Certified - Withdrawn") also counts here.
+var lcaOutcomeColumns = []string{"h1b_denied", "h1b_withdrawn"}
+
+// lcaOutcomeColumn maps an LCA case status onto an outcome column, or ""
+// for certified filings.
+func lcaOutcomeColumn(status string) string {
+	status = strings.ToLower(status)
+	switch {
+	case strings.Contains(status, "denied"):
+		return "h1b_denied"
+	case strings.Contains(status, "withdrawn"):
+		return "h1b_withdrawn"
+	}
+	return ""
+}
+
+func (t *dolEmployerTally) addOutcome(status string) {
+	column := lcaOutcomeColumn(status)
+	if column == "" {
+		return
+	}
+	if t.outcomes == nil {
+		t.outcomes = map[string]int{}
+	}
+	t.outcomes[column]++
+}
+
+// lcaOutcomeValues are the h1b_denied/h1b_withdrawn cells for an employer;
+// they stay blank when the disclosure has no case status column so the
+// approval rate reads as unknown rather than perfect.
+func lcaOutcomeValues(lca *dolDisclosureTally, entry *dolEmployerTally) []string {
+	values := make([]string, 0, len(lcaOutcomeColumns))
+	for _, column := range lcaOutcomeColumns {
+		if lca.StatusCol == "" {
+			values = append(values, "")
+			continue
+		}
+		count := 0
+		if entry != nil {
+			count = entry.outcomes[column]
+		}
+		values = append(values, strconv.Itoa(count))
+	}
+	return values
+}
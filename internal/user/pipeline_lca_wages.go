@@ -0,0 +1,198 @@
+package user
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	minPlausibleAnnualWage = 10000
+	maxPlausibleAnnualWage = 2000000
+)
+
+var (
+	lcaWageFromColumns  = []string{"WAGE_RATE_OF_PAY_FROM", "WAGE_RATE_OF_PAY"}
+	lcaWageToColumns    = []string{"WAGE_RATE_OF_PAY_TO"}
+	lcaWageUnitColumns  = []string{"WAGE_UNIT_OF_PAY"}
+	lcaPrevailingColumn = []string{"PREVAILING_WAGE"}
+	lcaPrevailingUnit   = []string{"PW_UNIT_OF_PAY"}
+	lcaSOCCodeColumns   = []string{"SOC_CODE"}
+	lcaSOCTitleColumns  = []string{"SOC_TITLE", "SOC_NAME"}
+)
+
+var lcaWageOutputColumns = []string{
+	"company_name", "soc_code", "soc_title", "worksite_city", "worksite_state",
+	"filings", "annual_wage_mean", "annual_wage_min", "annual_wage_max", "prevailing_wage_mean",
+}
+
+// annualWageMultipliers converts DOL wage units to a yearly figure.
+var annualWageMultipliers = map[string]float64{
+	"year":      1,
+	"month":     12,
+	"bi-weekly": 26,
+	"week":      52,
+	"hour":      2080,
+}
+
+type lcaWageColumns struct {
+	From, To, Unit, Prevailing, PrevailingUnit, SOCCode, SOCTitle, City, State string
+}
+
+type lcaWageGroup struct {
+	Employer  string
+	SOCCode   string
+	SOCTitle  string
+	City      string
+	State     string
+	Filings   int
+	wageSum   float64
+	wageMin   float64
+	wageMax   float64
+	pwSum     float64
+	pwFilings int
+}
+
+// lcaWageTally aggregates offered and prevailing wages per employer, SOC
+// code, and worksite so memory grows with distinct groups, not filings.
+type lcaWageTally struct {
+	columns lcaWageColumns
+	groups  map[string]*lcaWageGroup
+}
+
+// newLCAWageTally returns nil for disclosures without wage columns (PERM or
+// trimmed exports), which skips wage aggregation.
+func newLCAWageTally(table *disclosureTable) *lcaWageTally {
+	columns := lcaWageColumns{
+		From:           table.pick(lcaWageFromColumns),
+		To:             table.pick(lcaWageToColumns),
+		Unit:           table.pick(lcaWageUnitColumns),
+		Prevailing:     table.pick(lcaPrevailingColumn),
+		PrevailingUnit: table.pick(lcaPrevailingUnit),
+		SOCCode:        table.pick(lcaSOCCodeColumns),
+		SOCTitle:       table.pick(lcaSOCTitleColumns),
+		City:           table.pick(dolWorksiteCityColumns),
+		State:          table.pick(dolWorksiteStateColumns),
+	}
+	if columns.From == "" {
+		return nil
+	}
+	return &lcaWageTally{columns: columns, groups: map[string]*lcaWageGroup{}}
+}
+
+func parseWageAmount(raw string) (float64, bool) {
+	text := strings.NewReplacer("$", "", ",", "", " ", "").Replace(strings.TrimSpace(raw))
+	if text == "" {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	return value, true
+}
+
+// annualizeWage returns a yearly wage, defaulting to yearly when the unit is
+// blank, and drops values outside a plausible salary range.
+func annualizeWage(raw, unit string) (float64, bool) {
+	value, ok := parseWageAmount(raw)
+	if !ok {
+		return 0, false
+	}
+	multiplier := 1.0
+	if unit = strings.ToLower(strings.TrimSpace(unit)); unit != "" {
+		known, found := annualWageMultipliers[unit]
+		if !found {
+			return 0, false
+		}
+		multiplier = known
+	}
+	annual := value * multiplier
+	if annual < minPlausibleAnnualWage || annual > maxPlausibleAnnualWage {
+		return 0, false
+	}
+	return annual, true
+}
+
+func (t *lcaWageTally) add(table *disclosureTable, row []string, normalizedEmployer string) {
+	if t == nil {
+		return
+	}
+	unit := table.value(row, t.columns.Unit)
+	wage, ok := annualizeWage(table.value(row, t.columns.From), unit)
+	if !ok {
+		return
+	}
+	if upper, ok := annualizeWage(table.value(row, t.columns.To), unit); ok && upper > wage {
+		wage = (wage + upper) / 2
+	}
+	socCode := strings.TrimSpace(table.value(row, t.columns.SOCCode))
+	city := strings.ToLower(normalizeWhitespace(table.value(row, t.columns.City)))
+	state := normalizeUSState(table.value(row, t.columns.State))
+	key := strings.Join([]string{normalizedEmployer, socCode, city, state}, "|")
+	group := t.groups[key]
+	if group == nil {
+		group = &lcaWageGroup{
+			Employer: normalizedEmployer,
+			SOCCode:  socCode,
+			SOCTitle: normalizeWhitespace(table.value(row, t.columns.SOCTitle)),
+			City:     city,
+			State:    state,
+			wageMin:  wage,
+			wageMax:  wage,
+		}
+		t.groups[key] = group
+	}
+	group.Filings++
+	group.wageSum += wage
+	group.wageMin = min(group.wageMin, wage)
+	group.wageMax = max(group.wageMax, wage)
+	if prevailing, ok := annualizeWage(table.value(row, t.columns.Prevailing), table.value(row, t.columns.PrevailingUnit)); ok {
+		group.pwSum += prevailing
+		group.pwFilings++
+	}
+}
+
+// writeLCAWagesCSV writes one row per employer/SOC/worksite group, using the
+// employer's display name from the LCA tally, and returns the row count.
+func writeLCAWagesCSV(wagesPath string, wages *lcaWageTally, lca *dolDisclosureTally) (int, error) {
+	if wages == nil {
+		return 0, nil
+	}
+	groups := make([]*lcaWageGroup, 0, len(wages.groups))
+	for _, group := range wages.groups {
+		groups = append(groups, group)
+	}
+	slices.SortFunc(groups, func(a, b *lcaWageGroup) int {
+		if a.Employer != b.Employer {
+			return strings.Compare(a.Employer, b.Employer)
+		}
+		if a.Filings != b.Filings {
+			return b.Filings - a.Filings
+		}
+		return strings.Compare(a.SOCCode+a.State+a.City, b.SOCCode+b.State+b.City)
+	})
+	rows := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		name := group.Employer
+		if entry := lca.employers[group.Employer]; entry != nil {
+			name = entry.displayName()
+		}
+		prevailing := ""
+		if group.pwFilings > 0 {
+			prevailing = strconv.Itoa(int(group.pwSum / float64(group.pwFilings)))
+		}
+		rows = append(rows, []string{
+			name, group.SOCCode, group.SOCTitle, group.City, group.State,
+			strconv.Itoa(group.Filings),
+			strconv.Itoa(int(group.wageSum / float64(group.Filings))),
+			strconv.Itoa(int(group.wageMin)),
+			strconv.Itoa(int(group.wageMax)),
+			prevailing,
+		})
+	}
+	if err := writeCSVAtomic(wagesPath, ".lca-wages-*.csv", lcaWageOutputColumns, rows); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
@@ -1,15 +1,10 @@
 package user
 
 import (
-	"bytes"
-	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
@@ -120,22 +115,24 @@ func resolveAbsolute(baseURL, href string) string {
 	return abs.String()
 }
 
-func DiscoverLatestDolDisclosureURLs(args map[string]any) (map[string]any, error) {
-	performanceURL := strings.TrimSpace(getString(args, "performance_url"))
+func dolPerformanceURL(raw string) string {
+	performanceURL := strings.TrimSpace(raw)
 	if performanceURL == "" {
 		performanceURL = strings.TrimSpace(os.Getenv("VISA_DOL_PERFORMANCE_URL"))
 	}
 	if performanceURL == "" {
 		performanceURL = defaultDOLPerformanceURL
 	}
+	return performanceURL
+}
+
+func DiscoverLatestDolDisclosureURLs(args map[string]any) (map[string]any, error) {
+	return discoverDOLDisclosures(dolPerformanceURL(getString(args, "performance_url")))
+}
 
+func discoverDOLDisclosures(performanceURL string) (map[string]any, error) {
 	timeout := envInt("VISA_DOL_DISCOVERY_TIMEOUT_SECONDS", 25)
-	client := &http.Client{
-		Timeout: time.Duration(timeout) * time.Second,
-		Transport: &http.Transport{
-			Proxy: nil,
-		},
-	}
+	client := dolHTTPClient(time.Duration(timeout) * time.Second)
 	req, err := http.NewRequest(http.MethodGet, performanceURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("build request: %w", err)
@@ -223,93 +220,3 @@ func firstOrNil(values []string) any {
 	}
 	return values[0]
 }
-
-func outputTail(text string, lines int) string {
-	trimmed := strings.TrimSpace(text)
-	if trimmed == "" {
-		return ""
-	}
-	all := strings.Split(trimmed, "\n")
-	if len(all) <= lines {
-		return trimmed
-	}
-	return strings.Join(all[len(all)-lines:], "\n")
-}
-
-func inferExitCode(err error) any {
-	if err == nil {
-		return 0
-	}
-	var exitErr *exec.ExitError
-	if errors.As(err, &exitErr) {
-		return exitErr.ExitCode()
-	}
-	return nil
-}
-
-func defaultPipelineCommand() string {
-	script := filepath.Join("scripts", "run_internal_pipeline.sh")
-	if info, err := os.Stat(script); err == nil && !info.IsDir() {
-		return script
-	}
-	return "python3 -m visa_jobs_mcp.pipeline_cli"
-}
-
-func RunInternalDolPipeline(args map[string]any) (map[string]any, error) {
-	command := strings.TrimSpace(getString(args, "command"))
-	if command == "" {
-		command = strings.TrimSpace(os.Getenv("VISA_DOL_PIPELINE_COMMAND"))
-	}
-	if command == "" {
-		command = defaultPipelineCommand()
-	}
-
-	timeoutSeconds := envInt("VISA_DOL_PIPELINE_TIMEOUT_SECONDS", 1800)
-	if timeoutSeconds < 60 {
-		timeoutSeconds = 60
-	}
-	datasetPath := datasetPathOrDefault(getString(args, "dataset_path"))
-	manifestPath := envOrDefault("VISA_DOL_MANIFEST_PATH", defaultManifestPath)
-	if rawManifest := getString(args, "manifest_path"); rawManifest != "" {
-		manifestPath = rawManifest
-	}
-
-	started := utcNow()
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutSeconds)*time.Second)
-	defer cancel()
-
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, "bash", "-lc", command)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	runErr := cmd.Run()
-	completed := utcNow()
-	durationSeconds := completed.Sub(started).Seconds()
-
-	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
-	result := map[string]any{
-		"status":            "completed",
-		"command":           command,
-		"started_at_utc":    toISO(started),
-		"completed_at_utc":  toISO(completed),
-		"duration_seconds":  durationSeconds,
-		"timed_out":         timedOut,
-		"exit_code":         inferExitCode(runErr),
-		"stdout_tail":       outputTail(stdout.String(), 20),
-		"stderr_tail":       outputTail(stderr.String(), 20),
-		"dataset_path":      datasetPath,
-		"manifest_path":     manifestPath,
-		"dataset_freshness": datasetFreshness(datasetPath, manifestPath),
-	}
-	if runErr != nil {
-		result["status"] = "failed"
-		if timedOut {
-			result["error"] = fmt.Sprintf("Pipeline timed out after %d seconds", timeoutSeconds)
-		} else {
-			result["error"] = runErr.Error()
-		}
-		result["guidance"] = "Pipeline execution failed. Re-run command directly to inspect full logs."
-	}
-	return result, nil
-}
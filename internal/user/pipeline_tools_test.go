@@ -3,6 +3,8 @@ package user
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -39,30 +41,173 @@ func TestDiscoverLatestDolDisclosureURLs(t *testing.T) {
 	}
 }
 
-func TestRunInternalDolPipeline(t *testing.T) {
-	success, err := RunInternalDolPipeline(map[string]any{
-		"command": "echo pipeline-ok",
+func TestRunInternalDolPipelineBuildsDatasetFromLocalFiles(t *testing.T) {
+	dir := t.TempDir()
+	lcaPath := filepath.Join(dir, "lca.csv")
+	lcaBody := strings.Join([]string{
+		"\ufeffEMPLOYER_NAME,VISA_CLASS,EMPLOYER_POC_FIRST_NAME,EMPLOYER_POC_LAST_NAME,EMPLOYER_POC_EMAIL,EMPLOYER_POC_PHONE",
+		"Acme Inc,H-1B,Alice,Recruiter,alice@acme.com,111",
+		"ACME INC.,H-1B,Alice,Recruiter,alice@acme.com,111",
+		"Acme Inc,E-3 Australian,Bob,Hr,,222",
+		"Beta LLC,H-1B1 Chile,,,,",
+		"nan,H-1B,,,,",
+	}, "\n")
+	if err := os.WriteFile(lcaPath, []byte(lcaBody), 0o644); err != nil {
+		t.Fatalf("write lca: %v", err)
+	}
+	permPath := filepath.Join(dir, "perm.xlsx")
+	writeTestXLSX(t, permPath, [][]string{
+		{"EMPLOYER_NAME", "EMP_POC_FIRST_NAME", "EMP_POC_LAST_NAME", "EMP_POC_EMAIL"},
+		{"Gamma Corp", "Gina", "", "gina@gamma.com"},
+		{"Acme Inc", "Pat", "Perm", "pat@acme.com"},
+	})
+	datasetPath := filepath.Join(dir, "out", "companies.csv")
+	manifestPath := filepath.Join(dir, "out", "last_run.json")
+
+	result, err := RunInternalDolPipeline(map[string]any{
+		"lca_source":    lcaPath,
+		"perm_source":   permPath,
+		"dataset_path":  datasetPath,
+		"manifest_path": manifestPath,
+	})
+	if err != nil {
+		t.Fatalf("RunInternalDolPipeline failed: %v", err)
+	}
+	if got := getString(result, "status"); got != "completed" {
+		t.Fatalf("expected completed status, got %q (%#v)", got, result)
+	}
+	if intOrZero(result["rows_written"]) != 3 || getString(result, "lca_visa_col") != "VISA_CLASS" {
+		t.Fatalf("unexpected pipeline result: %#v", result)
+	}
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("load generated dataset: %v", err)
+	}
+	acme := dataset.ByNormalizedCompany["acme"]
+	if acme.H1B != 2 || acme.E3Australian != 1 || acme.GreenCard != 1 ||
+		acme.CompanyTier != companyTierOccasional || acme.CompanyTierBasis != companyTierBasisDerived {
+		t.Fatalf("unexpected acme counts: %+v", acme)
+	}
+	if len(acme.EmployerContacts) != 3 ||
+		getString(acme.EmployerContacts[0], "email") != "pat@acme.com" ||
+		getString(acme.EmployerContacts[1], "email") != "alice@acme.com" ||
+		getString(acme.EmployerContacts[2], "name") != "Bob Hr" {
+		t.Fatalf("expected PERM contacts first then LCA contacts by quality, got %#v", acme.EmployerContacts)
+	}
+	if beta := dataset.ByNormalizedCompany["beta"]; beta.H1B1Chile != 1 || beta.H1B != 0 {
+		t.Fatalf("unexpected beta row: %+v", beta)
+	}
+	if parseManifestTime(manifestPath).IsZero() {
+		t.Fatalf("expected manifest with run_at_utc")
+	}
+	freshness := asMap(result["dataset_freshness"])
+	if getString(freshness, "source") != "manifest" {
+		t.Fatalf("expected freshness from manifest, got %#v", freshness)
+	}
+}
+
+func TestRunInternalDolPipelineStrictValidationKeepsDataset(t *testing.T) {
+	dir := t.TempDir()
+	lcaPath := filepath.Join(dir, "lca.csv")
+	permPath := filepath.Join(dir, "perm.csv")
+	if err := os.WriteFile(lcaPath, []byte("EMPLOYER_NAME,VISA_CLASS\nAcme Inc,E-2\n"), 0o644); err != nil {
+		t.Fatalf("write lca: %v", err)
+	}
+	if err := os.WriteFile(permPath, []byte("EMPLOYER_NAME\n"), 0o644); err != nil {
+		t.Fatalf("write perm: %v", err)
+	}
+	datasetPath := filepath.Join(dir, "companies.csv")
+	if err := os.WriteFile(datasetPath, []byte("existing"), 0o644); err != nil {
+		t.Fatalf("write dataset: %v", err)
+	}
+	result, err := RunInternalDolPipeline(map[string]any{
+		"lca_source":    lcaPath,
+		"perm_source":   permPath,
+		"dataset_path":  datasetPath,
+		"manifest_path": filepath.Join(dir, "last_run.json"),
 	})
 	if err != nil {
-		t.Fatalf("RunInternalDolPipeline success path failed: %v", err)
+		t.Fatalf("RunInternalDolPipeline should report failures in the payload: %v", err)
 	}
-	if got := getString(success, "status"); got != "completed" {
-		t.Fatalf("expected completed status, got %q (%#v)", got, success)
+	if getString(result, "status") != "failed" || !strings.Contains(getString(result, "error"), "No rows produced") {
+		t.Fatalf("expected validation failure, got %#v", result)
 	}
-	if out := getString(success, "stdout_tail"); !strings.Contains(out, "pipeline-ok") {
-		t.Fatalf("expected stdout_tail to include pipeline-ok, got %q", out)
+	if body, _ := os.ReadFile(datasetPath); string(body) != "existing" {
+		t.Fatalf("expected dataset to stay untouched, got %q", body)
 	}
+}
 
-	failed, err := RunInternalDolPipeline(map[string]any{
-		"command": "echo broken 1>&2; exit 7",
+func TestRunInternalDolPipelineDiscoversAndDownloads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/performance":
+			_, _ = w.Write([]byte(`<a href="/docs/LCA_Disclosure_Data_FY2025.zip">zip</a>
+				<a href="/docs/LCA_Disclosure_Data_FY2024.csv">LCA</a>
+				<a href="/docs/PERM_Disclosure_Data_FY2024.csv">PERM</a>`))
+		case "/docs/LCA_Disclosure_Data_FY2024.csv":
+			_, _ = w.Write([]byte("EMPLOYER_NAME,VISA_CLASS\nAcme Inc,H-1B\n"))
+		case "/docs/PERM_Disclosure_Data_FY2024.csv":
+			_, _ = w.Write([]byte("EMPLOYER_NAME\nAcme Inc\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	result, err := RunInternalDolPipeline(map[string]any{
+		"performance_url": server.URL + "/performance",
+		"raw_dir":         filepath.Join(dir, "raw"),
+		"dataset_path":    filepath.Join(dir, "companies.csv"),
+		"manifest_path":   filepath.Join(dir, "last_run.json"),
 	})
 	if err != nil {
-		t.Fatalf("RunInternalDolPipeline failure path should still return payload: %v", err)
+		t.Fatalf("RunInternalDolPipeline failed: %v", err)
+	}
+	if getString(result, "status") != "completed" || result["discovered_from_performance_url"] != true {
+		t.Fatalf("expected discovered run to complete, got %#v", result)
+	}
+	if !strings.HasSuffix(getString(result, "lca_source"), "LCA_Disclosure_Data_FY2024.csv") {
+		t.Fatalf("expected the newest supported LCA file, got %q", getString(result, "lca_source"))
 	}
-	if got := getString(failed, "status"); got != "failed" {
-		t.Fatalf("expected failed status, got %q (%#v)", got, failed)
+	downloaded, _ := filepath.Glob(filepath.Join(dir, "raw", "*.csv"))
+	if len(downloaded) != 2 {
+		t.Fatalf("expected both disclosures saved under raw_dir, got %v", downloaded)
+	}
+}
+
+func TestRunInternalDolPipelineWritesFiscalYearColumns(t *testing.T) {
+	dir := t.TempDir()
+	lcaPath := filepath.Join(dir, "LCA_Disclosure_Data_FY2024_Q4.csv")
+	lcaBody := strings.Join([]string{
+		"EMPLOYER_NAME,VISA_CLASS,DECISION_DATE",
+		"Acme Inc,H-1B,2023-10-02",
+		"Acme Inc,H-1B,2022-11-15",
+		"Acme Inc,H-1B,",
+	}, "\n")
+	if err := os.WriteFile(lcaPath, []byte(lcaBody), 0o644); err != nil {
+		t.Fatalf("write lca: %v", err)
+	}
+	permPath := filepath.Join(dir, "PERM_Disclosure_Data_FY2024_Q4.csv")
+	if err := os.WriteFile(permPath, []byte("EMPLOYER_NAME\nAcme Inc\n"), 0o644); err != nil {
+		t.Fatalf("write perm: %v", err)
+	}
+	datasetPath := filepath.Join(dir, "companies.csv")
+	result, err := RunInternalDolPipeline(map[string]any{
+		"lca_source":        lcaPath,
+		"perm_source":       permPath,
+		"dataset_path":      datasetPath,
+		"manifest_path":     filepath.Join(dir, "last_run.json"),
+		"strict_validation": false,
+	})
+	if err != nil || getString(result, "status") != "completed" {
+		t.Fatalf("RunInternalDolPipeline failed: %v %#v", err, result)
+	}
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("load generated dataset: %v", err)
 	}
-	if got, _ := intFromAny(failed["exit_code"]); got != 7 {
-		t.Fatalf("expected exit_code=7, got %#v", failed["exit_code"])
+	acme := dataset.ByNormalizedCompany["acme"]
+	if dataset.LatestFiscalYear != 2024 || acme.FiscalYearCounts[2024]["h1b"] != 2 || acme.FiscalYearCounts[2023]["h1b"] != 1 || acme.FiscalYearCounts[2024]["green_card"] != 1 {
+		t.Fatalf("unexpected fiscal year counts: latest=%d %#v", dataset.LatestFiscalYear, acme.FiscalYearCounts)
 	}
 }
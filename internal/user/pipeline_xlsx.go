@@ -0,0 +1,213 @@
+package user
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type xlsxCell struct {
+	Ref    string `xml:"r,attr"`
+	Type   string `xml:"t,attr"`
+	Value  string `xml:"v"`
+	Inline struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"is"`
+}
+
+type xlsxSharedString struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (s xlsxSharedString) value() string {
+	if len(s.Runs) == 0 {
+		return s.Text
+	}
+	var out strings.Builder
+	out.WriteString(s.Text)
+	for _, run := range s.Runs {
+		out.WriteString(run.Text)
+	}
+	return out.String()
+}
+
+// streamXLSXRows calls onRow for every row of the workbook's first sheet.
+// Only the shared-strings table is held in memory; worksheet rows are decoded
+// one at a time so multi-hundred-megabyte disclosure files stay cheap to read.
+func streamXLSXRows(filePath string, onRow func(row []string) error) error {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return fmt.Errorf("open xlsx: %w", err)
+	}
+	defer archive.Close()
+
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+	shared, err := readXLSXSharedStrings(files["xl/sharedStrings.xml"])
+	if err != nil {
+		return err
+	}
+	sheet := files[firstXLSXSheetPath(files)]
+	if sheet == nil {
+		return fmt.Errorf("xlsx has no worksheet")
+	}
+	reader, err := sheet.Open()
+	if err != nil {
+		return fmt.Errorf("open worksheet: %w", err)
+	}
+	defer reader.Close()
+
+	decoder := xml.NewDecoder(reader)
+	var row []string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read worksheet: %w", err)
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "row":
+				row = row[:0]
+			case "c":
+				var cell xlsxCell
+				if err := decoder.DecodeElement(&cell, &element); err != nil {
+					return fmt.Errorf("read worksheet cell: %w", err)
+				}
+				column := len(row)
+				if cell.Ref != "" {
+					column = xlsxColumnIndex(cell.Ref)
+				}
+				for len(row) <= column {
+					row = append(row, "")
+				}
+				row[column] = xlsxCellValue(cell, shared)
+			}
+		case xml.EndElement:
+			if element.Name.Local == "row" {
+				if err := onRow(append([]string(nil), row...)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func readXLSXSharedStrings(file *zip.File) ([]string, error) {
+	if file == nil {
+		return nil, nil
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open shared strings: %w", err)
+	}
+	defer reader.Close()
+	decoder := xml.NewDecoder(reader)
+	out := []string{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read shared strings: %w", err)
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "si" {
+			continue
+		}
+		var item xlsxSharedString
+		if err := decoder.DecodeElement(&item, &element); err != nil {
+			return nil, fmt.Errorf("read shared string: %w", err)
+		}
+		out = append(out, item.value())
+	}
+}
+
+// firstXLSXSheetPath follows workbook.xml and its relationships to the first
+// sheet, falling back to the conventional sheet1.xml location.
+func firstXLSXSheetPath(files map[string]*zip.File) string {
+	const fallback = "xl/worksheets/sheet1.xml"
+	var workbook struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Items []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if decodeXLSXPart(files["xl/workbook.xml"], &workbook) != nil || len(workbook.Sheets) == 0 {
+		return fallback
+	}
+	if decodeXLSXPart(files["xl/_rels/workbook.xml.rels"], &rels) != nil {
+		return fallback
+	}
+	for _, rel := range rels.Items {
+		if rel.ID != workbook.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join("xl", rel.Target)
+	}
+	return fallback
+}
+
+func decodeXLSXPart(file *zip.File, target any) error {
+	if file == nil {
+		return fmt.Errorf("missing xlsx part")
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return xml.NewDecoder(reader).Decode(target)
+}
+
+func xlsxCellValue(cell xlsxCell, shared []string) string {
+	switch cell.Type {
+	case "s":
+		index, err := strconv.Atoi(strings.TrimSpace(cell.Value))
+		if err != nil || index < 0 || index >= len(shared) {
+			return ""
+		}
+		return shared[index]
+	case "inlineStr":
+		return xlsxSharedString{Text: cell.Inline.Text, Runs: cell.Inline.Runs}.value()
+	default:
+		return cell.Value
+	}
+}
+
+// xlsxColumnIndex converts a cell reference such as "AB12" to a zero-based
+// column index.
+func xlsxColumnIndex(ref string) int {
+	index := 0
+	for _, char := range strings.ToUpper(ref) {
+		if char < 'A' || char > 'Z' {
+			break
+		}
+		index = index*26 + int(char-'A'+1)
+	}
+	return max(index-1, 0)
+}
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeTestXLSX writes a minimal workbook: the header row uses the shared
+// strings table and data rows use inline strings.
+func writeTestXLSX(t *testing.T, path string, rows [][]string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create xlsx: %v", err)
+	}
+	defer file.Close()
+	archive := zip.NewWriter(file)
+	write := func(name, body string) {
+		part, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if _, err := part.Write([]byte(body)); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	write("xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Data" sheetId="1" r:id="rId7"/></sheets></workbook>`)
+	write("xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId7" Target="worksheets/data.xml"/></Relationships>`)
+	shared := strings.Builder{}
+	for _, value := range rows[0] {
+		fmt.Fprintf(&shared, "<si><t>%s</t></si>", value)
+	}
+	write("xl/sharedStrings.xml", "<sst>"+shared.String()+"</sst>")
+	sheet := strings.Builder{}
+	sheet.WriteString("<worksheet><sheetData>")
+	for r, row := range rows {
+		fmt.Fprintf(&sheet, `<row r="%d">`, r+1)
+		for c, value := range row {
+			if value == "" {
+				continue
+			}
+			ref := fmt.Sprintf("%c%d", 'A'+c, r+1)
+			if r == 0 {
+				fmt.Fprintf(&sheet, `<c r="%s" t="s"><v>%d</v></c>`, ref, c)
+			} else {
+				fmt.Fprintf(&sheet, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, value)
+			}
+		}
+		sheet.WriteString("</row>")
+	}
+	sheet.WriteString("</sheetData></worksheet>")
+	write("xl/worksheets/data.xml", sheet.String())
+	if err := archive.Close(); err != nil {
+		t.Fatalf("close xlsx: %v", err)
+	}
+}
+
+func TestStreamXLSXRowsFollowsWorkbookAndCellRefs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sample.xlsx")
+	writeTestXLSX(t, path, [][]string{
+		{"EMPLOYER_NAME", "VISA_CLASS", "EMAIL"},
+		{"Acme Inc", "", "hr@acme.com"},
+	})
+	rows := [][]string{}
+	if err := streamXLSXRows(path, func(row []string) error {
+		rows = append(rows, row)
+		return nil
+	}); err != nil {
+		t.Fatalf("streamXLSXRows failed: %v", err)
+	}
+	want := [][]string{{"EMPLOYER_NAME", "VISA_CLASS", "EMAIL"}, {"Acme Inc", "", "hr@acme.com"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("unexpected rows %#v", rows)
+	}
+	if xlsxColumnIndex("AB12") != 27 || xlsxColumnIndex("A1") != 0 {
+		t.Fatalf("unexpected column index conversion")
+	}
+}
@@ -16,22 +16,71 @@ const (
 )
 
 var visaTypeAliases = map[string]string{
-	"h1b":                  "h1b",
-	"h-1b":                 "h1b",
-	"h1b1_chile":           "h1b1_chile",
-	"h-1b1 chile":          "h1b1_chile",
-	"h1b1 chile":           "h1b1_chile",
-	"h1b1_chile/singapore": "h1b1_chile",
-	"h1b1_singapore":       "h1b1_singapore",
-	"h-1b1 singapore":      "h1b1_singapore",
-	"h1b1 singapore":       "h1b1_singapore",
-	"e3":                   "e3_australian",
-	"e-3":                  "e3_australian",
-	"e3_australian":        "e3_australian",
-	"e-3 australian":       "e3_australian",
-	"green_card":           "green_card",
-	"green card":           "green_card",
-	"perm":                 "green_card",
+	"h1b":                    "h1b",
+	"h-1b":                   "h1b",
+	"h1b1_chile":             "h1b1_chile",
+	"h-1b1 chile":            "h1b1_chile",
+	"h1b1 chile":             "h1b1_chile",
+	"h1b1_chile/singapore":   "h1b1_chile",
+	"h1b1_singapore":         "h1b1_singapore",
+	"h-1b1 singapore":        "h1b1_singapore",
+	"h1b1 singapore":         "h1b1_singapore",
+	"e3":                     "e3_australian",
+	"e-3":                    "e3_australian",
+	"e3_australian":          "e3_australian",
+	"e-3 australian":         "e3_australian",
+	"green_card":             "green_card",
+	"green card":             "green_card",
+	"perm":                   "green_card",
+	"skilled_worker_uk":      "skilled_worker_uk",
+	"uk skilled worker":      "skilled_worker_uk",
+	"skilled worker":         "skilled_worker_uk",
+	"tier 2":                 "skilled_worker_uk",
+	"au_482":                 "au_482",
+	"482":                    "au_482",
+	"subclass 482":           "au_482",
+	"tss":                    "au_482",
+	"skills in demand":       "au_482",
+	"au_186":                 "au_186",
+	"186":                    "au_186",
+	"subclass 186":           "au_186",
+	"ens":                    "au_186",
+	"ca_lmia":                "ca_lmia",
+	"lmia":                   "ca_lmia",
+	"canada work permit":     "ca_lmia",
+	"ca_lmia_pr":             "ca_lmia_pr",
+	"lmia pr":                "ca_lmia_pr",
+	"express entry":          "ca_lmia_pr",
+	"tn":                     "tn",
+	"tn visa":                "tn",
+	"tn status":              "tn",
+	"tn-1":                   "tn",
+	"tn-2":                   "tn",
+	"usmca":                  "tn",
+	"nafta":                  "tn",
+	"o1":                     "o1",
+	"o-1":                    "o1",
+	"o1a":                    "o1",
+	"o-1a":                   "o1",
+	"o1 visa":                "o1",
+	"o-1 visa":               "o1",
+	"extraordinary ability":  "o1",
+	"f1_opt":                 "f1_opt",
+	"opt":                    "f1_opt",
+	"f-1 opt":                "f1_opt",
+	"f1 opt":                 "f1_opt",
+	"cpt":                    "f1_opt",
+	"stem_opt":               "stem_opt",
+	"stem opt":               "stem_opt",
+	"stem opt extension":     "stem_opt",
+	"l1":                     "l1",
+	"l-1":                    "l1",
+	"l1a":                    "l1",
+	"l-1a":                   "l1",
+	"l1b":                    "l1",
+	"l-1b":                   "l1",
+	"intracompany transfer":  "l1",
+	"intra-company transfer": "l1",
 }
 
 var supportedWorkModes = map[string]struct{}{
@@ -204,20 +253,10 @@ func SetUserPreferences(args map[string]any) (map[string]any, error) {
 	if uid == "" {
 		return nil, fmt.Errorf("user_id is required")
 	}
-	visaTypes := getStringList(args, "preferred_visa_types")
-	normalizedSet := map[string]struct{}{}
-	for _, value := range visaTypes {
-		normalized, err := normalizeVisaType(value)
-		if err != nil {
-			return nil, err
-		}
-		normalizedSet[normalized] = struct{}{}
-	}
-	normalizedTypes := make([]string, 0, len(normalizedSet))
-	for value := range normalizedSet {
-		normalizedTypes = append(normalizedTypes, value)
+	normalizedTypes, err := normalizeVisaTypeList(getStringList(args, "preferred_visa_types"))
+	if err != nil {
+		return nil, err
 	}
-	slices.Sort(normalizedTypes)
 
 	prefs, err := loadPrefs()
 	if err != nil {
@@ -228,6 +267,13 @@ func SetUserPreferences(args map[string]any) (map[string]any, error) {
 		user = map[string]any{}
 	}
 	user["preferred_visa_types"] = normalizedTypes
+	if raw, has := args["visa_type_weights"]; has && raw != nil {
+		weights, err := normalizeVisaTypeWeights(raw)
+		if err != nil {
+			return nil, err
+		}
+		user["visa_type_weights"] = weights
+	}
 	prefs[uid] = user
 	if err := savePrefs(prefs); err != nil {
 		return nil, err
@@ -358,18 +404,21 @@ func getOptionalUserVisaTypes(userID string) ([]string, error) {
 	if len(rawTypes) == 0 {
 		return []string{}, nil
 	}
-	normalizedSet := map[string]struct{}{}
-	for _, raw := range rawTypes {
-		normalized, err := normalizeVisaType(raw)
+	return normalizeVisaTypeList(rawTypes)
+}
+
+// normalizeVisaTypeList keeps the caller's order, which is the user's visa
+// priority, and drops repeats.
+func normalizeVisaTypeList(values []string) ([]string, error) {
+	normalized := []string{}
+	for _, raw := range values {
+		visa, err := normalizeVisaType(raw)
 		if err != nil {
 			return nil, err
 		}
-		normalizedSet[normalized] = struct{}{}
-	}
-	normalized := make([]string, 0, len(normalizedSet))
-	for key := range normalizedSet {
-		normalized = append(normalized, key)
+		if !slices.Contains(normalized, visa) {
+			normalized = append(normalized, visa)
+		}
 	}
-	slices.Sort(normalized)
 	return normalized, nil
 }
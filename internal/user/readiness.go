@@ -52,7 +52,7 @@ func datasetFreshness(datasetPath, manifestPath string) map[string]any {
 
 	datasetExists := false
 	var fileTime time.Time
-	if info, err := os.Stat(datasetPath); err == nil {
+	if info, err := os.Stat(primaryDatasetPath(datasetPath)); err == nil {
 		datasetExists = true
 		fileTime = info.ModTime().UTC()
 	}
@@ -77,7 +77,7 @@ func datasetFreshness(datasetPath, manifestPath string) map[string]any {
 		}
 		ageSeconds = seconds
 		daysSinceRefresh = seconds / 86400.0
-		isStale = (seconds / 86400.0) >= 30.0
+		isStale = (seconds / 86400.0) >= float64(datasetStaleDays())
 	}
 
 	lastUpdated := any(nil)
@@ -111,7 +111,7 @@ func GetUserReadiness(args map[string]any) (map[string]any, error) {
 		return nil, errRequired("user_id")
 	}
 
-	datasetPath := datasetPathOrDefault(getString(args, "dataset_path"))
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
 	manifestPath := getString(args, "manifest_path")
 	if manifestPath == "" {
 		manifestPath = envOrDefault("VISA_DOL_MANIFEST_PATH", defaultManifestPath)
@@ -165,7 +165,7 @@ func GetUserReadiness(args map[string]any) (map[string]any, error) {
 	}
 
 	datasetExists := false
-	if _, err := os.Stat(datasetPath); err == nil {
+	if _, err := os.Stat(primaryDatasetPath(datasetPath)); err == nil {
 		datasetExists = true
 	}
 
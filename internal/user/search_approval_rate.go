@@ -0,0 +1,49 @@
+package user
+
+import "math"
+
+const (
+	// approvalRateMinFilings keeps small employers from being penalized on a
+	// handful of withdrawn filings.
+	approvalRateMinFilings = 10
+	// Approval rates at or above approvalRateFullCredit keep the full
+	// dataset signal; lower rates scale it down to approvalRateMinFactor.
+	approvalRateFullCredit = 0.9
+	approvalRateMinFactor  = 0.5
+)
+
+// approvalRate is the share of an employer's H-1B LCA filings that were not
+// denied or withdrawn. It is unknown when the dataset has no outcome
+// columns or the employer has no H-1B filings.
+func approvalRate(record companyDatasetRecord) (float64, bool) {
+	if !record.HasOutcomes || record.H1B == 0 {
+		return 0, false
+	}
+	approved := record.H1B - record.H1BDenied - record.H1BWithdrawn
+	return math.Max(0, float64(approved)/float64(record.H1B)), true
+}
+
+// approvalRateFactor scales the dataset part of confidence_score for
+// employers that sponsor a lot but see many denials or withdrawals.
+func approvalRateFactor(record companyDatasetRecord) float64 {
+	rate, ok := approvalRate(record)
+	if !ok || record.H1B < approvalRateMinFilings || rate >= approvalRateFullCredit {
+		return 1
+	}
+	return math.Max(approvalRateMinFactor, rate/approvalRateFullCredit)
+}
+
+// visaCountsWithApproval adds approval_rate and the outcome counts to the
+// visa_counts output when the dataset carries them.
+func visaCountsWithApproval(counts map[string]int, record companyDatasetRecord) map[string]any {
+	out := map[string]any{}
+	for key, value := range counts {
+		out[key] = value
+	}
+	if rate, ok := approvalRate(record); ok {
+		out["approval_rate"] = math.Round(rate*1000) / 1000
+		out["h1b_denied"] = record.H1BDenied
+		out["h1b_withdrawn"] = record.H1BWithdrawn
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApprovalRateFactorPenalizesDenials(t *testing.T) {
+	unknown := companyDatasetRecord{H1B: 100}
+	if _, ok := approvalRate(unknown); ok || approvalRateFactor(unknown) != 1 {
+		t.Fatalf("expected no approval rate without outcome columns")
+	}
+	clean := companyDatasetRecord{H1B: 100, H1BDenied: 5, HasOutcomes: true}
+	if approvalRateFactor(clean) != 1 {
+		t.Fatalf("expected a 95%% approval rate to keep full credit")
+	}
+	denied := companyDatasetRecord{H1B: 100, H1BDenied: 30, H1BWithdrawn: 15, HasOutcomes: true}
+	if rate, _ := approvalRate(denied); rate != 0.55 {
+		t.Fatalf("expected a 0.55 approval rate, got %v", rate)
+	}
+	if factor := approvalRateFactor(denied); factor < 0.61 || factor > 0.62 {
+		t.Fatalf("expected the factor to scale by rate/0.9, got %v", factor)
+	}
+	if factor := approvalRateFactor(companyDatasetRecord{H1B: 100, H1BDenied: 90, HasOutcomes: true}); factor != approvalRateMinFactor {
+		t.Fatalf("expected the factor floor, got %v", factor)
+	}
+	if small := (companyDatasetRecord{H1B: 4, H1BWithdrawn: 3, HasOutcomes: true}); approvalRateFactor(small) != 1 {
+		t.Fatalf("expected small employers to be left alone")
+	}
+	counts := visaCountsWithApproval(visaCountsFromRecord(denied), denied)
+	if counts["approval_rate"] != 0.55 || counts["h1b_denied"] != 30 {
+		t.Fatalf("expected approval_rate in visa_counts, got %#v", counts)
+	}
+}
+
+func TestRunInternalDolPipelineWritesLCAOutcomes(t *testing.T) {
+	dir := t.TempDir()
+	lcaPath := filepath.Join(dir, "lca.csv")
+	lcaBody := strings.Join([]string{
+		"EMPLOYER_NAME,VISA_CLASS,CASE_STATUS",
+		"Acme Inc,H-1B,Certified",
+		"Acme Inc,H-1B,Denied",
+		"Acme Inc,H-1B,Certified - Withdrawn",
+		"Acme Inc,H-1B,Withdrawn",
+		"Acme Inc,E-3 Australian,Denied",
+	}, "\n")
+	if err := os.WriteFile(lcaPath, []byte(lcaBody), 0o644); err != nil {
+		t.Fatalf("write lca: %v", err)
+	}
+	permPath := filepath.Join(dir, "perm.csv")
+	if err := os.WriteFile(permPath, []byte("EMPLOYER_NAME,CASE_STATUS\nAcme Inc,Denied\n"), 0o644); err != nil {
+		t.Fatalf("write perm: %v", err)
+	}
+	datasetPath := filepath.Join(dir, "companies.csv")
+	if _, err := RunInternalDolPipeline(map[string]any{
+		"lca_source":        lcaPath,
+		"perm_source":       permPath,
+		"dataset_path":      datasetPath,
+		"manifest_path":     filepath.Join(dir, "last_run.json"),
+		"strict_validation": false,
+	}); err != nil {
+		t.Fatalf("RunInternalDolPipeline failed: %v", err)
+	}
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("load dataset: %v", err)
+	}
+	acme := dataset.ByNormalizedCompany["acme"]
+	if !acme.HasOutcomes || acme.H1B != 4 || acme.H1BDenied != 1 || acme.H1BWithdrawn != 2 {
+		t.Fatalf("expected H-1B outcomes only, got %+v", acme)
+	}
+	if rate, _ := approvalRate(acme); rate != 0.25 {
+		t.Fatalf("expected a 0.25 approval rate, got %v", rate)
+	}
+}
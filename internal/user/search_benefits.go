@@ -0,0 +1,63 @@
+package user
+
+import (
+	"regexp"
+	"slices"
+)
+
+const (
+	benefitRelocation      = "relocation_assistance"
+	benefitVisaSponsorship = "visa_sponsorship"
+	benefitHealthInsurance = "health_insurance"
+	benefitDentalVision    = "dental_vision"
+	benefitRetirement      = "retirement_401k"
+	benefitSigningBonus    = "signing_bonus"
+	benefitEquity          = "equity"
+	benefitPaidTimeOff     = "paid_time_off"
+	benefitParentalLeave   = "parental_leave"
+	benefitTuition         = "tuition_reimbursement"
+)
+
+// benefitPatterns are checked in order, so benefits are always listed in the
+// same order regardless of where they appear in the description.
+var benefitPatterns = []struct {
+	Name    string
+	Pattern *regexp.Regexp
+}{
+	{benefitHealthInsurance, regexp.MustCompile(`(?i)\b(health|medical)\s+(insurance|coverage|benefits|plans?)\b`)},
+	{benefitDentalVision, regexp.MustCompile(`(?i)\bdental\b|\bvision\s+(insurance|coverage|plans?)\b`)},
+	{benefitRetirement, regexp.MustCompile(`(?i)\b401\s?\(?k\)?|\bretirement\s+(plan|savings|matching)\b|\bpension\b`)},
+	{benefitRelocation, regexp.MustCompile(`(?i)\breloc(ation|ate)\s+(assistance|support|package|stipend|bonus|benefits?)\b|\brelocation\s+(is\s+)?(provided|available|offered)\b`)},
+	{benefitVisaSponsorship, regexp.MustCompile(`(?i)\b(visa|immigration|h-?1b)\s+(sponsorship|support|assistance)\b`)},
+	{benefitSigningBonus, regexp.MustCompile(`(?i)\bsign(ing|-on|\s+on)\s+bonus\b`)},
+	{benefitEquity, regexp.MustCompile(`(?i)\b(equity|stock\s+options|rsus?)\b`)},
+	{benefitPaidTimeOff, regexp.MustCompile(`(?i)\b(paid\s+time\s+off|pto|unlimited\s+vacation|paid\s+vacation)\b`)},
+	{benefitParentalLeave, regexp.MustCompile(`(?i)\b(parental|maternity|paternity)\s+leave\b`)},
+	{benefitTuition, regexp.MustCompile(`(?i)\b(tuition|education)\s+(reimbursement|assistance)\b|\blearning\s+stipend\b`)},
+}
+
+// extractBenefits lists the perks a description mentions. Visa sponsorship
+// only counts as a benefit when the description has no negative sponsorship
+// language, so "no visa sponsorship" is not reported as a perk.
+func extractBenefits(description string) []string {
+	out := []string{}
+	if normalizeWhitespace(description) == "" {
+		return out
+	}
+	_, negative, _ := detectDescriptionSignals(description)
+	for _, benefit := range benefitPatterns {
+		if benefit.Name == benefitVisaSponsorship && negative {
+			continue
+		}
+		if benefit.Pattern.MatchString(description) {
+			out = append(out, benefit.Name)
+		}
+	}
+	return out
+}
+
+// hasMobilityBenefit reports relocation or sponsorship perks, which raise the
+// visa confidence score by benefits_weight.
+func hasMobilityBenefit(benefits []string) bool {
+	return slices.Contains(benefits, benefitRelocation) || slices.Contains(benefits, benefitVisaSponsorship)
+}
@@ -0,0 +1,48 @@
+package user
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractBenefits(t *testing.T) {
+	description := "We offer medical insurance, dental and a 401(k) match. Relocation assistance and a sign-on bonus are available. We provide H-1B sponsorship and generous PTO."
+	got := extractBenefits(description)
+	want := []string{
+		benefitHealthInsurance,
+		benefitDentalVision,
+		benefitRetirement,
+		benefitRelocation,
+		benefitVisaSponsorship,
+		benefitSigningBonus,
+		benefitPaidTimeOff,
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("extractBenefits() = %v, want %v", got, want)
+	}
+	if !hasMobilityBenefit(got) {
+		t.Fatalf("expected relocation/sponsorship to count as a mobility benefit")
+	}
+}
+
+func TestExtractBenefitsSkipsNegativeSponsorship(t *testing.T) {
+	got := extractBenefits("Health insurance included. No visa sponsorship is available for this role.")
+	if slices.Contains(got, benefitVisaSponsorship) || !slices.Contains(got, benefitHealthInsurance) {
+		t.Fatalf("expected health insurance without sponsorship, got %v", got)
+	}
+	if hasMobilityBenefit(got) {
+		t.Fatalf("expected no mobility benefit")
+	}
+	if got := extractBenefits(""); len(got) != 0 {
+		t.Fatalf("expected no benefits for empty description, got %v", got)
+	}
+}
+
+func TestMobilityBenefitRaisesConfidence(t *testing.T) {
+	weights := defaultRankingWeights
+	base := scoreConfidence(confidenceFeatures{TotalCount: 5, DescriptionPositive: true, DatasetFactor: 1}, weights).Score
+	boosted := scoreConfidence(confidenceFeatures{TotalCount: 5, DescriptionPositive: true, MobilityBenefit: true, DatasetFactor: 1}, weights).Score
+	if boosted <= base {
+		t.Fatalf("expected mobility benefit to raise confidence, got %v <= %v", boosted, base)
+	}
+}
@@ -0,0 +1,93 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	capExemptBasisDataset   = "dataset"
+	capExemptBasisHeuristic = "name_heuristic"
+)
+
+// capExemptNameRegex matches employer names of the kinds USCIS exempts from
+// the H-1B cap: higher-education institutions, nonprofit research
+// organizations, and hospitals or health systems affiliated with them.
+var capExemptNameRegex = regexp.MustCompile(`\b(university|universities|college|polytechnic|institute of technology|school of medicine|board of regents|regents of the|trustees of|hospital|hospitals|medical center|health system|health sciences|clinic|cancer center|research institute|research foundation|research center|national laboratory|national lab)\b`)
+
+// capExemptExcludeRegex drops obvious for-profit businesses that borrow
+// those words ("College Hunks Hauling Junk LLC", "Hospital Staffing Inc").
+var capExemptExcludeRegex = regexp.MustCompile(`\b(llc|l l c|llp|staffing|consulting|solutions|recruiting|software|technologies)\b`)
+
+// capExemptByName is the heuristic used when the dataset has no cap_exempt
+// value for an employer.
+func capExemptByName(company string) bool {
+	name := strings.ToLower(normalizeWhitespace(nonAlnumCompanyRegex.ReplaceAllString(company, " ")))
+	if name == "" || capExemptExcludeRegex.MatchString(name) {
+		return false
+	}
+	return capExemptNameRegex.MatchString(name)
+}
+
+// parseCapExemptColumn reads the dataset cap_exempt column, reporting
+// whether it held an explicit yes/no value.
+func parseCapExemptColumn(raw string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "true", "yes", "y", "1":
+		return true, true
+	case "false", "no", "n", "0":
+		return false, true
+	}
+	return false, false
+}
+
+// capExemptRecord resolves cap-exempt status for a dataset row: an explicit
+// cap_exempt value wins, otherwise the name heuristic applies.
+func capExemptRecord(companyName, raw string) (bool, string) {
+	if value, ok := parseCapExemptColumn(raw); ok {
+		return value, capExemptBasisDataset
+	}
+	if capExemptByName(companyName) {
+		return true, capExemptBasisHeuristic
+	}
+	return false, ""
+}
+
+// capExemptColumnValue is what run_internal_dol_pipeline writes to the
+// cap_exempt column; maintainers can set false to override a false positive.
+func capExemptColumnValue(companyName string) string {
+	if capExemptByName(companyName) {
+		return "true"
+	}
+	return ""
+}
+
+// jobCapExempt returns the status for a listing, falling back to the name
+// heuristic for companies that are not in the dataset.
+func jobCapExempt(record companyDatasetRecord, hasCompany bool, company string) (bool, string) {
+	if hasCompany {
+		return record.CapExempt, record.CapExemptBasis
+	}
+	if capExemptByName(company) {
+		return true, capExemptBasisHeuristic
+	}
+	return false, ""
+}
+
+func capExemptFact(basis string) string {
+	if basis == capExemptBasisDataset {
+		return "Marked H-1B cap-exempt in the sponsor dataset; petitions are not subject to the annual lottery."
+	}
+	return "Likely H-1B cap-exempt (university, nonprofit research organization, or affiliated hospital); confirm with the employer."
+}
+
+func parseCapExemptOptions(args map[string]any, query map[string]any) error {
+	if value, has, err := getOptionalBool(args, "cap_exempt_only"); has {
+		if err != nil {
+			return fmt.Errorf("cap_exempt_only must be a boolean when provided")
+		}
+		query["cap_exempt_only"] = value
+	}
+	return nil
+}
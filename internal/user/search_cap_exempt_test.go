@@ -0,0 +1,70 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCapExemptByName(t *testing.T) {
+	cases := map[string]bool{
+		"Leland Stanford Junior University":     true,
+		"Trustees of Boston University":         true,
+		"Massachusetts General Hospital":        true,
+		"Fred Hutchinson Cancer Center":         true,
+		"Lawrence Berkeley National Laboratory": true,
+		"Acme Inc":                              false,
+		"College Hunks Hauling Junk LLC":        false,
+		"University Staffing Partners":          false,
+	}
+	for company, want := range cases {
+		if got := capExemptByName(company); got != want {
+			t.Fatalf("capExemptByName(%q)=%v, want %v", company, got, want)
+		}
+	}
+	if exempt, basis := capExemptRecord("Acme Research Labs", "yes"); !exempt || basis != capExemptBasisDataset {
+		t.Fatalf("expected dataset column to mark cap-exempt, got %v %q", exempt, basis)
+	}
+	if exempt, basis := capExemptRecord("Mayo Clinic", "false"); exempt || basis != capExemptBasisDataset {
+		t.Fatalf("expected dataset column to override heuristic, got %v %q", exempt, basis)
+	}
+}
+
+func TestCapExemptOnlyKeepsCapExemptEmployers(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	body := strings.Join([]string{
+		"company_name,h1b,h1b1_chile,h1b1_singapore,e3_australian,green_card,cap_exempt",
+		"Acme Inc,10,0,0,0,0,",
+		"Stanford University,20,0,0,0,0,",
+		"Acme Research Foundation,5,0,0,0,0,false",
+	}, "\n")
+	if err := os.WriteFile(datasetPath, []byte(body), 0o644); err != nil {
+		t.Fatalf("write dataset: %v", err)
+	}
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/acme-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/stanford-1/", Title: "Software Engineer", Company: "Stanford University", Location: "Stanford, CA"},
+				{JobURL: "https://www.linkedin.com/jobs/view/foundation-1/", Title: "Software Engineer", Company: "Acme Research Foundation", Location: "New York, NY"},
+			},
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":         "u1",
+		"location":        "United States",
+		"job_title":       "Software Engineer",
+		"dataset_path":    datasetPath,
+		"results_wanted":  5,
+		"cap_exempt_only": true,
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 || getString(asMap(jobs[0]), "company") != "Stanford University" || asMap(jobs[0])["cap_exempt"] != true {
+		t.Fatalf("expected only the university job, got %#v", jobs)
+	}
+	if got := intOrZero(asMap(results["stats"])["cap_exempt_filtered_out"]); got != 2 {
+		t.Fatalf("expected cap_exempt_filtered_out=2, got %d", got)
+	}
+}
@@ -0,0 +1,152 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCircuitBreakerThreshold       = 5
+	defaultCircuitBreakerCooldownSeconds = 300
+	circuitClosed                        = "closed"
+	circuitOpen                          = "open"
+	circuitHalfOpen                      = "half_open"
+	searchErrorUpstreamUnavailable       = "upstream_unavailable"
+)
+
+var errUpstreamUnavailable = errors.New("linkedin upstream unavailable: circuit breaker is open after repeated failures")
+
+// circuitBreaker stops sending LinkedIn requests after a run of consecutive
+// failures. Once the cool-down passes, a single probe request is let through;
+// success closes the circuit and failure opens it for another cool-down.
+type circuitBreaker struct {
+	mu          sync.Mutex
+	failures    int
+	openedAt    time.Time
+	probing     bool
+	trips       int
+	lastFailure string
+	now         func() time.Time
+}
+
+var sharedCircuitBreaker = &circuitBreaker{now: utcNow}
+
+func circuitBreakerThreshold() int {
+	return envInt("VISA_CIRCUIT_BREAKER_THRESHOLD", defaultCircuitBreakerThreshold)
+}
+
+func circuitBreakerCooldown() time.Duration {
+	return time.Duration(max(envInt("VISA_CIRCUIT_BREAKER_COOLDOWN_SECONDS", defaultCircuitBreakerCooldownSeconds), 0)) * time.Second
+}
+
+func (b *circuitBreaker) stateLocked() string {
+	if b.openedAt.IsZero() {
+		return circuitClosed
+	}
+	if b.now().Sub(b.openedAt) < circuitBreakerCooldown() {
+		return circuitOpen
+	}
+	return circuitHalfOpen
+}
+
+// allow reports whether a request may go out. In half-open state only one
+// probe is admitted at a time.
+func (b *circuitBreaker) allow() bool {
+	if circuitBreakerThreshold() < 1 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.stateLocked() {
+	case circuitOpen:
+		return false
+	case circuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+	}
+	return true
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openedAt = time.Time{}
+	b.probing = false
+}
+
+func (b *circuitBreaker) recordFailure(reason string) {
+	threshold := circuitBreakerThreshold()
+	if threshold < 1 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.lastFailure = reason
+	if b.probing || (b.openedAt.IsZero() && b.failures >= threshold) {
+		b.openedAt = b.now()
+		b.trips++
+	}
+	b.probing = false
+}
+
+// release frees a half-open probe slot without judging upstream, used when
+// the probe was cancelled before it finished.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// retryAt returns when the circuit will admit a probe, or zero when closed.
+func (b *circuitBreaker) retryAt() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stateLocked() != circuitOpen {
+		return time.Time{}
+	}
+	return b.openedAt.Add(circuitBreakerCooldown())
+}
+
+func (b *circuitBreaker) snapshot() map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state := b.stateLocked()
+	out := map[string]any{
+		"state":                state,
+		"consecutive_failures": b.failures,
+		"threshold":            circuitBreakerThreshold(),
+		"cooldown_seconds":     int(circuitBreakerCooldown().Seconds()),
+		"trips":                b.trips,
+		"last_failure":         optionalString(b.lastFailure),
+		"retry_at_utc":         nil,
+	}
+	if state == circuitOpen {
+		out["retry_at_utc"] = toISO(b.openedAt.Add(circuitBreakerCooldown()))
+	}
+	return out
+}
+
+// upstreamUnavailableError reports an open circuit to a run that was about
+// to start, so queued runs fail fast instead of hammering LinkedIn.
+func upstreamUnavailableError() error {
+	retryAt := sharedCircuitBreaker.retryAt()
+	if retryAt.IsZero() {
+		return nil
+	}
+	return fmt.Errorf("%w; retry after %s", errUpstreamUnavailable, toISO(retryAt))
+}
+
+// circuitFailure reports whether a finished request should count against the
+// circuit: transport errors, exhausted rate-limit retries, blocks, and 5xx.
+func circuitFailure(status int, err error) bool {
+	if err != nil {
+		return !errors.Is(err, errSearchRunCancelled)
+	}
+	return status == 403 || status == 999 || status >= 500
+}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensProbesAndCloses(t *testing.T) {
+	t.Setenv("VISA_CIRCUIT_BREAKER_THRESHOLD", "2")
+	t.Setenv("VISA_CIRCUIT_BREAKER_COOLDOWN_SECONDS", "60")
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := &circuitBreaker{now: func() time.Time { return clock }}
+
+	breaker.recordFailure("status 503")
+	if !breaker.allow() {
+		t.Fatalf("expected circuit to stay closed below the threshold")
+	}
+	breaker.recordFailure("status 503")
+	if breaker.allow() || breaker.snapshot()["state"] != circuitOpen {
+		t.Fatalf("expected circuit to open at the threshold, got %v", breaker.snapshot())
+	}
+
+	clock = clock.Add(61 * time.Second)
+	if !breaker.allow() {
+		t.Fatalf("expected a half-open probe after the cool-down")
+	}
+	if breaker.allow() {
+		t.Fatalf("expected only one probe at a time")
+	}
+	breaker.recordFailure("status 403")
+	if breaker.allow() {
+		t.Fatalf("expected a failed probe to reopen the circuit")
+	}
+
+	clock = clock.Add(61 * time.Second)
+	if !breaker.allow() {
+		t.Fatalf("expected another probe after the second cool-down")
+	}
+	breaker.recordSuccess()
+	snapshot := breaker.snapshot()
+	if snapshot["state"] != circuitClosed || snapshot["trips"] != 2 || !breaker.allow() {
+		t.Fatalf("expected a successful probe to close the circuit, got %v", snapshot)
+	}
+}
+
+func TestCircuitFailureClassification(t *testing.T) {
+	if circuitFailure(200, nil) || circuitFailure(404, nil) || circuitFailure(0, errSearchRunCancelled) {
+		t.Fatalf("expected success, 404, and cancellation not to count as failures")
+	}
+	if !circuitFailure(503, nil) || !circuitFailure(999, nil) || !circuitFailure(0, errors.New("connection reset")) {
+		t.Fatalf("expected 5xx, 999, and transport errors to count as failures")
+	}
+}
+
+func TestOpenCircuitFailsRunFast(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_CIRCUIT_BREAKER_THRESHOLD", "1")
+	sharedCircuitBreaker.recordFailure("status 503")
+	t.Cleanup(sharedCircuitBreaker.recordSuccess)
+
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	client := &fakeLinkedInClient{pages: map[int][]linkedInJob{0: acmeListingPage(1, 1)}}
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient { return client }
+
+	started, err := StartVisaJobSearch(map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	})
+	if err != nil {
+		t.Fatalf("StartVisaJobSearch failed: %v", err)
+	}
+	status := waitForTerminalRunStatus(t, "u1", getString(started, "run_id"), 3*time.Second)
+	if getString(status, "status") != "failed" || getString(status, "error_code") != searchErrorUpstreamUnavailable {
+		t.Fatalf("expected upstream_unavailable failure, got %v / %v", status["status"], status["error_code"])
+	}
+	if !strings.Contains(getString(status, "error"), "retry after") || intOrZero(status["attempt_count"]) != 1 {
+		t.Fatalf("expected a single fast failure with a retry time, got %v", status)
+	}
+}
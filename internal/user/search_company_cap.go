@@ -0,0 +1,75 @@
+package user
+
+func companyGroupKey(job map[string]any) string {
+	key := normalizeCompanyName(getString(job, "company"))
+	if key == "" {
+		return getString(job, "job_url")
+	}
+	return key
+}
+
+func collapsedJobSummary(job map[string]any) map[string]any {
+	return map[string]any{
+		"result_id":        getString(job, "result_id"),
+		"job_url":          getString(job, "job_url"),
+		"title":            getString(job, "title"),
+		"location":         getString(job, "location"),
+		"date_posted":      job["date_posted"],
+		"confidence_score": job["confidence_score"],
+	}
+}
+
+func collapseJobsByCompany(jobs []map[string]any, maxPerCompany int) ([]map[string]any, int) {
+	return appendCollapsedJobs(nil, jobs, maxPerCompany)
+}
+
+// appendCollapsedJobs adds jobs to an already-collapsed visible list, counting
+// the jobs each company already shows or has grouped toward the cap.
+func appendCollapsedJobs(existing []map[string]any, jobs []map[string]any, maxPerCompany int) ([]map[string]any, int) {
+	if maxPerCompany < 1 {
+		return append(existing, jobs...), 0
+	}
+	visible := make([]map[string]any, 0, len(existing)+len(jobs))
+	leaderIndex := map[string]int{}
+	counts := map[string]int{}
+	collapsed := 0
+	for _, job := range existing {
+		key := companyGroupKey(job)
+		if _, ok := leaderIndex[key]; !ok {
+			leaderIndex[key] = len(visible)
+		}
+		counts[key] += 1 + intOrZero(mapOrNil(job["more_from_company"])["count"])
+		visible = append(visible, job)
+	}
+	for _, job := range jobs {
+		key := companyGroupKey(job)
+		counts[key]++
+		if counts[key] <= maxPerCompany {
+			if _, ok := leaderIndex[key]; !ok {
+				leaderIndex[key] = len(visible)
+			}
+			visible = append(visible, job)
+			continue
+		}
+		collapsed++
+		leader := visible[leaderIndex[key]]
+		group := mapOrNil(leader["more_from_company"])
+		if group == nil {
+			group = map[string]any{
+				"company": getString(job, "company"),
+				"count":   0,
+				"jobs":    []any{},
+			}
+		}
+		group["jobs"] = append(listOrEmpty(group["jobs"]), collapsedJobSummary(job))
+		group["count"] = intOrZero(group["count"]) + 1
+		leader["more_from_company"] = group
+	}
+	return visible, collapsed
+}
+
+func withinCompanyCap(counts map[string]int, company string, maxPerCompany int) bool {
+	key := normalizeCompanyName(company)
+	counts[key]++
+	return maxPerCompany < 1 || key == "" || counts[key] <= maxPerCompany
+}
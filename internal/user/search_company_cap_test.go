@@ -0,0 +1,111 @@
+package user
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func runFakeVisaSearch(t *testing.T, client *fakeLinkedInClient, args map[string]any) map[string]any {
+	t.Helper()
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() {
+		linkedInClientFactory = originalFactory
+	})
+	linkedInClientFactory = func() linkedInClient {
+		return client
+	}
+
+	started, err := StartVisaJobSearch(args)
+	if err != nil {
+		t.Fatalf("StartVisaJobSearch failed: %v", err)
+	}
+	runID := getString(started, "run_id")
+	userID := getString(args, "user_id")
+	finalStatus := waitForTerminalRunStatus(t, userID, runID, 3*time.Second)
+	if got := getString(finalStatus, "status"); got != "completed" {
+		t.Fatalf("expected completed status, got %q (%#v)", got, finalStatus)
+	}
+	results, err := GetVisaJobSearchResults(map[string]any{
+		"user_id": userID,
+		"run_id":  runID,
+	})
+	if err != nil {
+		t.Fatalf("GetVisaJobSearchResults failed: %v", err)
+	}
+	results["run_id"] = runID
+	return results
+}
+
+func TestMaxResultsPerCompanyCollapsesOverflow(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	rows := []linkedInJob{}
+	for idx := 0; idx < 4; idx++ {
+		rows = append(rows, linkedInJob{
+			JobURL:   fmt.Sprintf("https://www.linkedin.com/jobs/view/acme-%d/", idx+1),
+			Title:    "Software Engineer",
+			Company:  "Acme Inc",
+			Location: "New York, NY",
+		})
+	}
+	rows = append(rows, linkedInJob{
+		JobURL:   "https://www.linkedin.com/jobs/view/beta-1/",
+		Title:    "Software Engineer",
+		Company:  "Beta LLC",
+		Location: "New York, NY",
+	})
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{0: rows},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/beta-1/": "We sponsor H-1B visas for this role.",
+		},
+	}
+
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":                 "u1",
+		"location":                "New York, NY",
+		"job_title":               "Software Engineer",
+		"dataset_path":            datasetPath,
+		"results_wanted":          2,
+		"max_returned":            10,
+		"max_results_per_company": 1,
+	})
+
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 visible jobs, got %d (%#v)", len(jobs), jobs)
+	}
+	leader := mapOrNil(jobs[0])
+	group := mapOrNil(leader["more_from_company"])
+	if group == nil || intOrZero(group["count"]) != 3 {
+		t.Fatalf("expected 3 collapsed Acme jobs, got %#v", leader["more_from_company"])
+	}
+	if got := intOrZero(asMap(results["stats"])["collapsed_by_company"]); got != 3 {
+		t.Fatalf("expected collapsed_by_company=3, got %d", got)
+	}
+
+	hidden := mapOrNil(listOrEmpty(group["jobs"])[0])
+	saved, err := SaveJobForLater(map[string]any{
+		"user_id":   "u1",
+		"result_id": getString(hidden, "result_id"),
+	})
+	if err != nil {
+		t.Fatalf("SaveJobForLater on collapsed result failed: %v", err)
+	}
+	if got := getString(saved, "action"); got != "saved_new" {
+		t.Fatalf("expected action=saved_new, got %q", got)
+	}
+
+	if _, err := StartVisaJobSearch(map[string]any{
+		"user_id":                 "u1",
+		"location":                "New York, NY",
+		"job_title":               "Software Engineer",
+		"max_results_per_company": 0,
+	}); err == nil {
+		t.Fatal("expected max_results_per_company=0 to fail")
+	}
+}
@@ -0,0 +1,96 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// companyFactVisaOrder lists the counted filing categories in the order facts
+// name them. ukSkilledWorkerKey is not among them: it marks a sponsor licence
+// rather than a number of filings.
+var companyFactVisaOrder = []struct {
+	Key   string
+	Label string
+}{
+	{Key: "h1b", Label: "H-1B"},
+	{Key: "h1b1_chile", Label: "H-1B1 (Chile)"},
+	{Key: "h1b1_singapore", Label: "H-1B1 (Singapore)"},
+	{Key: "e3_australian", Label: "E-3"},
+	{Key: "green_card", Label: "green card (PERM)"},
+	{Key: "l1", Label: "L-1 intracompany transfer petitions"},
+	{Key: "au_482", Label: "Australian 482"},
+	{Key: "au_186", Label: "Australian 186"},
+	{Key: "ca_lmia", Label: "Canadian LMIA work-permit positions"},
+	{Key: "ca_lmia_pr", Label: "Canadian LMIA permanent-residence positions"},
+}
+
+const ukSkilledWorkerKey = "skilled_worker_uk"
+
+// companyFacts summarizes a sponsor record in plain sentences. When the
+// dataset has per-year columns, the filing summary uses the newest fiscal
+// year and names it; categories without per-year columns, and datasets
+// without them, are reported as all-year totals.
+func companyFacts(company string, record companyDatasetRecord, latestYear int) []string {
+	counts := visaCountsFromRecord(record)
+	perYear := latestYear > 0 && len(record.FiscalYearCounts) > 0
+	latestParts, allYearParts := []string{}, []string{}
+	for _, visa := range companyFactVisaOrder {
+		if perYear && slices.Contains(fiscalYearVisas, visa.Key) {
+			if count := record.FiscalYearCounts[latestYear][visa.Key]; count > 0 {
+				latestParts = append(latestParts, fmt.Sprintf("%d %s", count, visa.Label))
+			}
+			continue
+		}
+		if counts[visa.Key] > 0 {
+			allYearParts = append(allYearParts, fmt.Sprintf("%d %s", counts[visa.Key], visa.Label))
+		}
+	}
+	name := strings.TrimSpace(company)
+	if name == "" {
+		name = record.CompanyName
+	}
+	facts := []string{}
+	switch {
+	case len(latestParts) > 0:
+		facts = append(facts, fmt.Sprintf("%s filed %s in fiscal year %d.", name, joinFactParts(latestParts), latestYear))
+	case perYear:
+		facts = append(facts, fmt.Sprintf("%s has no H-1B, E-3 or green card (PERM) filings recorded for fiscal year %d.", name, latestYear))
+	}
+	if len(allYearParts) > 0 {
+		facts = append(facts, fmt.Sprintf("%s filed %s across all years in the sponsor dataset.", name, joinFactParts(allYearParts)))
+	}
+	if counts[ukSkilledWorkerKey] > 0 {
+		facts = append(facts, fmt.Sprintf("%s holds a UK Skilled Worker sponsor licence.", name))
+	}
+	if len(facts) == 0 {
+		facts = append(facts, fmt.Sprintf("%s appears in the sponsor dataset with no recorded visa filings.", name))
+	}
+	if name != record.CompanyName && normalizeCompanyName(name) != normalizeCompanyName(record.CompanyName) {
+		facts = append(facts, fmt.Sprintf("Filings are recorded under the sponsoring entity %s.", record.CompanyName))
+	}
+	if record.TotalVisas > 0 && (perYear || len(allYearParts) > 1) {
+		facts = append(facts, fmt.Sprintf("%d total visa filings across all years and tracked categories.", record.TotalVisas))
+	}
+	if tier := strings.TrimSpace(record.CompanyTier); tier != "" && record.CompanyTierBasis == companyTierBasisDerived {
+		facts = append(facts, fmt.Sprintf("Sponsor tier (from filing volume, approval rate, and recency): %s.", tier))
+	} else if tier != "" {
+		facts = append(facts, fmt.Sprintf("Company size tier: %s.", tier))
+	}
+	if n := len(record.EmployerContacts); n > 0 {
+		facts = append(facts, fmt.Sprintf("%d immigration/recruiting contact(s) on file.", n))
+	}
+	return facts
+}
+
+func joinFactParts(parts []string) string {
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return parts[0]
+	case 2:
+		return parts[0] + " and " + parts[1]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
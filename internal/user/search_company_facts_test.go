@@ -0,0 +1,95 @@
+package user
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompanyFactsSummarizesVisaCounts(t *testing.T) {
+	record := companyDatasetRecord{
+		CompanyName:      "Acme Inc",
+		H1B:              142,
+		E3Australian:     3,
+		TotalVisas:       145,
+		CompanyTier:      "enterprise",
+		EmployerContacts: []map[string]any{{"name": "Alice"}},
+	}
+	facts := companyFacts("Acme", record, 0)
+	if len(facts) != 4 {
+		t.Fatalf("expected 4 facts, got %#v", facts)
+	}
+	if facts[0] != "Acme filed 142 H-1B and 3 E-3 across all years in the sponsor dataset." {
+		t.Fatalf("unexpected summary fact: %q", facts[0])
+	}
+	if !strings.Contains(facts[1], "145 total") || !strings.Contains(facts[2], "enterprise") || !strings.Contains(facts[3], "1 immigration") {
+		t.Fatalf("unexpected facts: %#v", facts)
+	}
+
+	empty := companyFacts("", companyDatasetRecord{CompanyName: "Beta LLC"}, 0)
+	if len(empty) != 1 || !strings.Contains(empty[0], "Beta LLC appears in the sponsor dataset with no recorded") {
+		t.Fatalf("unexpected zero-count facts: %#v", empty)
+	}
+}
+
+func TestCompanyFactsUseLatestFiscalYear(t *testing.T) {
+	record := companyDatasetRecord{
+		CompanyName: "Acme Inc",
+		H1B:         30,
+		L1:          4,
+		TotalVisas:  34,
+		FiscalYearCounts: map[int]map[string]int{
+			2023: {"h1b": 18},
+			2024: {"h1b": 12},
+		},
+		RegisterCounts: map[string]int{"skilled_worker_uk": 1},
+	}
+	facts := companyFacts("Acme", record, 2024)
+	if facts[0] != "Acme filed 12 H-1B in fiscal year 2024." {
+		t.Fatalf("expected latest-year summary, got %q", facts[0])
+	}
+	joined := strings.Join(facts, " ")
+	if !strings.Contains(joined, "4 L-1 intracompany transfer petitions across all years") {
+		t.Fatalf("expected categories without per-year columns as all-year totals, got %#v", facts)
+	}
+	if !strings.Contains(joined, "holds a UK Skilled Worker sponsor licence") || strings.Contains(joined, "1 UK Skilled Worker") {
+		t.Fatalf("expected the UK licence as a licence, not a filing count, got %#v", facts)
+	}
+	if !strings.Contains(joined, "34 total visa filings across all years") {
+		t.Fatalf("expected the all-year total to be labelled, got %#v", facts)
+	}
+
+	lapsed := companyFacts("Acme", companyDatasetRecord{CompanyName: "Acme Inc", H1B: 5, FiscalYearCounts: map[int]map[string]int{2022: {"h1b": 5}}}, 2024)
+	if lapsed[0] != "Acme has no H-1B, E-3 or green card (PERM) filings recorded for fiscal year 2024." {
+		t.Fatalf("expected no-filings fact for the latest year, got %#v", lapsed)
+	}
+}
+
+func TestSearchJobsCarryCompanyFacts(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY", JobURL: "https://www.linkedin.com/jobs/view/facts-1"},
+			},
+		},
+	}
+	result := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":        "u1",
+		"location":       "New York, NY",
+		"job_title":      "Software Engineer",
+		"dataset_path":   datasetPath,
+		"results_wanted": 1,
+	})
+	jobs := listOrEmpty(result["jobs"])
+	if len(jobs) != 1 {
+		t.Fatalf("expected one job, got %#v", result)
+	}
+	facts := listOrEmpty(asMap(jobs[0])["company_facts"])
+	if len(facts) == 0 || !strings.Contains(stringFromAny(facts[0]), "10 H-1B and 5 E-3") {
+		t.Fatalf("expected company_facts on job, got %#v", facts)
+	}
+}
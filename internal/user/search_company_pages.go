@@ -0,0 +1,209 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultCompanyPageCachePath     = "data/config/company_page_cache.json"
+	defaultMaxCompanyPageFetches    = 10
+	maxCompanyPageFetchesLimit      = 50
+	companyPageCacheTTL             = 14 * 24 * time.Hour
+	companyProfileSourceLinkedIn    = "linkedin_company_page"
+	companyProfileSourceLinkedInHit = "linkedin_company_page_cache"
+)
+
+var companyPageCacheMu sync.Mutex
+
+type linkedInCompanyPage struct {
+	EmployeeCount string
+	Headquarters  string
+	Industry      string
+}
+
+// companyPageFetcher is implemented by clients that can read public LinkedIn
+// company pages. Other clients skip enrichment.
+type companyPageFetcher interface {
+	FetchCompanyPage(companyURL string, isCancelled func() bool) (linkedInCompanyPage, error)
+}
+
+func companyPageCachePath() string {
+	return envOrDefault("VISA_COMPANY_PAGE_CACHE_PATH", defaultCompanyPageCachePath)
+}
+
+func parseCompanyPageOptions(args map[string]any, query map[string]any) error {
+	if value, has, err := getOptionalBool(args, "enrich_company_pages"); has {
+		if err != nil {
+			return fmt.Errorf("enrich_company_pages must be a boolean when provided")
+		}
+		query["enrich_company_pages"] = value
+	}
+	parsed, has, err := getOptionalInt(args, "max_company_page_fetches")
+	if !has {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("max_company_page_fetches must be an integer when provided")
+	}
+	if parsed < 1 || parsed > maxCompanyPageFetchesLimit {
+		return fmt.Errorf("max_company_page_fetches must be between 1 and %d", maxCompanyPageFetchesLimit)
+	}
+	query["max_company_page_fetches"] = parsed
+	return nil
+}
+
+func companyPageCacheKey(companyURL string) string {
+	return strings.ToLower(strings.TrimSuffix(stripQuery(companyURL), "/"))
+}
+
+// parseLinkedInCompanyPageHTML reads the "About us" block of a guest company
+// page. Labels are matched on the dt text when the data-test-id hooks are
+// missing.
+func parseLinkedInCompanyPageHTML(html string) linkedInCompanyPage {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return linkedInCompanyPage{}
+	}
+	page := linkedInCompanyPage{
+		EmployeeCount: normalizeWhitespace(doc.Find(`div[data-test-id="about-us__size"] dd`).First().Text()),
+		Headquarters:  normalizeWhitespace(doc.Find(`div[data-test-id="about-us__headquarters"] dd`).First().Text()),
+		Industry:      normalizeWhitespace(doc.Find(`div[data-test-id="about-us__industry"] dd`).First().Text()),
+	}
+	doc.Find("dt").Each(func(_ int, label *goquery.Selection) {
+		value := normalizeWhitespace(label.NextFiltered("dd").Text())
+		switch strings.ToLower(normalizeWhitespace(label.Text())) {
+		case "company size":
+			page.EmployeeCount = firstNonEmpty(page.EmployeeCount, value)
+		case "headquarters":
+			page.Headquarters = firstNonEmpty(page.Headquarters, value)
+		case "industry", "industries":
+			page.Industry = firstNonEmpty(page.Industry, value)
+		}
+	})
+	return page
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (p linkedInCompanyPage) empty() bool {
+	return p.EmployeeCount == "" && p.Headquarters == "" && p.Industry == ""
+}
+
+func (c *liveLinkedInClient) FetchCompanyPage(companyURL string, isCancelled func() bool) (linkedInCompanyPage, error) {
+	resp, err := c.request(func() (*resty.Response, error) {
+		return c.httpClient.R().SetHeaders(c.requestHeaders(false)).Get(stripQuery(companyURL))
+	}, isCancelled)
+	if err != nil {
+		return linkedInCompanyPage{}, err
+	}
+	if resp.StatusCode() >= 400 {
+		return linkedInCompanyPage{}, &upstreamStatusError{Status: resp.StatusCode()}
+	}
+	return parseLinkedInCompanyPageHTML(string(resp.Body())), nil
+}
+
+func loadCachedCompanyPage(key string) (linkedInCompanyPage, bool) {
+	companyPageCacheMu.Lock()
+	defer companyPageCacheMu.Unlock()
+	data := loadJSONMap(companyPageCachePath(), map[string]any{"entries": map[string]any{}})
+	entry := mapOrNil(asMap(data["entries"])[key])
+	fetchedAt := parseISOTime(entry["fetched_at_utc"])
+	if entry == nil || fetchedAt.IsZero() || utcNow().Sub(fetchedAt) > companyPageCacheTTL {
+		return linkedInCompanyPage{}, false
+	}
+	return linkedInCompanyPage{
+		EmployeeCount: getString(entry, "employee_count"),
+		Headquarters:  getString(entry, "headquarters"),
+		Industry:      getString(entry, "industry"),
+	}, true
+}
+
+func storeCachedCompanyPages(pages map[string]linkedInCompanyPage) {
+	if len(pages) == 0 {
+		return
+	}
+	companyPageCacheMu.Lock()
+	defer companyPageCacheMu.Unlock()
+	data := loadJSONMap(companyPageCachePath(), map[string]any{"entries": map[string]any{}})
+	entries := asMap(data["entries"])
+	for key, page := range pages {
+		entries[key] = map[string]any{
+			"employee_count": page.EmployeeCount,
+			"headquarters":   page.Headquarters,
+			"industry":       page.Industry,
+			"fetched_at_utc": utcNowISO(),
+		}
+	}
+	data["entries"] = entries
+	_ = saveJSONMap(companyPageCachePath(), data)
+}
+
+// enrichCompanyPages attaches a company_profile to accepted jobs, reading
+// each company page at most once per run and at most maxFetches times in
+// total. Cached pages do not count against the budget.
+func enrichCompanyPages(client linkedInClient, accepted []map[string]any, maxFetches int, isCancelled func() bool) (int, int, error) {
+	if maxFetches < 1 {
+		maxFetches = defaultMaxCompanyPageFetches
+	}
+	fetcher, canFetch := client.(companyPageFetcher)
+	fetched, cacheHits := 0, 0
+	pages := map[string]*linkedInCompanyPage{}
+	sources := map[string]string{}
+	added := map[string]linkedInCompanyPage{}
+	for _, job := range accepted {
+		companyURL := getString(job, "company_url")
+		key := companyPageCacheKey(companyURL)
+		if key == "" {
+			continue
+		}
+		if _, seen := pages[key]; !seen {
+			pages[key] = nil
+			if page, ok := loadCachedCompanyPage(key); ok {
+				pages[key] = &page
+				sources[key] = companyProfileSourceLinkedInHit
+				cacheHits++
+			} else if canFetch && fetched < maxFetches {
+				page, err := fetcher.FetchCompanyPage(companyURL, isCancelled)
+				fetched++
+				if errors.Is(err, errSearchRunCancelled) {
+					return fetched, cacheHits, err
+				}
+				if err == nil && !page.empty() {
+					pages[key] = &page
+					sources[key] = companyProfileSourceLinkedIn
+					added[key] = page
+				}
+			}
+		}
+		page := pages[key]
+		if page == nil {
+			continue
+		}
+		job["company_profile"] = map[string]any{
+			"company_url":    stripQuery(companyURL),
+			"employee_count": optionalString(page.EmployeeCount),
+			"headquarters":   optionalString(page.Headquarters),
+			"industry":       optionalString(page.Industry),
+			"source":         sources[key],
+		}
+		if job["company_industry"] == nil && page.Industry != "" {
+			job["company_industry"] = page.Industry
+		}
+	}
+	storeCachedCompanyPages(added)
+	return fetched, cacheHits, nil
+}
@@ -0,0 +1,110 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeCompanyPageClient struct {
+	fakeLinkedInClient
+	companyPages map[string]linkedInCompanyPage
+	companyCalls []string
+}
+
+func (f *fakeCompanyPageClient) FetchCompanyPage(companyURL string, _ func() bool) (linkedInCompanyPage, error) {
+	f.companyCalls = append(f.companyCalls, companyURL)
+	page, ok := f.companyPages[companyURL]
+	if !ok {
+		return linkedInCompanyPage{}, errors.New("status 404")
+	}
+	return page, nil
+}
+
+func TestParseLinkedInCompanyPageHTML(t *testing.T) {
+	html := `<section><dl>
+		<div data-test-id="about-us__size"><dt>Company size</dt><dd> 1,001-5,000
+			employees </dd></div>
+		<div data-test-id="about-us__headquarters"><dt>Headquarters</dt><dd>San Francisco, CA</dd></div>
+		<div><dt>Industry</dt><dd>Software Development</dd></div>
+	</dl></section>`
+	page := parseLinkedInCompanyPageHTML(html)
+	if page.EmployeeCount != "1,001-5,000 employees" || page.Headquarters != "San Francisco, CA" || page.Industry != "Software Development" {
+		t.Fatalf("unexpected company page: %#v", page)
+	}
+	if !parseLinkedInCompanyPageHTML("<html></html>").empty() {
+		t.Fatalf("expected empty page for markup without an about block")
+	}
+}
+
+func TestParseLinkedInListHTMLCapturesCompanyURL(t *testing.T) {
+	html := `<div class="base-search-card">
+		<a class="base-card__full-link" href="https://www.linkedin.com/jobs/view/1?trk=x"></a>
+		<h3 class="base-search-card__title">Engineer</h3>
+		<h4 class="base-search-card__subtitle"><a href="https://www.linkedin.com/company/acme?trk=public_jobs">Acme Inc</a></h4>
+	</div>`
+	jobs, err := parseLinkedInListHTML(html)
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("expected one job, got %v (%v)", jobs, err)
+	}
+	if jobs[0].CompanyURL != "https://www.linkedin.com/company/acme" {
+		t.Fatalf("unexpected company url %q", jobs[0].CompanyURL)
+	}
+}
+
+func TestEnrichCompanyPagesRespectsBudgetAndCache(t *testing.T) {
+	setupUserToolPaths(t)
+	client := &fakeCompanyPageClient{companyPages: map[string]linkedInCompanyPage{
+		"https://www.linkedin.com/company/acme": {EmployeeCount: "51-200 employees", Headquarters: "Austin, TX", Industry: "Software Development"},
+		"https://www.linkedin.com/company/beta": {EmployeeCount: "11-50 employees"},
+	}}
+	accepted := []map[string]any{
+		{"company_url": "https://www.linkedin.com/company/acme", "company_industry": nil},
+		{"company_url": "https://www.linkedin.com/company/acme", "company_industry": "Fintech"},
+		{"company_url": "https://www.linkedin.com/company/beta"},
+		{"company_url": nil},
+	}
+	fetched, hits, err := enrichCompanyPages(client, accepted, 1, func() bool { return false })
+	if err != nil || fetched != 1 || hits != 0 {
+		t.Fatalf("expected one fetch within budget, got fetched=%d hits=%d err=%v", fetched, hits, err)
+	}
+	profile := asMap(accepted[0]["company_profile"])
+	if getString(profile, "headquarters") != "Austin, TX" || getString(profile, "source") != companyProfileSourceLinkedIn {
+		t.Fatalf("unexpected company profile: %v", profile)
+	}
+	if accepted[0]["company_industry"] != "Software Development" || accepted[1]["company_industry"] != "Fintech" {
+		t.Fatalf("expected industry fill only when missing: %v %v", accepted[0], accepted[1])
+	}
+	if accepted[2]["company_profile"] != nil || accepted[3]["company_profile"] != nil {
+		t.Fatalf("expected no profile beyond the budget or without a company url")
+	}
+
+	again := []map[string]any{{"company_url": "https://www.linkedin.com/company/acme/"}, {"company_url": "https://www.linkedin.com/company/beta"}}
+	fetched, hits, err = enrichCompanyPages(client, again, 1, func() bool { return false })
+	if err != nil || fetched != 1 || hits != 1 {
+		t.Fatalf("expected a cache hit plus one fetch, got fetched=%d hits=%d err=%v", fetched, hits, err)
+	}
+	if getString(asMap(again[0]["company_profile"]), "source") != companyProfileSourceLinkedInHit {
+		t.Fatalf("expected cached profile, got %v", again[0])
+	}
+	if getString(asMap(again[1]["company_profile"]), "employee_count") != "11-50 employees" {
+		t.Fatalf("expected beta profile, got %v", again[1])
+	}
+	if len(client.companyCalls) != 2 {
+		t.Fatalf("expected two company page requests, got %v", client.companyCalls)
+	}
+}
+
+func TestParseCompanyPageOptionsValidates(t *testing.T) {
+	query := map[string]any{}
+	if err := parseCompanyPageOptions(map[string]any{"enrich_company_pages": true, "max_company_page_fetches": 5}, query); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query["enrich_company_pages"] != true || query["max_company_page_fetches"] != 5 {
+		t.Fatalf("unexpected query options: %v", query)
+	}
+	err := parseCompanyPageOptions(map[string]any{"max_company_page_fetches": 0}, map[string]any{})
+	if err == nil || !strings.Contains(err.Error(), "between 1 and") {
+		t.Fatalf("expected range error, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package user
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	companyTierBasisDataset = "dataset"
+	companyTierBasisDerived = "derived"
+
+	companyTierHighVolumeRecent = "high_volume_recent"
+	companyTierHighVolume       = "high_volume"
+	companyTierRegular          = "regular"
+	companyTierOccasional       = "occasional"
+	companyTierLapsed           = "lapsed"
+	companyTierHighDenial       = "high_denial"
+
+	companyTierHighVolumeFilings = 100
+	companyTierRegularFilings    = 20
+	// companyTierRecentRecency is the sponsorshipRecency a high-volume
+	// sponsor needs to count as recent; below companyTierLapsedRecency most
+	// filings are several fiscal years old.
+	companyTierRecentRecency  = 0.8
+	companyTierLapsedRecency  = 0.5
+	companyTierHighDenialRate = 0.6
+	maxCompanyTierWeight      = 2.0
+)
+
+// derivedCompanyTiers lists the tiers derivedCompanyTier can return.
+var derivedCompanyTiers = []string{
+	companyTierHighVolumeRecent, companyTierHighVolume, companyTierRegular,
+	companyTierOccasional, companyTierLapsed, companyTierHighDenial,
+}
+
+// companyTierIsSourceMarker reports company_tier values that name where a
+// row came from ("dol" from run_internal_dol_pipeline, register names from
+// import_sponsor_register) rather than a tier.
+func companyTierIsSourceMarker(tier string) bool {
+	tier = strings.ToLower(strings.TrimSpace(tier))
+	return tier == "" || tier == "dol" || slices.Contains(sponsorRegisterNames(), tier)
+}
+
+// derivedCompanyTier classifies an employer from filing volume, H-1B
+// approval rate, and how recent its filings are.
+func derivedCompanyTier(record companyDatasetRecord, latestYear int) string {
+	recency := sponsorshipRecency(record, nil, latestYear)
+	if rate, ok := approvalRate(record); ok && record.H1B >= approvalRateMinFilings && rate < companyTierHighDenialRate {
+		return companyTierHighDenial
+	}
+	if recency < companyTierLapsedRecency {
+		return companyTierLapsed
+	}
+	switch {
+	case record.TotalVisas >= companyTierHighVolumeFilings && recency >= companyTierRecentRecency:
+		return companyTierHighVolumeRecent
+	case record.TotalVisas >= companyTierHighVolumeFilings:
+		return companyTierHighVolume
+	case record.TotalVisas >= companyTierRegularFilings:
+		return companyTierRegular
+	}
+	return companyTierOccasional
+}
+
+// resolveCompanyTier keeps a real company_tier from the dataset and derives
+// one otherwise.
+func resolveCompanyTier(record companyDatasetRecord, latestYear int) (string, string) {
+	if !companyTierIsSourceMarker(record.CompanyTier) {
+		return strings.TrimSpace(record.CompanyTier), companyTierBasisDataset
+	}
+	return derivedCompanyTier(record, latestYear), companyTierBasisDerived
+}
+
+func normalizeCompanyTier(raw string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(raw)), " ", "_")
+}
+
+// companyTierAllowed applies the company_tiers filter; companies outside the
+// dataset have no tier and are dropped when the filter is set.
+func companyTierAllowed(tiers []string, record companyDatasetRecord, hasCompany bool) bool {
+	if len(tiers) == 0 {
+		return true
+	}
+	return hasCompany && slices.Contains(tiers, normalizeCompanyTier(record.CompanyTier))
+}
+
+// companyTierFactor scales the dataset part of confidence_score by the
+// user's company_tier_weights.
+func companyTierFactor(weights map[string]float64, record companyDatasetRecord, hasCompany bool) float64 {
+	if !hasCompany {
+		return 1
+	}
+	if weight, ok := weights[normalizeCompanyTier(record.CompanyTier)]; ok {
+		return weight
+	}
+	return 1
+}
+
+func parseCompanyTierOptions(args map[string]any, query map[string]any) error {
+	if hasKey(args, "company_tiers") {
+		tiers := []string{}
+		for _, raw := range getStringList(args, "company_tiers") {
+			if tier := normalizeCompanyTier(raw); tier != "" && !slices.Contains(tiers, tier) {
+				tiers = append(tiers, tier)
+			}
+		}
+		query["company_tiers"] = tiers
+	}
+	if raw, has := args["company_tier_weights"]; has && raw != nil {
+		input, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("company_tier_weights must be an object of tier to weight")
+		}
+		weights := map[string]any{}
+		for tier, value := range input {
+			number, ok := value.(float64)
+			if !ok {
+				if asInt, isInt := value.(int); isInt {
+					number, ok = float64(asInt), true
+				}
+			}
+			if !ok || math.IsNaN(number) || number < 0 || number > maxCompanyTierWeight {
+				return fmt.Errorf("company_tier_weights.%s must be a number between 0 and %g", tier, maxCompanyTierWeight)
+			}
+			weights[normalizeCompanyTier(tier)] = number
+		}
+		query["company_tier_weights"] = weights
+	}
+	return nil
+}
+
+func companyTierWeightsFromQuery(queryMap map[string]any) map[string]float64 {
+	out := map[string]float64{}
+	for tier, value := range asMap(queryMap["company_tier_weights"]) {
+		out[tier] = floatOrZero(value)
+	}
+	return out
+}
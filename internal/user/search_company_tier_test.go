@@ -0,0 +1,104 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDerivedCompanyTierBranches(t *testing.T) {
+	cases := []struct {
+		name   string
+		record companyDatasetRecord
+		want   string
+	}{
+		{"high denial", companyDatasetRecord{H1B: 100, TotalVisas: 100, H1BDenied: 50, HasOutcomes: true}, companyTierHighDenial},
+		{"lapsed", companyDatasetRecord{H1B: 120, TotalVisas: 120, FiscalYearCounts: map[int]map[string]int{2019: {"h1b": 120}}}, companyTierLapsed},
+		{"high volume recent", companyDatasetRecord{H1B: 150, TotalVisas: 150}, companyTierHighVolumeRecent},
+		{"regular", companyDatasetRecord{H1B: 30, TotalVisas: 30}, companyTierRegular},
+		{"occasional", companyDatasetRecord{H1B: 3, TotalVisas: 3}, companyTierOccasional},
+	}
+	for _, tc := range cases {
+		if got := derivedCompanyTier(tc.record, 2025); got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+
+	if tier, basis := resolveCompanyTier(companyDatasetRecord{CompanyTier: "dol", TotalVisas: 30}, 0); tier != companyTierRegular || basis != companyTierBasisDerived {
+		t.Fatalf("expected the dol marker to be re-derived, got %q/%q", tier, basis)
+	}
+	if tier, basis := resolveCompanyTier(companyDatasetRecord{CompanyTier: "enterprise", TotalVisas: 3}, 0); tier != "enterprise" || basis != companyTierBasisDataset {
+		t.Fatalf("expected a dataset tier to be kept, got %q/%q", tier, basis)
+	}
+}
+
+func TestParseCompanyTierOptionsValidatesWeights(t *testing.T) {
+	query := map[string]any{}
+	if err := parseCompanyTierOptions(map[string]any{
+		"company_tiers":        []any{"High Volume Recent", "high_volume_recent"},
+		"company_tier_weights": map[string]any{"Lapsed": 0.5},
+	}, query); err != nil {
+		t.Fatalf("parseCompanyTierOptions failed: %v", err)
+	}
+	if tiers := getStringList(query, "company_tiers"); len(tiers) != 1 || tiers[0] != companyTierHighVolumeRecent {
+		t.Fatalf("expected one normalized tier, got %#v", tiers)
+	}
+	if weights := companyTierWeightsFromQuery(query); weights[companyTierLapsed] != 0.5 {
+		t.Fatalf("expected a normalized lapsed weight, got %#v", weights)
+	}
+	for _, raw := range []any{"heavy", map[string]any{"lapsed": 3.0}, map[string]any{"lapsed": "low"}} {
+		if err := parseCompanyTierOptions(map[string]any{"company_tier_weights": raw}, map[string]any{}); err == nil {
+			t.Fatalf("expected company_tier_weights=%#v to be rejected", raw)
+		}
+	}
+}
+
+func TestCompanyTiersFilterAndWeightSearch(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	body := strings.Join([]string{
+		"company_tier,company_name,h1b,h1b1_chile,h1b1_singapore,e3_australian,green_card",
+		"dol,Acme Inc,150,0,0,0,0",
+		"dol,Beta LLC,4,0,0,0,0",
+		"enterprise,Gamma Corp,40,0,0,0,0",
+	}, "\n")
+	if err := os.WriteFile(datasetPath, []byte(body), 0o644); err != nil {
+		t.Fatalf("write dataset: %v", err)
+	}
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/acme-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-1/", Title: "Software Engineer", Company: "Beta LLC", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/gamma-1/", Title: "Software Engineer", Company: "Gamma Corp", Location: "New York, NY"},
+			},
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":              "u1",
+		"location":             "United States",
+		"job_title":            "Software Engineer",
+		"dataset_path":         datasetPath,
+		"results_wanted":       5,
+		"preferred_visa_types": []any{"h1b"},
+		"company_tiers":        []any{"high_volume_recent", "enterprise"},
+		"company_tier_weights": map[string]any{"enterprise": 0},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 2 || intOrZero(asMap(results["stats"])["company_tier_filtered_out"]) != 1 {
+		t.Fatalf("expected Beta filtered out by tier, got %#v", results["stats"])
+	}
+	byCompany := map[string]map[string]any{}
+	for _, raw := range jobs {
+		job := asMap(raw)
+		byCompany[getString(job, "company")] = job
+	}
+	acme, gamma := byCompany["Acme Inc"], byCompany["Gamma Corp"]
+	if getString(acme, "company_tier") != companyTierHighVolumeRecent || getString(acme, "company_tier_basis") != companyTierBasisDerived {
+		t.Fatalf("expected a derived tier for Acme, got %#v", acme)
+	}
+	if getString(gamma, "company_tier_basis") != companyTierBasisDataset || floatOrZero(gamma["confidence_score"]) >= floatOrZero(acme["confidence_score"]) {
+		t.Fatalf("expected the zero enterprise weight to lower Gamma's confidence, got %#v", gamma)
+	}
+}
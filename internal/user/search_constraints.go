@@ -0,0 +1,108 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type searchConstraints struct {
+	WorkModes         []string
+	WillingToRelocate *bool
+	// DaysRemaining is the stored days_remaining counted down to today.
+	DaysRemaining *int
+}
+
+func (c searchConstraints) empty() bool {
+	return len(c.WorkModes) == 0 && c.WillingToRelocate == nil && c.DaysRemaining == nil
+}
+
+func (c searchConstraints) toMap() map[string]any {
+	var days any
+	if c.DaysRemaining != nil {
+		days = *c.DaysRemaining
+	}
+	return map[string]any{
+		"work_modes":          append([]string{}, c.WorkModes...),
+		"willing_to_relocate": optionalBool(c.WillingToRelocate),
+		"days_remaining":      days,
+	}
+}
+
+// loadSearchConstraints reads the constraints stored by set_user_constraints.
+// A missing or unreadable preferences file means no constraints.
+func loadSearchConstraints(userID string) searchConstraints {
+	out := searchConstraints{WorkModes: []string{}}
+	if strings.TrimSpace(userID) == "" {
+		return out
+	}
+	prefs, err := loadPrefs()
+	if err != nil {
+		return out
+	}
+	constraints := asMap(asMap(prefs[userID])["constraints"])
+	out.WorkModes = getStringList(constraints, "work_modes")
+	if relocate, ok := boolFromAny(constraints["willing_to_relocate"]); ok {
+		out.WillingToRelocate = boolPtr(relocate)
+	}
+	out.DaysRemaining = effectiveDaysRemaining(constraints, time.Now().UTC())
+	return out
+}
+
+func locationsOverlap(searchLocation, jobLocation string) bool {
+	area := strings.ToLower(normalizeWhitespace(strings.Split(searchLocation, ",")[0]))
+	if area == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(jobLocation), area)
+}
+
+// evaluateConstraints explains how a job fits the stored constraints.
+// Unknown workplace types never count as a mismatch.
+func evaluateConstraints(c searchConstraints, workplaceType, jobLocation, searchLocation string) ([]string, bool) {
+	effects := []string{}
+	mismatch := false
+	if len(c.WorkModes) > 0 && workplaceType != "" {
+		if slices.Contains(c.WorkModes, workplaceType) {
+			effects = append(effects, fmt.Sprintf("work_modes: %s matches preferred modes", workplaceType))
+		} else {
+			effects = append(effects, fmt.Sprintf("work_modes: %s is outside preferred %v", workplaceType, c.WorkModes))
+			mismatch = true
+		}
+	}
+	if c.WillingToRelocate != nil && !*c.WillingToRelocate && workplaceType != workplaceRemote {
+		if locationsOverlap(searchLocation, jobLocation) {
+			effects = append(effects, "willing_to_relocate: job is within the searched area")
+		} else {
+			effects = append(effects, fmt.Sprintf("willing_to_relocate: %q may require relocating", jobLocation))
+			mismatch = true
+		}
+	}
+	return effects, mismatch
+}
+
+// demoteConstraintMismatches keeps scan order but moves jobs that conflict
+// with soft constraints behind the ones that fit.
+func demoteConstraintMismatches(jobs []map[string]any) int {
+	demoted := 0
+	for _, job := range jobs {
+		if boolOrFalse(job["constraint_mismatch"]) {
+			demoted++
+		}
+	}
+	if demoted > 0 {
+		slices.SortStableFunc(jobs, func(a, b map[string]any) int {
+			aMismatch := boolOrFalse(a["constraint_mismatch"])
+			bMismatch := boolOrFalse(b["constraint_mismatch"])
+			switch {
+			case aMismatch == bMismatch:
+				return 0
+			case aMismatch:
+				return 1
+			}
+			return -1
+		})
+	}
+	return demoted
+}
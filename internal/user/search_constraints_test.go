@@ -0,0 +1,62 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func constraintTestClient() *fakeLinkedInClient {
+	return &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/sf-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "San Francisco, CA (On-site)"},
+				{JobURL: "https://www.linkedin.com/jobs/view/remote-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "United States (Remote)"},
+			},
+		},
+	}
+}
+
+func TestStoredConstraintsDemoteMismatchedJobs(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	if _, err := SetUserConstraints(map[string]any{
+		"user_id":             "u1",
+		"work_modes":          []any{"remote"},
+		"willing_to_relocate": false,
+	}); err != nil {
+		t.Fatalf("SetUserConstraints failed: %v", err)
+	}
+	args := map[string]any{
+		"user_id":        "u1",
+		"location":       "New York, NY",
+		"job_title":      "Software Engineer",
+		"dataset_path":   datasetPath,
+		"results_wanted": 5,
+	}
+
+	soft := runFakeVisaSearch(t, constraintTestClient(), args)
+	jobs := listOrEmpty(soft["jobs"])
+	if len(jobs) != 2 {
+		t.Fatalf("expected both jobs under soft constraints, got %d", len(jobs))
+	}
+	first := asMap(jobs[0])
+	if getString(first, "job_url") != "https://www.linkedin.com/jobs/view/remote-1/" || boolOrFalse(first["constraint_mismatch"]) {
+		t.Fatalf("expected remote job ranked first, got %#v", first)
+	}
+	if effects := getStringList(asMap(jobs[1]), "constraint_effects"); len(effects) != 2 {
+		t.Fatalf("expected work mode and relocation effects on onsite job, got %#v", effects)
+	}
+	if got := intOrZero(asMap(soft["stats"])["constraint_demoted"]); got != 1 {
+		t.Fatalf("expected constraint_demoted=1, got %d", got)
+	}
+
+	args["enforce_constraints"] = true
+	hard := runFakeVisaSearch(t, constraintTestClient(), args)
+	if got := len(listOrEmpty(hard["jobs"])); got != 1 {
+		t.Fatalf("expected enforce_constraints to drop the onsite job, got %d", got)
+	}
+	if got := intOrZero(asMap(hard["stats"])["constraint_filtered_out"]); got != 1 {
+		t.Fatalf("expected constraint_filtered_out=1, got %d", got)
+	}
+}
@@ -0,0 +1,161 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+)
+
+type searchContinuation struct {
+	SessionID    string
+	SeenURLs     map[string]struct{}
+	Resume       scanResume
+	PriorVisible int
+}
+
+// loadSearchContinuation returns the state needed to extend an existing
+// session, or nil when the query is a fresh search.
+func loadSearchContinuation(query searchQuery) (*searchContinuation, error) {
+	if query.ContinueSessionID == "" {
+		return nil, nil
+	}
+	session, err := loadSearchSessionForUser(query.ContinueSessionID, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]struct{}{}
+	for _, raw := range asMap(session["result_id_index"]) {
+		key := strings.ToLower(strings.TrimSpace(getString(mapOrNil(raw), "job_url")))
+		if key != "" {
+			seen[key] = struct{}{}
+		}
+	}
+	return &searchContinuation{
+		SessionID:    query.ContinueSessionID,
+		SeenURLs:     seen,
+		Resume:       scanResumeFromMap(asMap(session["scan_resume"])),
+		PriorVisible: len(listOrEmpty(session["accepted_jobs"])),
+	}, nil
+}
+
+// appendSearchSessionRecord adds newly accepted jobs to an existing session,
+// numbering result IDs after the ones already issued.
+func appendSearchSessionRecord(
+	continuation *searchContinuation,
+	query searchQuery,
+	acceptedJobs []map[string]any,
+	scanExhausted bool,
+	rawScanTarget int,
+	resume scanResume,
+) (map[string]any, error) {
+	sessionID := continuation.SessionID
+	var out map[string]any
+	err := withSearchSessionStore(true, func(store map[string]any) error {
+		sessions := mapOrNil(store["sessions"])
+		session := mapOrNil(sessions[sessionID])
+		if session == nil {
+			return fmt.Errorf("search session '%s' expired before the continuation finished", sessionID)
+		}
+		index := asMap(session["result_id_index"])
+		withIDs := make([]map[string]any, 0, len(acceptedJobs))
+		for idx, item := range acceptedJobs {
+			job := cloneMap(item)
+			job["result_id"] = fmt.Sprintf("%s:%d", sessionID, len(index)+idx+1)
+			withIDs = append(withIDs, job)
+		}
+		for resultID, entry := range buildResultIndex(withIDs) {
+			index[resultID] = entry
+		}
+		visible, collapsed := appendCollapsedJobs(sessionAcceptedJobs(session), withIDs, query.MaxResultsPerCompany)
+		acceptedList := make([]any, 0, len(visible))
+		for _, job := range visible {
+			acceptedList = append(acceptedList, job)
+		}
+		expiresAt := futureISO(searchSessionTTLSeconds())
+		session["accepted_jobs"] = acceptedList
+		session["result_id_index"] = index
+		session["accepted_jobs_total"] = len(visible)
+		session["collapsed_jobs_total"] = intOrZero(session["collapsed_jobs_total"]) + collapsed
+		session["latest_scan_target"] = rawScanTarget
+		session["scan_exhausted"] = scanExhausted
+		session["scan_resume"] = resume.toMap()
+		session["continuation_count"] = intOrZero(session["continuation_count"]) + 1
+		session["updated_at_utc"] = utcNowISO()
+		session["expires_at_utc"] = expiresAt
+		sessions[sessionID] = session
+		store["sessions"] = sessions
+		out = map[string]any{
+			"session_id":           sessionID,
+			"expires_at_utc":       expiresAt,
+			"accepted_jobs":        visible,
+			"result_id_index":      index,
+			"collapsed_jobs_total": session["collapsed_jobs_total"],
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func findRunForSession(sessionID, userID string) map[string]any {
+	var latest map[string]any
+	_ = withSearchRunStore(false, func(store map[string]any) error {
+		for _, raw := range mapOrNil(store["runs"]) {
+			run := mapOrNil(raw)
+			if run == nil || getString(run, "search_session_id") != sessionID || runUserID(run) != userID {
+				continue
+			}
+			if latest == nil || runCompletedAfter(run, latest) {
+				latest = cloneMap(run)
+			}
+		}
+		return nil
+	})
+	return latest
+}
+
+func ContinueJobSearch(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	sessionID, session, err := resolveResultSession(args)
+	if err != nil {
+		return nil, err
+	}
+	origin := findRunForSession(sessionID, userID)
+	if origin == nil {
+		return nil, fmt.Errorf("no search run found for session_id '%s'; it may have expired, start a new search instead", sessionID)
+	}
+	query := cloneMap(mapOrNil(origin["query"]))
+	if parsed, has, err := getOptionalInt(args, "results_wanted"); has {
+		if err != nil {
+			return nil, fmt.Errorf("results_wanted must be an integer when provided")
+		}
+		if parsed < 1 {
+			return nil, fmt.Errorf("results_wanted must be >= 1")
+		}
+		query["results_wanted"] = parsed
+	}
+	priority, err := normalizeSearchRunPriority(getString(args, "priority"))
+	if err != nil {
+		return nil, err
+	}
+	priorVisible := len(listOrEmpty(session["accepted_jobs"]))
+	query["continue_session_id"] = sessionID
+	query["offset"] = priorVisible
+
+	started, err := createSearchRun(
+		query,
+		priority,
+		searchToolNamesForMode(getString(query, "search_mode")),
+		"Continuing search from the previous scan position.",
+	)
+	if err != nil {
+		return nil, err
+	}
+	started["continued_session_id"] = sessionID
+	started["continued_from_run_id"] = getString(origin, "run_id")
+	started["prior_accepted_jobs"] = priorVisible
+	started["scan_resume"] = asMap(session["scan_resume"])
+	started["scan_previously_exhausted"] = boolOrFalse(session["scan_exhausted"])
+	return started, nil
+}
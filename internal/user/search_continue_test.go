@@ -0,0 +1,93 @@
+package user
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func acmeListingPage(from, count int) []linkedInJob {
+	rows := []linkedInJob{}
+	for idx := from; idx < from+count; idx++ {
+		rows = append(rows, linkedInJob{
+			JobURL:   fmt.Sprintf("https://www.linkedin.com/jobs/view/acme-%d/", idx),
+			Title:    "Software Engineer",
+			Company:  "Acme Inc",
+			Location: "New York, NY",
+		})
+	}
+	return rows
+}
+
+func TestContinueJobSearchAppendsToSameSession(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{pages: map[int][]linkedInJob{0: acmeListingPage(1, 2)}}
+	first := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":        "u1",
+		"location":       "New York, NY",
+		"job_title":      "Software Engineer",
+		"dataset_path":   datasetPath,
+		"results_wanted": 2,
+		"max_returned":   10,
+	})
+	sessionID := getString(asMap(asMap(first["status"])["search_session"]), "session_id")
+	if len(listOrEmpty(first["jobs"])) != 2 || sessionID == "" {
+		t.Fatalf("unexpected first run results: %#v", first)
+	}
+
+	// LinkedIn now has more listings after the ones already scanned.
+	client.pages[2] = acmeListingPage(3, 2)
+	started, err := ContinueJobSearch(map[string]any{
+		"user_id": "u1",
+		"run_id":  getString(first, "run_id"),
+	})
+	if err != nil {
+		t.Fatalf("ContinueJobSearch failed: %v", err)
+	}
+	if got := getString(started, "continued_session_id"); got != sessionID {
+		t.Fatalf("expected continued_session_id=%q, got %q", sessionID, got)
+	}
+	runID := getString(started, "run_id")
+	status := waitForTerminalRunStatus(t, "u1", runID, 3*time.Second)
+	if got := getString(status, "status"); got != "completed" {
+		t.Fatalf("expected continuation to complete, got %#v", status)
+	}
+
+	results, err := GetVisaJobSearchResults(map[string]any{"user_id": "u1", "run_id": runID})
+	if err != nil {
+		t.Fatalf("GetVisaJobSearchResults failed: %v", err)
+	}
+	if got := getString(asMap(asMap(results["status"])["search_session"]), "session_id"); got != sessionID {
+		t.Fatalf("expected same session %q, got %q", sessionID, got)
+	}
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 2 {
+		t.Fatalf("expected the default page to show 2 new jobs, got %#v", jobs)
+	}
+	for idx, raw := range jobs {
+		job := mapOrNil(raw)
+		if want := fmt.Sprintf("%s:%d", sessionID, idx+3); getString(job, "result_id") != want {
+			t.Fatalf("expected result_id %q, got %q", want, getString(job, "result_id"))
+		}
+		if !strings.Contains(getString(job, "job_url"), fmt.Sprintf("acme-%d", idx+3)) {
+			t.Fatalf("expected only unseen listings, got %q", getString(job, "job_url"))
+		}
+	}
+
+	session, err := loadSearchSessionForUser(sessionID, "u1")
+	if err != nil {
+		t.Fatalf("loadSearchSessionForUser failed: %v", err)
+	}
+	if got := len(listOrEmpty(session["accepted_jobs"])); got != 4 {
+		t.Fatalf("expected 4 accepted jobs in session, got %d", got)
+	}
+
+	if _, err := ContinueJobSearch(map[string]any{"user_id": "u1", "session_id": "missing"}); err == nil {
+		t.Fatal("expected unknown session to fail")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,6 +22,9 @@ type datasetCacheEntry struct {
 
 var datasetCache = map[string]datasetCacheEntry{}
 
+// maxDatasetKeyedCounts caps per-employer keyed count columns.
+const maxDatasetKeyedCounts = 20
+
 var datasetColumnAliases = map[string][]string{
 	"company_tier":    {"company_tier", "size"},
 	"company_name":    {"company_name", "employer"},
@@ -41,10 +45,20 @@ var datasetColumnAliases = map[string][]string{
 	"contact_3":       {"contact_3"},
 	"contact_3_title": {"contact_3_title"},
 	"contact_3_phone": {"contact_3_phone"},
+	"soc_counts":      {"soc_counts"},
+	"state_counts":    {"state_counts"},
+	"city_counts":     {"city_counts"},
+	"cap_exempt":      {"cap_exempt", "h1b_cap_exempt"},
+	"l1":              {"l1", "l-1", "l1_petitions"},
+	"h1b_denied":      {"h1b_denied"},
+	"h1b_withdrawn":   {"h1b_withdrawn"},
 }
 
 func datasetPathOrDefault(raw string) string {
 	path := strings.TrimSpace(raw)
+	if path == "" {
+		path = strings.TrimSpace(os.Getenv("VISA_COMPANY_DATASET_PATHS"))
+	}
 	if path == "" {
 		path = strings.TrimSpace(os.Getenv("VISA_COMPANY_DATASET_PATH"))
 	}
@@ -131,11 +145,14 @@ func buildContactsFromRow(row []string, idx map[string]int) []map[string]any {
 			"phone": phone,
 		})
 	}
-	return contacts
+	return cleanDatasetContacts(contacts)
 }
 
 func loadCompanyDataset(datasetPath string) (companyDataset, error) {
 	path := datasetPathOrDefault(datasetPath)
+	if paths := splitDatasetPaths(path); len(paths) > 1 {
+		return loadMergedCompanyDataset(paths)
+	}
 	info, err := os.Stat(path)
 	if err != nil {
 		return companyDataset{}, fmt.Errorf("dataset not found at '%s': %w", path, err)
@@ -145,6 +162,7 @@ func loadCompanyDataset(datasetPath string) (companyDataset, error) {
 	if cached, ok := datasetCache[path]; ok && cached.ModTime.Equal(info.ModTime().UTC()) {
 		data := cached.Data
 		datasetCacheMu.Unlock()
+		data.Aliases = loadCompanyAliases()
 		return data, nil
 	}
 	datasetCacheMu.Unlock()
@@ -167,9 +185,8 @@ func loadCompanyDataset(datasetPath string) (companyDataset, error) {
 	for canonical, aliases := range datasetColumnAliases {
 		canonicalIndex[canonical] = findColumnIndex(headerIndex, aliases)
 	}
-	required := []string{"company_name", "h1b", "h1b1_chile", "h1b1_singapore", "e3_australian", "green_card"}
 	missing := []string{}
-	for _, key := range required {
+	for _, key := range datasetRequiredColumns {
 		if canonicalIndex[key] < 0 {
 			missing = append(missing, key)
 		}
@@ -178,8 +195,16 @@ func loadCompanyDataset(datasetPath string) (companyDataset, error) {
 		return companyDataset{}, fmt.Errorf("dataset missing required columns: %s", strings.Join(missing, ", "))
 	}
 
+	fiscalYearColumns := fiscalYearColumnsFromHeader(headerIndex)
+	registerIndex := map[string]int{}
+	for _, column := range registerVisaColumns() {
+		if idx := findColumnIndex(headerIndex, []string{column}); idx >= 0 {
+			registerIndex[column] = idx
+		}
+	}
 	out := companyDataset{
 		ByNormalizedCompany: map[string]companyDatasetRecord{},
+		LatestFiscalYear:    latestFiscalYear(fiscalYearColumns),
 	}
 	for {
 		row, err := reader.Read()
@@ -194,17 +219,36 @@ func loadCompanyDataset(datasetPath string) (companyDataset, error) {
 
 		record := companyDatasetRecord{
 			CompanyName:      companyName,
+			DatasetSource:    path,
 			CompanyTier:      readCSVColumn(row, canonicalIndex["company_tier"]),
 			H1B:              parseIntCSV(readCSVColumn(row, canonicalIndex["h1b"])),
 			H1B1Chile:        parseIntCSV(readCSVColumn(row, canonicalIndex["h1b1_chile"])),
 			H1B1Singapore:    parseIntCSV(readCSVColumn(row, canonicalIndex["h1b1_singapore"])),
 			E3Australian:     parseIntCSV(readCSVColumn(row, canonicalIndex["e3_australian"])),
 			GreenCard:        parseIntCSV(readCSVColumn(row, canonicalIndex["green_card"])),
+			L1:               parseIntCSV(readCSVColumn(row, canonicalIndex["l1"])),
 			EmployerContacts: buildContactsFromRow(row, canonicalIndex),
+			FiscalYearCounts: readFiscalYearCounts(row, fiscalYearColumns),
+			SOCCounts:        parseSOCCounts(readCSVColumn(row, canonicalIndex["soc_counts"])),
+			StateCounts:      parseKeyedCounts(readCSVColumn(row, canonicalIndex["state_counts"]), normalizeUSState),
+			CityCounts:       parseKeyedCounts(readCSVColumn(row, canonicalIndex["city_counts"]), normalizeWorksiteCityKey),
+		}
+		record.CapExempt, record.CapExemptBasis = capExemptRecord(companyName, readCSVColumn(row, canonicalIndex["cap_exempt"]))
+		record.RegisterCounts = readRegisterCounts(row, registerIndex)
+		if deniedRaw, withdrawnRaw := readCSVColumn(row, canonicalIndex["h1b_denied"]), readCSVColumn(row, canonicalIndex["h1b_withdrawn"]); deniedRaw != "" || withdrawnRaw != "" {
+			record.H1BDenied, record.H1BWithdrawn, record.HasOutcomes = parseIntCSV(deniedRaw), parseIntCSV(withdrawnRaw), true
 		}
 		record.TotalVisas = record.H1B + record.H1B1Chile + record.H1B1Singapore + record.E3Australian + record.GreenCard
+		for _, count := range record.RegisterCounts {
+			record.TotalVisas += count
+		}
+
+		record.CompanyTier, record.CompanyTierBasis = resolveCompanyTier(record, out.LatestFiscalYear)
 
 		existing, exists := out.ByNormalizedCompany[normalized]
+		if exists {
+			out.noteNameCollision(normalized, existing, record)
+		}
 		if !exists || record.TotalVisas > existing.TotalVisas {
 			out.ByNormalizedCompany[normalized] = record
 		}
@@ -218,18 +262,22 @@ func loadCompanyDataset(datasetPath string) (companyDataset, error) {
 		Data:    out,
 	}
 	datasetCacheMu.Unlock()
+	out.Aliases = loadCompanyAliases()
 	return out, nil
 }
 
 func clearDatasetCache(datasetPath string) {
-	path := datasetPathOrDefault(datasetPath)
 	datasetCacheMu.Lock()
-	delete(datasetCache, path)
+	for _, path := range splitDatasetPaths(datasetPathOrDefault(datasetPath)) {
+		delete(datasetCache, path)
+	}
 	datasetCacheMu.Unlock()
 }
 
+// visaCountsFromRecord always carries the US visa keys; register-fed keys
+// appear only for employers listed on that register.
 func visaCountsFromRecord(record companyDatasetRecord) map[string]int {
-	return map[string]int{
+	counts := map[string]int{
 		"h1b":            record.H1B,
 		"h1b1_chile":     record.H1B1Chile,
 		"h1b1_singapore": record.H1B1Singapore,
@@ -237,6 +285,28 @@ func visaCountsFromRecord(record companyDatasetRecord) map[string]int {
 		"green_card":     record.GreenCard,
 		"total_visas":    record.TotalVisas,
 	}
+	for column, count := range record.RegisterCounts {
+		counts[column] = count
+	}
+	if record.L1 > 0 {
+		counts["l1"] = record.L1
+	}
+	return counts
+}
+
+// readRegisterCounts reads the sponsor-register visa columns present in the
+// dataset header, or nil when the row has none.
+func readRegisterCounts(row []string, registerIndex map[string]int) map[string]int {
+	var counts map[string]int
+	for column, idx := range registerIndex {
+		if value := parseIntCSV(readCSVColumn(row, idx)); value > 0 {
+			if counts == nil {
+				counts = map[string]int{}
+			}
+			counts[column] = value
+		}
+	}
+	return counts
 }
 
 func desiredVisaCount(record companyDatasetRecord, desired []string) int {
@@ -253,7 +323,54 @@ func desiredVisaCount(record companyDatasetRecord, desired []string) int {
 			total += record.E3Australian
 		case "green_card":
 			total += record.GreenCard
+		case "l1":
+			total += record.L1
+		default:
+			total += record.RegisterCounts[visa]
 		}
 	}
 	return total
 }
+
+// parseKeyedCounts reads "key:count;key:count" dataset columns such as
+// soc_counts, dropping keys that normalizeKey rejects.
+func parseKeyedCounts(raw string, normalizeKey func(string) string) map[string]int {
+	out := map[string]int{}
+	for _, part := range strings.Split(raw, ";") {
+		key, count, found := strings.Cut(part, ":")
+		if !found {
+			continue
+		}
+		if key = normalizeKey(key); key != "" {
+			if value := parseIntCSV(count); value > 0 {
+				out[key] += value
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// formatKeyedCounts writes the largest counts first, capped at limit keys so
+// a handful of very diverse employers do not bloat the dataset.
+func formatKeyedCounts(counts map[string]int, limit int) string {
+	keys := make([]string, 0, len(counts))
+	for key, count := range counts {
+		if count > 0 {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	parts := []string{}
+	for _, key := range keys[:min(len(keys), limit)] {
+		parts = append(parts, key+":"+strconv.Itoa(counts[key]))
+	}
+	return strings.Join(parts, ";")
+}
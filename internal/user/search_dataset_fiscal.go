@@ -0,0 +1,118 @@
+package user
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// fiscalYearRecencyDecay is the weight lost per fiscal year of age: filings
+// from the newest year in the dataset count fully, five-year-old filings
+// count about a tenth as much.
+const fiscalYearRecencyDecay = 0.65
+
+var fiscalYearColumnRegex = regexp.MustCompile(`^(h1b|h1b1_chile|h1b1_singapore|e3_australian|green_card)_fy(\d{4})$`)
+
+// fiscalYearVisas are the visa columns that can carry per-year counts.
+var fiscalYearVisas = []string{"h1b", "h1b1_chile", "h1b1_singapore", "e3_australian", "green_card"}
+
+type fiscalYearColumn struct {
+	Visa  string
+	Year  int
+	Index int
+}
+
+// fiscalYearColumnsFromHeader finds optional per-year count columns such as
+// h1b_fy2024 or green_card_fy2023.
+func fiscalYearColumnsFromHeader(headerIndex map[string]int) []fiscalYearColumn {
+	columns := []fiscalYearColumn{}
+	for name, index := range headerIndex {
+		match := fiscalYearColumnRegex.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		year, _ := strconv.Atoi(match[2])
+		columns = append(columns, fiscalYearColumn{Visa: match[1], Year: year, Index: index})
+	}
+	return columns
+}
+
+func readFiscalYearCounts(row []string, columns []fiscalYearColumn) map[int]map[string]int {
+	if len(columns) == 0 {
+		return nil
+	}
+	out := map[int]map[string]int{}
+	for _, column := range columns {
+		value := parseIntCSV(readCSVColumn(row, column.Index))
+		if value <= 0 {
+			continue
+		}
+		if out[column.Year] == nil {
+			out[column.Year] = map[string]int{}
+		}
+		out[column.Year][column.Visa] += value
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func latestFiscalYear(columns []fiscalYearColumn) int {
+	latest := 0
+	for _, column := range columns {
+		latest = max(latest, column.Year)
+	}
+	return latest
+}
+
+// sponsorshipRecency is the recency-weighted share of a company's filings for
+// the desired visas (all visas when none are desired), measured against the
+// newest fiscal year in the dataset. It is 1 when the dataset has no per-year
+// columns so older datasets score exactly as before.
+func sponsorshipRecency(record companyDatasetRecord, desired []string, latestYear int) float64 {
+	if len(record.FiscalYearCounts) == 0 || latestYear == 0 {
+		return 1
+	}
+	weighted, raw := 0.0, 0
+	for year, counts := range record.FiscalYearCounts {
+		count := 0
+		for visa, value := range counts {
+			if len(desired) == 0 || slices.Contains(desired, visa) {
+				count += value
+			}
+		}
+		age := max(latestYear-year, 0)
+		weighted += float64(count) * math.Pow(fiscalYearRecencyDecay, float64(age))
+		raw += count
+	}
+	if raw == 0 {
+		if len(desired) > 0 {
+			return sponsorshipRecency(record, nil, latestYear)
+		}
+		return 1
+	}
+	return math.Round(weighted/float64(raw)*100) / 100
+}
+
+// fiscalYearBreakdown lists per-year visa counts, newest year first.
+func fiscalYearBreakdown(record companyDatasetRecord) []map[string]any {
+	years := make([]int, 0, len(record.FiscalYearCounts))
+	for year := range record.FiscalYearCounts {
+		years = append(years, year)
+	}
+	slices.SortFunc(years, func(a, b int) int { return b - a })
+	out := make([]map[string]any, 0, len(years))
+	for _, year := range years {
+		row := map[string]any{"fiscal_year": year}
+		total := 0
+		for _, visa := range datasetVisaColumns {
+			row[visa] = record.FiscalYearCounts[year][visa]
+			total += record.FiscalYearCounts[year][visa]
+		}
+		row["total_visas"] = total
+		out = append(out, row)
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadCompanyDatasetReadsFiscalYearColumns(t *testing.T) {
+	setupUserToolPaths(t)
+	path := filepath.Join(t.TempDir(), "companies.csv")
+	body := strings.Join([]string{
+		"company_name,h1b,h1b1_chile,h1b1_singapore,e3_australian,green_card,h1b_fy2024,green_card_fy2024,h1b_fy2019",
+		"Fresh Co,10,0,0,0,2,10,2,0",
+		"Stale Co,10,0,0,0,0,0,0,10",
+		"Plain Co,5,0,0,0,0,,,",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write dataset: %v", err)
+	}
+	dataset, err := loadCompanyDataset(path)
+	if err != nil {
+		t.Fatalf("loadCompanyDataset failed: %v", err)
+	}
+	if dataset.LatestFiscalYear != 2024 {
+		t.Fatalf("expected latest fiscal year 2024, got %d", dataset.LatestFiscalYear)
+	}
+	fresh := dataset.ByNormalizedCompany["fresh"]
+	stale := dataset.ByNormalizedCompany["stale"]
+	plain := dataset.ByNormalizedCompany["plain"]
+
+	breakdown := fiscalYearBreakdown(fresh)
+	if len(breakdown) != 1 || breakdown[0]["fiscal_year"] != 2024 || breakdown[0]["h1b"] != 10 || breakdown[0]["total_visas"] != 12 {
+		t.Fatalf("unexpected fresh breakdown: %#v", breakdown)
+	}
+	if got := sponsorshipRecency(fresh, []string{"h1b"}, dataset.LatestFiscalYear); got != 1 {
+		t.Fatalf("expected full recency for current filings, got %v", got)
+	}
+	staleRecency := sponsorshipRecency(stale, []string{"h1b"}, dataset.LatestFiscalYear)
+	if staleRecency <= 0 || staleRecency > 0.15 {
+		t.Fatalf("expected five-year-old filings to be heavily discounted, got %v", staleRecency)
+	}
+	if got := sponsorshipRecency(plain, []string{"h1b"}, dataset.LatestFiscalYear); got != 1 || len(fiscalYearBreakdown(plain)) != 0 {
+		t.Fatalf("expected rows without per-year data to keep full recency, got %v", got)
+	}
+	if got := sponsorshipRecency(fresh, []string{"e3_australian"}, dataset.LatestFiscalYear); got != 1 {
+		t.Fatalf("expected fallback to all visas when desired visas have no per-year data, got %v", got)
+	}
+
+	weights := defaultRankingWeights
+	freshScore := scoreConfidence(confidenceFeatures{DesiredCount: 10, TotalCount: 12, Recency: 1, ApprovalRate: 1, LocationMatch: 1, DatasetFactor: 1}, weights).Score
+	staleScore := scoreConfidence(confidenceFeatures{DesiredCount: 10, TotalCount: 10, Recency: staleRecency, ApprovalRate: 1, LocationMatch: 1, DatasetFactor: 1}, weights).Score
+	if staleScore >= freshScore {
+		t.Fatalf("expected stale sponsor to score below fresh sponsor, got %v >= %v", staleScore, freshScore)
+	}
+}
+
+func TestDisclosureFiscalYear(t *testing.T) {
+	cases := map[string]int{
+		"2023-09-30":          2023,
+		"2023-10-01":          2024,
+		"10/15/2022":          2023,
+		"2024-01-05 00:00:00": 2024,
+		"45200":               2024,
+		"":                    2021,
+	}
+	for raw, want := range cases {
+		if got := disclosureFiscalYear(raw, 2021); got != want {
+			t.Fatalf("disclosureFiscalYear(%q) = %d, want %d", raw, got, want)
+		}
+	}
+	if got := fiscalYearFromFileName("/tmp/LCA_Disclosure_Data_FY2024_Q4.xlsx"); got != 2024 {
+		t.Fatalf("expected FY2024 from file name, got %d", got)
+	}
+	if got := fiscalYearForDate(time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)); got != 2025 {
+		t.Fatalf("expected October to start the next fiscal year, got %d", got)
+	}
+}
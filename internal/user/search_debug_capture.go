@@ -0,0 +1,77 @@
+package user
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const defaultScrapeDebugMaxFiles = 50
+
+var scrapeDebugMu sync.Mutex
+
+// scrapeDebugDir returns the opt-in capture directory, or "" when
+// VISA_SCRAPE_DEBUG_DIR is unset.
+func scrapeDebugDir() string {
+	return strings.TrimSpace(os.Getenv("VISA_SCRAPE_DEBUG_DIR"))
+}
+
+// captureScrapeDebug stores the raw body of a page that parsed to nothing,
+// with a sidecar JSON describing the request, so selector drift can be
+// fixed without a live repro. Empty bodies (the normal end of results) are
+// skipped and only the newest VISA_SCRAPE_DEBUG_MAX_FILES captures are kept.
+// Capture errors never affect the search.
+func captureScrapeDebug(kind, requestURL string, params map[string]string, status int, body, reason string) {
+	dir := scrapeDebugDir()
+	if dir == "" || strings.TrimSpace(body) == "" {
+		return
+	}
+	sum := sha1.Sum([]byte(requestURL + fmt.Sprint(params)))
+	now := utcNow()
+	base := fmt.Sprintf("%s-%s-%s", now.Format("20060102T150405.000Z"), kind, hex.EncodeToString(sum[:])[:10])
+	meta := map[string]any{
+		"kind":            kind,
+		"reason":          reason,
+		"url":             requestURL,
+		"params":          params,
+		"status_code":     status,
+		"body_bytes":      len(body),
+		"captured_at_utc": toISO(now),
+		"html_file":       base + ".html",
+	}
+	raw, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return
+	}
+
+	scrapeDebugMu.Lock()
+	defer scrapeDebugMu.Unlock()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(dir, base+".html"), []byte(body), 0o644); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, base+".json"), raw, 0o644)
+	pruneScrapeDebugDir(dir, max(envInt("VISA_SCRAPE_DEBUG_MAX_FILES", defaultScrapeDebugMaxFiles), 1))
+}
+
+// pruneScrapeDebugDir drops the oldest captures beyond limit. File names
+// start with a sortable UTC timestamp, so name order is capture order.
+func pruneScrapeDebugDir(dir string, limit int) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.html"))
+	if err != nil || len(matches) <= limit {
+		return
+	}
+	slices.Sort(matches)
+	for _, htmlPath := range matches[:len(matches)-limit] {
+		_ = os.Remove(htmlPath)
+		_ = os.Remove(strings.TrimSuffix(htmlPath, ".html") + ".json")
+	}
+}
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScrapeDebugCapturesUnparseablePages(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_UPSTREAM_REQUESTS_PER_MINUTE", "0")
+	dir := filepath.Join(t.TempDir(), "debug")
+	t.Setenv("VISA_SCRAPE_DEBUG_DIR", dir)
+	client := liveClientWithTransport(t, func(req *http.Request) *http.Response {
+		if strings.Contains(req.URL.Path, "seeMoreJobPostings") {
+			return stubResponse(req, 200, `<html><body><ul class="new-card-layout"></ul></body></html>`)
+		}
+		return stubResponse(req, 200, `<html><body><section class="redesigned"></section></body></html>`)
+	})
+
+	jobs, err := client.FetchSearchPage(linkedInSearchQuery{JobTitle: "Debug Capture Engineer", Location: "Austin, TX"}, nil)
+	if err != nil || len(jobs) != 0 {
+		t.Fatalf("expected an empty page without error, got %v (%v)", jobs, err)
+	}
+	if _, err := client.FetchJobDetails("https://www.linkedin.com/jobs/view/3812345678", "Engineer", "Austin, TX", nil); err != nil {
+		t.Fatalf("FetchJobDetails failed: %v", err)
+	}
+
+	metas, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(metas) != 2 {
+		t.Fatalf("expected 2 captures, got %v", metas)
+	}
+	reasons := map[string]bool{}
+	for _, path := range metas {
+		raw, _ := os.ReadFile(path)
+		var meta map[string]any
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			t.Fatalf("invalid capture metadata: %v", err)
+		}
+		reasons[getString(meta, "reason")] = true
+		if _, err := os.Stat(filepath.Join(dir, getString(meta, "html_file"))); err != nil {
+			t.Fatalf("expected html capture next to metadata: %v", err)
+		}
+	}
+	if !reasons["zero_cards"] || !reasons["empty_description"] {
+		t.Fatalf("expected zero_cards and empty_description captures, got %v", reasons)
+	}
+}
+
+func TestScrapeDebugSkipsEmptyBodiesAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("VISA_SCRAPE_DEBUG_DIR", dir)
+	t.Setenv("VISA_SCRAPE_DEBUG_MAX_FILES", "2")
+	captureScrapeDebug("search_page", linkedInSearchURL, nil, 200, "  ", "zero_cards")
+	if files, _ := filepath.Glob(filepath.Join(dir, "*")); len(files) != 0 {
+		t.Fatalf("expected empty bodies to be skipped, got %v", files)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		captureScrapeDebug("job_details", "https://www.linkedin.com/jobs/view/"+name, nil, 200, "<html></html>", "empty_description")
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.html")); len(files) != 2 {
+		t.Fatalf("expected pruning to keep 2 captures, got %v", files)
+	}
+}
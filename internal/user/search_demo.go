@@ -0,0 +1,154 @@
+package user
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed demo_fixtures/*
+var demoFixtures embed.FS
+
+const (
+	defaultDemoUserID   = "demo_user"
+	defaultDemoJobTitle = "Software Engineer"
+	defaultDemoLocation = "New York, NY"
+)
+
+type demoLinkedInClient struct{}
+
+func (c *demoLinkedInClient) FetchSearchPage(query linkedInSearchQuery, isCancelled func() bool) ([]linkedInJob, error) {
+	if isCancelled != nil && isCancelled() {
+		return nil, errSearchRunCancelled
+	}
+	if query.Start > 0 {
+		return []linkedInJob{}, nil
+	}
+	raw, err := demoFixtures.ReadFile("demo_fixtures/search_page.html")
+	if err != nil {
+		return nil, fmt.Errorf("read demo search fixture: %w", err)
+	}
+	return parseLinkedInListHTML(string(raw))
+}
+
+func (c *demoLinkedInClient) FetchJobDetails(jobURL, title, location string, isCancelled func() bool) (linkedInJobDetails, error) {
+	if isCancelled != nil && isCancelled() {
+		return linkedInJobDetails{}, errSearchRunCancelled
+	}
+	slug := filepath.Base(strings.TrimSuffix(jobURL, "/"))
+	raw, err := demoFixtures.ReadFile("demo_fixtures/job_" + slug + ".html")
+	if err != nil {
+		return linkedInJobDetails{}, fmt.Errorf("no demo fixture for %s", jobURL)
+	}
+	return parseLinkedInJobDetailsHTML(string(raw), title, location), nil
+}
+
+func demoDatasetPath() (string, error) {
+	raw, err := demoFixtures.ReadFile("demo_fixtures/companies.csv")
+	if err != nil {
+		return "", fmt.Errorf("read demo dataset fixture: %w", err)
+	}
+	path := filepath.Join(os.TempDir(), "visa-jobs-mcp-demo", "companies.csv")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if existing, err := os.ReadFile(path); err == nil && string(existing) == string(raw) {
+		return path, nil
+	}
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func RunDemoSearch(args map[string]any) (map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		userID = defaultDemoUserID
+	}
+	jobTitle := getString(args, "job_title")
+	if jobTitle == "" {
+		jobTitle = defaultDemoJobTitle
+	}
+	location := getString(args, "location")
+	if location == "" {
+		location = defaultDemoLocation
+	}
+	visaTypes := []string{"h1b"}
+	if hasKey(args, "preferred_visa_types") {
+		normalized, err := normalizeVisaTypeList(getStringList(args, "preferred_visa_types"))
+		if err != nil {
+			return nil, err
+		}
+		if len(normalized) > 0 {
+			visaTypes = normalized
+		}
+	}
+	strictness := strictnessOrDefault(getString(args, "strictness_mode"))
+	if err := validateStrictnessMode(strictness); err != nil {
+		return nil, err
+	}
+
+	datasetPath, err := demoDatasetPath()
+	if err != nil {
+		return nil, err
+	}
+	query := searchQuery{
+		RunID:                "demo",
+		UserID:               userID,
+		SearchMode:           searchModeVisa,
+		Location:             location,
+		JobTitle:             jobTitle,
+		HoursOld:             defaultSearchHoursOld,
+		DatasetPath:          datasetPath,
+		Site:                 "linkedin",
+		ResultsWanted:        defaultSearchResultsWanted,
+		MaxReturned:          defaultSearchMaxReturned,
+		StrictnessMode:       strictness,
+		ScanMultiplier:       defaultSearchScanMultiplier,
+		MaxScanResults:       defaultSearchMaxScanResults,
+		PreferredVisaTypes:   visaTypes,
+		Client:               &demoLinkedInClient{},
+		SkipLayoutTracking:   true,
+		SkipDescriptionCache: true,
+	}
+
+	events := []any{}
+	progress := func(phase, detail string, pct float64, payload map[string]any) {
+		event := map[string]any{
+			"event_id":         len(events),
+			"phase":            phase,
+			"detail":           detail,
+			"progress_percent": pct,
+		}
+		if len(payload) > 0 {
+			event["payload"] = payload
+		}
+		events = append(events, event)
+	}
+	response, _, sessionID, err := executeSearchQuery(query, progress, func() bool { return false })
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"demo":                 true,
+		"network_used":         false,
+		"user_id":              userID,
+		"search_session_id":    sessionID,
+		"events":               events,
+		"status":               asMap(response["status"]),
+		"stats":                asMap(response["stats"]),
+		"guidance":             asMap(response["guidance"]),
+		"dataset_freshness":    asMap(response["dataset_freshness"]),
+		"pagination":           asMap(response["pagination"]),
+		"recovery_suggestions": listOrEmpty(response["recovery_suggestions"]),
+		"jobs":                 listOrEmpty(response["jobs"]),
+		"next_steps": []string{
+			"Results use bundled fixture HTML and a sample dataset; company names and contacts are fictional.",
+			"Try save_job_for_later with one of jobs[].result_id to exercise job management wiring.",
+			"Call start_visa_job_search for a live LinkedIn search once wiring is confirmed.",
+		},
+	}, nil
+}
@@ -0,0 +1,52 @@
+package user
+
+import "testing"
+
+func TestRunDemoSearchUsesFixturesOnly(t *testing.T) {
+	setupUserToolPaths(t)
+	originalFactory := linkedInClientFactory
+	defer func() {
+		linkedInClientFactory = originalFactory
+	}()
+	linkedInClientFactory = func() linkedInClient {
+		t.Fatal("demo search must not build a live LinkedIn client")
+		return nil
+	}
+
+	payload, err := RunDemoSearch(map[string]any{})
+	if err != nil {
+		t.Fatalf("RunDemoSearch failed: %v", err)
+	}
+	if demo, _ := payload["demo"].(bool); !demo {
+		t.Fatalf("expected demo=true, got %#v", payload["demo"])
+	}
+	jobs := listOrEmpty(payload["jobs"])
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 accepted demo jobs, got %d (%#v)", len(jobs), jobs)
+	}
+	companies := map[string]bool{}
+	for _, raw := range jobs {
+		job := mapOrNil(raw)
+		companies[getString(job, "company")] = true
+		if getString(job, "result_id") == "" {
+			t.Fatalf("expected result_id on demo job: %#v", job)
+		}
+	}
+	if companies["Umbrella Health LLC"] {
+		t.Fatal("expected negative-sponsorship demo job to be filtered out")
+	}
+	if !companies["Initech"] {
+		t.Fatal("expected description-only demo job to be accepted")
+	}
+
+	saved, err := SaveJobForLater(map[string]any{
+		"user_id":   defaultDemoUserID,
+		"result_id": getString(mapOrNil(jobs[0]), "result_id"),
+	})
+	if err != nil {
+		t.Fatalf("SaveJobForLater on demo result failed: %v", err)
+	}
+	if got := getString(saved, "action"); got != "saved_new" {
+		t.Fatalf("expected action=saved_new, got %q", got)
+	}
+}
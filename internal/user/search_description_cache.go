@@ -0,0 +1,175 @@
+package user
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDescriptionCachePath       = "data/config/description_cache.json"
+	defaultDescriptionCacheTTLSeconds = 72 * 3600
+	maxDescriptionCacheEntries        = 2000
+)
+
+var descriptionCacheMu sync.Mutex
+
+func descriptionCachePath() string {
+	return envOrDefault("VISA_DESCRIPTION_CACHE_PATH", defaultDescriptionCachePath)
+}
+
+// descriptionCacheTTLSeconds returns 0 when the cache is disabled.
+func descriptionCacheTTLSeconds() int {
+	value := envInt("VISA_DESCRIPTION_CACHE_TTL_SECONDS", defaultDescriptionCacheTTLSeconds)
+	if value < 0 {
+		return 0
+	}
+	return value
+}
+
+func descriptionCacheKey(jobURL string) string {
+	return strings.ToLower(stripQuery(jobURL))
+}
+
+// descriptionCache is a per-run view of the on-disk cache. Lookups are served
+// from memory and new entries are merged back to disk once in flush.
+type descriptionCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]any
+	added   map[string]any
+	hits    int
+	misses  int
+}
+
+func loadDescriptionCache() *descriptionCache {
+	ttl := descriptionCacheTTLSeconds()
+	if ttl == 0 {
+		return nil
+	}
+	descriptionCacheMu.Lock()
+	data := loadJSONMap(descriptionCachePath(), map[string]any{"entries": map[string]any{}})
+	descriptionCacheMu.Unlock()
+	entries := mapOrNil(data["entries"])
+	if entries == nil {
+		entries = map[string]any{}
+	}
+	return &descriptionCache{
+		ttl:     time.Duration(ttl) * time.Second,
+		entries: entries,
+		added:   map[string]any{},
+	}
+}
+
+func (c *descriptionCache) get(jobURL string) (linkedInJobDetails, bool) {
+	if c == nil {
+		return linkedInJobDetails{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := mapOrNil(c.entries[descriptionCacheKey(jobURL)])
+	fetchedAt := parseISOTime(entry["fetched_at_utc"])
+	if entry == nil || fetchedAt.IsZero() || utcNow().Sub(fetchedAt) > c.ttl {
+		c.misses++
+		return linkedInJobDetails{}, false
+	}
+	c.hits++
+	details := linkedInJobDetails{
+		Description:     getString(entry, "description"),
+		JobType:         getString(entry, "job_type"),
+		JobLevel:        getString(entry, "job_level"),
+		CompanyIndustry: getString(entry, "company_industry"),
+		JobFunction:     getString(entry, "job_function"),
+		JobURLDirect:    getString(entry, "job_url_direct"),
+	}
+	if remote, ok := entry["is_remote"].(bool); ok {
+		details.IsRemote = boolPtr(remote)
+	}
+	if applicants, ok := intFromAny(entry["applicant_count"]); ok {
+		details.ApplicantCount = &applicants
+	}
+	if reposted, ok := entry["is_reposted"].(bool); ok {
+		details.IsReposted = boolPtr(reposted)
+	}
+	details.PostedAt = parseISOTime(entry["posted_at_utc"])
+	return details, true
+}
+
+func (c *descriptionCache) put(jobURL string, details linkedInJobDetails) {
+	if c == nil || normalizeWhitespace(details.Description) == "" {
+		return
+	}
+	entry := map[string]any{
+		"description":      details.Description,
+		"job_type":         details.JobType,
+		"job_level":        details.JobLevel,
+		"company_industry": details.CompanyIndustry,
+		"job_function":     details.JobFunction,
+		"job_url_direct":   details.JobURLDirect,
+		"is_remote":        optionalBool(details.IsRemote),
+		"applicant_count":  optionalInt(details.ApplicantCount),
+		"is_reposted":      optionalBool(details.IsReposted),
+		"posted_at_utc":    optionalTimeISO(details.PostedAt),
+		"fetched_at_utc":   utcNowISO(),
+	}
+	key := descriptionCacheKey(jobURL)
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.added[key] = entry
+	c.mu.Unlock()
+}
+
+func (c *descriptionCache) counts() (int, int) {
+	if c == nil {
+		return 0, 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// flush merges entries fetched during this run into the cache file, dropping
+// expired entries and the oldest ones beyond the size cap.
+func (c *descriptionCache) flush() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	added := c.added
+	c.added = map[string]any{}
+	c.mu.Unlock()
+	if len(added) == 0 {
+		return
+	}
+
+	descriptionCacheMu.Lock()
+	defer descriptionCacheMu.Unlock()
+	data := loadJSONMap(descriptionCachePath(), map[string]any{"entries": map[string]any{}})
+	entries := mapOrNil(data["entries"])
+	if entries == nil {
+		entries = map[string]any{}
+	}
+	for key, entry := range added {
+		entries[key] = entry
+	}
+	keys := make([]string, 0, len(entries))
+	for key, raw := range entries {
+		fetchedAt := parseISOTime(mapOrNil(raw)["fetched_at_utc"])
+		if fetchedAt.IsZero() || utcNow().Sub(fetchedAt) > c.ttl {
+			delete(entries, key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) > maxDescriptionCacheEntries {
+		slices.SortFunc(keys, func(a, b string) int {
+			return strings.Compare(getString(mapOrNil(entries[a]), "fetched_at_utc"), getString(mapOrNil(entries[b]), "fetched_at_utc"))
+		})
+		for _, key := range keys[:len(keys)-maxDescriptionCacheEntries] {
+			delete(entries, key)
+		}
+	}
+	data["entries"] = entries
+	_ = saveJSONMap(descriptionCachePath(), data)
+}
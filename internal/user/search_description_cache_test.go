@@ -0,0 +1,133 @@
+package user
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDescriptionCacheRoundTripAndExpiry(t *testing.T) {
+	t.Setenv("VISA_DESCRIPTION_CACHE_PATH", filepath.Join(t.TempDir(), "description_cache.json"))
+	jobURL := "https://www.linkedin.com/jobs/view/acme-1/?trk=guest"
+
+	cache := loadDescriptionCache()
+	if _, ok := cache.get(jobURL); ok {
+		t.Fatal("expected empty cache miss")
+	}
+	cache.put(jobURL, linkedInJobDetails{Description: "We sponsor H-1B visas.", JobType: "Full-time", IsRemote: boolPtr(true)})
+	cache.put("https://www.linkedin.com/jobs/view/empty/", linkedInJobDetails{})
+	cache.flush()
+
+	reloaded := loadDescriptionCache()
+	details, ok := reloaded.get("https://www.linkedin.com/jobs/view/acme-1/")
+	if !ok || details.Description != "We sponsor H-1B visas." || details.JobType != "Full-time" {
+		t.Fatalf("expected cached details, got %#v ok=%v", details, ok)
+	}
+	if details.IsRemote == nil || !*details.IsRemote {
+		t.Fatalf("expected cached is_remote=true, got %#v", details.IsRemote)
+	}
+	if _, ok := reloaded.get("https://www.linkedin.com/jobs/view/empty/"); ok {
+		t.Fatal("expected empty descriptions to stay uncached")
+	}
+	if hits, misses := reloaded.counts(); hits != 1 || misses != 1 {
+		t.Fatalf("expected 1 hit and 1 miss, got %d/%d", hits, misses)
+	}
+
+	reloaded.ttl = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if _, ok := reloaded.get(jobURL); ok {
+		t.Fatal("expected expired entry to miss")
+	}
+
+	t.Setenv("VISA_DESCRIPTION_CACHE_TTL_SECONDS", "0")
+	if loadDescriptionCache() != nil {
+		t.Fatal("expected TTL 0 to disable the cache")
+	}
+}
+
+func TestDescriptionCacheSkipsRefetchAcrossRuns(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	jobURL := "https://www.linkedin.com/jobs/view/gamma-1/"
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{0: {{
+			JobURL:   jobURL,
+			Title:    "Software Engineer",
+			Company:  "Gamma Corp",
+			Location: "New York, NY",
+		}}},
+		descriptions: map[string]string{jobURL: "We sponsor H-1B visas for this role."},
+	}
+	args := map[string]any{
+		"user_id":              "u1",
+		"location":             "New York, NY",
+		"job_title":            "Software Engineer",
+		"dataset_path":         datasetPath,
+		"preferred_visa_types": []any{"h1b"},
+	}
+
+	first := runFakeVisaSearch(t, client, args)
+	if got := intOrZero(asMap(first["stats"])["description_cache_misses"]); got != 1 {
+		t.Fatalf("expected 1 cache miss on first run, got %d", got)
+	}
+	second := runFakeVisaSearch(t, client, args)
+	stats := asMap(second["stats"])
+	if got := intOrZero(stats["description_cache_hits"]); got != 1 {
+		t.Fatalf("expected 1 cache hit on second run, got %d (stats=%#v)", got, stats)
+	}
+	if got := intOrZero(stats["description_fetches"]); got != 0 {
+		t.Fatalf("expected no network fetches on second run, got %d", got)
+	}
+	if client.descCalls != 1 {
+		t.Fatalf("expected 1 total description call, got %d", client.descCalls)
+	}
+	if len(listOrEmpty(second["jobs"])) != 1 {
+		t.Fatalf("expected cached description to still accept the job, got %#v", second["jobs"])
+	}
+}
+
+func TestDescriptionCacheKeepsFetchesFromCancelledRuns(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	firstURL := "https://www.linkedin.com/jobs/view/gamma-2/"
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{0: {
+			{JobURL: firstURL, Title: "Software Engineer", Company: "Gamma Corp", Location: "New York, NY"},
+			{JobURL: "https://www.linkedin.com/jobs/view/gamma-3/", Title: "Software Engineer", Company: "Gamma Corp", Location: "New York, NY"},
+		}},
+		descriptions: map[string]string{firstURL: "We sponsor H-1B visas for this role."},
+	}
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient { return client }
+
+	// Cancel as soon as the first description has been fetched.
+	cancelled := func() bool {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		return client.descCalls > 0
+	}
+	_, _, _, err := executeSearchQuery(searchQuery{
+		UserID:                   "u1",
+		SearchMode:               searchModeVisa,
+		Location:                 "New York, NY",
+		JobTitle:                 "Software Engineer",
+		HoursOld:                 defaultSearchHoursOld,
+		DatasetPath:              datasetPath,
+		Site:                     "linkedin",
+		ResultsWanted:            defaultSearchResultsWanted,
+		MaxReturned:              defaultSearchMaxReturned,
+		ScanMultiplier:           defaultSearchScanMultiplier,
+		MaxScanResults:           defaultSearchMaxScanResults,
+		RequireDescriptionSignal: true,
+	}, func(string, string, float64, map[string]any) {}, cancelled)
+	if !errors.Is(err, errSearchRunCancelled) {
+		t.Fatalf("expected the run to be cancelled, got %v", err)
+	}
+	if _, ok := loadDescriptionCache().get(firstURL); !ok {
+		t.Fatal("expected the description fetched before cancelling to be cached")
+	}
+}
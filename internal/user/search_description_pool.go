@@ -0,0 +1,136 @@
+package user
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultDescriptionFetchConcurrency = 3
+
+func descriptionFetchConcurrency() int {
+	value := envInt("VISA_DESCRIPTION_FETCH_CONCURRENCY", defaultDescriptionFetchConcurrency)
+	if value < 1 {
+		return 1
+	}
+	return value
+}
+
+type descriptionResult struct {
+	Details linkedInJobDetails
+	Err     error
+	Cached  bool
+	// Skipped is set when the fetch budget ran out before this job's turn.
+	Skipped bool
+}
+
+// descriptionPool fetches descriptions ahead of the scoring loop with a fixed
+// number of workers. Jobs are claimed in the planned order, so the budget is
+// spent on the listings ranked most likely to be accepted. Cache hits are
+// served without touching the budget.
+type descriptionPool struct {
+	client      linkedInClient
+	cache       *descriptionCache
+	jobs        []linkedInJob
+	order       []int
+	results     map[int]chan descriptionResult
+	limit       int
+	deadline    time.Time
+	isCancelled func() bool
+
+	mu      sync.Mutex
+	next    int
+	fetches int
+	stopped bool
+}
+
+func startDescriptionPool(
+	client linkedInClient,
+	cache *descriptionCache,
+	jobs []linkedInJob,
+	order []int,
+	limit int,
+	deadline time.Time,
+	workers int,
+	isCancelled func() bool,
+) *descriptionPool {
+	pool := &descriptionPool{
+		client:      client,
+		cache:       cache,
+		jobs:        jobs,
+		order:       order,
+		results:     make(map[int]chan descriptionResult, len(order)),
+		limit:       limit,
+		deadline:    deadline,
+		isCancelled: isCancelled,
+	}
+	for _, idx := range order {
+		pool.results[idx] = make(chan descriptionResult, 1)
+	}
+	for i := 0; i < min(workers, len(order)); i++ {
+		go pool.work()
+	}
+	return pool
+}
+
+func (p *descriptionPool) cancelled() bool {
+	p.mu.Lock()
+	stopped := p.stopped
+	p.mu.Unlock()
+	return stopped || (p.isCancelled != nil && p.isCancelled())
+}
+
+func (p *descriptionPool) work() {
+	for {
+		p.mu.Lock()
+		if p.stopped || p.next >= len(p.order) {
+			p.mu.Unlock()
+			return
+		}
+		idx := p.order[p.next]
+		p.next++
+		p.mu.Unlock()
+
+		job := p.jobs[idx]
+		if details, ok := p.cache.get(job.JobURL); ok {
+			p.results[idx] <- descriptionResult{Details: details, Cached: true}
+			continue
+		}
+		p.mu.Lock()
+		if p.fetches >= p.limit || !time.Now().Before(p.deadline) {
+			p.mu.Unlock()
+			p.results[idx] <- descriptionResult{Skipped: true}
+			continue
+		}
+		p.fetches++
+		p.mu.Unlock()
+
+		details, err := p.client.FetchJobDetails(job.JobURL, job.Title, job.Location, p.cancelled)
+		if err == nil {
+			p.cache.put(job.JobURL, details)
+		}
+		p.results[idx] <- descriptionResult{Details: details, Err: err}
+	}
+}
+
+// Await blocks until the description for rawJobs[idx] is available. Jobs that
+// were not planned for a fetch report Skipped.
+func (p *descriptionPool) Await(idx int) descriptionResult {
+	ch, ok := p.results[idx]
+	if !ok {
+		return descriptionResult{Skipped: true}
+	}
+	return <-ch
+}
+
+// Stop keeps workers from claiming more jobs once the scoring loop is done.
+func (p *descriptionPool) Stop() {
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+}
+
+func (p *descriptionPool) Fetches() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.fetches
+}
@@ -0,0 +1,68 @@
+package user
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type slowDetailsClient struct {
+	fakeLinkedInClient
+	delay       time.Duration
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (c *slowDetailsClient) FetchJobDetails(jobURL, title, location string, isCancelled func() bool) (linkedInJobDetails, error) {
+	c.mu.Lock()
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.mu.Unlock()
+	time.Sleep(c.delay)
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+	return linkedInJobDetails{Description: "details for " + jobURL}, nil
+}
+
+func TestDescriptionPoolFetchesConcurrentlyWithinBudget(t *testing.T) {
+	jobs := acmeListingPage(1, 6)
+	client := &slowDetailsClient{delay: 20 * time.Millisecond}
+	pool := startDescriptionPool(client, nil, jobs, []int{0, 1, 2, 3, 4, 5}, 4, time.Now().Add(time.Minute), 3, nil)
+	defer pool.Stop()
+
+	for idx := 0; idx < 4; idx++ {
+		result := pool.Await(idx)
+		if result.Skipped || result.Details.Description != "details for "+jobs[idx].JobURL {
+			t.Fatalf("unexpected result for job %d: %#v", idx, result)
+		}
+	}
+	for _, idx := range []int{4, 5} {
+		if !pool.Await(idx).Skipped {
+			t.Fatalf("expected job %d to be skipped once the budget ran out", idx)
+		}
+	}
+	if got := pool.Fetches(); got != 4 {
+		t.Fatalf("expected 4 fetches, got %d", got)
+	}
+	if client.maxInFlight < 2 || client.maxInFlight > 3 {
+		t.Fatalf("expected 2-3 concurrent fetches, got %d", client.maxInFlight)
+	}
+	if !pool.Await(99).Skipped {
+		t.Fatal("expected unplanned jobs to report Skipped")
+	}
+}
+
+func TestDescriptionPoolStopHaltsNewClaims(t *testing.T) {
+	jobs := acmeListingPage(1, 10)
+	order := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	client := &slowDetailsClient{delay: 20 * time.Millisecond}
+	pool := startDescriptionPool(client, nil, jobs, order, 10, time.Now().Add(time.Minute), 2, nil)
+	pool.Await(0)
+	pool.Stop()
+	time.Sleep(50 * time.Millisecond)
+	if got := pool.Fetches(); got >= len(order) {
+		t.Fatalf("expected Stop to prevent fetching every job, got %d fetches", got)
+	}
+}
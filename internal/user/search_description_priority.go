@@ -0,0 +1,64 @@
+package user
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	descriptionPriorityDesiredVisas = iota
+	descriptionPriorityOtherVisas
+	descriptionPriorityNearMiss
+	descriptionPriorityUnknown
+)
+
+// prioritizeDescriptionOrder reorders planned description fetches so the
+// fetch budget goes to the listings most likely to be accepted: companies
+// that sponsor the requested visas, then other sponsors in the dataset, then
+// near-miss names sharing a leading word with a sponsor. Scan order is kept
+// within each tier.
+func prioritizeDescriptionOrder(order []int, rawJobs []linkedInJob, dataset companyDataset, desiredVisaTypes []string) []int {
+	if len(order) < 2 {
+		return order
+	}
+	sponsorLeads := map[string]struct{}{}
+	for normalized, record := range dataset.ByNormalizedCompany {
+		if desiredVisaCount(record, desiredVisaTypes) > 0 {
+			sponsorLeads[leadingCompanyToken(normalized)] = struct{}{}
+		}
+	}
+	tiers := make(map[int]int, len(order))
+	for _, idx := range order {
+		tiers[idx] = descriptionFetchTier(rawJobs[idx].Company, dataset, desiredVisaTypes, sponsorLeads)
+	}
+	out := slices.Clone(order)
+	slices.SortStableFunc(out, func(a, b int) int {
+		return tiers[a] - tiers[b]
+	})
+	return out
+}
+
+func descriptionFetchTier(company string, dataset companyDataset, desiredVisaTypes []string, sponsorLeads map[string]struct{}) int {
+	normalized := normalizeCompanyName(company)
+	if normalized == "" {
+		return descriptionPriorityUnknown
+	}
+	if record, ok := dataset.lookup(company); ok {
+		if desiredVisaCount(record, desiredVisaTypes) > 0 {
+			return descriptionPriorityDesiredVisas
+		}
+		if record.TotalVisas > 0 {
+			return descriptionPriorityOtherVisas
+		}
+		return descriptionPriorityUnknown
+	}
+	if _, ok := sponsorLeads[leadingCompanyToken(normalized)]; ok {
+		return descriptionPriorityNearMiss
+	}
+	return descriptionPriorityUnknown
+}
+
+func leadingCompanyToken(normalized string) string {
+	token, _, _ := strings.Cut(normalized, " ")
+	return token
+}
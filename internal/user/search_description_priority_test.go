@@ -0,0 +1,26 @@
+package user
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPrioritizeDescriptionOrderPutsLikelySponsorsFirst(t *testing.T) {
+	dataset := companyDataset{ByNormalizedCompany: map[string]companyDatasetRecord{
+		"acme":           {CompanyName: "Acme Inc", E3Australian: 5, TotalVisas: 5},
+		"beta":           {CompanyName: "Beta LLC", H1B: 3, TotalVisas: 3},
+		"northwind labs": {CompanyName: "Northwind Labs", E3Australian: 2, TotalVisas: 2},
+	}}
+	rawJobs := []linkedInJob{
+		{Company: "Gamma Corp"},
+		{Company: "Northwind Analytics"},
+		{Company: "Beta LLC"},
+		{Company: "Acme Inc"},
+		{Company: "Delta Co"},
+	}
+	got := prioritizeDescriptionOrder([]int{0, 1, 2, 3, 4}, rawJobs, dataset, []string{"e3_australian"})
+	want := []int{3, 2, 1, 0, 4}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected order %v, got %v", want, got)
+	}
+}
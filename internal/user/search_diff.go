@@ -0,0 +1,161 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const maxDiffRemovedJobs = 50
+
+func resultIDOrdinal(resultID string) int {
+	idx := strings.LastIndex(resultID, ":")
+	if idx < 0 {
+		return 0
+	}
+	value, _ := strconv.Atoi(resultID[idx+1:])
+	return value
+}
+
+// findLastComparableRun returns the user's most recent completed run for the
+// same title, location and search mode, skipping the run being executed.
+func findLastComparableRun(query searchQuery) map[string]any {
+	var latest map[string]any
+	_ = withSearchRunStore(false, func(store map[string]any) error {
+		for runID, raw := range mapOrNil(store["runs"]) {
+			run := mapOrNil(raw)
+			if run == nil || runID == query.RunID || getString(run, "status") != "completed" {
+				continue
+			}
+			prior := mapOrNil(run["query"])
+			if getString(prior, "user_id") != query.UserID ||
+				searchModeOrDefault(getString(prior, "search_mode")) != searchModeOrDefault(query.SearchMode) ||
+				!strings.EqualFold(normalizeWhitespace(getString(prior, "job_title")), normalizeWhitespace(query.JobTitle)) ||
+				!strings.EqualFold(normalizeWhitespace(getString(prior, "location")), normalizeWhitespace(query.Location)) {
+				continue
+			}
+			if latest == nil || runCompletedAfter(run, latest) {
+				latest = cloneMap(run)
+			}
+		}
+		return nil
+	})
+	return latest
+}
+
+// runCompletedAfter orders runs by completion time. completed_at_utc only has
+// second precision, so ties fall back to the nanosecond queue timestamp.
+func runCompletedAfter(a, b map[string]any) bool {
+	if cmp := strings.Compare(getString(a, "completed_at_utc"), getString(b, "completed_at_utc")); cmp != 0 {
+		return cmp > 0
+	}
+	return getString(a, "queued_at_utc") > getString(b, "queued_at_utc")
+}
+
+func resolveDiffBaseline(query searchQuery) (map[string]any, error) {
+	if query.DiffAgainstRunID != "" {
+		run, err := loadRunForUser(query.DiffAgainstRunID, query.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if getString(run, "search_session_id") == "" {
+			return nil, fmt.Errorf("run '%s' has no completed results to diff against", query.DiffAgainstRunID)
+		}
+		return run, nil
+	}
+	run := findLastComparableRun(query)
+	if run == nil || getString(run, "search_session_id") == "" {
+		return nil, fmt.Errorf("no earlier completed run found for %q in %q", query.JobTitle, query.Location)
+	}
+	return run, nil
+}
+
+// buildSearchDiff compares this run's accepted jobs with the results of a
+// baseline run, matching on job URL.
+func buildSearchDiff(query searchQuery, currentIndex map[string]any) map[string]any {
+	out := map[string]any{
+		"available":           false,
+		"baseline_run_id":     nil,
+		"baseline_session_id": nil,
+		"new_jobs":            []any{},
+		"removed_jobs":        []any{},
+		"new_count":           0,
+		"removed_count":       0,
+		"unchanged_count":     0,
+	}
+	baseline, err := resolveDiffBaseline(query)
+	if err != nil {
+		out["reason"] = err.Error()
+		return out
+	}
+	baselineSessionID := getString(baseline, "search_session_id")
+	out["baseline_run_id"] = getString(baseline, "run_id")
+	out["baseline_session_id"] = baselineSessionID
+	session, err := loadSearchSessionForUser(baselineSessionID, query.UserID)
+	if err != nil {
+		out["reason"] = fmt.Sprintf("baseline results are no longer available: %v", err)
+		return out
+	}
+
+	baselineJobs := map[string]map[string]any{}
+	for _, raw := range asMap(session["result_id_index"]) {
+		entry := mapOrNil(raw)
+		if key := strings.ToLower(strings.TrimSpace(getString(entry, "job_url"))); key != "" {
+			baselineJobs[key] = entry
+		}
+	}
+	resultIDs := make([]string, 0, len(currentIndex))
+	for resultID := range currentIndex {
+		resultIDs = append(resultIDs, resultID)
+	}
+	slices.SortFunc(resultIDs, func(a, b string) int {
+		return resultIDOrdinal(a) - resultIDOrdinal(b)
+	})
+
+	newJobs := []any{}
+	current := map[string]struct{}{}
+	unchanged := 0
+	for _, resultID := range resultIDs {
+		entry := mapOrNil(currentIndex[resultID])
+		key := strings.ToLower(strings.TrimSpace(getString(entry, "job_url")))
+		current[key] = struct{}{}
+		if _, existed := baselineJobs[key]; existed {
+			unchanged++
+			continue
+		}
+		newJobs = append(newJobs, diffJobSummary(entry))
+	}
+	removedKeys := []string{}
+	for key := range baselineJobs {
+		if _, still := current[key]; !still {
+			removedKeys = append(removedKeys, key)
+		}
+	}
+	slices.SortFunc(removedKeys, func(a, b string) int {
+		return resultIDOrdinal(getString(baselineJobs[a], "result_id")) - resultIDOrdinal(getString(baselineJobs[b], "result_id"))
+	})
+	removedJobs := []any{}
+	for _, key := range removedKeys[:min(len(removedKeys), maxDiffRemovedJobs)] {
+		removedJobs = append(removedJobs, diffJobSummary(baselineJobs[key]))
+	}
+
+	out["available"] = true
+	out["new_jobs"] = newJobs
+	out["removed_jobs"] = removedJobs
+	out["new_count"] = len(newJobs)
+	out["removed_count"] = len(removedKeys)
+	out["unchanged_count"] = unchanged
+	out["baseline_completed_at_utc"] = getString(baseline, "completed_at_utc")
+	return out
+}
+
+func diffJobSummary(entry map[string]any) map[string]any {
+	return map[string]any{
+		"result_id": getString(entry, "result_id"),
+		"job_url":   getString(entry, "job_url"),
+		"title":     getString(entry, "title"),
+		"company":   getString(entry, "company"),
+		"location":  getString(entry, "location"),
+	}
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffAgainstRunReportsNewAndRemovedJobs(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	args := map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	}
+
+	client := &fakeLinkedInClient{pages: map[int][]linkedInJob{0: acmeListingPage(1, 3)}}
+	first := runFakeVisaSearch(t, client, args)
+	if _, ok := first["diff"]; ok {
+		t.Fatal("expected no diff unless requested")
+	}
+
+	client.pages[0] = acmeListingPage(2, 3)
+	args["diff_against_run_id"] = getString(first, "run_id")
+	second := runFakeVisaSearch(t, client, args)
+	diff := asMap(second["diff"])
+	if !boolOrFalse(diff["available"]) || getString(diff, "baseline_run_id") != getString(first, "run_id") {
+		t.Fatalf("expected diff against first run, got %#v", diff)
+	}
+	newJobs := listOrEmpty(diff["new_jobs"])
+	removed := listOrEmpty(diff["removed_jobs"])
+	if len(newJobs) != 1 || getString(mapOrNil(newJobs[0]), "job_url") != "https://www.linkedin.com/jobs/view/acme-4/" {
+		t.Fatalf("expected acme-4 as the only new job, got %#v", newJobs)
+	}
+	if len(removed) != 1 || getString(mapOrNil(removed[0]), "job_url") != "https://www.linkedin.com/jobs/view/acme-1/" {
+		t.Fatalf("expected acme-1 as the only removed job, got %#v", removed)
+	}
+	if got := intOrZero(diff["unchanged_count"]); got != 2 {
+		t.Fatalf("expected unchanged_count=2, got %d", got)
+	}
+
+	delete(args, "diff_against_run_id")
+	args["diff_against_last_run"] = true
+	third := runFakeVisaSearch(t, client, args)
+	auto := asMap(third["diff"])
+	if getString(auto, "baseline_run_id") != getString(second, "run_id") || intOrZero(auto["new_count"]) != 0 {
+		t.Fatalf("expected automatic diff against the second run with no new jobs, got %#v", auto)
+	}
+
+	if _, err := StartVisaJobSearch(map[string]any{
+		"user_id":             "u1",
+		"location":            "New York, NY",
+		"job_title":           "Software Engineer",
+		"diff_against_run_id": "missing",
+	}); err == nil {
+		t.Fatal("expected unknown diff_against_run_id to fail")
+	}
+}
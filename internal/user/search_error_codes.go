@@ -0,0 +1,114 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	searchErrorRateLimited = "rate_limited"
+	searchErrorBlocked     = "blocked_403"
+	searchErrorParse       = "parse_error"
+	searchErrorTimeout     = "timeout"
+	searchErrorCancelled   = "cancelled"
+	searchErrorInterrupted = "interrupted"
+	searchErrorNetwork     = "network_error"
+	searchErrorUpstream    = "upstream_error"
+	searchErrorUnknown     = "unknown"
+)
+
+var searchErrorGuidance = map[string]string{
+	searchErrorRateLimited:         "LinkedIn is rate limiting this host. Wait a few minutes, then retry with a smaller results_wanted or lower VISA_UPSTREAM_REQUESTS_PER_MINUTE.",
+	searchErrorBlocked:             "LinkedIn refused the request (403/999). Pause searches for a while; if a LinkedIn session is configured, refresh it with set_linkedin_session or clear it.",
+	searchErrorParse:               "The LinkedIn page could not be parsed, which usually means the page layout changed. Retry later and report the issue if it persists.",
+	searchErrorTimeout:             "LinkedIn did not respond in time. Retry the search; raise VISA_LINKEDIN_TIMEOUT_SECONDS on slow connections.",
+	searchErrorCancelled:           "The run was cancelled. Start a new search or continue_job_search when ready.",
+	searchErrorInterrupted:         "The server stopped while this run was in progress. Start the search again or continue_job_search.",
+	searchErrorNetwork:             "The network request failed before LinkedIn responded. Check connectivity with get_server_health, then retry.",
+	searchErrorUpstream:            "LinkedIn returned a server error. Retry shortly; these are usually temporary.",
+	searchErrorUpstreamUnavailable: "Recent LinkedIn requests kept failing, so new requests are paused. Wait until the retry time in the error, then start the search again; get_server_health shows the circuit state.",
+	searchErrorUnknown:             "The search failed unexpectedly. Check the error text, then retry or start a new search.",
+}
+
+// upstreamStatusError is returned when LinkedIn answers with an HTTP error
+// status. The message keeps the "status NNN" form transient detection keys on.
+type upstreamStatusError struct {
+	Status int
+}
+
+func (e *upstreamStatusError) Error() string {
+	return fmt.Sprintf("linkedin request failed with status %d", e.Status)
+}
+
+// searchParseError wraps failures to read an upstream page.
+type searchParseError struct {
+	Err error
+}
+
+func (e *searchParseError) Error() string {
+	return "could not parse linkedin page: " + e.Err.Error()
+}
+
+func (e *searchParseError) Unwrap() error {
+	return e.Err
+}
+
+// classifySearchError maps a run failure to a stable error_code agents can
+// branch on instead of matching error text.
+func classifySearchError(err error) string {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, errSearchRunCancelled) || errors.Is(err, context.Canceled) {
+		return searchErrorCancelled
+	}
+	if errors.Is(err, errUpstreamUnavailable) {
+		return searchErrorUpstreamUnavailable
+	}
+	var statusErr *upstreamStatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case isRateLimitStatus(statusErr.Status):
+			return searchErrorRateLimited
+		case statusErr.Status == 403 || statusErr.Status == 999:
+			return searchErrorBlocked
+		case statusErr.Status >= 500:
+			return searchErrorUpstream
+		}
+	}
+	if isRateLimitError(err) {
+		return searchErrorRateLimited
+	}
+	var parseErr *searchParseError
+	if errors.As(err, &parseErr) {
+		return searchErrorParse
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return searchErrorTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return searchErrorTimeout
+		}
+		return searchErrorNetwork
+	}
+	text := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(text, "timeout") || strings.Contains(text, "timed out"):
+		return searchErrorTimeout
+	case strings.Contains(text, "connection reset") || strings.Contains(text, "connection refused") || strings.Contains(text, "no such host"):
+		return searchErrorNetwork
+	}
+	return searchErrorUnknown
+}
+
+func searchErrorRecoveryGuidance(code string) any {
+	if guidance, ok := searchErrorGuidance[code]; ok {
+		return guidance
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type blockedLinkedInClient struct {
+	fakeLinkedInClient
+}
+
+func (b *blockedLinkedInClient) FetchSearchPage(linkedInSearchQuery, func() bool) ([]linkedInJob, error) {
+	return nil, &upstreamStatusError{Status: 403}
+}
+
+func TestClassifySearchError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{errSearchRunCancelled, searchErrorCancelled},
+		{&upstreamStatusError{Status: 429}, searchErrorRateLimited},
+		{errors.New("rate limited by upstream job source (429/Too Many Requests)"), searchErrorRateLimited},
+		{&upstreamStatusError{Status: 403}, searchErrorBlocked},
+		{fmt.Errorf("page 2: %w", &upstreamStatusError{Status: 999}), searchErrorBlocked},
+		{&upstreamStatusError{Status: 503}, searchErrorUpstream},
+		{&searchParseError{Err: errors.New("bad html")}, searchErrorParse},
+		{context.DeadlineExceeded, searchErrorTimeout},
+		{errors.New("Get \"https://www.linkedin.com\": net/http: request canceled (Client.Timeout exceeded)"), searchErrorTimeout},
+		{errors.New("read tcp: connection reset by peer"), searchErrorNetwork},
+		{errors.New("dataset missing"), searchErrorUnknown},
+	}
+	for _, tc := range cases {
+		if got := classifySearchError(tc.err); got != tc.want {
+			t.Fatalf("classifySearchError(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+	for code := range searchErrorGuidance {
+		if searchErrorRecoveryGuidance(code) == nil {
+			t.Fatalf("expected guidance for %q", code)
+		}
+	}
+}
+
+func TestFailedRunRecordsErrorCodeAndGuidance(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := t.TempDir() + "/companies.csv"
+	writeTestDataset(t, datasetPath)
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient { return &blockedLinkedInClient{} }
+
+	started, err := StartVisaJobSearch(map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	})
+	if err != nil {
+		t.Fatalf("StartVisaJobSearch failed: %v", err)
+	}
+	status := waitForTerminalRunStatus(t, "u1", getString(started, "run_id"), 3*time.Second)
+	if getString(status, "status") != "failed" || getString(status, "error_code") != searchErrorBlocked {
+		t.Fatalf("expected blocked_403 failure, got %v / %v", status["status"], status["error_code"])
+	}
+	if status["recovery_guidance"] != searchErrorGuidance[searchErrorBlocked] {
+		t.Fatalf("expected blocked guidance, got %v", status["recovery_guidance"])
+	}
+	if got := intOrZero(status["attempt_count"]); got != 1 {
+		t.Fatalf("expected a 403 not to be retried, got %d attempts", got)
+	}
+}
@@ -2,7 +2,6 @@ package user
 
 import (
 	"fmt"
-	"math"
 	"regexp"
 	"slices"
 	"strings"
@@ -16,6 +15,9 @@ var visaPositiveRegexes = []*regexp.Regexp{
 	regexp.MustCompile(`(?i)\bopt\b`),
 	regexp.MustCompile(`(?i)\bcpt\b`),
 	regexp.MustCompile(`(?i)\bgreen card\b`),
+	regexp.MustCompile(`(?i)\btn (?:visa|status)\b|\bsupport(?:s|ing)? tn\b`),
+	regexp.MustCompile(`(?i)\bo-?1a?\b|\bextraordinary ability\b`),
+	regexp.MustCompile(`(?i)\bl-1[ab]?\b|\bl1[ab]? (?:visa|transfer)\b|\bintra-?company transfer\b`),
 }
 
 var visaNegativeRegexes = []*regexp.Regexp{
@@ -62,6 +64,34 @@ func detectDescriptionSignals(description string) (positive bool, negative bool,
 	if regexp.MustCompile(`(?i)\bgreen card\b`).MatchString(text) || regexp.MustCompile(`(?i)\bperm\b`).MatchString(text) {
 		add("green_card")
 	}
+	if regexp.MustCompile(`(?i)\bskilled worker visa\b|\bcertificate of sponsorship\b|\btier 2\b`).MatchString(text) {
+		add("skilled_worker_uk")
+	}
+	if regexp.MustCompile(`(?i)\bsubclass 482\b|\b482 visa\b|\btss visa\b|\bskills in demand visa\b`).MatchString(text) {
+		add("au_482")
+	}
+	if regexp.MustCompile(`(?i)\bsubclass 186\b|\b186 visa\b|\bemployer nomination scheme\b`).MatchString(text) {
+		add("au_186")
+	}
+	if regexp.MustCompile(`(?i)\blmia\b|\blabou?r market impact assessment\b`).MatchString(text) {
+		add("ca_lmia")
+	}
+	// A bare "TN" is too often Tennessee; require visa wording.
+	if regexp.MustCompile(`(?i)\btn (?:visa|status)\b|\bsupport(?:s|ing)? tn\b|\btn-[12]\b|\busmca professional\b`).MatchString(text) {
+		add("tn")
+	}
+	if regexp.MustCompile(`(?i)\bo-?1a?\b|\bextraordinary ability\b`).MatchString(text) {
+		add("o1")
+	}
+	if regexp.MustCompile(`(?i)\bopt\b|\bcpt\b`).MatchString(text) {
+		add("f1_opt")
+	}
+	if regexp.MustCompile(`(?i)\bstem opt\b`).MatchString(text) {
+		add("stem_opt")
+	}
+	if regexp.MustCompile(`(?i)\bl-1[ab]?\b|\bl1[ab]? (?:visa|transfer)\b|\bintra-?company transfer(?:ee)?s?\b`).MatchString(text) {
+		add("l1")
+	}
 	return positive, negative, out
 }
 
@@ -88,39 +118,9 @@ func labelsForDesiredVisas(desired []string) []string {
 	return labels
 }
 
-func confidenceScore(
-	desiredCount int,
-	totalCount int,
-	descriptionPositive bool,
-	descriptionNegative bool,
-	descriptionDesiredMention bool,
-) float64 {
-	score := 0.0
-	if desiredCount > 0 {
-		score += 0.65
-		score += math.Min(0.2, float64(desiredCount)/50.0)
-	}
-	if descriptionPositive {
-		score += 0.1
-	}
-	if descriptionDesiredMention {
-		score += 0.2
-	}
-	if descriptionNegative {
-		score -= 0.6
-	}
-	if desiredCount == 0 && totalCount > 0 {
-		score += 0.05
-	}
-	if score < 0 {
-		score = 0
-	}
-	if score > 1 {
-		score = 1
-	}
-	return math.Round(score*100) / 100
-}
-
+// confidenceScore combines dataset and description evidence. datasetFactor
+// (sponsorshipRecency times sponsorshipLocality) scales the dataset terms so
+// stale or out-of-area sponsors rank lower.
 func visaMatchStrength(desiredCount int, descriptionDesiredMention bool, descriptionPositive bool) string {
 	if desiredCount > 0 && descriptionDesiredMention {
 		return "strong"
@@ -164,6 +164,7 @@ func shouldAcceptJob(
 	descriptionNegative bool,
 	descriptionDesiredMention bool,
 	requireDescriptionSignal bool,
+	titleMatch bool,
 ) bool {
 	if descriptionNegative {
 		return false
@@ -177,8 +178,8 @@ func shouldAcceptJob(
 	if companyEligible || descriptionEligible {
 		return true
 	}
-	if strictness == "balanced" {
-		return false
+	if strictness == "lenient" {
+		return descriptionPositive || titleMatch
 	}
 	return false
 }
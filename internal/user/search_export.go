@@ -0,0 +1,204 @@
+package user
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	searchExportFormats    = []string{"csv", "markdown"}
+	searchExportExtensions = map[string]string{"csv": ".csv", "markdown": ".md"}
+	searchExportCSVHeader  = []string{
+		"result_id",
+		"title",
+		"company",
+		"location",
+		"date_posted",
+		"visas_sponsored",
+		"visa_match_strength",
+		"confidence_score",
+		"salary_text",
+		"job_url",
+		"job_url_direct",
+	}
+)
+
+func normalizeSearchExportFormat(raw string) (string, error) {
+	clean := strings.ToLower(strings.TrimSpace(raw))
+	switch clean {
+	case "", "md":
+		return "markdown", nil
+	case "csv", "markdown":
+		return clean, nil
+	}
+	return "", fmt.Errorf("format must be one of [%s]", strings.Join(searchExportFormats, " "))
+}
+
+func exportVisaList(job map[string]any) string {
+	return strings.Join(getStringList(job, "visas_sponsored"), ", ")
+}
+
+func exportConfidence(job map[string]any) string {
+	value, ok := job["confidence_score"].(float64)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+// csvCell quotes cells that a spreadsheet would evaluate as a formula. Titles
+// and company names are scraped, so they cannot be trusted.
+func csvCell(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+func renderSearchExportCSV(jobs []map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(searchExportCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		row := []string{
+			getString(job, "result_id"),
+			getString(job, "title"),
+			getString(job, "company"),
+			getString(job, "location"),
+			getString(job, "date_posted"),
+			exportVisaList(job),
+			getString(job, "visa_match_strength"),
+			exportConfidence(job),
+			getString(job, "salary_text"),
+			getString(job, "job_url"),
+			getString(job, "job_url_direct"),
+		}
+		for i, cell := range row {
+			row[i] = csvCell(cell)
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+func markdownCell(text string) string {
+	clean := normalizeWhitespace(text)
+	if clean == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(clean, "|", `\|`)
+}
+
+func renderSearchExportMarkdown(title string, query map[string]any, sessionID string, jobs []map[string]any) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s\n\n", normalizeWhitespace(title))
+	fmt.Fprintf(&buf, "- Search: %s in %s\n", markdownCell(getString(query, "job_title")), markdownCell(getString(query, "location")))
+	if visas := labelsForDesiredVisas(getStringList(query, "preferred_visa_types")); len(visas) > 0 {
+		fmt.Fprintf(&buf, "- Visa types: %s\n", strings.Join(visas, ", "))
+	}
+	fmt.Fprintf(&buf, "- Session: `%s`\n", sessionID)
+	fmt.Fprintf(&buf, "- Generated: %s\n- Jobs: %d\n\n", utcNowISO(), len(jobs))
+	if len(jobs) == 0 {
+		buf.WriteString("No accepted jobs in this session.\n")
+		return buf.Bytes()
+	}
+	buf.WriteString("| # | Title | Company | Location | Visa signals | Confidence | Salary | Link |\n")
+	buf.WriteString("|---|---|---|---|---|---|---|---|\n")
+	for idx, job := range jobs {
+		link := "-"
+		if url := reportSafeURL(getString(job, "job_url")); url != "" {
+			link = fmt.Sprintf("[View](%s)", string(url))
+		}
+		visas := exportVisaList(job)
+		if strength := getString(job, "visa_match_strength"); strength != "" {
+			visas = strings.TrimSpace(fmt.Sprintf("%s (%s)", visas, strength))
+		}
+		fmt.Fprintf(&buf, "| %d | %s | %s | %s | %s | %s | %s | %s |\n",
+			idx+1,
+			markdownCell(getString(job, "title")),
+			markdownCell(getString(job, "company")),
+			markdownCell(getString(job, "location")),
+			markdownCell(visas),
+			markdownCell(exportConfidence(job)),
+			markdownCell(getString(job, "salary_text")),
+			link,
+		)
+	}
+	return buf.Bytes()
+}
+
+func ExportSearchResults(args map[string]any) (map[string]any, error) {
+	format, err := normalizeSearchExportFormat(getString(args, "format"))
+	if err != nil {
+		return nil, err
+	}
+	sessionID, session, err := resolveResultSession(args)
+	if err != nil {
+		return nil, err
+	}
+	maxJobs := defaultReportMaxJobs
+	if parsed, has, err := getOptionalInt(args, "max_jobs"); has {
+		if err != nil {
+			return nil, fmt.Errorf("max_jobs must be an integer when provided")
+		}
+		if parsed < 1 {
+			return nil, fmt.Errorf("max_jobs must be >= 1")
+		}
+		maxJobs = min(parsed, maxReportMaxJobsLimit)
+	}
+	query := asMap(session["query"])
+	available := sessionAcceptedJobs(session)
+	jobs := available[:min(len(available), maxJobs)]
+
+	var content []byte
+	if format == "csv" {
+		if content, err = renderSearchExportCSV(jobs); err != nil {
+			return nil, fmt.Errorf("render csv export: %w", err)
+		}
+	} else {
+		title := getString(args, "title")
+		if title == "" {
+			title = fmt.Sprintf("%s jobs in %s", getString(query, "job_title"), getString(query, "location"))
+		}
+		content = renderSearchExportMarkdown(title, query, sessionID, jobs)
+	}
+
+	extension := searchExportExtensions[format]
+	outputPath := getString(args, "output_path")
+	if outputPath == "" {
+		outputPath = filepath.Join(reportsDir(), fmt.Sprintf("results_%s%s", sessionID, extension))
+	}
+	if !strings.HasSuffix(strings.ToLower(outputPath), extension) {
+		return nil, fmt.Errorf("output_path must end with %s for format %s", extension, format)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(outputPath, content, 0o644); err != nil {
+		return nil, err
+	}
+	absPath, err := filepath.Abs(outputPath)
+	if err != nil {
+		absPath = outputPath
+	}
+	return map[string]any{
+		"user_id":           getString(args, "user_id"),
+		"search_session_id": sessionID,
+		"format":            format,
+		"output_path":       absPath,
+		"file_url":          fileURL(absPath),
+		"jobs_exported":     len(jobs),
+		"jobs_available":    len(available),
+		"bytes_written":     len(content),
+		"content":           string(content),
+	}, nil
+}
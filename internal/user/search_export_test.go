@@ -0,0 +1,116 @@
+package user
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runExportFixtureSearch(t *testing.T) map[string]any {
+	t.Helper()
+	setupUserToolPaths(t)
+	root := t.TempDir()
+	t.Setenv("VISA_REPORTS_DIR", filepath.Join(root, "reports"))
+	datasetPath := filepath.Join(root, "companies.csv")
+	writeTestDataset(t, datasetPath)
+	if _, err := SetUserPreferences(map[string]any{"user_id": "u1", "preferred_visa_types": []any{"E3"}}); err != nil {
+		t.Fatalf("SetUserPreferences failed: %v", err)
+	}
+	client := &fakeLinkedInClient{pages: map[int][]linkedInJob{0: {
+		{JobURL: "https://www.linkedin.com/jobs/view/1/", Title: "Software Engineer | Platform", Company: "Acme Inc", Location: "New York, NY", SalaryText: "$150,000 - $180,000"},
+		{JobURL: "https://www.linkedin.com/jobs/view/2/", Title: "Backend Engineer", Company: "Acme Inc", Location: "New York, NY"},
+	}}}
+	return runFakeVisaSearch(t, client, map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	})
+}
+
+func TestExportSearchResultsMarkdown(t *testing.T) {
+	results := runExportFixtureSearch(t)
+	sessionID := getString(asMap(asMap(results["status"])["search_session"]), "session_id")
+
+	out, err := ExportSearchResults(map[string]any{"user_id": "u1", "session_id": sessionID})
+	if err != nil {
+		t.Fatalf("ExportSearchResults failed: %v", err)
+	}
+	if getString(out, "format") != "markdown" || intOrZero(out["jobs_exported"]) != 2 {
+		t.Fatalf("unexpected export summary: %#v", out)
+	}
+	content := getString(out, "content")
+	for _, want := range []string{
+		"# Software Engineer jobs in New York, NY",
+		`Software Engineer \| Platform`,
+		"E-3 Australian (company_dataset)",
+		"[View](https://www.linkedin.com/jobs/view/1/)",
+		"$150,000 - $180,000",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected markdown to contain %q, got:\n%s", want, content)
+		}
+	}
+	if !strings.HasSuffix(getString(out, "output_path"), ".md") {
+		t.Fatalf("expected a .md output path, got %q", out["output_path"])
+	}
+}
+
+func TestExportSearchResultsCSV(t *testing.T) {
+	results := runExportFixtureSearch(t)
+
+	out, err := ExportSearchResults(map[string]any{"user_id": "u1", "run_id": getString(results, "run_id"), "format": "csv", "max_jobs": 1})
+	if err != nil {
+		t.Fatalf("ExportSearchResults failed: %v", err)
+	}
+	raw, err := os.ReadFile(getString(out, "output_path"))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(string(raw))).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(rows) != 2 || rows[0][1] != "title" || rows[1][1] != "Software Engineer | Platform" {
+		t.Fatalf("unexpected csv rows: %#v", rows)
+	}
+	if intOrZero(out["jobs_available"]) != 2 {
+		t.Fatalf("expected jobs_available=2, got %#v", out["jobs_available"])
+	}
+}
+
+func TestExportSearchResultsValidatesFormatAndPath(t *testing.T) {
+	results := runExportFixtureSearch(t)
+	runID := getString(results, "run_id")
+	if _, err := ExportSearchResults(map[string]any{"user_id": "u1", "run_id": runID, "format": "pdf"}); err == nil {
+		t.Fatal("expected an unsupported format to be rejected")
+	}
+	if _, err := ExportSearchResults(map[string]any{"user_id": "u1", "run_id": runID, "format": "csv", "output_path": filepath.Join(t.TempDir(), "out.md")}); err == nil {
+		t.Fatal("expected a mismatched extension to be rejected")
+	}
+}
+
+func TestSearchExportCSVNeutralizesFormulas(t *testing.T) {
+	raw, err := renderSearchExportCSV([]map[string]any{{
+		"title":    `=HYPERLINK("https://evil.example","Apply")`,
+		"company":  "@SUM(A1:A9)",
+		"location": "-2+3",
+		"job_url":  "https://www.linkedin.com/jobs/view/1/",
+	}})
+	if err != nil {
+		t.Fatalf("renderSearchExportCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(string(raw))).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	row := rows[1]
+	if row[1] != `'=HYPERLINK("https://evil.example","Apply")` || row[2] != "'@SUM(A1:A9)" || row[3] != "'-2+3" {
+		t.Fatalf("expected formula cells to be prefixed, got %#v", row)
+	}
+	if row[9] != "https://www.linkedin.com/jobs/view/1/" {
+		t.Fatalf("expected plain cells unchanged, got %q", row[9])
+	}
+}
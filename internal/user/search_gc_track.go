@@ -0,0 +1,30 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// gcTrackRegex matches listings that promise permanent residency, which is a
+// separate commitment from work-visa sponsorship: the employer files PERM
+// and an I-140 for the candidate.
+var gcTrackRegex = regexp.MustCompile(`(?i)\b(?:green card|permanent residen(?:cy|ce)|gc|lawful permanent resident) (?:sponsorship|process|processing|application|filing)\b|\bsponsor\w* (?:your |a |the |for )?(?:green cards?|permanent residen(?:cy|ce))\b|\bperm (?:process|labor certification|application|filing|sponsorship)\b|\bgc (?:from|on) day (?:one|1)\b|\bgreen card (?:from|on) day (?:one|1)\b|\bi-?140\b`)
+
+// gcTrackClosedRegex matches listings that rule green card sponsorship out
+// even when they offer a work visa.
+var gcTrackClosedRegex = regexp.MustCompile(`(?i)\b(?:no|not|without|does not|do not|don't|unable to|cannot|will not)\b[^.]{0,30}\b(?:green cards?|permanent residen(?:cy|ce)|perm)\b`)
+
+// gcTrackListing reports whether the listing commits to a green card process.
+func gcTrackListing(description string) bool {
+	return gcTrackRegex.MatchString(description) && !gcTrackClosedRegex.MatchString(description)
+}
+
+func parseGCTrackOptions(args map[string]any, query map[string]any) error {
+	if value, has, err := getOptionalBool(args, "require_gc_track"); has {
+		if err != nil {
+			return fmt.Errorf("require_gc_track must be a boolean when provided")
+		}
+		query["require_gc_track"] = value
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGCTrackListingDetection(t *testing.T) {
+	for _, text := range []string{
+		"We offer green card sponsorship for all engineers.",
+		"The PERM process starts after six months.",
+		"GC from day one.",
+		"We will sponsor your green card.",
+	} {
+		if !gcTrackListing(text) {
+			t.Fatalf("expected %q to be a green card track", text)
+		}
+	}
+	for _, text := range []string{
+		"We sponsor H-1B visas.",
+		"H-1B sponsorship available; we do not sponsor green cards.",
+		"Must be a US citizen or green card holder.",
+	} {
+		if gcTrackListing(text) {
+			t.Fatalf("expected %q not to be a green card track", text)
+		}
+	}
+}
+
+func TestRequireGCTrackFiltersListings(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/acme-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/acme-2/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/acme-1/": "H-1B sponsorship available.",
+			"https://www.linkedin.com/jobs/view/acme-2/": "H-1B sponsorship and green card sponsorship from day one.",
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":              "u1",
+		"location":             "United States",
+		"job_title":            "Software Engineer",
+		"dataset_path":         datasetPath,
+		"results_wanted":       5,
+		"preferred_visa_types": []any{"h1b"},
+		"require_gc_track":     true,
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 {
+		t.Fatalf("expected only the green card listing, got %#v", jobs)
+	}
+	job := asMap(jobs[0])
+	if getString(job, "job_url") != "https://www.linkedin.com/jobs/view/acme-2/" || job["gc_track"] != true {
+		t.Fatalf("unexpected job: %#v", job)
+	}
+	if intOrZero(asMap(results["stats"])["gc_track_filtered_out"]) != 1 {
+		t.Fatalf("unexpected stats: %#v", results["stats"])
+	}
+}
@@ -0,0 +1,181 @@
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	linkedInGeoTypeaheadURL = "https://www.linkedin.com/jobs-guest/api/typeaheadHits"
+	defaultGeoIDCachePath   = "data/config/geo_id_cache.json"
+	geoIDCacheTTL           = 30 * 24 * time.Hour
+	geoIDNegativeCacheTTL   = 24 * time.Hour
+	geoIDSourceExplicit     = "explicit"
+	geoIDSourceBuiltin      = "builtin"
+	geoIDSourceCache        = "cache"
+	geoIDSourceTypeahead    = "typeahead"
+	geoIDSourceUnresolved   = "unresolved"
+	geoIDSourceDisabled     = "disabled"
+	geoIDSourceNotSupported = "not_supported"
+)
+
+var (
+	geoIDCacheMu  sync.Mutex
+	geoIDPattern  = regexp.MustCompile(`^\d{4,12}$`)
+	builtinGeoIDs = map[string]linkedInGeo{
+		"united states":                   {ID: "103644278", DisplayName: "United States"},
+		"usa":                             {ID: "103644278", DisplayName: "United States"},
+		"canada":                          {ID: "101174742", DisplayName: "Canada"},
+		"united kingdom":                  {ID: "101165590", DisplayName: "United Kingdom"},
+		"uk":                              {ID: "101165590", DisplayName: "United Kingdom"},
+		"india":                           {ID: "102713980", DisplayName: "India"},
+		"new york city metropolitan area": {ID: "90000070", DisplayName: "New York City Metropolitan Area"},
+		"san francisco bay area":          {ID: "90000084", DisplayName: "San Francisco Bay Area"},
+		"greater seattle area":            {ID: "90000091", DisplayName: "Greater Seattle Area"},
+		"greater boston":                  {ID: "90000007", DisplayName: "Greater Boston"},
+	}
+)
+
+type linkedInGeo struct {
+	ID          string
+	DisplayName string
+}
+
+// geoResolver is implemented by clients that can turn free-text locations
+// into LinkedIn geoIds. Clients without it search by the location keyword.
+type geoResolver interface {
+	ResolveGeoID(location string, isCancelled func() bool) (linkedInGeo, error)
+}
+
+func geoIDCachePath() string {
+	return envOrDefault("VISA_GEO_ID_CACHE_PATH", defaultGeoIDCachePath)
+}
+
+func normalizeGeoID(raw string) (string, error) {
+	clean := strings.TrimSpace(raw)
+	if !geoIDPattern.MatchString(clean) {
+		return "", fmt.Errorf("geo_id must be a numeric LinkedIn geoId")
+	}
+	return clean, nil
+}
+
+func geoLookupKey(location string) string {
+	return strings.ToLower(normalizeWhitespace(location))
+}
+
+// resolveSearchGeo picks the geoId for a search: an explicit geo_id wins,
+// then the built-in metro table, the local cache, and finally LinkedIn's
+// location typeahead. Failures fall back to the keyword location.
+func resolveSearchGeo(client linkedInClient, query searchQuery, isCancelled func() bool) (linkedInGeo, string) {
+	if query.GeoID != "" {
+		return linkedInGeo{ID: query.GeoID}, geoIDSourceExplicit
+	}
+	if query.SkipGeoResolution {
+		return linkedInGeo{}, geoIDSourceDisabled
+	}
+	key := geoLookupKey(query.Location)
+	if key == "" {
+		return linkedInGeo{}, geoIDSourceUnresolved
+	}
+	if geo, ok := builtinGeoIDs[key]; ok {
+		return geo, geoIDSourceBuiltin
+	}
+	if geo, ok := loadCachedGeo(key); ok {
+		if geo.ID == "" {
+			return linkedInGeo{}, geoIDSourceUnresolved
+		}
+		return geo, geoIDSourceCache
+	}
+	resolver, ok := client.(geoResolver)
+	if !ok {
+		return linkedInGeo{}, geoIDSourceNotSupported
+	}
+	geo, err := resolver.ResolveGeoID(query.Location, isCancelled)
+	if err != nil {
+		// Transient lookup errors are not cached so the next run tries again.
+		return linkedInGeo{}, geoIDSourceUnresolved
+	}
+	storeCachedGeo(key, geo)
+	if geo.ID == "" {
+		return linkedInGeo{}, geoIDSourceUnresolved
+	}
+	return geo, geoIDSourceTypeahead
+}
+
+func loadCachedGeo(key string) (linkedInGeo, bool) {
+	geoIDCacheMu.Lock()
+	defer geoIDCacheMu.Unlock()
+	data := loadJSONMap(geoIDCachePath(), map[string]any{"entries": map[string]any{}})
+	entry := mapOrNil(asMap(data["entries"])[key])
+	resolvedAt := parseISOTime(entry["resolved_at_utc"])
+	if entry == nil || resolvedAt.IsZero() {
+		return linkedInGeo{}, false
+	}
+	geo := linkedInGeo{ID: getString(entry, "geo_id"), DisplayName: getString(entry, "display_name")}
+	ttl := geoIDCacheTTL
+	if geo.ID == "" {
+		ttl = geoIDNegativeCacheTTL
+	}
+	if utcNow().Sub(resolvedAt) > ttl {
+		return linkedInGeo{}, false
+	}
+	return geo, true
+}
+
+func storeCachedGeo(key string, geo linkedInGeo) {
+	geoIDCacheMu.Lock()
+	defer geoIDCacheMu.Unlock()
+	data := loadJSONMap(geoIDCachePath(), map[string]any{"entries": map[string]any{}})
+	entries := asMap(data["entries"])
+	entries[key] = map[string]any{
+		"geo_id":          geo.ID,
+		"display_name":    geo.DisplayName,
+		"resolved_at_utc": utcNowISO(),
+	}
+	data["entries"] = entries
+	_ = saveJSONMap(geoIDCachePath(), data)
+}
+
+// parseGeoTypeaheadHits returns the first hit from LinkedIn's typeahead
+// response, or an empty geo when nothing matched.
+func parseGeoTypeaheadHits(body []byte) (linkedInGeo, error) {
+	var hits []struct {
+		ID          json.RawMessage `json:"id"`
+		DisplayName string          `json:"displayName"`
+	}
+	if err := json.Unmarshal(body, &hits); err != nil {
+		return linkedInGeo{}, fmt.Errorf("parse geo typeahead response: %w", err)
+	}
+	for _, hit := range hits {
+		id := strings.Trim(strings.TrimSpace(string(hit.ID)), `"`)
+		if geoIDPattern.MatchString(id) {
+			return linkedInGeo{ID: id, DisplayName: normalizeWhitespace(hit.DisplayName)}, nil
+		}
+	}
+	return linkedInGeo{}, nil
+}
+
+func (c *liveLinkedInClient) ResolveGeoID(location string, isCancelled func() bool) (linkedInGeo, error) {
+	resp, err := c.request(func() (*resty.Response, error) {
+		return c.httpClient.R().
+			SetHeaders(c.requestHeaders(false)).
+			SetHeader("Accept", "application/json").
+			SetQueryParams(map[string]string{
+				"origin":        "jserp",
+				"typeaheadType": "GEO",
+				"geoTypes":      "POPULATED_PLACE,ADMIN_DIVISION_2,MARKET_AREA,COUNTRY_REGION",
+				"query":         location,
+			}).
+			Get(linkedInGeoTypeaheadURL)
+	}, isCancelled)
+	if err != nil {
+		return linkedInGeo{}, err
+	}
+	return parseGeoTypeaheadHits(resp.Body())
+}
@@ -0,0 +1,98 @@
+package user
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+type geoFakeClient struct {
+	fakeLinkedInClient
+	geo     linkedInGeo
+	err     error
+	lookups int
+}
+
+func (c *geoFakeClient) ResolveGeoID(location string, isCancelled func() bool) (linkedInGeo, error) {
+	c.lookups++
+	return c.geo, c.err
+}
+
+func TestParseGeoTypeaheadHits(t *testing.T) {
+	geo, err := parseGeoTypeaheadHits([]byte(`[{"id":"90000070","displayName":"New York City Metropolitan Area","type":"GEO"}]`))
+	if err != nil || geo.ID != "90000070" || geo.DisplayName != "New York City Metropolitan Area" {
+		t.Fatalf("unexpected geo %#v (%v)", geo, err)
+	}
+	geo, err = parseGeoTypeaheadHits([]byte(`[{"id":102571732,"displayName":"New York, NY"}]`))
+	if err != nil || geo.ID != "102571732" {
+		t.Fatalf("expected numeric id to parse, got %#v (%v)", geo, err)
+	}
+	if geo, err := parseGeoTypeaheadHits([]byte(`[]`)); err != nil || geo.ID != "" {
+		t.Fatalf("expected empty geo for no hits, got %#v (%v)", geo, err)
+	}
+}
+
+func TestResolveSearchGeoUsesCacheAfterTypeahead(t *testing.T) {
+	t.Setenv("VISA_GEO_ID_CACHE_PATH", filepath.Join(t.TempDir(), "geo_id_cache.json"))
+	client := &geoFakeClient{geo: linkedInGeo{ID: "102571732", DisplayName: "New York, New York, United States"}}
+	query := searchQuery{Location: "New York, NY"}
+
+	geo, source := resolveSearchGeo(client, query, func() bool { return false })
+	if geo.ID != "102571732" || source != geoIDSourceTypeahead {
+		t.Fatalf("expected typeahead resolution, got %#v from %s", geo, source)
+	}
+	geo, source = resolveSearchGeo(client, query, func() bool { return false })
+	if geo.ID != "102571732" || source != geoIDSourceCache || client.lookups != 1 {
+		t.Fatalf("expected cached resolution without a second lookup, got %#v from %s (%d lookups)", geo, source, client.lookups)
+	}
+}
+
+func TestResolveSearchGeoPrecedence(t *testing.T) {
+	t.Setenv("VISA_GEO_ID_CACHE_PATH", filepath.Join(t.TempDir(), "geo_id_cache.json"))
+	client := &geoFakeClient{err: errors.New("connection reset")}
+	never := func() bool { return false }
+
+	if geo, source := resolveSearchGeo(client, searchQuery{Location: "Boston", GeoID: "123456"}, never); geo.ID != "123456" || source != geoIDSourceExplicit {
+		t.Fatalf("expected explicit geo_id to win, got %#v from %s", geo, source)
+	}
+	if geo, source := resolveSearchGeo(client, searchQuery{Location: "San Francisco Bay Area"}, never); geo.ID != "90000084" || source != geoIDSourceBuiltin {
+		t.Fatalf("expected builtin metro, got %#v from %s", geo, source)
+	}
+	if _, source := resolveSearchGeo(client, searchQuery{Location: "Boston", SkipGeoResolution: true}, never); source != geoIDSourceDisabled || client.lookups != 0 {
+		t.Fatalf("expected resolution to be skipped, got %s", source)
+	}
+	if geo, source := resolveSearchGeo(client, searchQuery{Location: "Boston"}, never); geo.ID != "" || source != geoIDSourceUnresolved {
+		t.Fatalf("expected lookup errors to fall back to the keyword, got %#v from %s", geo, source)
+	}
+	if _, cached := loadCachedGeo("boston"); cached {
+		t.Fatal("expected failed lookups to not be cached")
+	}
+	if _, source := resolveSearchGeo(&fakeLinkedInClient{}, searchQuery{Location: "Boston"}, never); source != geoIDSourceNotSupported {
+		t.Fatalf("expected clients without a resolver to report not_supported, got %s", source)
+	}
+}
+
+func TestLinkedInSearchParamsIncludeGeoID(t *testing.T) {
+	params := linkedInSearchParams(linkedInSearchQuery{JobTitle: "Engineer", Location: "New York, NY", GeoID: "90000070"})
+	if params["geoId"] != "90000070" || params["location"] != "New York, NY" {
+		t.Fatalf("expected geoId alongside location, got %#v", params)
+	}
+	if _, ok := linkedInSearchParams(linkedInSearchQuery{JobTitle: "Engineer"})["geoId"]; ok {
+		t.Fatal("expected no geoId param without a resolved geo")
+	}
+}
+
+func TestParseSearchOptionsValidatesGeoID(t *testing.T) {
+	if err := parseSearchOptions(map[string]any{"geo_id": "new york"}, map[string]any{}); err == nil {
+		t.Fatal("expected non-numeric geo_id to be rejected")
+	}
+	query := map[string]any{}
+	if err := parseSearchOptions(map[string]any{"geo_id": " 90000070 ", "resolve_geo_id": false}, query); err != nil {
+		t.Fatalf("parseSearchOptions failed: %v", err)
+	}
+	parsed := searchQuery{}
+	applySearchOptions(query, &parsed)
+	if parsed.GeoID != "90000070" || !parsed.SkipGeoResolution {
+		t.Fatalf("unexpected parsed geo options: %#v", parsed)
+	}
+}
@@ -0,0 +1,137 @@
+package user
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	headerRotationRequest = "request"
+	headerRotationPage    = "page"
+	headerRotationOff     = "off"
+)
+
+const defaultAcceptHeader = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+
+// builtinHeaderProfiles are realistic desktop browser header sets. The first
+// one matches the single Chrome profile the client used before rotation.
+var builtinHeaderProfiles = []map[string]string{
+	{
+		"User-Agent":         "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		"Accept":             defaultAcceptHeader,
+		"Accept-Language":    "en-US,en;q=0.9",
+		"Sec-Ch-Ua":          `"Not_A Brand";v="8", "Chromium";v="120", "Google Chrome";v="120"`,
+		"Sec-Ch-Ua-Mobile":   "?0",
+		"Sec-Ch-Ua-Platform": `"macOS"`,
+	},
+	{
+		"User-Agent":         "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/121.0.0.0 Safari/537.36",
+		"Accept":             defaultAcceptHeader,
+		"Accept-Language":    "en-US,en;q=0.9",
+		"Sec-Ch-Ua":          `"Not A(Brand";v="99", "Google Chrome";v="121", "Chromium";v="121"`,
+		"Sec-Ch-Ua-Mobile":   "?0",
+		"Sec-Ch-Ua-Platform": `"Windows"`,
+	},
+	{
+		"User-Agent":         "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/121.0.0.0 Safari/537.36 Edg/121.0.0.0",
+		"Accept":             defaultAcceptHeader,
+		"Accept-Language":    "en-US,en;q=0.9",
+		"Sec-Ch-Ua":          `"Not A(Brand";v="99", "Microsoft Edge";v="121", "Chromium";v="121"`,
+		"Sec-Ch-Ua-Mobile":   "?0",
+		"Sec-Ch-Ua-Platform": `"Windows"`,
+	},
+	{
+		"User-Agent":      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:122.0) Gecko/20100101 Firefox/122.0",
+		"Accept":          "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"Accept-Language": "en-US,en;q=0.5",
+	},
+	{
+		"User-Agent":      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Safari/605.1.15",
+		"Accept":          defaultAcceptHeader,
+		"Accept-Language": "en-US,en;q=0.9",
+	},
+	{
+		"User-Agent":         "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		"Accept":             defaultAcceptHeader,
+		"Accept-Language":    "en-US,en;q=0.9",
+		"Sec-Ch-Ua":          `"Not_A Brand";v="8", "Chromium";v="120", "Google Chrome";v="120"`,
+		"Sec-Ch-Ua-Mobile":   "?0",
+		"Sec-Ch-Ua-Platform": `"Linux"`,
+	},
+}
+
+func headerRotationMode() string {
+	switch mode := strings.ToLower(strings.TrimSpace(envOrDefault("VISA_HEADER_ROTATION", headerRotationRequest))); mode {
+	case headerRotationPage, headerRotationOff:
+		return mode
+	default:
+		return headerRotationRequest
+	}
+}
+
+// loadHeaderProfiles reads VISA_HEADER_PROFILES_PATH, a JSON list of header
+// maps (or {"profiles": [...]}). Profiles without a User-Agent are dropped,
+// and an unreadable or empty file falls back to the built-in set.
+func loadHeaderProfiles() []map[string]string {
+	path := strings.TrimSpace(os.Getenv("VISA_HEADER_PROFILES_PATH"))
+	if path == "" {
+		return builtinHeaderProfiles
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return builtinHeaderProfiles
+	}
+	var wrapped struct {
+		Profiles []map[string]string `json:"profiles"`
+	}
+	var profiles []map[string]string
+	if err := json.Unmarshal(raw, &profiles); err != nil {
+		if err := json.Unmarshal(raw, &wrapped); err != nil {
+			return builtinHeaderProfiles
+		}
+		profiles = wrapped.Profiles
+	}
+	out := []map[string]string{}
+	for _, profile := range profiles {
+		if strings.TrimSpace(profile["User-Agent"]) != "" {
+			out = append(out, profile)
+		}
+	}
+	if len(out) == 0 {
+		return builtinHeaderProfiles
+	}
+	return out
+}
+
+// headerRotator hands out header profiles round-robin from a random starting
+// point. In page mode only listing-page requests advance the rotation, so a
+// page and the descriptions fetched after it share one browser identity.
+type headerRotator struct {
+	mu       sync.Mutex
+	profiles []map[string]string
+	mode     string
+	next     int
+	current  int
+}
+
+func newHeaderRotator(profiles []map[string]string, mode string) *headerRotator {
+	start := 0
+	if mode != headerRotationOff && len(profiles) > 1 {
+		start = rand.IntN(len(profiles))
+	}
+	return &headerRotator{profiles: profiles, mode: mode, next: start, current: start}
+}
+
+func (r *headerRotator) headers(listingPage bool) map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	advance := r.mode == headerRotationRequest || (r.mode == headerRotationPage && listingPage)
+	if advance {
+		r.current = r.next
+		r.next = (r.next + 1) % len(r.profiles)
+	}
+	return r.profiles[r.current]
+}
@@ -0,0 +1,72 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHeaderRotatorRequestModeCyclesProfiles(t *testing.T) {
+	profiles := []map[string]string{{"User-Agent": "a"}, {"User-Agent": "b"}, {"User-Agent": "c"}}
+	rotator := newHeaderRotator(profiles, headerRotationRequest)
+	seen := map[string]bool{}
+	for i := 0; i < len(profiles); i++ {
+		seen[rotator.headers(false)["User-Agent"]] = true
+	}
+	if len(seen) != len(profiles) {
+		t.Fatalf("expected every profile once per cycle, got %v", seen)
+	}
+}
+
+func TestHeaderRotatorPageModeKeepsProfileBetweenPages(t *testing.T) {
+	profiles := []map[string]string{{"User-Agent": "a"}, {"User-Agent": "b"}}
+	rotator := newHeaderRotator(profiles, headerRotationPage)
+	page := rotator.headers(true)["User-Agent"]
+	if got := rotator.headers(false)["User-Agent"]; got != page {
+		t.Fatalf("expected description fetch to reuse page profile %q, got %q", page, got)
+	}
+	if got := rotator.headers(true)["User-Agent"]; got == page {
+		t.Fatalf("expected next page to rotate away from %q", page)
+	}
+}
+
+func TestHeaderRotatorOffModeUsesFirstProfile(t *testing.T) {
+	profiles := []map[string]string{{"User-Agent": "a"}, {"User-Agent": "b"}}
+	rotator := newHeaderRotator(profiles, headerRotationOff)
+	for i := 0; i < 3; i++ {
+		if got := rotator.headers(true)["User-Agent"]; got != "a" {
+			t.Fatalf("expected pinned profile 'a', got %q", got)
+		}
+	}
+}
+
+func TestLoadHeaderProfilesFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	body := `{"profiles":[{"User-Agent":"custom/1.0","Accept-Language":"en-GB"},{"Accept":"text/html"}]}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VISA_HEADER_PROFILES_PATH", path)
+	profiles := loadHeaderProfiles()
+	if len(profiles) != 1 || profiles[0]["User-Agent"] != "custom/1.0" {
+		t.Fatalf("expected only the profile with a User-Agent, got %v", profiles)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadHeaderProfiles(); len(got) != len(builtinHeaderProfiles) {
+		t.Fatalf("expected built-in fallback for invalid file, got %d profiles", len(got))
+	}
+}
+
+func TestHeaderRotationModeFallsBackToRequest(t *testing.T) {
+	t.Setenv("VISA_HEADER_ROTATION", "PAGE")
+	if got := headerRotationMode(); got != headerRotationPage {
+		t.Fatalf("expected page mode, got %q", got)
+	}
+	t.Setenv("VISA_HEADER_ROTATION", "sometimes")
+	if got := headerRotationMode(); got != headerRotationRequest {
+		t.Fatalf("expected request fallback, got %q", got)
+	}
+}
@@ -0,0 +1,44 @@
+package user
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultUpstreamMaxIdleConnsPerHost = 8
+
+var (
+	sharedUpstreamTransportOnce sync.Once
+	sharedUpstreamTransportPtr  *http.Transport
+)
+
+// sharedUpstreamTransport returns the connection pool every live LinkedIn
+// client dials through, so description-heavy scans and back-to-back runs reuse
+// warm keep-alive connections instead of opening new ones per client. Request
+// policy, headers, and session state stay per client. Proxy stays nil so
+// environment proxy settings are ignored.
+func sharedUpstreamTransport() *http.Transport {
+	sharedUpstreamTransportOnce.Do(func() {
+		perHost := envInt("VISA_HTTP_MAX_IDLE_CONNS_PER_HOST", defaultUpstreamMaxIdleConnsPerHost)
+		if perHost < 1 {
+			perHost = defaultUpstreamMaxIdleConnsPerHost
+		}
+		dialer := &net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}
+		sharedUpstreamTransportPtr = &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          perHost * 4,
+			MaxIdleConnsPerHost:   perHost,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		}
+	})
+	return sharedUpstreamTransportPtr
+}
@@ -0,0 +1,21 @@
+package user
+
+import "testing"
+
+func TestLiveClientsShareTunedTransport(t *testing.T) {
+	first := newLiveLinkedInClient().(*liveLinkedInClient)
+	second := newLiveLinkedInClient().(*liveLinkedInClient)
+	transport := sharedUpstreamTransport()
+	if first.httpClient.GetClient().Transport != transport || second.httpClient.GetClient().Transport != transport {
+		t.Fatalf("expected live clients to reuse the shared transport")
+	}
+	if first.httpClient == second.httpClient {
+		t.Fatalf("expected per-run resty clients so request policy stays per run")
+	}
+	if transport.Proxy != nil {
+		t.Fatalf("shared transport must not use a proxy")
+	}
+	if !transport.ForceAttemptHTTP2 || transport.MaxIdleConnsPerHost < 1 || transport.IdleConnTimeout <= 0 {
+		t.Fatalf("expected keep-alive and HTTP/2 tuning, got %+v", transport)
+	}
+}
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	applicantCountPattern = regexp.MustCompile(`([\d,]+)\s+applicants?`)
+	postedAgoPattern      = regexp.MustCompile(`(\d+)\s+(minute|hour|day|week|month|year)s?\s+ago`)
+	postedAgoUnits        = map[string]time.Duration{
+		"minute": time.Minute,
+		"hour":   time.Hour,
+		"day":    24 * time.Hour,
+		"week":   7 * 24 * time.Hour,
+		"month":  30 * 24 * time.Hour,
+		"year":   365 * 24 * time.Hour,
+	}
+)
+
+// parseApplicantCount reads LinkedIn's applicant caption. "Over 200
+// applicants" gives 200 and "Be among the first 25 applicants" gives 25, so
+// the number is a bound rather than an exact count.
+func parseApplicantCount(text string) *int {
+	match := applicantCountPattern.FindStringSubmatch(strings.ToLower(text))
+	if match == nil {
+		return nil
+	}
+	value, err := strconv.Atoi(strings.ReplaceAll(match[1], ",", ""))
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// parsePostedAgo turns "3 days ago" or "Reposted 2 weeks ago" into the time
+// the job was (re)posted, relative to now.
+func parsePostedAgo(text string, now time.Time) time.Time {
+	match := postedAgoPattern.FindStringSubmatch(strings.ToLower(text))
+	if match == nil {
+		return time.Time{}
+	}
+	amount, err := strconv.Atoi(match[1])
+	if err != nil {
+		return time.Time{}
+	}
+	return now.Add(-time.Duration(amount) * postedAgoUnits[match[2]])
+}
+
+// parseLinkedInJobActivity fills applicant count, repost flag, and posted
+// time from the job page's top card.
+func parseLinkedInJobActivity(doc *goquery.Document, details *linkedInJobDetails) {
+	applicants := normalizeWhitespace(firstNonEmptyText(doc.Selection, "figcaption.num-applicants__caption", "span.num-applicants__caption"))
+	details.ApplicantCount = parseApplicantCount(applicants)
+
+	posted := normalizeWhitespace(firstNonEmptyText(doc.Selection, "span.posted-time-ago__text", "span.posted-time-ago__text--new"))
+	if posted == "" {
+		return
+	}
+	details.IsReposted = boolPtr(strings.Contains(strings.ToLower(posted), "reposted"))
+	details.PostedAt = parsePostedAgo(posted, utcNow())
+}
+
+// postedAgeHours prefers the precise posted time from the job page and falls
+// back to the listing's date-only posted value.
+func postedAgeHours(postedAt time.Time, datePosted string, now time.Time) any {
+	if postedAt.IsZero() {
+		parsed, err := time.Parse("2006-01-02", strings.TrimSpace(datePosted))
+		if err != nil {
+			return nil
+		}
+		postedAt = parsed
+	}
+	hours := int(now.Sub(postedAt).Hours())
+	if hours < 0 {
+		hours = 0
+	}
+	return hours
+}
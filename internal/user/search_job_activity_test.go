@@ -0,0 +1,74 @@
+package user
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLinkedInJobDetailsActivity(t *testing.T) {
+	html := `<html><body>
+<section class="top-card-layout">
+  <span class="posted-time-ago__text">Reposted 3 days ago</span>
+  <figcaption class="num-applicants__caption">Over 1,200 applicants</figcaption>
+</section>
+<div class="show-more-less-html__markup">We sponsor H-1B visas.</div>
+</body></html>`
+	details := parseLinkedInJobDetailsHTML(html, "Engineer", "New York, NY")
+	if details.ApplicantCount == nil || *details.ApplicantCount != 1200 {
+		t.Fatalf("expected 1200 applicants, got %v", details.ApplicantCount)
+	}
+	if details.IsReposted == nil || !*details.IsReposted {
+		t.Fatalf("expected repost flag, got %v", details.IsReposted)
+	}
+	if age := postedAgeHours(details.PostedAt, "", utcNow()); age != 72 {
+		t.Fatalf("expected posted_age_hours=72, got %v", age)
+	}
+}
+
+func TestParseJobActivityText(t *testing.T) {
+	if got := parseApplicantCount("Be among the first 25 applicants"); got == nil || *got != 25 {
+		t.Fatalf("expected 25, got %v", got)
+	}
+	if got := parseApplicantCount("No applicant caption"); got != nil {
+		t.Fatalf("expected nil, got %v", *got)
+	}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if got := parsePostedAgo("2 weeks ago", now); !got.Equal(now.Add(-14 * 24 * time.Hour)) {
+		t.Fatalf("unexpected posted time %s", got)
+	}
+	if got := parsePostedAgo("just now", now); !got.IsZero() {
+		t.Fatalf("expected zero time for unparseable text, got %s", got)
+	}
+}
+
+func TestPostedAgeHoursFallsBackToListingDate(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if got := postedAgeHours(time.Time{}, "2026-03-09", now); got != 36 {
+		t.Fatalf("expected 36 hours from listing date, got %v", got)
+	}
+	if got := postedAgeHours(time.Time{}, "", now); got != nil {
+		t.Fatalf("expected nil without any posted date, got %v", got)
+	}
+}
+
+func TestDescriptionCacheKeepsJobActivity(t *testing.T) {
+	setupUserToolPaths(t)
+	cache := loadDescriptionCache()
+	count := 40
+	postedAt := utcNow().Add(-5 * time.Hour).Truncate(time.Second)
+	cache.put("https://www.linkedin.com/jobs/view/1", linkedInJobDetails{
+		Description:    "Role description",
+		ApplicantCount: &count,
+		IsReposted:     boolPtr(false),
+		PostedAt:       postedAt,
+	})
+	cache.flush()
+
+	details, ok := loadDescriptionCache().get("https://www.linkedin.com/jobs/view/1")
+	if !ok || details.ApplicantCount == nil || *details.ApplicantCount != 40 {
+		t.Fatalf("expected cached applicant count, got %+v", details)
+	}
+	if details.IsReposted == nil || *details.IsReposted || !details.PostedAt.Equal(postedAt) {
+		t.Fatalf("expected cached repost flag and posted time, got %+v", details)
+	}
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// jobClosedRegex matches the banner LinkedIn shows on postings that stopped
+// taking applications.
+var jobClosedRegex = regexp.MustCompile(`(?i)\b(?:no longer accepting applications|this job is no longer available|job is closed|this job has expired|posting has been closed)\b`)
+
+// parseLinkedInJobClosed reads the closed-job banner from a guest job page
+// and returns its text, or "" when the posting looks open.
+func parseLinkedInJobClosed(doc *goquery.Document) string {
+	banner := firstNonEmptyText(doc.Selection, "figure.closed-job", ".closed-job__flavor--closed")
+	topCard := doc.Find("section.top-card-layout, div.top-card-layout__entity-info").Text()
+	return jobClosedRegex.FindString(normalizeWhitespace(banner + " " + topCard))
+}
+
+// voyagerClosedReason maps the authenticated API's jobState to a closed
+// reason; LISTED and an absent state mean the posting is open.
+func voyagerClosedReason(jobState string) string {
+	state := strings.ToUpper(strings.TrimSpace(jobState))
+	if state == "" || state == "LISTED" {
+		return ""
+	}
+	return "job state " + strings.ToLower(state)
+}
+
+// jobPostingStatus summarizes a details fetch as closed, open, or unknown
+// (the page had neither a closed banner nor a description).
+func jobPostingStatus(details linkedInJobDetails) string {
+	switch {
+	case details.ClosedReason != "":
+		return "closed"
+	case normalizeWhitespace(details.Description) != "":
+		return "open"
+	}
+	return "unknown"
+}
@@ -0,0 +1,30 @@
+package user
+
+import "testing"
+
+func TestParseLinkedInJobDetailsClosed(t *testing.T) {
+	html := `<html><body>
+<section class="top-card-layout">
+  <figure class="closed-job"><figcaption class="closed-job__flavor--closed">No longer accepting applications</figcaption></figure>
+</section>
+<div class="show-more-less-html__markup">We sponsor H-1B visas.</div>
+</body></html>`
+	details := parseLinkedInJobDetailsHTML(html, "Engineer", "New York, NY")
+	if details.ClosedReason != "No longer accepting applications" {
+		t.Fatalf("expected closed banner text, got %q", details.ClosedReason)
+	}
+	if got := jobPostingStatus(details); got != "closed" {
+		t.Fatalf("expected closed status, got %q", got)
+	}
+
+	open := parseLinkedInJobDetailsHTML(`<html><body><div class="show-more-less-html__markup">Apply today.</div></body></html>`, "Engineer", "")
+	if open.ClosedReason != "" || jobPostingStatus(open) != "open" {
+		t.Fatalf("expected an open posting, got %#v", open)
+	}
+	if got := voyagerClosedReason("LISTED"); got != "" {
+		t.Fatalf("expected LISTED to be open, got %q", got)
+	}
+	if got := voyagerClosedReason("CLOSED"); got != "job state closed" {
+		t.Fatalf("unexpected voyager reason %q", got)
+	}
+}
@@ -0,0 +1,146 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// linkedInJobTypeCodes maps job types to LinkedIn's f_JT values.
+var linkedInJobTypeCodes = map[string]string{
+	"full-time":  "F",
+	"part-time":  "P",
+	"contract":   "C",
+	"temporary":  "T",
+	"internship": "I",
+	"volunteer":  "V",
+	"other":      "O",
+}
+
+// linkedInJobLevelCodes maps experience levels to LinkedIn's f_E values.
+var linkedInJobLevelCodes = map[string]string{
+	"internship": "1",
+	"entry":      "2",
+	"associate":  "3",
+	"mid-senior": "4",
+	"director":   "5",
+	"executive":  "6",
+}
+
+var jobTypeAliases = map[string]string{
+	"full time":  "full-time",
+	"fulltime":   "full-time",
+	"part time":  "part-time",
+	"parttime":   "part-time",
+	"contractor": "contract",
+	"temp":       "temporary",
+	"intern":     "internship",
+	// Localized "Employment type" values from regional LinkedIn hosts.
+	"vollzeit":            "full-time",
+	"teilzeit":            "part-time",
+	"befristet":           "temporary",
+	"praktikum":           "internship",
+	"temps plein":         "full-time",
+	"temps partiel":       "part-time",
+	"stage":               "internship",
+	"jornada completa":    "full-time",
+	"media jornada":       "part-time",
+	"tempo integral":      "full-time",
+	"tempo parcial":       "part-time",
+	"tempo pieno":         "full-time",
+	"fulltime (voltijds)": "full-time",
+}
+
+var jobLevelAliases = map[string]string{
+	"entry level":      "entry",
+	"entry-level":      "entry",
+	"junior":           "entry",
+	"mid-senior level": "mid-senior",
+	"mid senior":       "mid-senior",
+	"mid":              "mid-senior",
+	"senior":           "mid-senior",
+	"intern":           "internship",
+	// Localized "Seniority level" values from regional LinkedIn hosts.
+	"berufseinstieg":  "entry",
+	"einstiegslevel":  "entry",
+	"premier emploi":  "entry",
+	"débutant":        "entry",
+	"sin experiencia": "entry",
+	"direktor":        "director",
+	"directeur":       "director",
+}
+
+func canonicalCriteriaValue(raw string, codes map[string]string, aliases map[string]string) string {
+	clean := strings.ToLower(normalizeWhitespace(strings.ReplaceAll(raw, "_", " ")))
+	if _, ok := codes[clean]; ok {
+		return clean
+	}
+	if alias, ok := aliases[clean]; ok {
+		return alias
+	}
+	hyphenated := strings.ReplaceAll(clean, " ", "-")
+	if _, ok := codes[hyphenated]; ok {
+		return hyphenated
+	}
+	return ""
+}
+
+func normalizeCriteriaList(field string, values []string, codes map[string]string, aliases map[string]string) ([]string, error) {
+	out := []string{}
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		canonical := canonicalCriteriaValue(value, codes, aliases)
+		if canonical == "" {
+			return nil, fmt.Errorf("%s entries must be one of %v", field, sortedKeys(codes))
+		}
+		if !slices.Contains(out, canonical) {
+			out = append(out, canonical)
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+func normalizeJobTypes(values []string) ([]string, error) {
+	return normalizeCriteriaList("job_types", values, linkedInJobTypeCodes, jobTypeAliases)
+}
+
+func normalizeJobLevels(values []string) ([]string, error) {
+	return normalizeCriteriaList("job_levels", values, linkedInJobLevelCodes, jobLevelAliases)
+}
+
+func linkedInCriteriaFilter(values []string, codes map[string]string) string {
+	out := []string{}
+	for _, value := range values {
+		if code, ok := codes[value]; ok {
+			out = append(out, code)
+		}
+	}
+	slices.Sort(out)
+	return strings.Join(out, ",")
+}
+
+func sortedKeys(values map[string]string) []string {
+	out := make([]string, 0, len(values))
+	for key := range values {
+		out = append(out, key)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// jobCriteriaAllowed checks a parsed "Employment type" or "Seniority level"
+// value against the requested filter. Values that are missing or not
+// recognized pass, since LinkedIn already applied the filter server-side.
+func jobCriteriaAllowed(requested []string, parsed string, codes map[string]string, aliases map[string]string) bool {
+	if len(requested) == 0 {
+		return true
+	}
+	canonical := canonicalCriteriaValue(parsed, codes, aliases)
+	if canonical == "" {
+		return true
+	}
+	return slices.Contains(requested, canonical)
+}
@@ -0,0 +1,59 @@
+package user
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestJobCriteriaNormalizationAndParams(t *testing.T) {
+	jobTypes, err := normalizeJobTypes([]string{"Full Time", "contract", "full_time"})
+	if err != nil || !slices.Equal(jobTypes, []string{"contract", "full-time"}) {
+		t.Fatalf("unexpected job_types normalization: %#v (%v)", jobTypes, err)
+	}
+	jobLevels, err := normalizeJobLevels([]string{"Mid-Senior level", "entry"})
+	if err != nil || !slices.Equal(jobLevels, []string{"entry", "mid-senior"}) {
+		t.Fatalf("unexpected job_levels normalization: %#v (%v)", jobLevels, err)
+	}
+	if _, err := normalizeJobLevels([]string{"wizard"}); err == nil {
+		t.Fatalf("expected unknown job level to be rejected")
+	}
+
+	params := linkedInSearchParams(linkedInSearchQuery{JobTitle: "x", JobTypes: jobTypes, JobLevels: jobLevels})
+	if params["f_JT"] != "C,F" || params["f_E"] != "2,4" {
+		t.Fatalf("unexpected LinkedIn params: %#v", params)
+	}
+}
+
+func TestJobTypeFilterRejectsMismatchedCriteria(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/ft-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY", JobType: "Full-time", JobLevel: "Entry level"},
+				{JobURL: "https://www.linkedin.com/jobs/view/contract-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY", JobType: "Contract", JobLevel: "Entry level"},
+				{JobURL: "https://www.linkedin.com/jobs/view/director-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY", JobType: "Full-time", JobLevel: "Director"},
+			},
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":        "u1",
+		"location":       "New York, NY",
+		"job_title":      "Software Engineer",
+		"dataset_path":   datasetPath,
+		"results_wanted": 5,
+		"job_types":      []any{"full-time"},
+		"job_levels":     []any{"entry", "mid-senior"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 || getString(asMap(jobs[0]), "job_url") != "https://www.linkedin.com/jobs/view/ft-1/" {
+		t.Fatalf("expected only the full-time entry job, got %#v", jobs)
+	}
+	stats := asMap(results["stats"])
+	if intOrZero(stats["job_type_filtered_out"]) != 1 || intOrZero(stats["job_level_filtered_out"]) != 1 {
+		t.Fatalf("unexpected filter stats: %#v", stats)
+	}
+}
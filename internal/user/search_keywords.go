@@ -0,0 +1,84 @@
+package user
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+func normalizeKeywordList(values []string) []string {
+	out := []string{}
+	for _, value := range values {
+		clean := strings.ToLower(normalizeWhitespace(value))
+		if clean != "" && !slices.Contains(out, clean) {
+			out = append(out, clean)
+		}
+	}
+	return out
+}
+
+func isKeywordBoundary(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// containsKeyword does a case-insensitive match that requires the keyword to
+// stand alone, so "go" does not match "good" but "c++" still matches.
+func containsKeyword(text, keyword string) bool {
+	lower := strings.ToLower(text)
+	for offset := 0; offset <= len(lower); {
+		idx := strings.Index(lower[offset:], keyword)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(keyword)
+		beforeOK := start == 0 || isKeywordBoundary(rune(lower[start-1]))
+		afterOK := end == len(lower) || isKeywordBoundary(rune(lower[end]))
+		if beforeOK && afterOK {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+type keywordCheck struct {
+	Matched  []string
+	Missing  []string
+	Excluded []string
+}
+
+func (c keywordCheck) passes() bool {
+	return len(c.Missing) == 0 && len(c.Excluded) == 0
+}
+
+// checkKeywords matches include/exclude keywords against the job title and,
+// when it was fetched, the description.
+func checkKeywords(title, description string, mustInclude, exclude []string) keywordCheck {
+	text := title + "\n" + description
+	check := keywordCheck{Matched: []string{}, Missing: []string{}, Excluded: []string{}}
+	for _, keyword := range mustInclude {
+		if containsKeyword(text, keyword) {
+			check.Matched = append(check.Matched, keyword)
+		} else {
+			check.Missing = append(check.Missing, keyword)
+		}
+	}
+	for _, keyword := range exclude {
+		if containsKeyword(text, keyword) {
+			check.Excluded = append(check.Excluded, keyword)
+		}
+	}
+	return check
+}
+
+// excludedTitleKeyword returns the first exclude_title_keywords entry found
+// in the job title, or "".
+func excludedTitleKeyword(title string, exclude []string) string {
+	for _, keyword := range exclude {
+		if containsKeyword(title, keyword) {
+			return keyword
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package user
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContainsKeywordRespectsBoundaries(t *testing.T) {
+	if !containsKeyword("Experience with Go and C++ required", "c++") {
+		t.Fatalf("expected c++ to match")
+	}
+	if containsKeyword("A good engineer", "go") {
+		t.Fatalf("expected go not to match inside good")
+	}
+	if !containsKeyword("Golang, Kubernetes", "golang") {
+		t.Fatalf("expected golang to match before punctuation")
+	}
+}
+
+func TestKeywordFiltersUseDescriptionAndTitle(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/go-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/clearance-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/java-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/title-1/", Title: "Golang Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/go-1/":        "Build services in Golang.",
+			"https://www.linkedin.com/jobs/view/clearance-1/": "Golang role; active security clearance required.",
+			"https://www.linkedin.com/jobs/view/java-1/":      "Build services in Java.",
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":               "u1",
+		"location":              "New York, NY",
+		"job_title":             "Software Engineer",
+		"dataset_path":          datasetPath,
+		"results_wanted":        5,
+		"must_include_keywords": []any{"Golang"},
+		"exclude_keywords":      []any{"clearance"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 2 {
+		t.Fatalf("expected description and title Golang matches, got %#v", jobs)
+	}
+	reasons := strings.Join(getStringList(asMap(jobs[0]), "eligibility_reasons"), " ")
+	if !strings.Contains(reasons, "required keyword(s): golang") {
+		t.Fatalf("expected matched keyword in eligibility_reasons, got %q", reasons)
+	}
+	if got := intOrZero(asMap(results["stats"])["keyword_filtered_out"]); got != 2 {
+		t.Fatalf("expected keyword_filtered_out=2, got %d", got)
+	}
+}
+
+func TestExcludeTitleKeywordsSkipsSeniorRoles(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/new-grad-1/", Title: "Software Engineer, New Grad", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/senior-1/", Title: "Senior Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/staff-1/", Title: "Staff Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/staffing-1/", Title: "Software Engineer (Staffing Platform)", Company: "Acme Inc", Location: "New York, NY"},
+			},
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":                "u1",
+		"location":               "New York, NY",
+		"job_title":              "Software Engineer",
+		"dataset_path":           datasetPath,
+		"results_wanted":         5,
+		"exclude_title_keywords": []any{"Senior", "staff", "senior"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 2 {
+		t.Fatalf("expected only non-senior titles, got %#v", jobs)
+	}
+	stats := asMap(results["stats"])
+	if got := intOrZero(stats["title_excluded_out"]); got != 2 {
+		t.Fatalf("expected title_excluded_out=2, got %d", got)
+	}
+	if got := getStringList(stats, "exclude_title_keywords"); len(got) != 2 {
+		t.Fatalf("expected deduped title keywords in stats, got %v", got)
+	}
+}
@@ -0,0 +1,237 @@
+package user
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultLayoutBaselinePath   = "data/config/layout_baseline.json"
+	defaultLayoutDriftRatio     = 0.5
+	layoutBaselineMinSamples    = 3
+	layoutBaselineMaxSamples    = 30
+	layoutYieldFieldsPerJobCard = 5
+)
+
+var layoutBaselineMu sync.Mutex
+
+func layoutBaselinePath() string {
+	return envOrDefault("VISA_LAYOUT_BASELINE_PATH", defaultLayoutBaselinePath)
+}
+
+func layoutDriftRatio() float64 {
+	raw := strings.TrimSpace(os.Getenv("VISA_LAYOUT_DRIFT_RATIO"))
+	if raw == "" {
+		return defaultLayoutDriftRatio
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value <= 0 || value >= 1 {
+		return defaultLayoutDriftRatio
+	}
+	return value
+}
+
+type layoutYield struct {
+	Pages         int
+	Cards         int
+	CardsPerPage  float64
+	FieldFillRate float64
+}
+
+// measureLayoutYield summarizes how much structure the parser recovered from
+// the listing pages. The final page is left out of cards_per_page once more
+// than one page was fetched because it is usually a partial page.
+func measureLayoutYield(pageCardCounts []int, jobs []linkedInJob) layoutYield {
+	yield := layoutYield{Pages: len(pageCardCounts), Cards: len(jobs)}
+	counted := pageCardCounts
+	if len(counted) > 1 {
+		counted = counted[:len(counted)-1]
+	}
+	totalCards := 0
+	for _, count := range counted {
+		totalCards += count
+	}
+	if len(counted) > 0 {
+		yield.CardsPerPage = float64(totalCards) / float64(len(counted))
+	}
+	if len(jobs) == 0 {
+		return yield
+	}
+	populated := 0
+	for _, job := range jobs {
+		for _, value := range []string{job.JobURL, job.Title, job.Company, job.Location, job.DatePosted} {
+			if normalizeWhitespace(value) != "" {
+				populated++
+			}
+		}
+	}
+	yield.FieldFillRate = float64(populated) / float64(len(jobs)*layoutYieldFieldsPerJobCard)
+	return yield
+}
+
+func layoutBaselineAverages(samples []any) (float64, float64) {
+	if len(samples) == 0 {
+		return 0, 0
+	}
+	cards := 0.0
+	fill := 0.0
+	for _, raw := range samples {
+		sample := mapOrNil(raw)
+		cards += floatOrZero(sample["cards_per_page"])
+		fill += floatOrZero(sample["field_fill_rate"])
+	}
+	return cards / float64(len(samples)), fill / float64(len(samples))
+}
+
+func floatOrZero(value any) float64 {
+	switch typed := value.(type) {
+	case float64:
+		return typed
+	case int:
+		return float64(typed)
+	}
+	return 0
+}
+
+func roundYield(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
+
+// recordLayoutYield compares a run's parse yield against the rolling per-site
+// baseline and appends healthy runs to it. Runs flagged as drift are kept out
+// of the baseline so a broken layout cannot become the new normal.
+func recordLayoutYield(site string, yield layoutYield) map[string]any {
+	if site == "" {
+		site = "linkedin"
+	}
+	out := map[string]any{
+		"site":                   site,
+		"pages":                  yield.Pages,
+		"cards":                  yield.Cards,
+		"cards_per_page":         roundYield(yield.CardsPerPage),
+		"field_fill_rate":        roundYield(yield.FieldFillRate),
+		"baseline_samples":       0,
+		"baseline_cards":         nil,
+		"baseline_fill_rate":     nil,
+		"possible_layout_change": false,
+		"reasons":                []string{},
+	}
+	if yield.Cards == 0 {
+		// No cards is indistinguishable from a narrow query with no results.
+		return out
+	}
+
+	layoutBaselineMu.Lock()
+	defer layoutBaselineMu.Unlock()
+
+	data := loadJSONMap(layoutBaselinePath(), map[string]any{"sites": map[string]any{}})
+	sites := mapOrNil(data["sites"])
+	if sites == nil {
+		sites = map[string]any{}
+	}
+	entry := mapOrNil(sites[site])
+	if entry == nil {
+		entry = map[string]any{}
+	}
+	samples := listOrEmpty(entry["samples"])
+	baselineCards, baselineFill := layoutBaselineAverages(samples)
+	out["baseline_samples"] = len(samples)
+
+	reasons := []string{}
+	if len(samples) >= layoutBaselineMinSamples {
+		out["baseline_cards"] = roundYield(baselineCards)
+		out["baseline_fill_rate"] = roundYield(baselineFill)
+		ratio := layoutDriftRatio()
+		if yield.Pages > 1 && baselineCards > 0 && yield.CardsPerPage < baselineCards*ratio {
+			reasons = append(reasons, fmt.Sprintf(
+				"cards per page dropped to %.1f from a baseline of %.1f",
+				yield.CardsPerPage,
+				baselineCards,
+			))
+		}
+		if baselineFill > 0 && yield.FieldFillRate < baselineFill*ratio {
+			reasons = append(reasons, fmt.Sprintf(
+				"populated card fields dropped to %.0f%% from a baseline of %.0f%%",
+				yield.FieldFillRate*100,
+				baselineFill*100,
+			))
+		}
+	}
+	drift := len(reasons) > 0
+	out["possible_layout_change"] = drift
+	out["reasons"] = reasons
+
+	check := map[string]any{
+		"checked_at_utc":         utcNowISO(),
+		"possible_layout_change": drift,
+		"reasons":                reasons,
+		"cards_per_page":         out["cards_per_page"],
+		"field_fill_rate":        out["field_fill_rate"],
+	}
+	entry["last_check"] = check
+	if drift {
+		entry["last_drift"] = check
+	} else {
+		samples = append(samples, map[string]any{
+			"recorded_at_utc": utcNowISO(),
+			"pages":           yield.Pages,
+			"cards_per_page":  out["cards_per_page"],
+			"field_fill_rate": out["field_fill_rate"],
+		})
+		if len(samples) > layoutBaselineMaxSamples {
+			samples = samples[len(samples)-layoutBaselineMaxSamples:]
+		}
+	}
+	entry["samples"] = samples
+	sites[site] = entry
+	data["sites"] = sites
+	_ = saveJSONMap(layoutBaselinePath(), data)
+	return out
+}
+
+// LayoutHealth reports the latest layout drift check per site for
+// get_mcp_capabilities.
+func LayoutHealth() map[string]any {
+	layoutBaselineMu.Lock()
+	data := loadJSONMap(layoutBaselinePath(), map[string]any{"sites": map[string]any{}})
+	layoutBaselineMu.Unlock()
+
+	sites := map[string]any{}
+	warnings := []string{}
+	for site, raw := range mapOrNil(data["sites"]) {
+		entry := mapOrNil(raw)
+		check := mapOrNil(entry["last_check"])
+		drift := boolOrFalse(check["possible_layout_change"])
+		sites[site] = map[string]any{
+			"baseline_samples":       len(listOrEmpty(entry["samples"])),
+			"possible_layout_change": drift,
+			"last_checked_at_utc":    check["checked_at_utc"],
+			"last_drift":             entry["last_drift"],
+		}
+		if drift {
+			warnings = append(warnings, fmt.Sprintf(
+				"Possible %s layout change: the latest search parsed far fewer listing fields than usual. Results may be incomplete until the parser is updated.",
+				site,
+			))
+		}
+	}
+	return map[string]any{
+		"possible_layout_change": len(warnings) > 0,
+		"warnings":               warnings,
+		"sites":                  sites,
+		"baseline_path":          layoutBaselinePath(),
+	}
+}
+
+func runHasLayoutDriftEvent(events []any) bool {
+	for _, raw := range events {
+		if boolOrFalse(asMap(mapOrNil(raw)["payload"])["possible_layout_change"]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func healthyLayoutJobs(count int) []linkedInJob {
+	jobs := []linkedInJob{}
+	for idx := 0; idx < count; idx++ {
+		jobs = append(jobs, linkedInJob{
+			JobURL:     "https://www.linkedin.com/jobs/view/healthy",
+			Title:      "Software Engineer",
+			Company:    "Acme Inc",
+			Location:   "New York, NY",
+			DatePosted: "2026-01-01",
+		})
+	}
+	return jobs
+}
+
+func TestRecordLayoutYieldFlagsSharpDrop(t *testing.T) {
+	t.Setenv("VISA_LAYOUT_BASELINE_PATH", filepath.Join(t.TempDir(), "layout_baseline.json"))
+
+	for idx := 0; idx < layoutBaselineMinSamples; idx++ {
+		check := recordLayoutYield("linkedin", measureLayoutYield([]int{10, 10, 4}, healthyLayoutJobs(24)))
+		if boolOrFalse(check["possible_layout_change"]) {
+			t.Fatalf("healthy run %d flagged as drift: %#v", idx, check)
+		}
+	}
+
+	degraded := []linkedInJob{}
+	for idx := 0; idx < 6; idx++ {
+		degraded = append(degraded, linkedInJob{JobURL: "https://www.linkedin.com/jobs/view/broken", Title: "Engineer"})
+	}
+	check := recordLayoutYield("linkedin", measureLayoutYield([]int{2, 2, 2}, degraded))
+	if !boolOrFalse(check["possible_layout_change"]) {
+		t.Fatalf("expected drift to be flagged, got %#v", check)
+	}
+	if reasons, _ := check["reasons"].([]string); len(reasons) != 2 {
+		t.Fatalf("expected cards and field reasons, got %#v", check["reasons"])
+	}
+
+	health := LayoutHealth()
+	if !boolOrFalse(health["possible_layout_change"]) || len(listOrEmpty(health["warnings"])) != 1 {
+		t.Fatalf("expected capabilities warning, got %#v", health)
+	}
+	site := asMap(asMap(health["sites"])["linkedin"])
+	if got := intOrZero(site["baseline_samples"]); got != layoutBaselineMinSamples {
+		t.Fatalf("drifted run should not join the baseline, got %d samples", got)
+	}
+
+	recordLayoutYield("linkedin", measureLayoutYield([]int{10, 10, 4}, healthyLayoutJobs(24)))
+	if boolOrFalse(LayoutHealth()["possible_layout_change"]) {
+		t.Fatalf("expected warning to clear after a healthy run")
+	}
+}
+
+func TestSearchStatusReportsPossibleLayoutChange(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	for idx := 0; idx < layoutBaselineMinSamples; idx++ {
+		recordLayoutYield("linkedin", measureLayoutYield([]int{10}, healthyLayoutJobs(10)))
+	}
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {{JobURL: "https://www.linkedin.com/jobs/view/drift-1/", Title: "Software Engineer"}},
+		},
+	}
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient { return client }
+
+	started, err := StartVisaJobSearch(map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	})
+	if err != nil {
+		t.Fatalf("StartVisaJobSearch failed: %v", err)
+	}
+	status := waitForTerminalRunStatus(t, "u1", getString(started, "run_id"), 3*time.Second)
+	if !boolOrFalse(status["possible_layout_change"]) {
+		t.Fatalf("expected possible_layout_change in run status, got %#v", status)
+	}
+	if !boolOrFalse(asMap(status["latest_stats"])["possible_layout_change"]) {
+		t.Fatalf("expected possible_layout_change in stats, got %#v", status["latest_stats"])
+	}
+}
@@ -0,0 +1,298 @@
+package user
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLCAWagesFileName = "lca_wages.csv"
+	maxBenchmarkGroups      = 10
+)
+
+var (
+	lcaWageCacheMu sync.Mutex
+	lcaWageCache   = map[string]lcaWageCacheEntry{}
+)
+
+type lcaWageRow struct {
+	CompanyName    string
+	SOCCode        string
+	SOCTitle       string
+	City           string
+	State          string
+	Filings        int
+	Mean           int
+	Min            int
+	Max            int
+	PrevailingMean int
+}
+
+type lcaWageIndex struct {
+	Rows      int
+	ByCompany map[string][]lcaWageRow
+}
+
+type lcaWageCacheEntry struct {
+	ModTime time.Time
+	Data    lcaWageIndex
+}
+
+// lcaWagesPathFor keeps the wage table next to the dataset it was built with
+// unless VISA_LCA_WAGES_PATH points elsewhere.
+func lcaWagesPathFor(datasetPath string) string {
+	if path := strings.TrimSpace(os.Getenv("VISA_LCA_WAGES_PATH")); path != "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(primaryDatasetPath(datasetPathOrDefault(datasetPath))), defaultLCAWagesFileName)
+}
+
+func loadLCAWageIndex(path string) (lcaWageIndex, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return lcaWageIndex{}, fmt.Errorf("lca wage table not found at '%s': %w", path, err)
+	}
+	lcaWageCacheMu.Lock()
+	if cached, ok := lcaWageCache[path]; ok && cached.ModTime.Equal(info.ModTime().UTC()) {
+		lcaWageCacheMu.Unlock()
+		return cached.Data, nil
+	}
+	lcaWageCacheMu.Unlock()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return lcaWageIndex{}, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return lcaWageIndex{}, fmt.Errorf("read lca wage header: %w", err)
+	}
+	index := normalizedHeaderMap(header)
+	column := func(row []string, name string) string {
+		return readCSVColumn(row, findColumnIndex(index, []string{name}))
+	}
+	out := lcaWageIndex{ByCompany: map[string][]lcaWageRow{}}
+	for {
+		row, err := reader.Read()
+		if err != nil {
+			break
+		}
+		wage := lcaWageRow{
+			CompanyName:    column(row, "company_name"),
+			SOCCode:        column(row, "soc_code"),
+			SOCTitle:       column(row, "soc_title"),
+			City:           strings.ToLower(column(row, "worksite_city")),
+			State:          strings.ToUpper(column(row, "worksite_state")),
+			Filings:        parseIntCSV(column(row, "filings")),
+			Mean:           parseIntCSV(column(row, "annual_wage_mean")),
+			Min:            parseIntCSV(column(row, "annual_wage_min")),
+			Max:            parseIntCSV(column(row, "annual_wage_max")),
+			PrevailingMean: parseIntCSV(column(row, "prevailing_wage_mean")),
+		}
+		key := normalizeCompanyName(wage.CompanyName)
+		if key == "" || wage.Filings <= 0 || wage.Mean <= 0 {
+			continue
+		}
+		out.ByCompany[key] = append(out.ByCompany[key], wage)
+		out.Rows++
+	}
+
+	lcaWageCacheMu.Lock()
+	lcaWageCache[path] = lcaWageCacheEntry{ModTime: info.ModTime().UTC(), Data: out}
+	lcaWageCacheMu.Unlock()
+	return out, nil
+}
+
+// summarizeLCAWages combines wage groups weighted by filings.
+func summarizeLCAWages(rows []lcaWageRow) map[string]any {
+	if len(rows) == 0 {
+		return nil
+	}
+	filings, weighted, prevailingWeighted, prevailingFilings := 0, 0, 0, 0
+	low, high := rows[0].Min, rows[0].Max
+	titleFilings := map[string]int{}
+	for _, row := range rows {
+		filings += row.Filings
+		weighted += row.Mean * row.Filings
+		if row.PrevailingMean > 0 {
+			prevailingWeighted += row.PrevailingMean * row.Filings
+			prevailingFilings += row.Filings
+		}
+		low, high = min(low, row.Min), max(high, row.Max)
+		if row.SOCTitle != "" {
+			titleFilings[row.SOCTitle] += row.Filings
+		}
+	}
+	titles := make([]string, 0, len(titleFilings))
+	for title := range titleFilings {
+		titles = append(titles, title)
+	}
+	slices.SortFunc(titles, func(a, b string) int {
+		if titleFilings[a] != titleFilings[b] {
+			return titleFilings[b] - titleFilings[a]
+		}
+		return strings.Compare(a, b)
+	})
+	summary := map[string]any{
+		"filings":              filings,
+		"annual_wage_mean":     weighted / filings,
+		"annual_wage_min":      low,
+		"annual_wage_max":      high,
+		"prevailing_wage_mean": nil,
+		"soc_titles":           titles[:min(len(titles), 3)],
+		"source":               "dol_lca",
+	}
+	if prevailingFilings > 0 {
+		summary["prevailing_wage_mean"] = prevailingWeighted / prevailingFilings
+	}
+	return summary
+}
+
+// filterLCAWagesByLocation narrows rows to the listing's city, then state,
+// and reports which level matched ("" when the location could not narrow).
+func filterLCAWagesByLocation(rows []lcaWageRow, location string) ([]lcaWageRow, string) {
+	city, state := parseUSLocation(location)
+	if city != "" && state != "" {
+		if matched := slices.DeleteFunc(slices.Clone(rows), func(row lcaWageRow) bool { return row.City != city || row.State != state }); len(matched) > 0 {
+			return matched, "city"
+		}
+	}
+	if state != "" {
+		if matched := slices.DeleteFunc(slices.Clone(rows), func(row lcaWageRow) bool { return row.State != state }); len(matched) > 0 {
+			return matched, "state"
+		}
+	}
+	return rows, ""
+}
+
+// estimate returns the LCA wage summary for a job at normalizedCompany,
+// preferring filings at the listing's worksite, or nil without filings.
+func (idx lcaWageIndex) estimate(normalizedCompany, location string) map[string]any {
+	rows := idx.ByCompany[normalizedCompany]
+	if len(rows) == 0 {
+		return nil
+	}
+	matched, level := filterLCAWagesByLocation(rows, location)
+	summary := summarizeLCAWages(matched)
+	summary["match_level"] = "employer"
+	if level != "" {
+		summary["match_level"] = "employer_" + level
+	}
+	return summary
+}
+
+func lcaWageBelowMinimum(estimate map[string]any, minimum int) bool {
+	if minimum <= 0 || estimate == nil {
+		return false
+	}
+	return intOrZero(estimate["annual_wage_mean"]) < minimum
+}
+
+func parseLCAWageOptions(args map[string]any, query map[string]any) error {
+	parsed, has, err := getOptionalInt(args, "min_lca_wage")
+	if !has {
+		return nil
+	}
+	if err != nil || parsed < 1 || parsed > maxPlausibleAnnualWage {
+		return fmt.Errorf("min_lca_wage must be an annual amount between 1 and %d", maxPlausibleAnnualWage)
+	}
+	query["min_lca_wage"] = parsed
+	return nil
+}
+
+// lcaTitleMatches keeps rows whose SOC title shares a meaningful word stem
+// with the job title, so "Software Engineer" matches "Software Developers".
+func lcaTitleMatches(jobTitle, socTitle string) bool {
+	soc := strings.Fields(strings.ToLower(socTitle))
+	for _, word := range strings.Fields(strings.ToLower(jobTitle)) {
+		if len(word) < 4 {
+			continue
+		}
+		stem := word[:min(len(word), 5)]
+		for _, candidate := range soc {
+			if strings.HasPrefix(candidate, stem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func GetSalaryBenchmark(args map[string]any) (map[string]any, error) {
+	company := getString(args, "company_name")
+	jobTitle := normalizeWhitespace(getString(args, "job_title"))
+	socCode := strings.TrimSpace(getString(args, "soc_code"))
+	location := getString(args, "location")
+	if company == "" && jobTitle == "" && socCode == "" {
+		return nil, fmt.Errorf("at least one of company_name, job_title, or soc_code is required")
+	}
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
+	wagesPath := lcaWagesPathFor(datasetPath)
+	index, err := loadLCAWageIndex(wagesPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w; run run_internal_dol_pipeline to build it from LCA disclosures", err)
+	}
+
+	rows := []lcaWageRow{}
+	if company != "" {
+		key := normalizeCompanyName(company)
+		if target, ok := loadCompanyAliases()[key]; ok && len(index.ByCompany[key]) == 0 {
+			key = target
+		}
+		rows = slices.Clone(index.ByCompany[key])
+	} else {
+		for _, companyRows := range index.ByCompany {
+			rows = append(rows, companyRows...)
+		}
+	}
+	rows = slices.DeleteFunc(rows, func(row lcaWageRow) bool {
+		if socCode != "" && !strings.HasPrefix(row.SOCCode, socCode) {
+			return true
+		}
+		return jobTitle != "" && socCode == "" && !lcaTitleMatches(jobTitle, row.SOCTitle)
+	})
+	locationLevel := ""
+	if location != "" {
+		rows, locationLevel = filterLCAWagesByLocation(rows, location)
+	}
+
+	slices.SortFunc(rows, func(a, b lcaWageRow) int { return b.Filings - a.Filings })
+	groups := []any{}
+	for _, row := range rows[:min(len(rows), maxBenchmarkGroups)] {
+		groups = append(groups, map[string]any{
+			"company_name":         row.CompanyName,
+			"soc_code":             row.SOCCode,
+			"soc_title":            row.SOCTitle,
+			"worksite_city":        row.City,
+			"worksite_state":       row.State,
+			"filings":              row.Filings,
+			"annual_wage_mean":     row.Mean,
+			"annual_wage_min":      row.Min,
+			"annual_wage_max":      row.Max,
+			"prevailing_wage_mean": optionalPositiveInt(row.PrevailingMean),
+		})
+	}
+	return map[string]any{
+		"company":           optionalString(company),
+		"job_title":         optionalString(jobTitle),
+		"soc_code":          optionalString(socCode),
+		"location":          optionalString(location),
+		"location_match":    optionalString(locationLevel),
+		"matched_groups":    len(rows),
+		"benchmark":         summarizeLCAWages(rows),
+		"top_groups":        groups,
+		"lca_wages_path":    wagesPath,
+		"wage_basis":        "annualized offered wage from certified and filed LCAs (midpoint of the wage range when given)",
+		"guidance":          "Compare offers against annual_wage_mean; offers near prevailing_wage_mean are at the legal floor for that role and area.",
+		"total_wage_groups": index.Rows,
+	}, nil
+}
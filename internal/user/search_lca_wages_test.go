@@ -0,0 +1,93 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunInternalDolPipelineWritesLCAWageTable(t *testing.T) {
+	setupUserToolPaths(t)
+	dir := t.TempDir()
+	lcaPath := filepath.Join(dir, "lca.csv")
+	lcaBody := strings.Join([]string{
+		"EMPLOYER_NAME,VISA_CLASS,SOC_CODE,SOC_TITLE,WORKSITE_CITY,WORKSITE_STATE,WAGE_RATE_OF_PAY_FROM,WAGE_RATE_OF_PAY_TO,WAGE_UNIT_OF_PAY,PREVAILING_WAGE,PW_UNIT_OF_PAY",
+		"Acme Inc,H-1B,15-1252,Software Developers,Austin,TX,\"$120,000.00\",\"$140,000.00\",Year,100000,Year",
+		"Acme Inc,H-1B,15-1252,Software Developers,Austin,TX,60.00,,Hour,50.00,Hour",
+		"Acme Inc,H-1B,15-1252,Software Developers,Seattle,WA,160000,,Year,,",
+		"Acme Inc,H-1B,13-1161,Market Research Analysts,Austin,Texas,70000,,Year,65000,Year",
+		"Acme Inc,H-1B,15-1252,Software Developers,Austin,TX,5,,Year,,",
+	}, "\n")
+	if err := os.WriteFile(lcaPath, []byte(lcaBody), 0o644); err != nil {
+		t.Fatalf("write lca: %v", err)
+	}
+	permPath := filepath.Join(dir, "perm.csv")
+	if err := os.WriteFile(permPath, []byte("EMPLOYER_NAME\nAcme Inc\n"), 0o644); err != nil {
+		t.Fatalf("write perm: %v", err)
+	}
+	datasetPath := filepath.Join(dir, "companies.csv")
+	result, err := RunInternalDolPipeline(map[string]any{
+		"lca_source":        lcaPath,
+		"perm_source":       permPath,
+		"dataset_path":      datasetPath,
+		"manifest_path":     filepath.Join(dir, "last_run.json"),
+		"strict_validation": false,
+	})
+	if err != nil || getString(result, "status") != "completed" {
+		t.Fatalf("RunInternalDolPipeline failed: %v %#v", err, result)
+	}
+	if intOrZero(result["lca_wage_rows_written"]) != 3 || getString(result, "lca_wages_path") != filepath.Join(dir, "lca_wages.csv") {
+		t.Fatalf("unexpected wage output: %#v", result)
+	}
+
+	index, err := loadLCAWageIndex(lcaWagesPathFor(datasetPath))
+	if err != nil {
+		t.Fatalf("loadLCAWageIndex failed: %v", err)
+	}
+	estimate := index.estimate("acme", "Austin, TX")
+	if getString(estimate, "match_level") != "employer_city" || intOrZero(estimate["filings"]) != 3 {
+		t.Fatalf("unexpected city estimate: %#v", estimate)
+	}
+	// (130000 + 124800 + 70000) / 3
+	if got := intOrZero(estimate["annual_wage_mean"]); got != 108266 {
+		t.Fatalf("expected filing-weighted mean 108266, got %d", got)
+	}
+	if state := index.estimate("acme", "Washington, United States"); getString(state, "match_level") != "employer_state" || intOrZero(state["annual_wage_mean"]) != 160000 {
+		t.Fatalf("unexpected state estimate: %#v", state)
+	}
+	if index.estimate("unknown", "Austin, TX") != nil {
+		t.Fatalf("expected nil estimate for employer without filings")
+	}
+	if !lcaWageBelowMinimum(estimate, 120000) || lcaWageBelowMinimum(nil, 120000) {
+		t.Fatalf("unexpected min_lca_wage filtering")
+	}
+
+	benchmark, err := GetSalaryBenchmark(map[string]any{"job_title": "Senior Software Engineer", "location": "Austin, TX", "dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("GetSalaryBenchmark failed: %v", err)
+	}
+	summary := asMap(benchmark["benchmark"])
+	if intOrZero(summary["filings"]) != 2 || getString(benchmark, "location_match") != "city" || intOrZero(summary["prevailing_wage_mean"]) != 102000 {
+		t.Fatalf("unexpected benchmark: %#v", benchmark)
+	}
+	if _, err := GetSalaryBenchmark(map[string]any{"dataset_path": datasetPath}); err == nil {
+		t.Fatalf("expected error without company_name, job_title, or soc_code")
+	}
+}
+
+func TestParseUSLocation(t *testing.T) {
+	cases := map[string][2]string{
+		"Austin, TX":                         {"austin", "TX"},
+		"Seattle, Washington, United States": {"seattle", "WA"},
+		"California, United States":          {"", "CA"},
+		"New York":                           {"", "NY"},
+		"San Francisco Bay Area":             {"san francisco bay area", ""},
+	}
+	for raw, want := range cases {
+		city, state := parseUSLocation(raw)
+		if city != want[0] || state != want[1] {
+			t.Fatalf("parseUSLocation(%q) = %q, %q; want %q, %q", raw, city, state, want[0], want[1])
+		}
+	}
+}
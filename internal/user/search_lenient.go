@@ -0,0 +1,41 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const lenientAcceptanceReason = "accepted_by_lenient_mode"
+
+var supportedStrictnessModes = []string{"balanced", "lenient", "strict"}
+
+func validateStrictnessMode(mode string) error {
+	if !slices.Contains(supportedStrictnessModes, mode) {
+		return fmt.Errorf("strictness_mode must be one of [%s]", strings.Join(supportedStrictnessModes, " "))
+	}
+	return nil
+}
+
+// strongTitleMatch is stricter than jobMatchesRequestedTitle: every
+// meaningful token of the requested title must appear in the job title. In
+// lenient mode it is enough on its own to accept a job.
+func strongTitleMatch(requestedTitle, jobTitle string) bool {
+	requested := tokenizeSearchText(requestedTitle)
+	if len(requested) == 0 {
+		return false
+	}
+	titleTokens := tokenizeSearchText(jobTitle)
+	for _, token := range requested {
+		if !slices.Contains(titleTokens, token) {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptedOnlyByLenientMode reports whether neither the company dataset nor a
+// requested-visa description mention would have accepted the job.
+func acceptedOnlyByLenientMode(strictness string, desiredCount int, descriptionPositive, descriptionDesiredMention bool) bool {
+	return strictness == "lenient" && desiredCount == 0 && !(descriptionPositive && descriptionDesiredMention)
+}
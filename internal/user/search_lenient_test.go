@@ -0,0 +1,105 @@
+package user
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestShouldAcceptJobLenientMode(t *testing.T) {
+	if !shouldAcceptJob("lenient", 0, true, false, false, false, false) {
+		t.Fatal("expected lenient mode to accept any positive description signal")
+	}
+	if !shouldAcceptJob("lenient", 0, false, false, false, false, true) {
+		t.Fatal("expected lenient mode to accept a strong title match")
+	}
+	if shouldAcceptJob("lenient", 0, true, true, false, false, true) {
+		t.Fatal("expected negative sponsorship language to reject even in lenient mode")
+	}
+	if shouldAcceptJob("strict", 0, true, false, false, false, true) {
+		t.Fatal("expected strict mode to ignore title matches and generic signals")
+	}
+}
+
+func TestStrongTitleMatchRequiresEveryToken(t *testing.T) {
+	if !strongTitleMatch("Software Engineer", "Senior Software Engineer, Platform") {
+		t.Fatal("expected every requested token to match")
+	}
+	if strongTitleMatch("Software Engineer", "Software Developer") {
+		t.Fatal("expected a partial title to not be a strong match")
+	}
+}
+
+func TestValidateStrictnessModeAcceptsLenient(t *testing.T) {
+	if err := validateStrictnessMode("lenient"); err != nil {
+		t.Fatalf("expected lenient to be valid: %v", err)
+	}
+	if err := validateStrictnessMode("loose"); err == nil {
+		t.Fatal("expected unknown strictness mode to be rejected")
+	}
+}
+
+func TestLenientSearchAcceptsJobsOutsideDataset(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	if _, err := SetUserPreferences(map[string]any{
+		"user_id":              "u1",
+		"preferred_visa_types": []any{"E3"},
+	}); err != nil {
+		t.Fatalf("SetUserPreferences failed: %v", err)
+	}
+
+	titleURL := "https://www.linkedin.com/jobs/view/gamma-1/"
+	signalURL := "https://www.linkedin.com/jobs/view/gamma-2/"
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{0: {
+			{JobURL: titleURL, Title: "Software Engineer II", Company: "Gamma Corp", Location: "New York, NY"},
+			{JobURL: signalURL, Title: "Data Analyst", Company: "Gamma Corp", Location: "New York, NY"},
+			{JobURL: "https://www.linkedin.com/jobs/view/gamma-3/", Title: "Marketing Manager", Company: "Gamma Corp", Location: "New York, NY"},
+		}},
+		descriptions: map[string]string{signalURL: "We offer visa sponsorship for qualified candidates."},
+	}
+	args := map[string]any{
+		"user_id":        "u1",
+		"location":       "New York, NY",
+		"job_title":      "Software Engineer",
+		"dataset_path":   datasetPath,
+		"results_wanted": 3,
+	}
+
+	strict := runFakeVisaSearch(t, client, args)
+	if got := len(listOrEmpty(strict["jobs"])); got != 0 {
+		t.Fatalf("expected strict mode to accept nothing, got %d jobs", got)
+	}
+	hasLenientSuggestion := false
+	for _, raw := range listOrEmpty(strict["recovery_suggestions"]) {
+		if getString(mapOrNil(raw), "type") == "try_lenient_strictness" {
+			hasLenientSuggestion = true
+		}
+	}
+	if !hasLenientSuggestion {
+		t.Fatalf("expected a try_lenient_strictness suggestion, got %#v", strict["recovery_suggestions"])
+	}
+
+	args["strictness_mode"] = "lenient"
+	lenient := runFakeVisaSearch(t, client, args)
+	urls := []string{}
+	for _, raw := range listOrEmpty(lenient["jobs"]) {
+		job := mapOrNil(raw)
+		urls = append(urls, getString(job, "job_url"))
+		reasons := []string{}
+		for _, reason := range listOrEmpty(job["eligibility_reasons"]) {
+			reasons = append(reasons, reason.(string))
+		}
+		if !slices.Contains(reasons, lenientAcceptanceReason) {
+			t.Fatalf("expected %s in eligibility reasons, got %v", lenientAcceptanceReason, reasons)
+		}
+	}
+	if len(urls) != 2 || !slices.Contains(urls, titleURL) || !slices.Contains(urls, signalURL) {
+		t.Fatalf("expected the title match and the sponsorship-signal job, got %v", urls)
+	}
+	if got := intOrZero(asMap(lenient["stats"])["lenient_accepted"]); got != 2 {
+		t.Fatalf("expected lenient_accepted=2, got %d", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -17,23 +18,29 @@ const linkedInSearchURL = "https://www.linkedin.com/jobs-guest/jobs/api/seeMoreJ
 
 type liveLinkedInClient struct {
 	httpClient *resty.Client
+	headers    *headerRotator
+	session    *linkedInSessionState
+	policy     upstreamRequestPolicy
+	locale     linkedInLocale
+
+	pageCacheHits atomic.Int64
 }
 
 func newLiveLinkedInClient() linkedInClient {
-	transport := &http.Transport{
-		Proxy: nil,
-	}
 	client := resty.New()
-	client.SetTransport(transport)
-	client.SetHeader("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-	client.SetHeader("Accept-Language", "en-US,en;q=0.9")
+	client.SetTransport(sharedUpstreamTransport())
 	client.SetHeader("Cache-Control", "no-cache")
 	client.SetHeader("Pragma", "no-cache")
 	client.SetHeader("Upgrade-Insecure-Requests", "1")
-	client.SetHeader("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	client.SetTimeout(time.Duration(linkedInRequestTimeoutSeconds()) * time.Second)
+	policy := defaultUpstreamRequestPolicy()
+	client.SetTimeout(time.Duration(policy.TimeoutSeconds) * time.Second)
 	client.SetRetryCount(0)
-	return &liveLinkedInClient{httpClient: client}
+	return &liveLinkedInClient{
+		httpClient: client,
+		policy:     policy,
+		headers:    newHeaderRotator(loadHeaderProfiles(), headerRotationMode()),
+		session:    &linkedInSessionState{session: loadLinkedInSession()},
+	}
 }
 
 func stripQuery(raw string) string {
@@ -73,6 +80,7 @@ func parseLinkedInListHTML(html string) ([]linkedInJob, error) {
 		}
 		title := firstNonEmptyText(card, "h3.base-search-card__title", "span.sr-only")
 		company := strings.TrimSpace(card.Find("h4.base-search-card__subtitle").Text())
+		companyHref, _ := card.Find("h4.base-search-card__subtitle a").Attr("href")
 		location := strings.TrimSpace(card.Find("span.job-search-card__location").First().Text())
 		compensationText := normalizeWhitespace(firstNonEmptyText(card, "span.job-search-card__salary-info"))
 		compensation, hasCompensation := parseCompensation(compensationText)
@@ -89,6 +97,7 @@ func parseLinkedInListHTML(html string) ([]linkedInJob, error) {
 			Location:   location,
 			Site:       "linkedin",
 			DatePosted: datePosted,
+			CompanyURL: stripQuery(companyHref),
 		}
 		if hasCompensation {
 			job.SalaryText = compensation.Text
@@ -96,7 +105,7 @@ func parseLinkedInListHTML(html string) ([]linkedInJob, error) {
 			job.SalaryInterval = compensation.Interval
 			job.SalaryMin = compensation.MinAmount
 			job.SalaryMax = compensation.MaxAmount
-			job.SalarySource = "listing_card"
+			job.SalarySource = salarySourceListingCard
 		}
 		out = append(out, job)
 	})
@@ -119,6 +128,8 @@ func parseLinkedInJobDetailsHTML(html, title, location string) linkedInJobDetail
 	details.CompanyIndustry = criteria["industries"]
 	details.JobFunction = criteria["job function"]
 	details.JobURLDirect = parseLinkedInDirectApplyURL(doc)
+	parseLinkedInJobActivity(doc, &details)
+	details.ClosedReason = parseLinkedInJobClosed(doc)
 
 	isRemote := detectLinkedInRemote(title, location, details.Description)
 	details.IsRemote = boolPtr(isRemote)
@@ -162,7 +173,7 @@ func parseLinkedInCriteriaValues(doc *goquery.Document) map[string]string {
 func normalizeCriteriaKey(text string) string {
 	clean := strings.ToLower(normalizeWhitespace(text))
 	clean = strings.TrimSuffix(clean, ":")
-	return clean
+	return canonicalCriteriaLabel(clean)
 }
 
 func parseLinkedInDirectApplyURL(doc *goquery.Document) string {
@@ -287,13 +298,63 @@ func isRateLimitError(err error) bool {
 	return strings.Contains(text, "429") || strings.Contains(text, "rate limit") || strings.Contains(text, "too many requests")
 }
 
+func (c *liveLinkedInClient) setRequestPolicy(policy upstreamRequestPolicy) {
+	c.policy = policy
+	c.httpClient.SetTimeout(time.Duration(policy.TimeoutSeconds) * time.Second)
+}
+
+// request sends a LinkedIn request with this client's per-run policy.
+func (c *liveLinkedInClient) request(doRequest func() (*resty.Response, error), isCancelled func() bool) (*resty.Response, error) {
+	resp, _, _, err := requestWithPolicy(c.policy, doRequest, isCancelled)
+	return resp, err
+}
+
 func requestWithRateLimitBackoff(
 	doRequest func() (*resty.Response, error),
 	isCancelled func() bool,
 ) (*resty.Response, float64, int, error) {
-	window := float64(rateLimitRetryWindowSeconds())
-	backoff := float64(rateLimitInitialBackoffSeconds())
-	maxBackoff := float64(rateLimitMaxBackoffSeconds())
+	return requestWithPolicy(defaultUpstreamRequestPolicy(), doRequest, isCancelled)
+}
+
+// requestWithPolicy sends a LinkedIn request through the shared circuit
+// breaker, which short-circuits while upstream is failing.
+func requestWithPolicy(
+	policy upstreamRequestPolicy,
+	doRequest func() (*resty.Response, error),
+	isCancelled func() bool,
+) (*resty.Response, float64, int, error) {
+	if !sharedCircuitBreaker.allow() {
+		return nil, 0, 0, errUpstreamUnavailable
+	}
+	resp, elapsed, retries, err := retryRateLimitedRequest(policy, doRequest, isCancelled)
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode()
+	}
+	switch {
+	case errors.Is(err, errSearchRunCancelled):
+		sharedCircuitBreaker.release()
+	case circuitFailure(status, err):
+		reason := fmt.Sprintf("status %d", status)
+		if err != nil {
+			reason = err.Error()
+		}
+		sharedCircuitBreaker.recordFailure(reason)
+	default:
+		sharedCircuitBreaker.recordSuccess()
+	}
+	return resp, elapsed, retries, err
+}
+
+func retryRateLimitedRequest(
+	policy upstreamRequestPolicy,
+	doRequest func() (*resty.Response, error),
+	isCancelled func() bool,
+) (*resty.Response, float64, int, error) {
+	window := float64(policy.RetryWindowSeconds)
+	backoff := float64(policy.InitialBackoffSeconds)
+	maxBackoff := float64(policy.MaxBackoffSeconds)
+	exhausted := fmt.Errorf("rate limited by upstream job source (429/Too Many Requests). Retried for %d seconds without recovery. Please try again shortly", policy.RetryWindowSeconds)
 	elapsed := 0.0
 	retries := 0
 
@@ -301,6 +362,9 @@ func requestWithRateLimitBackoff(
 		if isCancelled != nil && isCancelled() {
 			return nil, elapsed, retries, errSearchRunCancelled
 		}
+		if !waitForUpstreamToken(isCancelled) {
+			return nil, elapsed, retries, errSearchRunCancelled
+		}
 		resp, err := doRequest()
 		if err == nil && resp != nil && !isRateLimitStatus(resp.StatusCode()) {
 			return resp, elapsed, retries, nil
@@ -318,13 +382,13 @@ func requestWithRateLimitBackoff(
 				return nil, elapsed, retries, err
 			}
 			if resp != nil {
-				return resp, elapsed, retries, fmt.Errorf("linkedin request failed with status %d", resp.StatusCode())
+				return resp, elapsed, retries, &upstreamStatusError{Status: resp.StatusCode()}
 			}
 			return nil, elapsed, retries, errors.New("linkedin request failed without response")
 		}
 
 		if elapsed >= window {
-			return nil, elapsed, retries, fmt.Errorf("rate limited by upstream job source (429/Too Many Requests). Retried for 3 minutes without recovery. Please try again shortly")
+			return nil, elapsed, retries, exhausted
 		}
 		sleepFor := backoff
 		if sleepFor > maxBackoff {
@@ -335,7 +399,7 @@ func requestWithRateLimitBackoff(
 			sleepFor = remaining
 		}
 		if sleepFor <= 0 {
-			return nil, elapsed, retries, fmt.Errorf("rate limited by upstream job source (429/Too Many Requests). Retried for 3 minutes without recovery. Please try again shortly")
+			return nil, elapsed, retries, exhausted
 		}
 		sleepDur := time.Duration(sleepFor * float64(time.Second))
 		if !sleepWithCancel(sleepDur, isCancelled) {
@@ -373,34 +437,90 @@ func sleepWithCancel(duration time.Duration, isCancelled func() bool) bool {
 	}
 }
 
-func (c *liveLinkedInClient) FetchSearchPage(query linkedInSearchQuery, isCancelled func() bool) ([]linkedInJob, error) {
+func linkedInSearchParams(query linkedInSearchQuery) map[string]string {
 	params := map[string]string{
 		"keywords": query.JobTitle,
 		"location": query.Location,
 		"start":    strconv.Itoa(query.Start),
 	}
+	if query.GeoID != "" {
+		params["geoId"] = query.GeoID
+	}
 	if query.HoursOld > 0 {
 		params["f_TPR"] = fmt.Sprintf("r%d", query.HoursOld*3600)
 	}
-	resp, _, _, err := requestWithRateLimitBackoff(func() (*resty.Response, error) {
+	if filter := linkedInWorkplaceFilter(query.WorkplaceTypes); filter != "" {
+		params["f_WT"] = filter
+	}
+	if filter := linkedInCriteriaFilter(query.JobTypes, linkedInJobTypeCodes); filter != "" {
+		params["f_JT"] = filter
+	}
+	if filter := linkedInCriteriaFilter(query.JobLevels, linkedInJobLevelCodes); filter != "" {
+		params["f_E"] = filter
+	}
+	return params
+}
+
+func (c *liveLinkedInClient) FetchSearchPage(query linkedInSearchQuery, isCancelled func() bool) ([]linkedInJob, error) {
+	params := linkedInSearchParams(query)
+	cacheKey := searchPageCacheKey(params, c.locale)
+	searchURL := localizedLinkedInURL(linkedInSearchURL, c.locale.Host)
+	ttl := searchPageCacheTTL()
+	if !query.BypassCache {
+		if body, ok := sharedSearchPageCache.get(cacheKey, ttl); ok {
+			c.pageCacheHits.Add(1)
+			return parseLinkedInListHTML(body)
+		}
+	}
+	resp, err := c.request(func() (*resty.Response, error) {
 		return c.httpClient.R().
+			SetHeaders(c.requestHeaders(true)).
 			SetQueryParams(params).
-			Get(linkedInSearchURL)
+			Get(searchURL)
 	}, isCancelled)
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode() >= 400 {
+		return nil, &upstreamStatusError{Status: resp.StatusCode()}
+	}
 	body := string(resp.Body())
-	return parseLinkedInListHTML(body)
+	jobs, err := parseLinkedInListHTML(body)
+	if err != nil {
+		captureScrapeDebug("search_page", searchURL, params, resp.StatusCode(), body, "parse_error")
+		return nil, &searchParseError{Err: err}
+	}
+	if len(jobs) == 0 {
+		captureScrapeDebug("search_page", searchURL, params, resp.StatusCode(), body, "zero_cards")
+		return jobs, nil
+	}
+	sharedSearchPageCache.put(cacheKey, body, ttl)
+	return jobs, nil
 }
 
 func (c *liveLinkedInClient) FetchJobDetails(jobURL, title, location string, isCancelled func() bool) (linkedInJobDetails, error) {
-	resp, _, _, err := requestWithRateLimitBackoff(func() (*resty.Response, error) {
-		return c.httpClient.R().Get(jobURL)
+	if session := c.session.active(); session != nil {
+		details, err := c.fetchAuthenticatedJobDetails(session, jobURL, title, location, isCancelled)
+		if err == nil {
+			return details, nil
+		}
+		if isCancelled != nil && isCancelled() {
+			return linkedInJobDetails{}, err
+		}
+	}
+	resp, err := c.request(func() (*resty.Response, error) {
+		return c.httpClient.R().SetHeaders(c.requestHeaders(false)).Get(jobURL)
 	}, isCancelled)
 	if err != nil {
 		return linkedInJobDetails{}, err
 	}
 	body := string(resp.Body())
-	return parseLinkedInJobDetailsHTML(body, title, location), nil
+	if code := resp.StatusCode(); code == 404 || code == 410 {
+		return linkedInJobDetails{ClosedReason: fmt.Sprintf("posting removed (HTTP %d)", code)}, nil
+	}
+	details := parseLinkedInJobDetailsHTML(body, title, location)
+	if details.Description == "" && details.ClosedReason == "" {
+		captureScrapeDebug("job_details", jobURL, nil, resp.StatusCode(), body, "empty_description")
+	}
+	return details, nil
 }
@@ -0,0 +1,254 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultLinkedInSessionPath  = "data/config/linkedin_session.json"
+	linkedInVoyagerJobURL       = "https://www.linkedin.com/voyager/api/jobs/jobPostings/"
+	linkedInVoyagerDecorationID = "com.linkedin.voyager.deco.jobs.web.shared.WebFullJobPosting-65"
+	linkedInSessionGuest        = "guest"
+	linkedInSessionAuth         = "authenticated"
+	linkedInSessionRejected     = "rejected"
+)
+
+var linkedInJobIDPattern = regexp.MustCompile(`(\d{6,})/?$`)
+
+var errLinkedInSessionRejected = errors.New("linkedin session cookie was rejected")
+
+// linkedInSession is an optional logged-in cookie pair. JSESSIONID doubles as
+// the csrf-token LinkedIn expects on authenticated API calls.
+type linkedInSession struct {
+	LiAt       string
+	JSessionID string
+	Source     string
+}
+
+func linkedInSessionPath() string {
+	return envOrDefault("VISA_LINKEDIN_SESSION_PATH", defaultLinkedInSessionPath)
+}
+
+// loadLinkedInSession prefers VISA_LINKEDIN_LI_AT over the stored session
+// file. It returns nil when neither is configured.
+func loadLinkedInSession() *linkedInSession {
+	if liAt := strings.TrimSpace(os.Getenv("VISA_LINKEDIN_LI_AT")); liAt != "" {
+		return &linkedInSession{
+			LiAt:       liAt,
+			JSessionID: jsessionOrDefault(os.Getenv("VISA_LINKEDIN_JSESSIONID")),
+			Source:     "env",
+		}
+	}
+	stored := loadJSONMap(linkedInSessionPath(), nil)
+	liAt := strings.TrimSpace(getString(stored, "li_at"))
+	if liAt == "" {
+		return nil
+	}
+	return &linkedInSession{
+		LiAt:       liAt,
+		JSessionID: jsessionOrDefault(getString(stored, "jsessionid")),
+		Source:     "file",
+	}
+}
+
+func jsessionOrDefault(raw string) string {
+	clean := strings.Trim(strings.TrimSpace(raw), `"`)
+	if clean == "" {
+		return fmt.Sprintf("ajax:%d", utcNow().UnixNano())
+	}
+	return clean
+}
+
+func maskSecret(value string) string {
+	if len(value) <= 8 {
+		return "****"
+	}
+	return value[:4] + "…" + value[len(value)-4:]
+}
+
+// linkedInSessionState tracks whether the configured session is still usable
+// by a client. Once LinkedIn rejects the cookie the client stays on the guest
+// API for the rest of its lifetime.
+type linkedInSessionState struct {
+	session  *linkedInSession
+	rejected atomic.Bool
+}
+
+func (s *linkedInSessionState) active() *linkedInSession {
+	if s == nil || s.session == nil || s.rejected.Load() {
+		return nil
+	}
+	return s.session
+}
+
+func (s *linkedInSessionState) status() string {
+	switch {
+	case s == nil || s.session == nil:
+		return linkedInSessionGuest
+	case s.rejected.Load():
+		return linkedInSessionRejected
+	default:
+		return linkedInSessionAuth
+	}
+}
+
+// linkedInSessionReporter is implemented by clients that can report which
+// mode (guest, authenticated, rejected) description fetches ran in.
+type linkedInSessionReporter interface {
+	LinkedInSessionStatus() string
+}
+
+func linkedInSessionStatusFor(client linkedInClient) string {
+	if reporter, ok := client.(linkedInSessionReporter); ok {
+		return reporter.LinkedInSessionStatus()
+	}
+	return linkedInSessionGuest
+}
+
+func (c *liveLinkedInClient) LinkedInSessionStatus() string {
+	return c.session.status()
+}
+
+func linkedInJobIDFromURL(jobURL string) string {
+	match := linkedInJobIDPattern.FindStringSubmatch(stripQuery(jobURL))
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+type voyagerJobPosting struct {
+	Description struct {
+		Text string `json:"text"`
+	} `json:"description"`
+	FormattedEmploymentStatus string          `json:"formattedEmploymentStatus"`
+	FormattedExperienceLevel  string          `json:"formattedExperienceLevel"`
+	FormattedIndustries       []string        `json:"formattedIndustries"`
+	FormattedJobFunctions     []string        `json:"formattedJobFunctions"`
+	WorkRemoteAllowed         bool            `json:"workRemoteAllowed"`
+	ListedAt                  int64           `json:"listedAt"`
+	RepostedJob               *bool           `json:"repostedJob"`
+	Applies                   *int            `json:"applies"`
+	ApplyMethod               json.RawMessage `json:"applyMethod"`
+	JobState                  string          `json:"jobState"`
+}
+
+func parseVoyagerJobPosting(raw []byte, title, location string) (linkedInJobDetails, error) {
+	var posting voyagerJobPosting
+	if err := json.Unmarshal(raw, &posting); err != nil {
+		return linkedInJobDetails{}, err
+	}
+	description := normalizeWhitespace(posting.Description.Text)
+	if description == "" {
+		return linkedInJobDetails{}, fmt.Errorf("authenticated job posting had no description")
+	}
+	details := linkedInJobDetails{
+		Description:     description,
+		JobType:         posting.FormattedEmploymentStatus,
+		JobLevel:        posting.FormattedExperienceLevel,
+		CompanyIndustry: strings.Join(posting.FormattedIndustries, ", "),
+		JobFunction:     strings.Join(posting.FormattedJobFunctions, ", "),
+		ApplicantCount:  posting.Applies,
+		IsReposted:      posting.RepostedJob,
+		ClosedReason:    voyagerClosedReason(posting.JobState),
+	}
+	if posting.ListedAt > 0 {
+		details.PostedAt = time.UnixMilli(posting.ListedAt).UTC()
+	}
+	var apply map[string]struct {
+		CompanyApplyURL string `json:"companyApplyUrl"`
+	}
+	if json.Unmarshal(posting.ApplyMethod, &apply) == nil {
+		for _, method := range apply {
+			if direct := normalizeExternalURL(method.CompanyApplyURL); direct != "" {
+				details.JobURLDirect = direct
+				break
+			}
+		}
+	}
+	details.IsRemote = boolPtr(posting.WorkRemoteAllowed || detectLinkedInRemote(title, location, description))
+	return details, nil
+}
+
+// fetchAuthenticatedJobDetails reads a posting through the logged-in API.
+// 401/403 mark the session rejected so later fetches skip straight to the
+// guest page.
+func (c *liveLinkedInClient) fetchAuthenticatedJobDetails(session *linkedInSession, jobURL, title, location string, isCancelled func() bool) (linkedInJobDetails, error) {
+	jobID := linkedInJobIDFromURL(jobURL)
+	if jobID == "" {
+		return linkedInJobDetails{}, fmt.Errorf("no LinkedIn job id in %s", jobURL)
+	}
+	resp, err := c.request(func() (*resty.Response, error) {
+		return c.httpClient.R().
+			SetHeaders(c.requestHeaders(false)).
+			SetHeader("Accept", "application/vnd.linkedin.normalized+json+2.1").
+			SetHeader("Csrf-Token", session.JSessionID).
+			SetHeader("X-Restli-Protocol-Version", "2.0.0").
+			SetHeader("Cookie", fmt.Sprintf(`li_at=%s; JSESSIONID="%s"`, session.LiAt, session.JSessionID)).
+			SetQueryParam("decorationId", linkedInVoyagerDecorationID).
+			Get(linkedInVoyagerJobURL + jobID)
+	}, isCancelled)
+	if err != nil {
+		return linkedInJobDetails{}, err
+	}
+	if code := resp.StatusCode(); code == 401 || code == 403 {
+		c.session.rejected.Store(true)
+		return linkedInJobDetails{}, errLinkedInSessionRejected
+	}
+	if resp.StatusCode() >= 400 {
+		return linkedInJobDetails{}, fmt.Errorf("authenticated job posting returned HTTP %d", resp.StatusCode())
+	}
+	return parseVoyagerJobPosting(resp.Body(), title, location)
+}
+
+func SetLinkedInSession(args map[string]any) (map[string]any, error) {
+	liAt := strings.TrimSpace(getString(args, "li_at"))
+	if liAt == "" {
+		return nil, fmt.Errorf("li_at is required")
+	}
+	if strings.ContainsAny(liAt, "; \t\n") {
+		return nil, fmt.Errorf("li_at must be the raw cookie value, not a Cookie header")
+	}
+	stored := map[string]any{
+		"li_at":        liAt,
+		"saved_at_utc": utcNowISO(),
+	}
+	if jsession := strings.Trim(strings.TrimSpace(getString(args, "jsessionid")), `"`); jsession != "" {
+		stored["jsessionid"] = jsession
+	}
+	path := linkedInSessionPath()
+	if err := saveSecretJSONMap(path, stored); err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"saved":               true,
+		"path":                path,
+		"li_at_masked":        maskSecret(liAt),
+		"jsessionid_provided": hasKey(stored, "jsessionid"),
+		"env_override_active": strings.TrimSpace(os.Getenv("VISA_LINKEDIN_LI_AT")) != "",
+		"applies_to":          "job description fetches in searches started after this call",
+		"fallback":            "guest job pages are used when the session is missing, expired, or rejected",
+	}, nil
+}
+
+func ClearLinkedInSession(args map[string]any) (map[string]any, error) {
+	path := linkedInSessionPath()
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return map[string]any{
+		"cleared":             err == nil,
+		"path":                path,
+		"env_override_active": strings.TrimSpace(os.Getenv("VISA_LINKEDIN_LI_AT")) != "",
+	}, nil
+}
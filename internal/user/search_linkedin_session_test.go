@@ -0,0 +1,186 @@
+package user
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type stubTransport func(req *http.Request) *http.Response
+
+func (f stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func stubResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+const guestJobPageHTML = `<html><body><div class="show-more-less-html__markup">Guest description with H-1B sponsorship.</div></body></html>`
+
+func liveClientWithTransport(t *testing.T, transport stubTransport) *liveLinkedInClient {
+	t.Helper()
+	client := newLiveLinkedInClient().(*liveLinkedInClient)
+	client.httpClient.SetTransport(transport)
+	return client
+}
+
+func TestLinkedInSessionFromFileAndEnv(t *testing.T) {
+	setupUserToolPaths(t)
+	if loadLinkedInSession() != nil {
+		t.Fatalf("expected no session before one is stored")
+	}
+	out, err := SetLinkedInSession(map[string]any{"li_at": "AQEDAStoredCookieValue", "jsessionid": `"ajax:123"`})
+	if err != nil {
+		t.Fatalf("SetLinkedInSession failed: %v", err)
+	}
+	if strings.Contains(getString(out, "li_at_masked"), "StoredCookie") {
+		t.Fatalf("expected masked cookie in response, got %v", out["li_at_masked"])
+	}
+	info, err := os.Stat(linkedInSessionPath())
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected session file with 0600 permissions, got %v (%v)", info, err)
+	}
+	session := loadLinkedInSession()
+	if session == nil || session.Source != "file" || session.JSessionID != "ajax:123" {
+		t.Fatalf("expected stored session, got %+v", session)
+	}
+
+	t.Setenv("VISA_LINKEDIN_LI_AT", "AQEDAEnvCookie")
+	if session := loadLinkedInSession(); session == nil || session.Source != "env" || !strings.HasPrefix(session.JSessionID, "ajax:") {
+		t.Fatalf("expected env session to win, got %+v", session)
+	}
+	t.Setenv("VISA_LINKEDIN_LI_AT", "")
+
+	cleared, err := ClearLinkedInSession(map[string]any{})
+	if err != nil || !boolOrFalse(cleared["cleared"]) {
+		t.Fatalf("expected session to be cleared, got %v (%v)", cleared, err)
+	}
+	if loadLinkedInSession() != nil {
+		t.Fatalf("expected no session after clear")
+	}
+}
+
+func TestSetLinkedInSessionReplacesReadableFileWithPrivateOne(t *testing.T) {
+	setupUserToolPaths(t)
+	path := linkedInSessionPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"li_at":"old"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SetLinkedInSession(map[string]any{"li_at": "AQEDANewCookieValue"}); err != nil {
+		t.Fatalf("SetLinkedInSession failed: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected session file with 0600 permissions, got %v (%v)", info, err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".secret-") {
+			t.Fatalf("expected temp file to be renamed away, found %s", entry.Name())
+		}
+	}
+}
+
+func TestSetLinkedInSessionRejectsCookieHeader(t *testing.T) {
+	setupUserToolPaths(t)
+	if _, err := SetLinkedInSession(map[string]any{}); err == nil {
+		t.Fatalf("expected missing li_at to fail")
+	}
+	if _, err := SetLinkedInSession(map[string]any{"li_at": "li_at=abc; JSESSIONID=x"}); err == nil {
+		t.Fatalf("expected a full Cookie header to be rejected")
+	}
+}
+
+func TestFetchJobDetailsUsesAuthenticatedPosting(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_LINKEDIN_LI_AT", "AQEDAEnvCookie")
+	client := liveClientWithTransport(t, func(req *http.Request) *http.Response {
+		if !strings.HasPrefix(req.URL.String(), linkedInVoyagerJobURL+"3812345678") {
+			t.Errorf("unexpected request %s", req.URL)
+			return stubResponse(req, 500, "")
+		}
+		if !strings.Contains(req.Header.Get("Cookie"), "li_at=AQEDAEnvCookie") || req.Header.Get("Csrf-Token") == "" {
+			t.Errorf("expected session cookie and csrf token, got %v", req.Header)
+		}
+		return stubResponse(req, 200, `{
+			"description": {"text": "We sponsor  H-1B visas."},
+			"formattedEmploymentStatus": "Full-time",
+			"formattedExperienceLevel": "Mid-Senior level",
+			"formattedIndustries": ["Software Development"],
+			"workRemoteAllowed": true,
+			"applyMethod": {"com.linkedin.voyager.jobs.OffsiteApply": {"companyApplyUrl": "https://careers.acme.com/jobs/1"}}
+		}`)
+	})
+	details, err := client.FetchJobDetails("https://www.linkedin.com/jobs/view/software-engineer-at-acme-3812345678?trk=x", "Software Engineer", "New York, NY", nil)
+	if err != nil {
+		t.Fatalf("FetchJobDetails failed: %v", err)
+	}
+	if details.Description != "We sponsor H-1B visas." || details.JobType != "Full-time" || details.CompanyIndustry != "Software Development" {
+		t.Fatalf("unexpected authenticated details: %+v", details)
+	}
+	if details.JobURLDirect != "https://careers.acme.com/jobs/1" || details.IsRemote == nil || !*details.IsRemote {
+		t.Fatalf("expected apply URL and remote flag, got %+v", details)
+	}
+	if got := client.LinkedInSessionStatus(); got != linkedInSessionAuth {
+		t.Fatalf("expected authenticated status, got %q", got)
+	}
+}
+
+func TestFetchJobDetailsFallsBackToGuestWhenSessionRejected(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_LINKEDIN_LI_AT", "AQEDAExpiredCookie")
+	authCalls := 0
+	client := liveClientWithTransport(t, func(req *http.Request) *http.Response {
+		if strings.HasPrefix(req.URL.String(), linkedInVoyagerJobURL) {
+			authCalls++
+			return stubResponse(req, 401, "")
+		}
+		if req.Header.Get("Cookie") != "" {
+			t.Errorf("guest request should not carry the session cookie")
+		}
+		return stubResponse(req, 200, guestJobPageHTML)
+	})
+	for i := 0; i < 2; i++ {
+		details, err := client.FetchJobDetails("https://www.linkedin.com/jobs/view/3812345678", "Engineer", "Remote", nil)
+		if err != nil {
+			t.Fatalf("FetchJobDetails failed: %v", err)
+		}
+		if !strings.Contains(details.Description, "Guest description") {
+			t.Fatalf("expected guest description, got %+v", details)
+		}
+	}
+	if authCalls != 1 {
+		t.Fatalf("expected rejected session to be skipped after the first call, got %d auth calls", authCalls)
+	}
+	if got := client.LinkedInSessionStatus(); got != linkedInSessionRejected {
+		t.Fatalf("expected rejected status, got %q", got)
+	}
+}
+
+func TestLinkedInJobIDFromURL(t *testing.T) {
+	cases := map[string]string{
+		"https://www.linkedin.com/jobs/view/software-engineer-at-acme-3812345678": "3812345678",
+		"https://www.linkedin.com/jobs/view/3812345678/?trk=abc":                  "3812345678",
+		"https://careers.acme.com/jobs/engineer":                                  "",
+	}
+	for raw, want := range cases {
+		if got := linkedInJobIDFromURL(raw); got != want {
+			t.Fatalf("linkedInJobIDFromURL(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
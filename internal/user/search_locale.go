@@ -0,0 +1,159 @@
+package user
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultLinkedInHost    = "www.linkedin.com"
+	maxAcceptLanguageChars = 128
+)
+
+var (
+	linkedInRegionPattern = regexp.MustCompile(`^[a-z]{2}$`)
+	acceptLanguagePattern = regexp.MustCompile(`^[A-Za-z*]{1,8}(-[A-Za-z0-9]{1,8})*(;q=[01](\.\d{1,3})?)?(\s*,\s*[A-Za-z*]{1,8}(-[A-Za-z0-9]{1,8})*(;q=[01](\.\d{1,3})?)?)*$`)
+)
+
+// localizedCriteriaLabels maps the job-criteria headings LinkedIn renders on
+// regional hosts onto the English keys the parser reads.
+var localizedCriteriaLabels = map[string]string{
+	// German
+	"anstellungsart":    "employment type",
+	"beschäftigungsart": "employment type",
+	"karrierestufe":     "seniority level",
+	"tätigkeitsbereich": "job function",
+	"branchen":          "industries",
+	// French
+	"type d’emploi":       "employment type",
+	"type d'emploi":       "employment type",
+	"niveau hiérarchique": "seniority level",
+	"fonction":            "job function",
+	"secteurs":            "industries",
+	// Spanish
+	"tipo de empleo":      "employment type",
+	"nivel de antigüedad": "seniority level",
+	"función laboral":     "job function",
+	"sectores":            "industries",
+	// Portuguese
+	"tipo de emprego":      "employment type",
+	"nível de experiência": "seniority level",
+	"função":               "job function",
+	"setores":              "industries",
+	// Italian
+	"tipo di impiego":      "employment type",
+	"livello di anzianità": "seniority level",
+	"funzione lavorativa":  "job function",
+	"settori":              "industries",
+	// Dutch
+	"dienstverband":      "employment type",
+	"senioriteitsniveau": "seniority level",
+	"functie":            "job function",
+	"sectoren":           "industries",
+}
+
+type linkedInLocale struct {
+	Host           string
+	AcceptLanguage string
+}
+
+func (l linkedInLocale) toMap() map[string]any {
+	return map[string]any{
+		"linkedin_host":   firstNonEmpty(l.Host, defaultLinkedInHost),
+		"accept_language": optionalString(l.AcceptLanguage),
+	}
+}
+
+// linkedInLocaleSetter is implemented by clients that can send requests to a
+// regional LinkedIn host or with a per-run Accept-Language header.
+type linkedInLocaleSetter interface {
+	setLocale(locale linkedInLocale)
+}
+
+func applyLinkedInLocale(client linkedInClient, locale linkedInLocale) {
+	if setter, ok := client.(linkedInLocaleSetter); ok {
+		setter.setLocale(locale)
+	}
+}
+
+// normalizeLinkedInHost accepts a two-letter region ("uk", "de") or a full
+// regional host ("uk.linkedin.com") and returns the host name.
+func normalizeLinkedInHost(raw string) (string, error) {
+	clean := strings.ToLower(strings.TrimSpace(raw))
+	clean = strings.TrimPrefix(strings.TrimPrefix(clean, "https://"), "http://")
+	clean = strings.TrimSuffix(clean, "/")
+	region := strings.TrimSuffix(clean, ".linkedin.com")
+	if region == "www" {
+		return defaultLinkedInHost, nil
+	}
+	if !linkedInRegionPattern.MatchString(region) {
+		return "", fmt.Errorf("linkedin_host must be a two-letter region like 'uk' or a host like 'de.linkedin.com'")
+	}
+	return region + ".linkedin.com", nil
+}
+
+func parseLinkedInLocaleOptions(args map[string]any, query map[string]any) error {
+	if raw := getString(args, "linkedin_host"); raw != "" {
+		host, err := normalizeLinkedInHost(raw)
+		if err != nil {
+			return err
+		}
+		query["linkedin_host"] = host
+	}
+	if raw := strings.TrimSpace(getString(args, "accept_language")); raw != "" {
+		if len(raw) > maxAcceptLanguageChars || !acceptLanguagePattern.MatchString(raw) {
+			return fmt.Errorf("accept_language must be an Accept-Language value like 'de-DE,de;q=0.9'")
+		}
+		query["accept_language"] = raw
+	}
+	return nil
+}
+
+func linkedInLocaleFromQuery(queryMap map[string]any) linkedInLocale {
+	return linkedInLocale{
+		Host:           getString(queryMap, "linkedin_host"),
+		AcceptLanguage: getString(queryMap, "accept_language"),
+	}
+}
+
+// localizedLinkedInURL moves a www.linkedin.com URL onto the configured
+// regional host. URLs on other hosts are returned unchanged.
+func localizedLinkedInURL(raw, host string) string {
+	if host == "" || host == defaultLinkedInHost {
+		return raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host != defaultLinkedInHost {
+		return raw
+	}
+	parsed.Host = host
+	return parsed.String()
+}
+
+func canonicalCriteriaLabel(label string) string {
+	if canonical, ok := localizedCriteriaLabels[label]; ok {
+		return canonical
+	}
+	return label
+}
+
+func (c *liveLinkedInClient) setLocale(locale linkedInLocale) {
+	c.locale = locale
+}
+
+// requestHeaders returns the rotated browser headers with the run's
+// Accept-Language override applied.
+func (c *liveLinkedInClient) requestHeaders(listingPage bool) map[string]string {
+	headers := c.headers.headers(listingPage)
+	if c.locale.AcceptLanguage == "" {
+		return headers
+	}
+	out := make(map[string]string, len(headers)+1)
+	for key, value := range headers {
+		out[key] = value
+	}
+	out["Accept-Language"] = c.locale.AcceptLanguage
+	return out
+}
@@ -0,0 +1,89 @@
+package user
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeLinkedInHost(t *testing.T) {
+	cases := map[string]string{
+		"uk":                       "uk.linkedin.com",
+		"DE":                       "de.linkedin.com",
+		"https://fr.linkedin.com/": "fr.linkedin.com",
+		"www":                      defaultLinkedInHost,
+		"www.linkedin.com":         defaultLinkedInHost,
+	}
+	for raw, want := range cases {
+		got, err := normalizeLinkedInHost(raw)
+		if err != nil || got != want {
+			t.Fatalf("normalizeLinkedInHost(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"evil.example.com", "united-kingdom", "uk.linkedin.com.evil"} {
+		if _, err := normalizeLinkedInHost(raw); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
+
+func TestParseLinkedInLocaleOptions(t *testing.T) {
+	query := map[string]any{}
+	if err := parseLinkedInLocaleOptions(map[string]any{"linkedin_host": "de", "accept_language": "de-DE,de;q=0.9,en;q=0.5"}, query); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	locale := linkedInLocaleFromQuery(query)
+	if locale.Host != "de.linkedin.com" || locale.AcceptLanguage != "de-DE,de;q=0.9,en;q=0.5" {
+		t.Fatalf("unexpected locale: %+v", locale)
+	}
+	err := parseLinkedInLocaleOptions(map[string]any{"accept_language": "de\r\nX-Injected: 1"}, map[string]any{})
+	if err == nil || !strings.Contains(err.Error(), "accept_language") {
+		t.Fatalf("expected accept_language validation error, got %v", err)
+	}
+}
+
+func TestParseLinkedInJobDetailsMapsLocalizedCriteria(t *testing.T) {
+	html := `<html><body>
+		<div class="show-more-less-html__markup">Wir bieten Visa-Unterstützung.</div>
+		<ul>
+			<li class="description__job-criteria-item"><h3>Karrierestufe</h3><span class="description__job-criteria-text">Berufseinstieg</span></li>
+			<li class="description__job-criteria-item"><h3>Anstellungsart</h3><span class="description__job-criteria-text">Vollzeit</span></li>
+			<li class="description__job-criteria-item"><h3>Tätigkeitsbereich</h3><span class="description__job-criteria-text">Informationstechnologie</span></li>
+			<li class="description__job-criteria-item"><h3>Branchen</h3><span class="description__job-criteria-text">Softwareentwicklung</span></li>
+		</ul>
+	</body></html>`
+	details := parseLinkedInJobDetailsHTML(html, "Softwareentwickler", "Berlin")
+	if details.JobType != "Vollzeit" || details.JobLevel != "Berufseinstieg" || details.JobFunction != "Informationstechnologie" || details.CompanyIndustry != "Softwareentwicklung" {
+		t.Fatalf("unexpected localized criteria: %+v", details)
+	}
+	if !jobCriteriaAllowed([]string{"full-time"}, details.JobType, linkedInJobTypeCodes, jobTypeAliases) ||
+		jobCriteriaAllowed([]string{"mid-senior"}, details.JobLevel, linkedInJobLevelCodes, jobLevelAliases) {
+		t.Fatalf("expected localized criteria values to map onto canonical filters")
+	}
+}
+
+func TestLiveClientUsesRegionalHostAndAcceptLanguage(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_UPSTREAM_REQUESTS_PER_MINUTE", "0")
+	sharedSearchPageCache = &searchPageCache{entries: map[string]searchPageCacheEntry{}}
+	var hosts, languages []string
+	client := liveClientWithTransport(t, func(req *http.Request) *http.Response {
+		hosts = append(hosts, req.URL.Host)
+		languages = append(languages, req.Header.Get("Accept-Language"))
+		return stubResponse(req, 200, listingPageHTML)
+	})
+	query := linkedInSearchQuery{JobTitle: "Software Engineer", Location: "London", Start: 0}
+	if _, err := client.FetchSearchPage(query, nil); err != nil {
+		t.Fatalf("FetchSearchPage failed: %v", err)
+	}
+	applyLinkedInLocale(client, linkedInLocale{Host: "uk.linkedin.com", AcceptLanguage: "en-GB,en;q=0.9"})
+	if _, err := client.FetchSearchPage(query, nil); err != nil {
+		t.Fatalf("FetchSearchPage failed: %v", err)
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("expected the regional request to miss the www cache entry, got %d requests", len(hosts))
+	}
+	if hosts[0] != defaultLinkedInHost || hosts[1] != "uk.linkedin.com" || languages[1] != "en-GB,en;q=0.9" {
+		t.Fatalf("unexpected hosts %v or languages %v", hosts, languages)
+	}
+}
@@ -33,7 +33,11 @@ const (
 
 type companyDatasetRecord struct {
 	CompanyName string
-	CompanyTier string
+	// CompanyTier is the dataset tier, or one derived from filing volume,
+	// approval rate, and recency when the column only names the source
+	// (CompanyTierBasis says which).
+	CompanyTier      string
+	CompanyTierBasis string
 
 	H1B              int
 	H1B1Chile        int
@@ -42,11 +46,46 @@ type companyDatasetRecord struct {
 	GreenCard        int
 	TotalVisas       int
 	EmployerContacts []map[string]any
+	// FiscalYearCounts holds optional per-year counts keyed by fiscal year
+	// and dataset visa column.
+	FiscalYearCounts map[int]map[string]int
+	// SOCCounts holds filings per SOC occupation code when the dataset has
+	// a soc_counts column.
+	SOCCounts map[string]int
+	// StateCounts and CityCounts hold filings per worksite state and
+	// "city, ST" when the dataset has state_counts/city_counts columns.
+	StateCounts map[string]int
+	CityCounts  map[string]int
+	// CapExempt marks H-1B cap-exempt employers; CapExemptBasis says whether
+	// the dataset column or the name heuristic decided it.
+	CapExempt      bool
+	CapExemptBasis string
+	// RegisterCounts holds counts for visa columns fed by non-US sponsor
+	// registers (see sponsorRegisters), keyed by dataset column.
+	RegisterCounts map[string]int
+	// H1BDenied and H1BWithdrawn count H-1B LCA filings that ended denied or
+	// withdrawn; HasOutcomes is false when the dataset has no such columns.
+	H1BDenied    int
+	H1BWithdrawn int
+	HasOutcomes  bool
+	// L1 counts L-1 intracompany transfer petitions from an optional
+	// auxiliary l1 column (USCIS, not DOL, data); it is not in TotalVisas.
+	L1 int
+	// DatasetSource is the dataset file the record was read from; with
+	// merged datasets it shows which file supplied the company.
+	DatasetSource string
 }
 
 type companyDataset struct {
 	Rows                int
 	ByNormalizedCompany map[string]companyDatasetRecord
+	// LatestFiscalYear is the newest per-year column, or 0 without them.
+	LatestFiscalYear int
+	// Aliases maps normalized brand names to normalized dataset companies.
+	Aliases map[string]string
+	// NameCollisions lists the distinct company names behind a normalized
+	// key when more than one was found.
+	NameCollisions map[string][]companyNameVariant
 }
 
 type linkedInJob struct {
@@ -68,6 +107,7 @@ type linkedInJob struct {
 	CompanyIndustry string
 	JobFunction     string
 	JobURLDirect    string
+	CompanyURL      string
 }
 
 type linkedInJobDetails struct {
@@ -78,13 +118,22 @@ type linkedInJobDetails struct {
 	JobFunction     string
 	JobURLDirect    string
 	IsRemote        *bool
+	ApplicantCount  *int
+	IsReposted      *bool
+	PostedAt        time.Time
+	ClosedReason    string
 }
 
 type linkedInSearchQuery struct {
-	JobTitle string
-	Location string
-	HoursOld int
-	Start    int
+	JobTitle       string
+	Location       string
+	GeoID          string
+	HoursOld       int
+	Start          int
+	WorkplaceTypes []string
+	JobTypes       []string
+	JobLevels      []string
+	BypassCache    bool
 }
 
 type linkedInClient interface {
@@ -109,6 +158,44 @@ type searchQuery struct {
 	RefreshSession           bool
 	ScanMultiplier           int
 	MaxScanResults           int
+	PreferredVisaTypes       []string
+	MaxResultsPerCompany     int
+	Client                   linkedInClient
+	OnPartialResults         func(jobs []map[string]any)
+	SkipLayoutTracking       bool
+	SkipDescriptionCache     bool
+	WorkplaceTypes           []string
+	JobTypes                 []string
+	JobLevels                []string
+	ExcludeStaffingAgencies  bool
+	StaffingAgencyPatterns   []string
+	MustIncludeKeywords      []string
+	ExcludeKeywords          []string
+	ExcludeTitleKeywords     []string
+	EnforceConstraints       bool
+	HidePreviouslySeen       bool
+	DiffAgainstRunID         string
+	DiffAgainstLastRun       bool
+	RankingWeights           map[string]any
+	ContinueSessionID        string
+	MinSalary                int
+	SalaryInterval           string
+	GeoID                    string
+	SkipGeoResolution        bool
+	PostedAfter              time.Time
+	PostedBefore             time.Time
+	MaxRuntimeSeconds        int
+	RuntimeDeadline          time.Time
+	RequestPolicy            upstreamRequestPolicy
+	EnrichCompanyPages       bool
+	Locale                   linkedInLocale
+	MaxCompanyPageFetches    int
+	MinLCAWage               int
+	CapExemptOnly            bool
+	RequireGCTrack           bool
+	CompanyTiers             []string
+	CompanyTierWeights       map[string]float64
+	VisaTypeWeights          map[string]float64
 }
 
 type searchExecutionStats struct {
@@ -119,9 +206,33 @@ type searchExecutionStats struct {
 	DescriptionSignalMatches int
 	DescriptionFetches       int
 	DescriptionFetchSkipped  int
+	DescriptionCacheHits     int
+	DescriptionCacheMisses   int
 	IgnoredJobsSkipped       int
 	IgnoredCompaniesSkipped  int
 	DatasetRows              int
+	CollapsedByCompany       int
+	WorkplaceFilteredOut     int
+	SalaryFilteredOut        int
+	JobTypeFilteredOut       int
+	JobLevelFilteredOut      int
+	StaffingAgenciesSkipped  int
+	KeywordFilteredOut       int
+	ConstraintFilteredOut    int
+	ConstraintDemoted        int
+	UrgencyPromoted          int
+	VisaPrioritySecondary    int
+	PreviouslySeenSkipped    int
+	LenientAccepted          int
+	PostedDateFilteredOut    int
+	TitleExcludedOut         int
+	DescriptionSalaryFound   int
+	CompanyPagesFetched      int
+	CompanyPageCacheHits     int
+	LCAWageFilteredOut       int
+	CapExemptFilteredOut     int
+	GCTrackFilteredOut       int
+	CompanyTierFilteredOut   int
 	RetrySleepSeconds        float64
 	RetryAttempts            int
 }
@@ -195,9 +306,6 @@ func strictnessOrDefault(value string) string {
 	if mode == "" {
 		return "strict"
 	}
-	if mode == "strict" || mode == "balanced" {
-		return mode
-	}
 	return mode
 }
 
@@ -0,0 +1,130 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var socCodeRegex = regexp.MustCompile(`^(\d{2})-?(\d{4})`)
+
+var dolSOCColumns = []string{"SOC_CODE", "PW_SOC_CODE", "Soc Code"}
+
+// occupationRules maps job-title words to SOC code prefixes. Every rule whose
+// word appears in the title contributes its prefixes.
+var occupationRules = []struct {
+	Words    []string
+	Prefixes []string
+}{
+	{Words: []string{"software", "developer", "programmer", "frontend", "backend", "fullstack", "devops", "sre", "qa"}, Prefixes: []string{"15-125"}},
+	{Words: []string{"data", "database", "dba"}, Prefixes: []string{"15-124", "15-205"}},
+	{Words: []string{"scientist", "statistician", "actuary", "mathematician"}, Prefixes: []string{"15-2", "19-"}},
+	{Words: []string{"network", "cloud", "security", "systems", "infrastructure", "it"}, Prefixes: []string{"15-12"}},
+	{Words: []string{"engineer", "engineering"}, Prefixes: []string{"15-12", "17-2"}},
+	{Words: []string{"mechanical", "electrical", "civil", "chemical", "hardware", "industrial", "aerospace"}, Prefixes: []string{"17-2"}},
+	{Words: []string{"designer", "ux", "ui"}, Prefixes: []string{"15-1255", "27-102"}},
+	{Words: []string{"analyst", "consultant"}, Prefixes: []string{"13-1", "13-2", "15-121", "15-2"}},
+	{Words: []string{"accountant", "auditor", "finance", "financial"}, Prefixes: []string{"13-2"}},
+	{Words: []string{"marketing", "brand", "growth"}, Prefixes: []string{"11-202", "13-1161"}},
+	{Words: []string{"sales", "account"}, Prefixes: []string{"41-", "11-2022"}},
+	{Words: []string{"manager", "director", "head"}, Prefixes: []string{"11-"}},
+	{Words: []string{"product"}, Prefixes: []string{"11-3021", "15-1299", "13-1"}},
+	{Words: []string{"researcher", "research", "chemist", "biologist", "physicist"}, Prefixes: []string{"19-"}},
+	{Words: []string{"physician", "nurse", "pharmacist", "therapist", "clinical"}, Prefixes: []string{"29-"}},
+	{Words: []string{"teacher", "professor", "lecturer", "instructor"}, Prefixes: []string{"25-"}},
+	{Words: []string{"lawyer", "attorney", "counsel", "paralegal"}, Prefixes: []string{"23-"}},
+}
+
+// normalizeSOCCode reduces codes such as "15-1252.00" or "151252" to
+// "15-1252".
+func normalizeSOCCode(raw string) string {
+	match := socCodeRegex.FindStringSubmatch(strings.TrimSpace(raw))
+	if match == nil {
+		return ""
+	}
+	return match[1] + "-" + match[2]
+}
+
+// parseSOCCounts reads the dataset soc_counts column ("15-1252:120;13-1161:4").
+func parseSOCCounts(raw string) map[string]int {
+	return parseKeyedCounts(raw, normalizeSOCCode)
+}
+
+// socPrefixesForTitle maps a job title onto SOC code prefixes; an empty
+// result means the title is not specific enough to narrow by occupation.
+func socPrefixesForTitle(title string) []string {
+	words := strings.Fields(nonAlnumCompanyRegex.ReplaceAllString(strings.ToLower(title), " "))
+	prefixes := []string{}
+	for _, rule := range occupationRules {
+		if !slices.ContainsFunc(rule.Words, func(word string) bool { return slices.Contains(words, word) }) {
+			continue
+		}
+		for _, prefix := range rule.Prefixes {
+			if !slices.Contains(prefixes, prefix) {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+	return prefixes
+}
+
+// occupationPrefixes prefers the searched title and falls back to the
+// listing title when the search is too generic to map.
+func occupationPrefixes(searchTitle, listingTitle string) []string {
+	if prefixes := socPrefixesForTitle(searchTitle); len(prefixes) > 0 {
+		return prefixes
+	}
+	return socPrefixesForTitle(listingTitle)
+}
+
+type occupationMatch struct {
+	Prefixes       []string
+	MatchedFilings int
+	TotalFilings   int
+}
+
+func matchOccupation(record companyDatasetRecord, prefixes []string) (occupationMatch, bool) {
+	if len(prefixes) == 0 || len(record.SOCCounts) == 0 {
+		return occupationMatch{}, false
+	}
+	match := occupationMatch{Prefixes: prefixes}
+	for code, count := range record.SOCCounts {
+		match.TotalFilings += count
+		if slices.ContainsFunc(prefixes, func(prefix string) bool { return strings.HasPrefix(code, prefix) }) {
+			match.MatchedFilings += count
+		}
+	}
+	return match, match.TotalFilings > 0
+}
+
+// occupationVisaCount scales the company's desired-visa filings by the share
+// of its filings in occupations matching the title. Companies or titles
+// without SOC data keep the whole-company count.
+func occupationVisaCount(record companyDatasetRecord, desired []string, prefixes []string) int {
+	count := desiredVisaCount(record, desired)
+	match, ok := matchOccupation(record, prefixes)
+	if !ok || count == 0 {
+		return count
+	}
+	if match.MatchedFilings == 0 {
+		return 0
+	}
+	return max(1, count*match.MatchedFilings/match.TotalFilings)
+}
+
+func (m occupationMatch) toMap() map[string]any {
+	return map[string]any{
+		"soc_prefixes":        m.Prefixes,
+		"occupation_filings":  m.MatchedFilings,
+		"company_soc_filings": m.TotalFilings,
+		"occupation_share":    float64(m.MatchedFilings*100/m.TotalFilings) / 100,
+	}
+}
+
+func occupationFact(match occupationMatch) string {
+	if match.MatchedFilings == 0 {
+		return fmt.Sprintf("None of %d recorded filings are in occupations matching this title.", match.TotalFilings)
+	}
+	return fmt.Sprintf("%d of %d recorded filings are in occupations matching this title.", match.MatchedFilings, match.TotalFilings)
+}
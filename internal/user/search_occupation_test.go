@@ -0,0 +1,95 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOccupationVisaCountNarrowsToMatchingSOC(t *testing.T) {
+	record := companyDatasetRecord{
+		H1B:        100,
+		TotalVisas: 100,
+		SOCCounts:  map[string]int{"15-1252": 80, "13-1161": 20},
+	}
+	desired := []string{"h1b"}
+	engineer := socPrefixesForTitle("Senior Software Engineer")
+	if got := occupationVisaCount(record, desired, engineer); got != 80 {
+		t.Fatalf("expected engineering share of 80, got %d", got)
+	}
+	nurse := socPrefixesForTitle("Registered Nurse")
+	if got := occupationVisaCount(record, desired, nurse); got != 0 {
+		t.Fatalf("expected no sponsorship for unmatched occupation, got %d", got)
+	}
+	if got := occupationVisaCount(record, desired, socPrefixesForTitle("Associate")); got != 100 {
+		t.Fatalf("expected whole-company count for unmapped title, got %d", got)
+	}
+	noSOC := companyDatasetRecord{H1B: 7, TotalVisas: 7}
+	if got := occupationVisaCount(noSOC, desired, engineer); got != 7 {
+		t.Fatalf("expected whole-company count without SOC data, got %d", got)
+	}
+	if prefixes := occupationPrefixes("", "Marketing Manager"); !slices.Contains(prefixes, "11-202") {
+		t.Fatalf("expected listing title fallback, got %#v", prefixes)
+	}
+	match, ok := matchOccupation(record, nurse)
+	if !ok || !strings.HasPrefix(occupationFact(match), "None of 100") {
+		t.Fatalf("unexpected occupation fact: %#v %q", match, occupationFact(match))
+	}
+}
+
+func TestSOCCountsRoundTrip(t *testing.T) {
+	if got := normalizeSOCCode("15-1252.00"); got != "15-1252" {
+		t.Fatalf("normalizeSOCCode = %q", got)
+	}
+	if got := normalizeSOCCode("151132"); got != "15-1132" {
+		t.Fatalf("normalizeSOCCode = %q", got)
+	}
+	formatted := formatKeyedCounts(map[string]int{"13-1161": 4, "15-1252": 120}, maxDatasetKeyedCounts)
+	if formatted != "15-1252:120;13-1161:4" {
+		t.Fatalf("formatKeyedCounts = %q", formatted)
+	}
+	parsed := parseSOCCounts(formatted)
+	if parsed["15-1252"] != 120 || parsed["13-1161"] != 4 || parseSOCCounts("") != nil {
+		t.Fatalf("parseSOCCounts = %#v", parsed)
+	}
+}
+
+func TestRunInternalDolPipelineWritesSOCCounts(t *testing.T) {
+	setupUserToolPaths(t)
+	dir := t.TempDir()
+	lcaPath := filepath.Join(dir, "lca.csv")
+	lcaBody := strings.Join([]string{
+		"EMPLOYER_NAME,VISA_CLASS,SOC_CODE",
+		"Acme Inc,H-1B,15-1252.00",
+		"Acme Inc,H-1B,15-1252.00",
+		"Acme Inc,H-1B,13-1161.00",
+	}, "\n")
+	if err := os.WriteFile(lcaPath, []byte(lcaBody), 0o644); err != nil {
+		t.Fatalf("write lca: %v", err)
+	}
+	permPath := filepath.Join(dir, "perm.csv")
+	if err := os.WriteFile(permPath, []byte("EMPLOYER_NAME,PW_SOC_CODE\nAcme Inc,15-1252\n"), 0o644); err != nil {
+		t.Fatalf("write perm: %v", err)
+	}
+	datasetPath := filepath.Join(dir, "companies.csv")
+	result, err := RunInternalDolPipeline(map[string]any{
+		"lca_source":        lcaPath,
+		"perm_source":       permPath,
+		"dataset_path":      datasetPath,
+		"manifest_path":     filepath.Join(dir, "last_run.json"),
+		"strict_validation": false,
+	})
+	if err != nil || getString(result, "status") != "completed" {
+		t.Fatalf("RunInternalDolPipeline failed: %v %#v", err, result)
+	}
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("load generated dataset: %v", err)
+	}
+	acme := dataset.ByNormalizedCompany["acme"]
+	if acme.SOCCounts["15-1252"] != 3 || acme.SOCCounts["13-1161"] != 1 {
+		t.Fatalf("unexpected SOC counts: %#v", acme.SOCCounts)
+	}
+}
@@ -0,0 +1,202 @@
+package user
+
+import "fmt"
+
+func parseSearchOptions(args map[string]any, query map[string]any) error {
+	if parsed, has, err := getOptionalInt(args, "max_results_per_company"); has {
+		if err != nil {
+			return fmt.Errorf("max_results_per_company must be an integer when provided")
+		}
+		if parsed < 1 {
+			return fmt.Errorf("max_results_per_company must be >= 1")
+		}
+		query["max_results_per_company"] = parsed
+	}
+	if hasKey(args, "preferred_visa_types") {
+		visaTypes, err := normalizeVisaTypeList(getStringList(args, "preferred_visa_types"))
+		if err != nil {
+			return err
+		}
+		if len(visaTypes) > 0 {
+			query["preferred_visa_types"] = visaTypes
+		}
+	}
+	if raw, has := args["visa_type_weights"]; has && raw != nil {
+		weights, err := normalizeVisaTypeWeights(raw)
+		if err != nil {
+			return err
+		}
+		query["visa_type_weights"] = weights
+	}
+	if hasKey(args, "workplace_types") {
+		workplaceTypes, err := normalizeWorkplaceTypes(getStringList(args, "workplace_types"))
+		if err != nil {
+			return err
+		}
+		if len(workplaceTypes) > 0 {
+			query["workplace_types"] = workplaceTypes
+		}
+	}
+	if hasKey(args, "job_types") {
+		jobTypes, err := normalizeJobTypes(getStringList(args, "job_types"))
+		if err != nil {
+			return err
+		}
+		if len(jobTypes) > 0 {
+			query["job_types"] = jobTypes
+		}
+	}
+	if hasKey(args, "job_levels") {
+		jobLevels, err := normalizeJobLevels(getStringList(args, "job_levels"))
+		if err != nil {
+			return err
+		}
+		if len(jobLevels) > 0 {
+			query["job_levels"] = jobLevels
+		}
+	}
+	if value, has, err := getOptionalBool(args, "exclude_staffing_agencies"); has {
+		if err != nil {
+			return fmt.Errorf("exclude_staffing_agencies must be a boolean when provided")
+		}
+		query["exclude_staffing_agencies"] = value
+	}
+	if hasKey(args, "staffing_agency_patterns") {
+		query["staffing_agency_patterns"] = normalizeStaffingPatterns(getStringList(args, "staffing_agency_patterns"))
+	}
+	if hasKey(args, "must_include_keywords") {
+		query["must_include_keywords"] = normalizeKeywordList(getStringList(args, "must_include_keywords"))
+	}
+	if hasKey(args, "exclude_keywords") {
+		query["exclude_keywords"] = normalizeKeywordList(getStringList(args, "exclude_keywords"))
+	}
+	if hasKey(args, "exclude_title_keywords") {
+		query["exclude_title_keywords"] = normalizeKeywordList(getStringList(args, "exclude_title_keywords"))
+	}
+	if value, has, err := getOptionalBool(args, "enforce_constraints"); has {
+		if err != nil {
+			return fmt.Errorf("enforce_constraints must be a boolean when provided")
+		}
+		query["enforce_constraints"] = value
+	}
+	if runID := getString(args, "diff_against_run_id"); runID != "" {
+		if _, err := loadRunForUser(runID, getString(args, "user_id")); err != nil {
+			return fmt.Errorf("diff_against_run_id: %w", err)
+		}
+		query["diff_against_run_id"] = runID
+	}
+	if value, has, err := getOptionalBool(args, "diff_against_last_run"); has {
+		if err != nil {
+			return fmt.Errorf("diff_against_last_run must be a boolean when provided")
+		}
+		query["diff_against_last_run"] = value
+	}
+	if hasKey(args, "ranking_weights") {
+		weights, err := normalizeRankingWeights(args["ranking_weights"])
+		if err != nil {
+			return err
+		}
+		query["ranking_weights"] = weights
+	}
+	if value, has, err := getOptionalBool(args, "hide_previously_seen"); has {
+		if err != nil {
+			return fmt.Errorf("hide_previously_seen must be a boolean when provided")
+		}
+		query["hide_previously_seen"] = value
+	}
+	if raw := getString(args, "geo_id"); raw != "" {
+		geoID, err := normalizeGeoID(raw)
+		if err != nil {
+			return err
+		}
+		query["geo_id"] = geoID
+	}
+	if value, has, err := getOptionalBool(args, "resolve_geo_id"); has {
+		if err != nil {
+			return fmt.Errorf("resolve_geo_id must be a boolean when provided")
+		}
+		query["resolve_geo_id"] = value
+	}
+	if err := parsePostedRangeOptions(args, query); err != nil {
+		return err
+	}
+	if err := parseRuntimeBudgetOption(args, query); err != nil {
+		return err
+	}
+	if err := parseRequestPolicyOptions(args, query); err != nil {
+		return err
+	}
+	if err := parseCompanyPageOptions(args, query); err != nil {
+		return err
+	}
+	if err := parseLinkedInLocaleOptions(args, query); err != nil {
+		return err
+	}
+	if err := parseLCAWageOptions(args, query); err != nil {
+		return err
+	}
+	if err := parseCapExemptOptions(args, query); err != nil {
+		return err
+	}
+	if err := parseGCTrackOptions(args, query); err != nil {
+		return err
+	}
+	if err := parseCompanyTierOptions(args, query); err != nil {
+		return err
+	}
+	if parsed, has, err := getOptionalInt(args, "min_salary"); has {
+		if err != nil {
+			return fmt.Errorf("min_salary must be an integer when provided")
+		}
+		if parsed < 1 {
+			return fmt.Errorf("min_salary must be >= 1")
+		}
+		interval, err := normalizeSalaryInterval(getString(args, "salary_interval"))
+		if err != nil {
+			return err
+		}
+		query["min_salary"] = parsed
+		query["salary_interval"] = interval
+	} else if getString(args, "salary_interval") != "" {
+		return fmt.Errorf("salary_interval requires min_salary")
+	}
+	return nil
+}
+
+func applySearchOptions(queryMap map[string]any, query *searchQuery) {
+	query.MaxResultsPerCompany = intOrZero(queryMap["max_results_per_company"])
+	query.PreferredVisaTypes = getStringList(queryMap, "preferred_visa_types")
+	query.WorkplaceTypes = getStringList(queryMap, "workplace_types")
+	query.JobTypes = getStringList(queryMap, "job_types")
+	query.JobLevels = getStringList(queryMap, "job_levels")
+	query.ExcludeStaffingAgencies = boolOrFalse(queryMap["exclude_staffing_agencies"])
+	query.StaffingAgencyPatterns = getStringList(queryMap, "staffing_agency_patterns")
+	query.MustIncludeKeywords = getStringList(queryMap, "must_include_keywords")
+	query.ExcludeKeywords = getStringList(queryMap, "exclude_keywords")
+	query.ExcludeTitleKeywords = getStringList(queryMap, "exclude_title_keywords")
+	query.EnforceConstraints = boolOrFalse(queryMap["enforce_constraints"])
+	query.HidePreviouslySeen = boolOrFalse(queryMap["hide_previously_seen"])
+	query.DiffAgainstRunID = getString(queryMap, "diff_against_run_id")
+	query.DiffAgainstLastRun = boolOrFalse(queryMap["diff_against_last_run"])
+	query.RankingWeights = asMap(queryMap["ranking_weights"])
+	query.ContinueSessionID = getString(queryMap, "continue_session_id")
+	query.MinSalary = intOrZero(queryMap["min_salary"])
+	query.SalaryInterval = getString(queryMap, "salary_interval")
+	query.GeoID = getString(queryMap, "geo_id")
+	query.PostedAfter = parseISOTime(queryMap["posted_after"])
+	query.PostedBefore = parseISOTime(queryMap["posted_before"])
+	query.MaxRuntimeSeconds = intOrZero(queryMap["max_runtime_seconds"])
+	query.RequestPolicy = requestPolicyFromQuery(queryMap)
+	query.EnrichCompanyPages = boolOrFalse(queryMap["enrich_company_pages"])
+	query.MaxCompanyPageFetches = intOrZero(queryMap["max_company_page_fetches"])
+	query.Locale = linkedInLocaleFromQuery(queryMap)
+	query.MinLCAWage = intOrZero(queryMap["min_lca_wage"])
+	query.CapExemptOnly = boolOrFalse(queryMap["cap_exempt_only"])
+	query.RequireGCTrack = boolOrFalse(queryMap["require_gc_track"])
+	query.CompanyTiers = getStringList(queryMap, "company_tiers")
+	query.CompanyTierWeights = companyTierWeightsFromQuery(queryMap)
+	query.VisaTypeWeights = visaTypeWeightsFromMap(asMap(queryMap["visa_type_weights"]))
+	if value, ok := queryMap["resolve_geo_id"].(bool); ok {
+		query.SkipGeoResolution = !value
+	}
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPreferredVisaTypesOverrideDoesNotMutatePreferences(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	if _, err := SetUserPreferences(map[string]any{
+		"user_id":              "u1",
+		"preferred_visa_types": []any{"E3"},
+	}); err != nil {
+		t.Fatalf("SetUserPreferences failed: %v", err)
+	}
+
+	newClient := func() *fakeLinkedInClient {
+		return &fakeLinkedInClient{
+			pages: map[int][]linkedInJob{
+				0: {{
+					JobURL:   "https://www.linkedin.com/jobs/view/beta-1/",
+					Title:    "Software Engineer",
+					Company:  "Beta LLC",
+					Location: "New York, NY",
+				}},
+			},
+			descriptions: map[string]string{
+				"https://www.linkedin.com/jobs/view/beta-1/": "We provide H-1B visa sponsorship.",
+			},
+		}
+	}
+	args := map[string]any{
+		"user_id":         "u1",
+		"location":        "New York, NY",
+		"job_title":       "Software Engineer",
+		"dataset_path":    datasetPath,
+		"strictness_mode": "strict",
+	}
+
+	stored := runFakeVisaSearch(t, newClient(), args)
+	if got := len(listOrEmpty(stored["jobs"])); got != 0 {
+		t.Fatalf("expected no E-3 matches from stored preferences, got %d", got)
+	}
+	if got := getString(asMap(stored["status"]), "visa_types_source"); got != "user_preferences" {
+		t.Fatalf("expected visa_types_source=user_preferences, got %q", got)
+	}
+
+	args["preferred_visa_types"] = []any{"H1B"}
+	overridden := runFakeVisaSearch(t, newClient(), args)
+	if got := len(listOrEmpty(overridden["jobs"])); got != 1 {
+		t.Fatalf("expected H-1B override to match one job, got %d", got)
+	}
+	status := asMap(overridden["status"])
+	if got := getString(status, "visa_types_source"); got != "search_override" {
+		t.Fatalf("expected visa_types_source=search_override, got %q", got)
+	}
+	if desired := getStringList(status, "desired_visa_types"); !slices.Equal(desired, []string{"h1b"}) {
+		t.Fatalf("expected desired_visa_types=[h1b], got %#v", desired)
+	}
+
+	prefs, err := GetUserPreferences(map[string]any{"user_id": "u1"})
+	if err != nil {
+		t.Fatalf("GetUserPreferences failed: %v", err)
+	}
+	saved := getStringList(asMap(prefs["preferences"]), "preferred_visa_types")
+	if !slices.Equal(saved, []string{"e3_australian"}) {
+		t.Fatalf("expected stored preferences to stay [e3_australian], got %#v (%#v)", saved, prefs)
+	}
+
+	args["preferred_visa_types"] = []any{"tourist"}
+	if _, err := StartVisaJobSearch(args); err == nil {
+		t.Fatal("expected unsupported preferred_visa_types override to fail")
+	}
+}
@@ -0,0 +1,117 @@
+package user
+
+import (
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSearchPageCacheTTLSeconds = 600
+	maxSearchPageCacheEntries        = 200
+)
+
+// searchPageCache keeps recent listing-page bodies in memory so back-to-back
+// runs with the same query (for example visa mode then general mode) reuse
+// pages instead of downloading them again. Bodies are stored raw so parsing
+// and layout-drift checks still run on every hit.
+type searchPageCache struct {
+	mu      sync.Mutex
+	entries map[string]searchPageCacheEntry
+}
+
+type searchPageCacheEntry struct {
+	body      string
+	fetchedAt time.Time
+}
+
+var sharedSearchPageCache = &searchPageCache{entries: map[string]searchPageCacheEntry{}}
+
+// searchPageCacheTTL returns 0 when the cache is disabled.
+func searchPageCacheTTL() time.Duration {
+	value := envInt("VISA_SEARCH_PAGE_CACHE_TTL_SECONDS", defaultSearchPageCacheTTLSeconds)
+	if value < 0 {
+		value = 0
+	}
+	return time.Duration(value) * time.Second
+}
+
+// searchPageCacheKey covers every request parameter, including the f_TPR
+// hours bucket and start offset, plus the regional host and language since
+// those change the markup LinkedIn returns.
+func searchPageCacheKey(params map[string]string, locale linkedInLocale) string {
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, value)
+	}
+	if locale.Host != "" {
+		values.Set("_host", locale.Host)
+	}
+	if locale.AcceptLanguage != "" {
+		values.Set("_lang", locale.AcceptLanguage)
+	}
+	return values.Encode()
+}
+
+func (c *searchPageCache) get(key string, ttl time.Duration) (string, bool) {
+	if ttl <= 0 {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if utcNow().Sub(entry.fetchedAt) > ttl {
+		delete(c.entries, key)
+		return "", false
+	}
+	return entry.body, true
+}
+
+// put stores a page, evicting expired entries first and then the oldest one
+// when the cache is full.
+func (c *searchPageCache) put(key, body string, ttl time.Duration) {
+	if ttl <= 0 || body == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := utcNow()
+	if len(c.entries) >= maxSearchPageCacheEntries {
+		oldestKey := ""
+		oldest := now
+		for existing, entry := range c.entries {
+			if now.Sub(entry.fetchedAt) > ttl {
+				delete(c.entries, existing)
+				continue
+			}
+			if !entry.fetchedAt.After(oldest) {
+				oldest = entry.fetchedAt
+				oldestKey = existing
+			}
+		}
+		if len(c.entries) >= maxSearchPageCacheEntries && oldestKey != "" {
+			delete(c.entries, oldestKey)
+		}
+	}
+	c.entries[key] = searchPageCacheEntry{body: body, fetchedAt: now}
+}
+
+// searchPageCacheReporter is implemented by clients that serve listing pages
+// from the shared cache.
+type searchPageCacheReporter interface {
+	SearchPageCacheHits() int
+}
+
+func searchPageCacheHitsFor(client linkedInClient) int {
+	if reporter, ok := client.(searchPageCacheReporter); ok {
+		return reporter.SearchPageCacheHits()
+	}
+	return 0
+}
+
+func (c *liveLinkedInClient) SearchPageCacheHits() int {
+	return int(c.pageCacheHits.Load())
+}
@@ -0,0 +1,75 @@
+package user
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+const listingPageHTML = `<div class="base-search-card">
+<a class="base-card__full-link" href="https://www.linkedin.com/jobs/view/3812345678?trk=x"></a>
+<h3 class="base-search-card__title">Software Engineer</h3>
+<h4 class="base-search-card__subtitle">Acme Inc</h4>
+<span class="job-search-card__location">New York, NY</span>
+</div>`
+
+func TestSearchPageCacheServesRepeatPages(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_UPSTREAM_REQUESTS_PER_MINUTE", "0")
+	sharedSearchPageCache = &searchPageCache{entries: map[string]searchPageCacheEntry{}}
+	requests := 0
+	client := liveClientWithTransport(t, func(req *http.Request) *http.Response {
+		requests++
+		return stubResponse(req, 200, listingPageHTML)
+	})
+	query := linkedInSearchQuery{JobTitle: "Software Engineer", Location: "New York, NY", HoursOld: 24, Start: 0}
+	for i := 0; i < 2; i++ {
+		jobs, err := client.FetchSearchPage(query, nil)
+		if err != nil || len(jobs) != 1 || jobs[0].Company != "Acme Inc" {
+			t.Fatalf("unexpected page %d: %+v (%v)", i, jobs, err)
+		}
+	}
+	if requests != 1 || client.SearchPageCacheHits() != 1 {
+		t.Fatalf("expected one request and one cache hit, got %d requests, %d hits", requests, client.SearchPageCacheHits())
+	}
+
+	query.Start = 10
+	if _, err := client.FetchSearchPage(query, nil); err != nil || requests != 2 {
+		t.Fatalf("expected a different start offset to miss the cache, got %d requests (%v)", requests, err)
+	}
+	query.BypassCache = true
+	if _, err := client.FetchSearchPage(query, nil); err != nil || requests != 3 {
+		t.Fatalf("expected refresh to bypass the cache, got %d requests (%v)", requests, err)
+	}
+}
+
+func TestSearchPageCacheDisabledAndExpiry(t *testing.T) {
+	cache := &searchPageCache{entries: map[string]searchPageCacheEntry{}}
+	cache.put("k", "body", 0)
+	if _, ok := cache.get("k", time.Minute); ok {
+		t.Fatalf("expected zero TTL to disable caching")
+	}
+	cache.entries["k"] = searchPageCacheEntry{body: "body", fetchedAt: utcNow().Add(-2 * time.Minute)}
+	if _, ok := cache.get("k", time.Minute); ok {
+		t.Fatalf("expected expired entry to miss")
+	}
+	if len(cache.entries) != 0 {
+		t.Fatalf("expected expired entry to be evicted")
+	}
+}
+
+func TestSearchPageCacheEvictsOldestWhenFull(t *testing.T) {
+	cache := &searchPageCache{entries: map[string]searchPageCacheEntry{}}
+	base := utcNow()
+	for i := 0; i < maxSearchPageCacheEntries; i++ {
+		cache.entries[string(rune('a'+i%26))+time.Duration(i).String()] = searchPageCacheEntry{body: "x", fetchedAt: base.Add(time.Duration(i) * time.Millisecond)}
+	}
+	cache.entries["oldest"] = searchPageCacheEntry{body: "x", fetchedAt: base.Add(-time.Second)}
+	cache.put("new", "body", time.Hour)
+	if _, ok := cache.entries["oldest"]; ok {
+		t.Fatalf("expected the oldest entry to be evicted")
+	}
+	if _, ok := cache.get("new", time.Hour); !ok {
+		t.Fatalf("expected the new entry to be cached")
+	}
+}
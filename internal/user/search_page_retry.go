@@ -0,0 +1,60 @@
+package user
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	defaultSearchPageMaxAttempts         = 3
+	defaultSearchPageRetryBackoffSeconds = 2
+)
+
+func searchPageMaxAttempts() int {
+	value := envInt("VISA_SEARCH_PAGE_MAX_ATTEMPTS", defaultSearchPageMaxAttempts)
+	if value < 1 {
+		return 1
+	}
+	return value
+}
+
+func searchPageRetryBackoff(attempt int) time.Duration {
+	seconds := envInt("VISA_SEARCH_PAGE_RETRY_BACKOFF_SECONDS", defaultSearchPageRetryBackoffSeconds)
+	if seconds < 0 {
+		seconds = 0
+	}
+	return time.Duration(seconds*attempt) * time.Second
+}
+
+// pageFetchRetryable skips rate limits: the client already waits those out
+// inside its own retry window, so retrying the page again only adds load.
+func pageFetchRetryable(err error) bool {
+	return isTransientSearchError(err) && !isRateLimitError(err)
+}
+
+// fetchSearchPageWithRetry retries a single listing page on transient errors
+// and reports how many retries it spent.
+func fetchSearchPageWithRetry(
+	client linkedInClient,
+	query linkedInSearchQuery,
+	isCancelled func() bool,
+) ([]linkedInJob, int, error) {
+	maxAttempts := searchPageMaxAttempts()
+	retries := 0
+	for attempt := 1; ; attempt++ {
+		jobs, err := client.FetchSearchPage(query, isCancelled)
+		if err == nil || attempt >= maxAttempts || !pageFetchRetryable(err) {
+			return jobs, retries, err
+		}
+		if !sleepWithCancel(searchPageRetryBackoff(attempt), isCancelled) {
+			return nil, retries, errSearchRunCancelled
+		}
+		retries++
+	}
+}
+
+// tolerablePageFailure reports whether the scan can stop early and keep the
+// listings it already has instead of failing the whole run.
+func tolerablePageFailure(err error, scannedJobs int) bool {
+	return scannedJobs > 0 && !errors.Is(err, errSearchRunCancelled)
+}
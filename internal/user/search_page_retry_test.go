@@ -0,0 +1,83 @@
+package user
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// pageFailingClient fails the listing page at failStart, either a fixed number
+// of times or forever when failures is negative.
+type pageFailingClient struct {
+	fakeLinkedInClient
+	failStart int
+	failures  int
+	mu        sync.Mutex
+	calls     int
+}
+
+func (c *pageFailingClient) FetchSearchPage(query linkedInSearchQuery, isCancelled func() bool) ([]linkedInJob, error) {
+	if query.Start == c.failStart {
+		c.mu.Lock()
+		c.calls++
+		fail := c.failures != 0
+		if c.failures > 0 {
+			c.failures--
+		}
+		c.mu.Unlock()
+		if fail {
+			return nil, errors.New("read tcp: connection reset by peer")
+		}
+	}
+	return c.fakeLinkedInClient.FetchSearchPage(query, isCancelled)
+}
+
+func scanWithClient(t *testing.T, client linkedInClient, target int) (listingScan, error) {
+	t.Helper()
+	query := searchQuery{JobTitle: "Software Engineer", Location: "New York, NY", HoursOld: 336}
+	return scanLinkedInListings(client, query, target, scanResume{}, nil, func(string, string, float64, map[string]any) {}, func() bool { return false })
+}
+
+func TestScanRetriesTransientPageFailures(t *testing.T) {
+	t.Setenv("VISA_SEARCH_PAGE_RETRY_BACKOFF_SECONDS", "0")
+	client := &pageFailingClient{failStart: 2, failures: 1}
+	client.pages = map[int][]linkedInJob{0: acmeListingPage(1, 2), 2: acmeListingPage(3, 2)}
+
+	scan, err := scanWithClient(t, client, 4)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if len(scan.Jobs) != 4 || scan.PageRetries != 1 || scan.PagesFailed != 0 {
+		t.Fatalf("expected 4 jobs after one retry, got jobs=%d retries=%d failed=%d", len(scan.Jobs), scan.PageRetries, scan.PagesFailed)
+	}
+}
+
+func TestScanKeepsCollectedJobsWhenPageKeepsFailing(t *testing.T) {
+	t.Setenv("VISA_SEARCH_PAGE_RETRY_BACKOFF_SECONDS", "0")
+	t.Setenv("VISA_SEARCH_PAGE_MAX_ATTEMPTS", "3")
+	client := &pageFailingClient{failStart: 2, failures: -1}
+	client.pages = map[int][]linkedInJob{0: acmeListingPage(1, 2)}
+
+	scan, err := scanWithClient(t, client, 4)
+	if err != nil {
+		t.Fatalf("expected the scan to tolerate the failed page, got %v", err)
+	}
+	if len(scan.Jobs) != 2 || scan.PagesFailed != 1 || client.calls != 3 {
+		t.Fatalf("expected 2 jobs, 1 failed page, 3 attempts; got jobs=%d failed=%d calls=%d", len(scan.Jobs), scan.PagesFailed, client.calls)
+	}
+	if scan.Exhausted {
+		t.Fatal("expected a scan that stopped on a failed page to not be marked exhausted")
+	}
+	if scan.Resume.Start != 2 {
+		t.Fatalf("expected resume point on the failed page, got %#v", scan.Resume)
+	}
+}
+
+func TestScanFailsWhenFirstPageKeepsFailing(t *testing.T) {
+	t.Setenv("VISA_SEARCH_PAGE_RETRY_BACKOFF_SECONDS", "0")
+	client := &pageFailingClient{failStart: 0, failures: -1}
+
+	if _, err := scanWithClient(t, client, 4); err == nil {
+		t.Fatal("expected the scan to fail when no listings could be fetched")
+	}
+}
@@ -0,0 +1,101 @@
+package user
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	partialResultsFlushInterval = time.Second
+	maxPartialResultJobs        = 100
+)
+
+// partialResultsReporter hands accepted jobs to the run while the scan is
+// still going, at most once per flush interval after the first job.
+type partialResultsReporter struct {
+	flush func(jobs []map[string]any)
+	last  time.Time
+}
+
+func newPartialResultsReporter(query searchQuery) *partialResultsReporter {
+	return &partialResultsReporter{flush: query.OnPartialResults}
+}
+
+func (r *partialResultsReporter) report(accepted []map[string]any) {
+	if r.flush == nil || len(accepted) == 0 {
+		return
+	}
+	if !r.last.IsZero() && time.Since(r.last) < partialResultsFlushInterval {
+		return
+	}
+	r.last = time.Now()
+	snapshot := make([]map[string]any, 0, min(len(accepted), maxPartialResultJobs))
+	for _, job := range accepted[:min(len(accepted), maxPartialResultJobs)] {
+		snapshot = append(snapshot, cloneMap(job))
+	}
+	r.flush(snapshot)
+}
+
+func partialResultsSnapshot(jobs []map[string]any) map[string]any {
+	list := make([]any, 0, len(jobs))
+	for _, job := range jobs {
+		list = append(list, job)
+	}
+	return map[string]any{
+		"jobs":           list,
+		"updated_at_utc": utcNowISO(),
+	}
+}
+
+// partialResultsResponse serves the in-progress snapshot of a running search.
+// Partial jobs have no result_id yet; those are assigned when the run finishes.
+func partialResultsResponse(run map[string]any, offset, maxReturned int, sortBy string) (map[string]any, bool) {
+	partial := asMap(run["partial_response"])
+	if len(partial) == 0 || searchRunIsTerminal(getString(run, "status")) {
+		return nil, false
+	}
+	jobs := []map[string]any{}
+	for _, raw := range listOrEmpty(partial["jobs"]) {
+		if row := mapOrNil(raw); row != nil {
+			jobs = append(jobs, row)
+		}
+	}
+	query := asMap(run["query"])
+	page, pagination := sliceAcceptedJobs(
+		jobs,
+		offset,
+		maxReturned,
+		intOrZero(run["current_scan_target"]),
+		max(defaultSearchMaxScanResults, intOrZero(query["max_scan_results"])),
+		false,
+		sortBy,
+	)
+	pagination["partial"] = true
+	pageJobs := make([]any, 0, len(page))
+	for _, job := range page {
+		pageJobs = append(pageJobs, job)
+	}
+	return map[string]any{
+		"run": map[string]any{
+			"run_id":           getString(run, "run_id"),
+			"status":           getString(run, "status"),
+			"attempt_count":    intOrZero(run["attempt_count"]),
+			"search_runs_path": searchRunsPath(),
+		},
+		"status": map[string]any{
+			"outcome": "running",
+			"partial": true,
+			"message": fmt.Sprintf(
+				"Search is still running; showing %d job(s) accepted so far. Result IDs are assigned when the run completes.",
+				len(jobs),
+			),
+			"snapshot_updated_at_utc": partial["updated_at_utc"],
+		},
+		"stats":                map[string]any{"accepted_jobs": len(jobs), "returned_jobs": len(page)},
+		"guidance":             map[string]any{},
+		"dataset_freshness":    map[string]any{},
+		"pagination":           pagination,
+		"recovery_suggestions": []any{},
+		"jobs":                 pageJobs,
+	}, true
+}
@@ -0,0 +1,96 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// gatedDetailsClient returns descriptions immediately for the first job and
+// holds every later one until release is closed.
+type gatedDetailsClient struct {
+	fakeLinkedInClient
+	firstURL string
+	release  chan struct{}
+}
+
+func (c *gatedDetailsClient) FetchJobDetails(jobURL, title, location string, isCancelled func() bool) (linkedInJobDetails, error) {
+	if jobURL != c.firstURL {
+		<-c.release
+	}
+	return linkedInJobDetails{Description: "We sponsor H-1B visas for this role."}, nil
+}
+
+func TestGetVisaJobSearchResultsReturnsPartialPageWhileRunning(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_DESCRIPTION_FETCH_CONCURRENCY", "1")
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	jobs := acmeListingPage(1, 3)
+	client := &gatedDetailsClient{
+		fakeLinkedInClient: fakeLinkedInClient{pages: map[int][]linkedInJob{0: jobs}},
+		firstURL:           jobs[0].JobURL,
+		release:            make(chan struct{}),
+	}
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient { return client }
+
+	started, err := StartVisaJobSearch(map[string]any{
+		"user_id":                    "u1",
+		"location":                   "New York, NY",
+		"job_title":                  "Software Engineer",
+		"dataset_path":               datasetPath,
+		"results_wanted":             3,
+		"require_description_signal": true,
+	})
+	if err != nil {
+		t.Fatalf("StartVisaJobSearch failed: %v", err)
+	}
+	runID := getString(started, "run_id")
+
+	var partial map[string]any
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		partial, err = GetVisaJobSearchResults(map[string]any{"user_id": "u1", "run_id": runID})
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	close(client.release)
+	if err != nil {
+		t.Fatalf("expected a partial page before the run finished, got %v", err)
+	}
+	status := asMap(partial["status"])
+	if getString(status, "outcome") != "running" || !boolOrFalse(status["partial"]) {
+		t.Fatalf("expected running partial status, got %#v", status)
+	}
+	partialJobs := listOrEmpty(partial["jobs"])
+	if len(partialJobs) != 1 || getString(mapOrNil(partialJobs[0]), "job_url") != jobs[0].JobURL {
+		t.Fatalf("expected the first accepted job in the partial page, got %#v", partialJobs)
+	}
+	if !boolOrFalse(asMap(partial["pagination"])["partial"]) {
+		t.Fatalf("expected pagination to be marked partial, got %#v", partial["pagination"])
+	}
+
+	final := waitForTerminalRunStatus(t, "u1", runID, 3*time.Second)
+	if got := getString(final, "status"); got != "completed" {
+		t.Fatalf("expected completed status, got %#v", final)
+	}
+	results, err := GetVisaJobSearchResults(map[string]any{"user_id": "u1", "run_id": runID})
+	if err != nil {
+		t.Fatalf("GetVisaJobSearchResults failed: %v", err)
+	}
+	if len(listOrEmpty(results["jobs"])) != 3 || boolOrFalse(asMap(results["status"])["partial"]) {
+		t.Fatalf("expected the final page with every job, got %#v", results)
+	}
+	run, err := loadRunForUser(runID, "u1")
+	if err != nil {
+		t.Fatalf("loadRunForUser failed: %v", err)
+	}
+	if _, ok := run["partial_response"]; ok {
+		t.Fatal("expected partial_response to be cleared when the run completed")
+	}
+}
@@ -0,0 +1,100 @@
+package user
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const postedDateLayout = "2006-01-02"
+
+// postedRangeSlackHours pads the f_TPR window because listing dates carry no
+// time of day or timezone.
+const postedRangeSlackHours = 24
+
+// parsePostedBound accepts a YYYY-MM-DD date or an RFC3339 timestamp. A bare
+// date used as an upper bound covers the whole day.
+func parsePostedBound(raw string, endOfDay bool) (time.Time, error) {
+	clean := strings.TrimSpace(raw)
+	if parsed, err := time.Parse(time.RFC3339, clean); err == nil {
+		return parsed.UTC(), nil
+	}
+	parsed, err := time.Parse(postedDateLayout, clean)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		return parsed.Add(24*time.Hour - time.Second), nil
+	}
+	return parsed, nil
+}
+
+func parsePostedRangeOptions(args map[string]any, query map[string]any) error {
+	var after, before time.Time
+	if raw := getString(args, "posted_after"); raw != "" {
+		parsed, err := parsePostedBound(raw, false)
+		if err != nil {
+			return fmt.Errorf("posted_after must be a YYYY-MM-DD date or RFC3339 timestamp")
+		}
+		after = parsed
+		query["posted_after"] = toISO(parsed)
+	}
+	if raw := getString(args, "posted_before"); raw != "" {
+		parsed, err := parsePostedBound(raw, true)
+		if err != nil {
+			return fmt.Errorf("posted_before must be a YYYY-MM-DD date or RFC3339 timestamp")
+		}
+		before = parsed
+		query["posted_before"] = toISO(parsed)
+	}
+	if !after.IsZero() && !before.IsZero() && before.Before(after) {
+		return fmt.Errorf("posted_before must not be earlier than posted_after")
+	}
+	return nil
+}
+
+func jobPostedAt(job linkedInJob) (time.Time, bool) {
+	parsed, err := parsePostedBound(job.DatePosted, false)
+	return parsed, err == nil
+}
+
+// postedOutsideRange keeps listings without a parseable date, matching how
+// the salary filter treats listings without pay.
+func postedOutsideRange(job linkedInJob, after, before time.Time) bool {
+	if after.IsZero() && before.IsZero() {
+		return false
+	}
+	posted, ok := jobPostedAt(job)
+	if !ok {
+		return false
+	}
+	if !after.IsZero() && posted.Before(after.Truncate(24*time.Hour)) {
+		return true
+	}
+	return !before.IsZero() && posted.After(before)
+}
+
+// postedRangeHoursOld narrows the LinkedIn f_TPR window to cover posted_after
+// when that is tighter than hours_old. LinkedIn only filters on "posted within
+// the last N hours", so posted_before is enforced after the scan only.
+func postedRangeHoursOld(hoursOld int, after time.Time, now time.Time) int {
+	if after.IsZero() {
+		return hoursOld
+	}
+	hours := int(math.Ceil(now.Sub(after).Hours())) + postedRangeSlackHours
+	if hours < 1 {
+		hours = 1
+	}
+	if hoursOld > 0 && hours >= hoursOld {
+		return hoursOld
+	}
+	return hours
+}
+
+func optionalTimeISO(value time.Time) any {
+	if value.IsZero() {
+		return nil
+	}
+	return toISO(value)
+}
@@ -0,0 +1,72 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParsePostedRangeOptions(t *testing.T) {
+	query := map[string]any{}
+	if err := parsePostedRangeOptions(map[string]any{"posted_after": "2026-10-05", "posted_before": "2026-10-07"}, query); err != nil {
+		t.Fatalf("parsePostedRangeOptions failed: %v", err)
+	}
+	if query["posted_after"] != "2026-10-05T00:00:00Z" || query["posted_before"] != "2026-10-07T23:59:59Z" {
+		t.Fatalf("unexpected normalized bounds: %#v", query)
+	}
+	if err := parsePostedRangeOptions(map[string]any{"posted_after": "last week"}, map[string]any{}); err == nil {
+		t.Fatal("expected an unparseable posted_after to be rejected")
+	}
+	if err := parsePostedRangeOptions(map[string]any{"posted_after": "2026-10-07", "posted_before": "2026-10-05"}, map[string]any{}); err == nil {
+		t.Fatal("expected an inverted range to be rejected")
+	}
+}
+
+func TestPostedRangeHoursOldNarrowsWindow(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	after := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	if got := postedRangeHoursOld(336, after, now); got != 84 {
+		t.Fatalf("expected 60h plus 24h slack, got %d", got)
+	}
+	if got := postedRangeHoursOld(24, after, now); got != 24 {
+		t.Fatalf("expected a tighter hours_old to win, got %d", got)
+	}
+	if got := postedRangeHoursOld(336, time.Time{}, now); got != 336 {
+		t.Fatalf("expected hours_old unchanged without posted_after, got %d", got)
+	}
+}
+
+func TestSearchFiltersListingsOutsidePostedRange(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	jobs := acmeListingPage(1, 4)
+	jobs[0].DatePosted = "2026-10-01"
+	jobs[1].DatePosted = "2026-10-06"
+	jobs[2].DatePosted = "2026-10-09"
+	client := &fakeLinkedInClient{pages: map[int][]linkedInJob{0: jobs}}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":        "u1",
+		"location":       "New York, NY",
+		"job_title":      "Software Engineer",
+		"dataset_path":   datasetPath,
+		"results_wanted": 4,
+		"posted_after":   "2026-10-05",
+		"posted_before":  "2026-10-07",
+	})
+	urls := map[string]bool{}
+	for _, raw := range listOrEmpty(results["jobs"]) {
+		urls[getString(mapOrNil(raw), "job_url")] = true
+	}
+	if len(urls) != 2 || !urls[jobs[1].JobURL] || !urls[jobs[3].JobURL] {
+		t.Fatalf("expected the in-range job and the undated job, got %v", urls)
+	}
+	stats := asMap(results["stats"])
+	if got := intOrZero(stats["posted_date_filtered_out"]); got != 2 {
+		t.Fatalf("expected 2 listings filtered by date, got %d", got)
+	}
+	if getString(stats, "posted_after") != "2026-10-05T00:00:00Z" {
+		t.Fatalf("expected posted_after in stats, got %#v", stats["posted_after"])
+	}
+}
@@ -0,0 +1,81 @@
+package user
+
+import "strings"
+
+const (
+	prefilterIgnoredJob     = "ignored_job"
+	prefilterIgnoredCompany = "ignored_company"
+	prefilterStaffingAgency = "staffing_agency"
+	prefilterSalary         = "salary"
+	prefilterPreviouslySeen = "previously_seen"
+	prefilterPostedDate     = "posted_date"
+	prefilterExcludedTitle  = "excluded_title"
+)
+
+// listingPrefilter holds the card-level checks that drop a listing before any
+// dataset lookup or description fetch.
+type listingPrefilter struct {
+	query            searchQuery
+	ignoredJobs      map[string]struct{}
+	ignoredCompanies map[string]struct{}
+	staffingPatterns []string
+	previouslySeen   map[string]struct{}
+}
+
+func newListingPrefilter(query searchQuery) listingPrefilter {
+	return listingPrefilter{
+		query:            query,
+		ignoredJobs:      ignoredJobURLSet(query.UserID),
+		ignoredCompanies: ignoredCompanySet(query.UserID),
+		staffingPatterns: staffingAgencyPatterns(query.StaffingAgencyPatterns),
+		previouslySeen:   previouslySeenJobURLs(query.UserID, query.ContinueSessionID),
+	}
+}
+
+// reason returns why a listing is skipped, or "" when it should be evaluated.
+func (p listingPrefilter) reason(raw linkedInJob) string {
+	if _, ignored := p.ignoredJobs[strings.ToLower(strings.TrimSpace(raw.JobURL))]; ignored {
+		return prefilterIgnoredJob
+	}
+	if normalized := normalizeCompanyName(raw.Company); normalized != "" {
+		if _, ignored := p.ignoredCompanies[normalized]; ignored {
+			return prefilterIgnoredCompany
+		}
+	}
+	if excludedTitleKeyword(raw.Title, p.query.ExcludeTitleKeywords) != "" {
+		return prefilterExcludedTitle
+	}
+	if p.query.ExcludeStaffingAgencies && looksLikeStaffingAgency(raw.Company, p.staffingPatterns) {
+		return prefilterStaffingAgency
+	}
+	if salaryBelowMinimum(raw, p.query.MinSalary, p.query.SalaryInterval) {
+		return prefilterSalary
+	}
+	if postedOutsideRange(raw, p.query.PostedAfter, p.query.PostedBefore) {
+		return prefilterPostedDate
+	}
+	if p.query.HidePreviouslySeen && p.seenBefore(raw) {
+		return prefilterPreviouslySeen
+	}
+	return ""
+}
+
+func (p listingPrefilter) seenBefore(raw linkedInJob) bool {
+	_, seen := p.previouslySeen[strings.ToLower(strings.TrimSpace(raw.JobURL))]
+	return seen
+}
+
+// jobNeedsDescription reports whether the listing card alone cannot decide the
+// job, so its description has to be fetched.
+func jobNeedsDescription(query searchQuery, raw linkedInJob, applyVisaFiltering bool, desiredCount int) bool {
+	if query.RequireDescriptionSignal || query.RequireGCTrack || (applyVisaFiltering && desiredCount == 0) {
+		return true
+	}
+	if len(query.WorkplaceTypes) > 0 && classifyWorkplaceType(raw.Title, raw.Location, "", raw.IsRemote) == "" {
+		return true
+	}
+	if len(query.MustIncludeKeywords) > 0 && !checkKeywords(raw.Title, "", query.MustIncludeKeywords, nil).passes() {
+		return true
+	}
+	return false
+}
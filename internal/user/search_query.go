@@ -14,20 +14,30 @@ func executeSearchQuery(
 	onProgress func(phase, detail string, progress float64, payload map[string]any),
 	isCancelled func() bool,
 ) (map[string]any, map[string]any, string, error) {
+	started := time.Now()
+	query.RuntimeDeadline = runtimeDeadline(started, query.MaxRuntimeSeconds)
 	queryMode := searchModeOrDefault(query.SearchMode)
-	desiredVisaTypes, err := getOptionalUserVisaTypes(query.UserID)
-	if err != nil {
-		return nil, nil, "", err
+	desiredVisaTypes := query.PreferredVisaTypes
+	visaTypesSource := "search_override"
+	if len(desiredVisaTypes) == 0 {
+		visaTypesSource = "user_preferences"
+		stored, err := getOptionalUserVisaTypes(query.UserID)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		desiredVisaTypes = stored
 	}
 	applyVisaFiltering := queryMode == searchModeVisa && len(desiredVisaTypes) > 0
 	if !applyVisaFiltering {
 		desiredVisaTypes = []string{}
+		visaTypesSource = "none"
 	}
+	priority := newVisaPriority(desiredVisaTypes, visaTypeWeightsFor(query.UserID, query.VisaTypeWeights))
 
 	onProgress("dataset", "Loading sponsor dataset.", 5, nil)
 	dataset := companyDataset{Rows: 0, ByNormalizedCompany: map[string]companyDatasetRecord{}}
 	datasetPath := datasetPathOrDefault(query.DatasetPath)
-	dataset, err = loadCompanyDataset(datasetPath)
+	dataset, err := loadCompanyDataset(datasetPath)
 	datasetLoadWarning := ""
 	if err != nil {
 		dataset = companyDataset{Rows: 0, ByNormalizedCompany: map[string]companyDatasetRecord{}}
@@ -36,14 +46,30 @@ func executeSearchQuery(
 			"warning": datasetLoadWarning,
 		})
 	}
+	wages, _ := loadLCAWageIndex(lcaWagesPathFor(datasetPath))
+	eVerify, _ := loadEVerifyIndex(eVerifyPath())
 	freshness := datasetFreshness(datasetPath, envOrDefault("VISA_DOL_MANIFEST_PATH", defaultManifestPath))
-	ignoredJobs := ignoredJobURLSet(query.UserID)
-	ignoredCompanies := ignoredCompanySet(query.UserID)
+	constraints := loadSearchConstraints(query.UserID)
+	signalPatterns := loadVisaSignalPatterns(query.UserID)
+	prefilter := newListingPrefilter(query)
+	partialResults := newPartialResultsReporter(query)
+	weights := rankingWeightsFromMap(query.RankingWeights)
 
 	requiredAccepted := query.ResultsWanted
 	if query.Offset+query.MaxReturned > requiredAccepted {
 		requiredAccepted = query.Offset + query.MaxReturned
 	}
+	continuation, err := loadSearchContinuation(query)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	resume := scanResume{}
+	var seenURLs map[string]struct{}
+	if continuation != nil {
+		requiredAccepted = max(query.ResultsWanted, requiredAccepted-continuation.PriorVisible)
+		resume = continuation.Resume
+		seenURLs = continuation.SeenURLs
+	}
 	if requiredAccepted < 1 {
 		requiredAccepted = 1
 	}
@@ -55,62 +81,35 @@ func executeSearchQuery(
 		rawScanTarget = query.MaxScanResults
 	}
 
-	client, err := newSiteClient(query.Site)
-	if err != nil {
-		return nil, nil, "", err
-	}
-	rawJobs := []linkedInJob{}
-	seenURLs := map[string]struct{}{}
-	start := 0
-	const maxLinkedInStart = 1000
-	scanExhausted := false
-	stats := searchExecutionStats{}
-	onProgress("scrape", "Scanning LinkedIn listings.", 15, map[string]any{"scan_target": rawScanTarget})
-	for len(rawJobs) < rawScanTarget && start <= maxLinkedInStart {
-		if isCancelled() {
-			return nil, nil, "", errSearchRunCancelled
-		}
-		pageJobs, err := client.FetchSearchPage(linkedInSearchQuery{
-			JobTitle: query.JobTitle,
-			Location: query.Location,
-			HoursOld: query.HoursOld,
-			Start:    start,
-		}, isCancelled)
+	client := query.Client
+	if client == nil {
+		client, err = newSiteClient(query.Site)
 		if err != nil {
 			return nil, nil, "", err
 		}
-		if len(pageJobs) == 0 {
-			scanExhausted = true
-			break
-		}
-		added := 0
-		for _, job := range pageJobs {
-			key := strings.ToLower(strings.TrimSpace(job.JobURL))
-			if key == "" {
-				continue
-			}
-			if _, exists := seenURLs[key]; exists {
-				continue
-			}
-			seenURLs[key] = struct{}{}
-			rawJobs = append(rawJobs, job)
-			added++
-			if len(rawJobs) >= rawScanTarget {
-				break
-			}
-		}
-		if added == 0 {
-			scanExhausted = true
-			break
-		}
-		start += len(pageJobs)
-		progress := 15.0 + (60.0 * float64(len(rawJobs)) / float64(max(1, rawScanTarget)))
-		onProgress("scrape", "Collected LinkedIn pages.", progress, map[string]any{
-			"raw_jobs_scanned": len(rawJobs),
-		})
 	}
-	if len(rawJobs) < rawScanTarget {
-		scanExhausted = true
+	if query.RequestPolicy == (upstreamRequestPolicy{}) {
+		query.RequestPolicy = defaultUpstreamRequestPolicy()
+	}
+	applyRequestPolicy(client, query.RequestPolicy)
+	applyLinkedInLocale(client, query.Locale)
+	stats := searchExecutionStats{}
+	geo, geoSource := resolveSearchGeo(client, query, isCancelled)
+	query.GeoID = geo.ID
+	onProgress("scrape", "Scanning LinkedIn listings.", 15, map[string]any{"scan_target": rawScanTarget})
+	scan, err := scanLinkedInListings(client, query, rawScanTarget, resume, seenURLs, onProgress, isCancelled)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	rawJobs := scan.Jobs
+	scanExhausted := scan.Exhausted
+	pageCardCounts := scan.PageCardCounts
+	layoutCheck := map[string]any{"possible_layout_change": false}
+	if !query.SkipLayoutTracking {
+		layoutCheck = recordLayoutYield(query.Site, measureLayoutYield(pageCardCounts, rawJobs))
+		if boolOrFalse(layoutCheck["possible_layout_change"]) {
+			onProgress("scrape", "Listing parse yield dropped sharply versus baseline; possible LinkedIn layout change.", 75, layoutCheck)
+		}
 	}
 
 	filterDetail := "Evaluating visa relevance."
@@ -121,28 +120,84 @@ func executeSearchQuery(
 	accepted := []map[string]any{}
 	descriptionFetches := 0
 	descriptionFetchLimit := maxDescriptionFetches()
-	descriptionDeadline := time.Now().Add(time.Duration(descriptionBudgetSeconds()) * time.Second)
+	descriptionDeadline := earliestDeadline(time.Now().Add(time.Duration(descriptionBudgetSeconds())*time.Second), query.RuntimeDeadline)
 	descriptionBudgetHit := false
+	descriptionOrder := []int{}
+	for idx, raw := range rawJobs {
+		if prefilter.reason(raw) != "" {
+			continue
+		}
+		record, hasCompany := dataset.lookup(raw.Company)
+		if capExempt, _ := jobCapExempt(record, hasCompany, raw.Company); query.CapExemptOnly && !capExempt {
+			continue
+		}
+		if !companyTierAllowed(query.CompanyTiers, record, hasCompany) {
+			continue
+		}
+		desiredCount := 0
+		if hasCompany {
+			desiredCount = occupationVisaCount(record, desiredVisaTypes, occupationPrefixes(query.JobTitle, raw.Title))
+		}
+		if jobNeedsDescription(query, raw, applyVisaFiltering, desiredCount) {
+			descriptionOrder = append(descriptionOrder, idx)
+		}
+	}
+	descriptionOrder = prioritizeDescriptionOrder(descriptionOrder, rawJobs, dataset, desiredVisaTypes)
+	var cache *descriptionCache
+	if !query.SkipDescriptionCache {
+		cache = loadDescriptionCache()
+	}
+	// Flush on every exit so descriptions fetched by a cancelled, failed, or
+	// retried run are not fetched again. Deferred before the pool's Stop, so
+	// it runs after the pool stops starting new fetches.
+	defer cache.flush()
+	descriptions := startDescriptionPool(
+		client,
+		cache,
+		rawJobs,
+		descriptionOrder,
+		descriptionFetchLimit,
+		descriptionDeadline,
+		descriptionFetchConcurrency(),
+		isCancelled,
+	)
+	defer descriptions.Stop()
+	companyCounts := map[string]int{}
+	staffingCompanies := []string{}
+	visibleAccepted := 0
 	for idx, raw := range rawJobs {
 		if isCancelled() {
 			return nil, nil, "", errSearchRunCancelled
 		}
 		stats.RawJobsScanned++
-		jobURLKey := strings.ToLower(strings.TrimSpace(raw.JobURL))
-		if _, ignored := ignoredJobs[jobURLKey]; ignored {
+		switch prefilter.reason(raw) {
+		case prefilterIgnoredJob:
 			stats.IgnoredJobsSkipped++
 			continue
-		}
-
-		normalizedCompany := normalizeCompanyName(raw.Company)
-		if normalizedCompany != "" {
-			if _, ignored := ignoredCompanies[normalizedCompany]; ignored {
-				stats.IgnoredCompaniesSkipped++
-				continue
+		case prefilterIgnoredCompany:
+			stats.IgnoredCompaniesSkipped++
+			continue
+		case prefilterStaffingAgency:
+			stats.StaffingAgenciesSkipped++
+			if !slices.Contains(staffingCompanies, raw.Company) {
+				staffingCompanies = append(staffingCompanies, raw.Company)
 			}
+			continue
+		case prefilterSalary:
+			stats.SalaryFilteredOut++
+			continue
+		case prefilterPreviouslySeen:
+			stats.PreviouslySeenSkipped++
+			continue
+		case prefilterPostedDate:
+			stats.PostedDateFilteredOut++
+			continue
+		case prefilterExcludedTitle:
+			stats.TitleExcludedOut++
+			continue
 		}
 
-		record, hasCompany := dataset.ByNormalizedCompany[normalizedCompany]
+		record, hasCompany := dataset.lookup(raw.Company)
 		desiredCount := 0
 		totalCount := 0
 		visaCounts := map[string]int{
@@ -154,13 +209,39 @@ func executeSearchQuery(
 			"total_visas":    0,
 		}
 		contacts := []map[string]any{}
+		facts := []string{}
+		recency, localityFactor, approvalFactor := 1.0, 1.0, 1.0
+		fiscalYears := []map[string]any{}
+		var occupation, locality map[string]any
 		if hasCompany {
+			facts = companyFacts(raw.Company, record, dataset.LatestFiscalYear)
+			recency = sponsorshipRecency(record, desiredVisaTypes, dataset.LatestFiscalYear)
+			localityFactor, locality = sponsorshipLocality(record, raw.Location, query.Location)
+			approvalFactor = approvalRateFactor(record)
+			fiscalYears = fiscalYearBreakdown(record)
 			stats.CompanyMatches++
-			desiredCount = desiredVisaCount(record, desiredVisaTypes)
+			prefixes := occupationPrefixes(query.JobTitle, raw.Title)
+			desiredCount = occupationVisaCount(record, desiredVisaTypes, prefixes)
+			if match, ok := matchOccupation(record, prefixes); ok {
+				occupation = match.toMap()
+				facts = append(facts, occupationFact(match))
+			}
 			totalCount = record.TotalVisas
 			visaCounts = visaCountsFromRecord(record)
 			contacts = record.EmployerContacts
 		}
+		capExempt, capExemptBasis := jobCapExempt(record, hasCompany, raw.Company)
+		if query.CapExemptOnly && !capExempt {
+			stats.CapExemptFilteredOut++
+			continue
+		}
+		if !companyTierAllowed(query.CompanyTiers, record, hasCompany) {
+			stats.CompanyTierFilteredOut++
+			continue
+		}
+		if capExempt {
+			facts = append(facts, capExemptFact(capExemptBasis))
+		}
 
 		descriptionText := ""
 		fetchedDescription := false
@@ -170,10 +251,10 @@ func executeSearchQuery(
 		jobFunction := raw.JobFunction
 		jobURLDirect := raw.JobURLDirect
 		isRemote := raw.IsRemote
-		needsDescription := query.RequireDescriptionSignal || (applyVisaFiltering && desiredCount == 0)
-		if needsDescription {
-			canFetchDescription := descriptionFetches < descriptionFetchLimit && time.Now().Before(descriptionDeadline)
-			if canFetchDescription {
+		activity := linkedInJobDetails{}
+		if jobNeedsDescription(query, raw, applyVisaFiltering, desiredCount) {
+			fetched := descriptions.Await(idx)
+			if !fetched.Skipped {
 				if descriptionFetches%5 == 0 {
 					detail := "Checking job descriptions for relevance signals."
 					if applyVisaFiltering {
@@ -185,7 +266,7 @@ func executeSearchQuery(
 						"accepted_jobs":           len(accepted),
 					})
 				}
-				details, fetchErr := client.FetchJobDetails(raw.JobURL, raw.Title, raw.Location, isCancelled)
+				details, fetchErr := fetched.Details, fetched.Err
 				if errors.Is(fetchErr, errSearchRunCancelled) {
 					return nil, nil, "", errSearchRunCancelled
 				}
@@ -210,16 +291,30 @@ func executeSearchQuery(
 					if details.IsRemote != nil {
 						isRemote = details.IsRemote
 					}
+					activity = details
 				}
 				descriptionFetches++
-				stats.DescriptionFetches = descriptionFetches
 			} else {
 				descriptionBudgetHit = true
 				stats.DescriptionFetchSkipped++
 			}
 		}
+		if withSalary, found := withDescriptionSalary(raw, descriptionText); found {
+			raw = withSalary
+			stats.DescriptionSalaryFound++
+			if salaryBelowMinimum(raw, query.MinSalary, query.SalaryInterval) {
+				stats.SalaryFilteredOut++
+				continue
+			}
+		}
 		descriptionPositive, descriptionNegative, mentioned := detectDescriptionSignals(descriptionText)
-		descriptionDesired := hasDesiredMention(mentioned, desiredVisaTypes)
+		custom := signalPatterns.match(descriptionText)
+		descriptionPositive = descriptionPositive || custom.Positive
+		descriptionNegative = descriptionNegative || custom.Negative
+		descriptionDesired := hasDesiredMention(mentioned, desiredVisaTypes) || custom.Positive
+		eVerifyEnrolled := eVerify.enrolled(raw.Company, record.CompanyName)
+		heuristics := evaluateVisaHeuristics(desiredVisaTypes, raw.Title, descriptionText, record, hasCompany, eVerifyEnrolled == true)
+		descriptionNegative = descriptionNegative && !heuristics.WaivesNegative
 		if applyVisaFiltering && descriptionPositive && descriptionDesired {
 			stats.DescriptionSignalMatches++
 		}
@@ -227,6 +322,44 @@ func executeSearchQuery(
 			continue
 		}
 
+		workplaceType := classifyWorkplaceType(raw.Title, raw.Location, descriptionText, isRemote)
+		if workplaceType == "" && len(query.WorkplaceTypes) == 1 {
+			// LinkedIn applied the f_WT filter server-side, so a listing
+			// without local evidence has the one requested type.
+			workplaceType = query.WorkplaceTypes[0]
+		}
+		if isRemote == nil {
+			isRemote = boolPtr(detectLinkedInRemote(raw.Title, raw.Location, descriptionText))
+		}
+		if !workplaceTypeAllowed(query.WorkplaceTypes, workplaceType) {
+			stats.WorkplaceFilteredOut++
+			continue
+		}
+		if !jobCriteriaAllowed(query.JobTypes, jobType, linkedInJobTypeCodes, jobTypeAliases) {
+			stats.JobTypeFilteredOut++
+			continue
+		}
+		if !jobCriteriaAllowed(query.JobLevels, jobLevel, linkedInJobLevelCodes, jobLevelAliases) {
+			stats.JobLevelFilteredOut++
+			continue
+		}
+		keywords := checkKeywords(raw.Title, descriptionText, query.MustIncludeKeywords, query.ExcludeKeywords)
+		if !keywords.passes() {
+			stats.KeywordFilteredOut++
+			continue
+		}
+		gcTrack := gcTrackListing(descriptionText)
+		if query.RequireGCTrack && !gcTrack {
+			stats.GCTrackFilteredOut++
+			continue
+		}
+		constraintEffects, constraintMismatch := evaluateConstraints(constraints, workplaceType, raw.Location, query.Location)
+		urgency := urgencySignals(constraints, capExempt, record, hasCompany, descriptionText)
+		if constraintMismatch && query.EnforceConstraints {
+			stats.ConstraintFilteredOut++
+			continue
+		}
+
 		acceptJob := false
 		if applyVisaFiltering {
 			acceptJob = shouldAcceptJob(
@@ -236,7 +369,8 @@ func executeSearchQuery(
 				descriptionNegative,
 				descriptionDesired,
 				query.RequireDescriptionSignal,
-			)
+				strongTitleMatch(query.JobTitle, raw.Title),
+			) || heuristics.accepts(descriptionNegative)
 		} else {
 			acceptJob = true
 			if query.RequireDescriptionSignal && strings.TrimSpace(descriptionText) == "" {
@@ -246,11 +380,18 @@ func executeSearchQuery(
 		if !acceptJob {
 			continue
 		}
+		wageEstimate := wages.estimate(normalizeCompanyName(firstNonEmpty(record.CompanyName, raw.Company)), raw.Location)
+		if lcaWageBelowMinimum(wageEstimate, query.MinLCAWage) {
+			stats.LCAWageFilteredOut++
+			continue
+		}
 
 		visasSponsored := []string{}
+		matchedVisas := []string{}
 		if applyVisaFiltering {
 			for _, visa := range desiredVisaTypes {
-				if visaCounts[visa] > 0 || (descriptionDesired && slices.Contains(mentioned, visa)) {
+				if visaCounts[visa] > 0 || (descriptionDesired && slices.Contains(mentioned, visa)) || slices.Contains(heuristics.Eligible, visa) {
+					matchedVisas = append(matchedVisas, visa)
 					if label, ok := visaTypeLabels[visa]; ok {
 						visasSponsored = append(visasSponsored, label)
 					} else {
@@ -261,14 +402,39 @@ func executeSearchQuery(
 		} else {
 			visasSponsored = allVisaLabelsFromCounts(visaCounts)
 		}
-		conf := confidenceScore(desiredCount, totalCount, descriptionPositive, descriptionNegative, descriptionDesired)
-		reasons := buildEligibilityReasons(desiredCount, descriptionPositive, descriptionNegative, descriptionDesired, desiredVisaTypes)
-		visaMatchStrength := visaMatchStrength(desiredCount, descriptionDesired, descriptionPositive)
+		benefits := extractBenefits(descriptionText)
+		priorityVisa, priorityWeight := priority.best(matchedVisas)
+		score := scoreConfidence(confidenceFeatures{
+			DesiredCount:        desiredCount,
+			TotalCount:          totalCount,
+			Recency:             recency,
+			ApprovalRate:        approvalFactor,
+			LocationMatch:       localityFactor,
+			TitleMatch:          titleMatchValue(query.JobTitle, raw.Title),
+			DescriptionPositive: descriptionPositive,
+			DescriptionNegative: descriptionNegative,
+			DesiredMention:      descriptionDesired,
+			MobilityBenefit:     hasMobilityBenefit(benefits),
+			HeuristicMatch:      len(heuristics.Eligible) > 0,
+			DatasetFactor:       companyTierFactor(query.CompanyTierWeights, record, hasCompany) * priorityWeight,
+		}, weights)
+		conf := score.Score
+		var breakdown any = score.toMap()
+		reasons := append(buildEligibilityReasons(desiredCount, descriptionPositive, descriptionNegative, descriptionDesired, desiredVisaTypes), heuristics.Reasons...)
+		if applyVisaFiltering && acceptedOnlyByLenientMode(query.StrictnessMode, desiredCount, descriptionPositive, descriptionDesired) {
+			reasons = append(reasons, lenientAcceptanceReason)
+			stats.LenientAccepted++
+		}
+		visaMatchStrength := priority.strength(heuristics.strength(visaMatchStrength(desiredCount, descriptionDesired, descriptionPositive)), priorityVisa)
 		if !applyVisaFiltering {
 			conf = generalConfidenceScore(hasCompany, fetchedDescription)
+			breakdown = nil
 			reasons = buildGeneralEligibilityReasons(query.JobTitle, hasCompany, fetchedDescription)
 			visaMatchStrength = "not_requested"
 		}
+		if len(keywords.Matched) > 0 {
+			reasons = append(reasons, fmt.Sprintf("Listing mentions required keyword(s): %s.", strings.Join(keywords.Matched, ", ")))
+		}
 		guidance := "Apply and tailor outreach to the hiring team."
 		if len(contacts) > 0 {
 			primary := contacts[0]
@@ -278,10 +444,7 @@ func executeSearchQuery(
 				guidance = fmt.Sprintf("Prioritize outreach to %s %s after applying.", name, email)
 			}
 		}
-		if isRemote == nil {
-			isRemote = boolPtr(detectLinkedInRemote(raw.Title, raw.Location, descriptionText))
-		}
-
+		guidance += urgencyGuidance(constraints, urgency)
 		accepted = append(accepted, map[string]any{
 			"job_url":             raw.JobURL,
 			"title":               raw.Title,
@@ -297,28 +460,60 @@ func executeSearchQuery(
 				}
 				return descriptionText
 			}(),
-			"salary_text":              optionalString(raw.SalaryText),
-			"salary_currency":          optionalString(raw.SalaryCurrency),
-			"salary_interval":          optionalString(raw.SalaryInterval),
-			"salary_min_amount":        optionalInt(raw.SalaryMin),
-			"salary_max_amount":        optionalInt(raw.SalaryMax),
-			"salary_source":            optionalString(raw.SalarySource),
-			"job_type":                 optionalString(jobType),
-			"job_level":                optionalString(jobLevel),
-			"company_industry":         optionalString(companyIndustry),
-			"job_function":             optionalString(jobFunction),
-			"job_url_direct":           optionalString(jobURLDirect),
-			"is_remote":                optionalBool(isRemote),
-			"employer_contacts":        contacts,
-			"visa_counts":              visaCounts,
-			"visas_sponsored":          visasSponsored,
-			"visa_match_strength":      visaMatchStrength,
-			"eligibility_reasons":      reasons,
-			"confidence_score":         conf,
-			"confidence_model_version": "v1.1.0-rules-go",
-			"agent_guidance":           guidance,
+			"salary_text":                optionalString(raw.SalaryText),
+			"salary_currency":            optionalString(raw.SalaryCurrency),
+			"salary_interval":            optionalString(raw.SalaryInterval),
+			"salary_min_amount":          optionalInt(raw.SalaryMin),
+			"salary_max_amount":          optionalInt(raw.SalaryMax),
+			"salary_source":              optionalString(raw.SalarySource),
+			"job_type":                   optionalString(jobType),
+			"job_level":                  optionalString(jobLevel),
+			"company_industry":           optionalString(companyIndustry),
+			"job_function":               optionalString(jobFunction),
+			"job_url_direct":             optionalString(jobURLDirect),
+			"company_url":                optionalString(raw.CompanyURL),
+			"is_remote":                  optionalBool(isRemote),
+			"applicant_count":            optionalInt(activity.ApplicantCount),
+			"is_reposted":                optionalBool(activity.IsReposted),
+			"posted_age_hours":           postedAgeHours(activity.PostedAt, raw.DatePosted, utcNow()),
+			"benefits":                   benefits,
+			"workplace_type":             optionalString(workplaceType),
+			"constraint_effects":         constraintEffects,
+			"constraint_mismatch":        constraintMismatch,
+			"previously_seen":            prefilter.seenBefore(raw),
+			"employer_contacts":          contacts,
+			"company_facts":              facts,
+			"visa_counts":                visaCountsWithApproval(visaCounts, record),
+			"lca_wage_estimate":          wageEstimate,
+			"occupation_match":           occupation,
+			"sponsorship_locality":       locality,
+			"cap_exempt":                 capExempt,
+			"cap_exempt_basis":           optionalString(capExemptBasis),
+			"dataset_source":             optionalString(record.DatasetSource),
+			"company_tier":               optionalString(record.CompanyTier),
+			"company_tier_basis":         optionalString(record.CompanyTierBasis),
+			"visa_heuristic_flags":       heuristics.Flags,
+			"custom_signal_matches":      custom.Matched,
+			"visa_priority_match":        optionalString(priorityVisa),
+			"visa_priority_weight":       priorityWeight,
+			"gc_track":                   gcTrack,
+			"e_verify_enrolled":          eVerifyEnrolled,
+			"urgency_signals":            urgency,
+			"visa_counts_by_fiscal_year": fiscalYears,
+			"sponsorship_recency":        recency,
+			"visas_sponsored":            visasSponsored,
+			"visa_match_strength":        visaMatchStrength,
+			"eligibility_reasons":        reasons,
+			"confidence_score":           conf,
+			"confidence_model_version":   weights.modelVersion(),
+			"confidence_breakdown":       breakdown,
+			"agent_guidance":             guidance,
 		})
-		if len(accepted) >= requiredAccepted {
+		partialResults.report(accepted)
+		if withinCompanyCap(companyCounts, raw.Company, query.MaxResultsPerCompany) {
+			visibleAccepted++
+		}
+		if visibleAccepted >= requiredAccepted {
 			break
 		}
 
@@ -334,7 +529,29 @@ func executeSearchQuery(
 		}
 	}
 
-	sessionRecord, err := saveSearchSessionRecord(query, desiredVisaTypes, accepted, scanExhausted, rawScanTarget)
+	descriptions.Stop()
+	stats.DescriptionFetches = descriptions.Fetches()
+	stats.DescriptionCacheHits, stats.DescriptionCacheMisses = cache.counts()
+	if query.EnrichCompanyPages {
+		stats.CompanyPagesFetched, stats.CompanyPageCacheHits, err = enrichCompanyPages(client, accepted, query.MaxCompanyPageFetches, isCancelled)
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
+	stats.VisaPrioritySecondary = sortByVisaPriority(accepted, priority)
+	if constraints.urgent() {
+		stats.UrgencyPromoted = promoteUrgentMatches(accepted)
+	}
+	if !query.EnforceConstraints {
+		stats.ConstraintDemoted = demoteConstraintMismatches(accepted)
+	}
+
+	var sessionRecord map[string]any
+	if continuation != nil {
+		sessionRecord, err = appendSearchSessionRecord(continuation, query, accepted, scanExhausted, rawScanTarget, scan.Resume)
+	} else {
+		sessionRecord, err = saveSearchSessionRecord(query, desiredVisaTypes, accepted, scanExhausted, rawScanTarget, scan.Resume)
+	}
 	if err != nil {
 		return nil, nil, "", err
 	}
@@ -347,8 +564,9 @@ func executeSearchQuery(
 		}
 	}
 
-	page, pagination := sliceAcceptedJobs(acceptedWithIDs, query.Offset, query.MaxReturned, rawScanTarget, query.MaxScanResults, scanExhausted)
+	page, pagination := sliceAcceptedJobs(acceptedWithIDs, query.Offset, query.MaxReturned, rawScanTarget, query.MaxScanResults, scanExhausted, "")
 	stats.AcceptedJobs = len(acceptedWithIDs)
+	stats.CollapsedByCompany = intOrZero(sessionRecord["collapsed_jobs_total"])
 	stats.ReturnedJobs = len(page)
 	stats.DatasetRows = dataset.Rows
 
@@ -360,6 +578,25 @@ func executeSearchQuery(
 			"suggested_titles": findRelatedTitlesInternal(query.JobTitle, 8),
 		})
 	}
+	if len(page) == 0 && applyVisaFiltering && query.StrictnessMode != "lenient" {
+		recoverySuggestions = append(recoverySuggestions, map[string]any{
+			"type":    "try_lenient_strictness",
+			"message": "No jobs passed the visa filters; rerun with strictness_mode=lenient to also accept jobs with any sponsorship language or a strong title match.",
+		})
+	}
+	if len(page) == 0 && stats.PreviouslySeenSkipped > 0 {
+		recoverySuggestions = append(recoverySuggestions, map[string]any{
+			"type":    "previously_seen_hidden",
+			"message": fmt.Sprintf("%d listing(s) were hidden because you have already seen them; set hide_previously_seen=false to include them.", stats.PreviouslySeenSkipped),
+		})
+	}
+	if len(staffingCompanies) > 0 {
+		recoverySuggestions = append(recoverySuggestions, map[string]any{
+			"type":      "staffing_agencies_excluded",
+			"message":   "Skipped listings from staffing/recruiting firms; set exclude_staffing_agencies=false to include them.",
+			"companies": staffingCompanies[:min(len(staffingCompanies), maxStaffingAgencySuggestions)],
+		})
+	}
 	if descriptionBudgetHit {
 		recoverySuggestions = append(recoverySuggestions, map[string]any{
 			"type":                    "description_probe_budget_reached",
@@ -367,6 +604,27 @@ func executeSearchQuery(
 			"description_fetch_limit": descriptionFetchLimit,
 		})
 	}
+	if scan.RuntimeBudgetHit || runtimeBudgetExceeded(query.RuntimeDeadline) {
+		recoverySuggestions = append(recoverySuggestions, map[string]any{
+			"type":       "runtime_budget_reached",
+			"message":    "Stopped at max_runtime_seconds with the jobs accepted so far; call continue_job_search with this session_id to resume from the checkpoint.",
+			"session_id": sessionID,
+		})
+	}
+	if boolOrFalse(layoutCheck["possible_layout_change"]) {
+		recoverySuggestions = append(recoverySuggestions, map[string]any{
+			"type":    "possible_layout_change",
+			"message": "LinkedIn listing pages parsed far fewer fields than usual; results may be incomplete until the parser is updated.",
+			"reasons": layoutCheck["reasons"],
+		})
+	}
+	if scan.PagesFailed > 0 {
+		recoverySuggestions = append(recoverySuggestions, map[string]any{
+			"type":    "listing_pages_failed",
+			"message": "A LinkedIn listing page kept failing, so the scan stopped early with the listings collected so far; run continue_job_search later to resume from that page.",
+			"error":   scan.PageFailure,
+		})
+	}
 	if datasetLoadWarning != "" {
 		recoverySuggestions = append(recoverySuggestions, map[string]any{
 			"type":    "dataset_unavailable",
@@ -410,6 +668,8 @@ func executeSearchQuery(
 		"company_matches":            stats.CompanyMatches,
 		"description_signal_matches": stats.DescriptionSignalMatches,
 		"description_fetches":        stats.DescriptionFetches,
+		"description_cache_hits":     stats.DescriptionCacheHits,
+		"description_cache_misses":   stats.DescriptionCacheMisses,
 		"description_fetch_skipped":  stats.DescriptionFetchSkipped,
 		"description_fetch_limit":    descriptionFetchLimit,
 		"description_budget_hit":     descriptionBudgetHit,
@@ -417,6 +677,64 @@ func executeSearchQuery(
 		"ignored_companies_skipped":  stats.IgnoredCompaniesSkipped,
 		"dataset_rows":               stats.DatasetRows,
 		"visa_filtering_enabled":     applyVisaFiltering,
+		"collapsed_by_company":       stats.CollapsedByCompany,
+		"max_results_per_company":    optionalPositiveInt(query.MaxResultsPerCompany),
+		"workplace_types":            append([]string{}, query.WorkplaceTypes...),
+		"workplace_filtered_out":     stats.WorkplaceFilteredOut,
+		"job_types":                  append([]string{}, query.JobTypes...),
+		"job_levels":                 append([]string{}, query.JobLevels...),
+		"job_type_filtered_out":      stats.JobTypeFilteredOut,
+		"job_level_filtered_out":     stats.JobLevelFilteredOut,
+		"exclude_staffing_agencies":  query.ExcludeStaffingAgencies,
+		"staffing_agencies_skipped":  stats.StaffingAgenciesSkipped,
+		"keyword_filtered_out":       stats.KeywordFilteredOut,
+		"exclude_title_keywords":     query.ExcludeTitleKeywords,
+		"title_excluded_out":         stats.TitleExcludedOut,
+		"constraints_applied":        constraints.toMap(),
+		"enforce_constraints":        query.EnforceConstraints,
+		"hide_previously_seen":       query.HidePreviouslySeen,
+		"ranking_weights":            weights.toMap(),
+		"confidence_model_version":   weights.modelVersion(),
+		"previously_seen_skipped":    stats.PreviouslySeenSkipped,
+		"lenient_accepted":           stats.LenientAccepted,
+		"constraint_filtered_out":    stats.ConstraintFilteredOut,
+		"constraint_demoted":         stats.ConstraintDemoted,
+		"urgency_promoted":           stats.UrgencyPromoted,
+		"visa_type_weights":          priority.Weights,
+		"visa_priority_secondary":    stats.VisaPrioritySecondary,
+		"min_salary":                 optionalPositiveInt(query.MinSalary),
+		"salary_filtered_out":        stats.SalaryFilteredOut,
+		"description_salary_found":   stats.DescriptionSalaryFound,
+		"company_pages_fetched":      stats.CompanyPagesFetched,
+		"min_lca_wage":               optionalPositiveInt(query.MinLCAWage),
+		"lca_wage_filtered_out":      stats.LCAWageFilteredOut,
+		"cap_exempt_only":            query.CapExemptOnly,
+		"cap_exempt_filtered_out":    stats.CapExemptFilteredOut,
+		"require_gc_track":           query.RequireGCTrack,
+		"gc_track_filtered_out":      stats.GCTrackFilteredOut,
+		"company_tiers":              query.CompanyTiers,
+		"company_tier_filtered_out":  stats.CompanyTierFilteredOut,
+		"company_page_cache_hits":    stats.CompanyPageCacheHits,
+		"continued_session_id":       optionalString(query.ContinueSessionID),
+		"new_accepted_jobs":          len(accepted),
+		"scan_cap_hit":               scan.CapHit,
+		"scan_slices":                scan.Slices,
+		"page_retries":               scan.PageRetries,
+		"search_page_cache_hits":     searchPageCacheHitsFor(client),
+		"geo_id":                     optionalString(geo.ID),
+		"posted_after":               optionalTimeISO(query.PostedAfter),
+		"posted_before":              optionalTimeISO(query.PostedBefore),
+		"posted_date_filtered_out":   stats.PostedDateFilteredOut,
+		"effective_hours_old":        query.HoursOld,
+		"geo_id_source":              geoSource,
+		"geo_display_name":           optionalString(geo.DisplayName),
+		"linkedin_session":           linkedInSessionStatusFor(client),
+		"request_policy":             query.RequestPolicy.toMap(),
+		"linkedin_locale":            query.Locale.toMap(),
+		"pages_failed":               scan.PagesFailed,
+		"runtime_checkpoint":         runtimeCheckpoint(query, started, scan, descriptions.Fetches(), len(accepted)),
+		"possible_layout_change":     boolOrFalse(layoutCheck["possible_layout_change"]),
+		"layout_yield":               layoutCheck,
 	}
 
 	searchTools := map[string]any{
@@ -450,6 +768,7 @@ func executeSearchQuery(
 			"search_mode":        queryMode,
 			"visa_filtering":     applyVisaFiltering,
 			"desired_visa_types": desiredVisaTypes,
+			"visa_types_source":  visaTypesSource,
 			"search_session": map[string]any{
 				"session_id":          sessionID,
 				"expires_at_utc":      sessionRecord["expires_at_utc"],
@@ -459,6 +778,8 @@ func executeSearchQuery(
 				"scan_exhausted":        scanExhausted,
 				"requested_scan_target": rawScanTarget,
 				"max_scan_results":      query.MaxScanResults,
+				"start_cap_hit":         scan.CapHit,
+				"slices_scanned":        len(scan.Slices),
 			},
 		},
 		"stats": statsMap,
@@ -477,6 +798,9 @@ func executeSearchQuery(
 			return out
 		}(),
 	}
+	if query.DiffAgainstRunID != "" || query.DiffAgainstLastRun {
+		response["diff"] = buildSearchDiff(query, asMap(sessionRecord["result_id_index"]))
+	}
 	onProgress("completed", "Search run completed.", 100, map[string]any{
 		"accepted_jobs": len(acceptedWithIDs),
 		"returned_jobs": len(page),
@@ -492,6 +816,13 @@ func optionalString(value string) any {
 	return clean
 }
 
+func optionalPositiveInt(value int) any {
+	if value < 1 {
+		return nil
+	}
+	return value
+}
+
 func optionalInt(value *int) any {
 	if value == nil {
 		return nil
@@ -507,7 +838,7 @@ func optionalBool(value *bool) any {
 }
 
 func allVisaLabelsFromCounts(visaCounts map[string]int) []string {
-	order := []string{"h1b", "h1b1_chile", "h1b1_singapore", "e3_australian", "green_card"}
+	order := append(slices.Clone(datasetVisaColumns), registerVisaColumns()...)
 	out := []string{}
 	for _, key := range order {
 		if visaCounts[key] <= 0 {
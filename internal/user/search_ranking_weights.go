@@ -0,0 +1,133 @@
+package user
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const confidenceModelVersion = "v2"
+
+// rankingWeights are the feature weights of the v2 confidence model
+// (scoreConfidence). Raising the description terms favors recall from
+// listings that state sponsorship; raising negative_penalty favors precision.
+type rankingWeights struct {
+	DatasetWeight        float64
+	DatasetVolumeWeight  float64
+	RecencyWeight        float64
+	ApprovalRateWeight   float64
+	LocationMatchWeight  float64
+	TitleMatchWeight     float64
+	DescriptionWeight    float64
+	DesiredMentionWeight float64
+	NegativePenalty      float64
+	OtherVisaWeight      float64
+	BenefitsWeight       float64
+}
+
+var defaultRankingWeights = rankingWeights{
+	DatasetWeight:        0.35,
+	DatasetVolumeWeight:  0.1,
+	RecencyWeight:        0.1,
+	ApprovalRateWeight:   0.05,
+	LocationMatchWeight:  0.05,
+	TitleMatchWeight:     0.1,
+	DescriptionWeight:    0.1,
+	DesiredMentionWeight: 0.15,
+	NegativePenalty:      0.6,
+	OtherVisaWeight:      0.05,
+	BenefitsWeight:       0.05,
+}
+
+func (w *rankingWeights) fields() map[string]*float64 {
+	return map[string]*float64{
+		"dataset_weight":         &w.DatasetWeight,
+		"dataset_volume_weight":  &w.DatasetVolumeWeight,
+		"recency_weight":         &w.RecencyWeight,
+		"approval_rate_weight":   &w.ApprovalRateWeight,
+		"location_match_weight":  &w.LocationMatchWeight,
+		"title_match_weight":     &w.TitleMatchWeight,
+		"description_weight":     &w.DescriptionWeight,
+		"desired_mention_weight": &w.DesiredMentionWeight,
+		"negative_penalty":       &w.NegativePenalty,
+		"other_visa_weight":      &w.OtherVisaWeight,
+		"benefits_weight":        &w.BenefitsWeight,
+	}
+}
+
+func (w rankingWeights) toMap() map[string]any {
+	return map[string]any{
+		"dataset_weight":         w.DatasetWeight,
+		"dataset_volume_weight":  w.DatasetVolumeWeight,
+		"recency_weight":         w.RecencyWeight,
+		"approval_rate_weight":   w.ApprovalRateWeight,
+		"location_match_weight":  w.LocationMatchWeight,
+		"title_match_weight":     w.TitleMatchWeight,
+		"description_weight":     w.DescriptionWeight,
+		"desired_mention_weight": w.DesiredMentionWeight,
+		"negative_penalty":       w.NegativePenalty,
+		"other_visa_weight":      w.OtherVisaWeight,
+		"benefits_weight":        w.BenefitsWeight,
+	}
+}
+
+// normalizeRankingWeights validates a ranking_weights argument. Only the keys
+// provided are returned; missing keys keep their defaults.
+func normalizeRankingWeights(raw any) (map[string]any, error) {
+	input, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("ranking_weights must be an object when provided")
+	}
+	allowed := make([]string, 0, len(defaultRankingWeights.fields()))
+	for key := range defaultRankingWeights.fields() {
+		allowed = append(allowed, key)
+	}
+	slices.Sort(allowed)
+	out := map[string]any{}
+	for key, value := range input {
+		if !slices.Contains(allowed, key) {
+			return nil, fmt.Errorf("ranking_weights.%s is not supported; use one of %v", key, allowed)
+		}
+		number, ok := value.(float64)
+		if !ok {
+			if asInt, isInt := value.(int); isInt {
+				number, ok = float64(asInt), true
+			}
+		}
+		if !ok || math.IsNaN(number) || number < 0 || number > 1 {
+			return nil, fmt.Errorf("ranking_weights.%s must be a number between 0 and 1", key)
+		}
+		out[key] = number
+	}
+	return out, nil
+}
+
+func rankingWeightsFromMap(overrides map[string]any) rankingWeights {
+	weights := defaultRankingWeights
+	fields := weights.fields()
+	for key, value := range overrides {
+		if target, ok := fields[key]; ok {
+			*target = floatOrZero(value)
+		}
+	}
+	return weights
+}
+
+// modelVersion tags the scoring model with any non-default weights so saved
+// scores stay comparable only with scores produced by the same weights.
+func (w rankingWeights) modelVersion() string {
+	defaults := defaultRankingWeights.toMap()
+	changed := []string{}
+	for key, value := range w.toMap() {
+		if value != defaults[key] {
+			changed = append(changed, key+"="+strconv.FormatFloat(value.(float64), 'f', -1, 64))
+		}
+	}
+	if len(changed) == 0 {
+		return confidenceModelVersion
+	}
+	slices.Sort(changed)
+	return confidenceModelVersion + "+weights(" + strings.Join(changed, ",") + ")"
+}
@@ -0,0 +1,77 @@
+package user
+
+import "testing"
+
+func TestRankingWeightsOverrideConfidenceScore(t *testing.T) {
+	defaults := rankingWeightsFromMap(nil)
+	full := confidenceFeatures{DesiredCount: 50, TotalCount: 50, Recency: 1, ApprovalRate: 1, LocationMatch: 1, TitleMatch: 1, DescriptionPositive: true, DesiredMention: true, DatasetFactor: 1}
+	if got := scoreConfidence(full, defaults).Score; got != 1 {
+		t.Fatalf("expected default score 1, got %v", got)
+	}
+	if got := defaults.modelVersion(); got != "v2" {
+		t.Fatalf("expected default model version v2, got %q", got)
+	}
+
+	overrides, err := normalizeRankingWeights(map[string]any{"dataset_weight": 0.3, "negative_penalty": 1, "title_match_weight": 0})
+	if err != nil {
+		t.Fatalf("normalizeRankingWeights failed: %v", err)
+	}
+	tuned := rankingWeightsFromMap(overrides)
+	datasetOnly := confidenceFeatures{DesiredCount: 10, TotalCount: 10, Recency: 1, ApprovalRate: 1, LocationMatch: 1, TitleMatch: 1, DatasetFactor: 1}
+	if got := scoreConfidence(datasetOnly, tuned).Score; got != 0.52 {
+		t.Fatalf("expected tuned dataset score 0.52, got %v", got)
+	}
+	datasetOnly.DescriptionNegative = true
+	if got := scoreConfidence(datasetOnly, tuned).Score; got != 0 {
+		t.Fatalf("expected full negative penalty to zero the score, got %v", got)
+	}
+	want := confidenceModelVersion + "+weights(dataset_weight=0.3,negative_penalty=1,title_match_weight=0)"
+	if got := tuned.modelVersion(); got != want {
+		t.Fatalf("expected model version %q, got %q", want, got)
+	}
+	if defaultRankingWeights.DatasetWeight != 0.35 {
+		t.Fatal("expected overrides to leave the defaults untouched")
+	}
+
+	for _, bad := range []any{
+		"heavy",
+		map[string]any{"salary_weight": 0.5},
+		map[string]any{"dataset_weight": 1.5},
+		map[string]any{"dataset_weight": "high"},
+	} {
+		if _, err := normalizeRankingWeights(bad); err == nil {
+			t.Fatalf("expected %#v to fail validation", bad)
+		}
+	}
+}
+
+func TestConfidenceBreakdownExplainsEachFeature(t *testing.T) {
+	score := scoreConfidence(confidenceFeatures{
+		DesiredCount:  10,
+		TotalCount:    10,
+		Recency:       0.5,
+		ApprovalRate:  1,
+		LocationMatch: 0.8,
+		TitleMatch:    0.5,
+		DatasetFactor: 1,
+	}, defaultRankingWeights)
+	contributions := map[string]float64{}
+	sum := 0.0
+	for _, feature := range score.Features {
+		contributions[feature.Feature] = feature.Contribution
+		sum += feature.Contribution
+	}
+	if contributions["dataset_count"] != 0.35 || contributions["recency"] != 0.05 || contributions["location_match"] != 0.04 || contributions["title_match"] != 0.05 {
+		t.Fatalf("unexpected contributions: %#v", contributions)
+	}
+	if roundScore(sum) != score.Score || score.Score != 0.56 {
+		t.Fatalf("expected contributions to add up to %v, got %v", score.Score, sum)
+	}
+	if titleMatchValue("Software Engineer", "Senior Software Engineer") != 1 || titleMatchValue("", "Anything") != 1 || titleMatchValue("Software Engineer", "Account Executive") != 0 {
+		t.Fatalf("unexpected title match values")
+	}
+	unknown := scoreConfidence(confidenceFeatures{TotalCount: 5, Recency: 1, ApprovalRate: 1, LocationMatch: 1, TitleMatch: 1, DatasetFactor: 1}, defaultRankingWeights)
+	if unknown.Score != 0.15 {
+		t.Fatalf("expected dataset terms to need a desired-visa sponsor, got %#v", unknown)
+	}
+}
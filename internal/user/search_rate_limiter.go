@@ -0,0 +1,105 @@
+package user
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	defaultUpstreamRequestsPerMinute = 60
+	defaultUpstreamBurst             = 5
+)
+
+// tokenBucket paces upstream requests. reserve always takes a token and
+// returns how long the caller must wait for it, so concurrent callers queue
+// up in arrival order instead of racing for the next refill.
+type tokenBucket struct {
+	mu            sync.Mutex
+	perMinute     int
+	burst         int
+	tokens        float64
+	last          time.Time
+	now           func() time.Time
+	waits         int
+	waitedSeconds float64
+}
+
+func newTokenBucket(perMinute, burst int, now func() time.Time) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{perMinute: perMinute, burst: burst, tokens: float64(burst), last: now(), now: now}
+}
+
+func (b *tokenBucket) enabled() bool {
+	return b != nil && b.perMinute > 0
+}
+
+func (b *tokenBucket) reserve() time.Duration {
+	if !b.enabled() {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ratePerSecond := float64(b.perMinute) / 60.0
+	now := b.now()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(b.burst), b.tokens+elapsed*ratePerSecond)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	wait := -b.tokens / ratePerSecond
+	b.waits++
+	b.waitedSeconds += wait
+	return time.Duration(wait * float64(time.Second))
+}
+
+func (b *tokenBucket) snapshot() map[string]any {
+	out := map[string]any{
+		"enabled":                b.enabled(),
+		"requests_per_minute":    0,
+		"burst":                  0,
+		"throttled_requests":     0,
+		"throttled_wait_seconds": 0.0,
+	}
+	if b == nil {
+		return out
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out["requests_per_minute"] = b.perMinute
+	out["burst"] = b.burst
+	out["throttled_requests"] = b.waits
+	out["throttled_wait_seconds"] = math.Round(b.waitedSeconds*100) / 100
+	return out
+}
+
+var (
+	upstreamLimiterMu sync.Mutex
+	upstreamLimiter   *tokenBucket
+)
+
+// sharedUpstreamLimiter returns the process-wide limiter every LinkedIn
+// request goes through, so concurrent runs and users share one budget. It is
+// rebuilt when VISA_UPSTREAM_REQUESTS_PER_MINUTE or VISA_UPSTREAM_BURST
+// change.
+func sharedUpstreamLimiter() *tokenBucket {
+	perMinute := envInt("VISA_UPSTREAM_REQUESTS_PER_MINUTE", defaultUpstreamRequestsPerMinute)
+	burst := envInt("VISA_UPSTREAM_BURST", defaultUpstreamBurst)
+	upstreamLimiterMu.Lock()
+	defer upstreamLimiterMu.Unlock()
+	if upstreamLimiter == nil || upstreamLimiter.perMinute != perMinute || upstreamLimiter.burst != max(burst, 1) {
+		upstreamLimiter = newTokenBucket(perMinute, burst, time.Now)
+	}
+	return upstreamLimiter
+}
+
+// waitForUpstreamToken blocks until the shared limiter admits one request.
+// It returns false if the run was cancelled while waiting.
+func waitForUpstreamToken(isCancelled func() bool) bool {
+	return sleepWithCancel(sharedUpstreamLimiter().reserve(), isCancelled)
+}
@@ -0,0 +1,79 @@
+package user
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketAllowsBurstThenPaces(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := newTokenBucket(60, 2, func() time.Time { return clock })
+	if bucket.reserve() != 0 || bucket.reserve() != 0 {
+		t.Fatalf("expected burst requests to pass without waiting")
+	}
+	if wait := bucket.reserve(); wait != time.Second {
+		t.Fatalf("expected 1s wait after burst at 60/min, got %s", wait)
+	}
+	if wait := bucket.reserve(); wait != 2*time.Second {
+		t.Fatalf("expected queued caller to wait 2s, got %s", wait)
+	}
+	clock = clock.Add(10 * time.Second)
+	if wait := bucket.reserve(); wait != 0 {
+		t.Fatalf("expected refilled bucket to admit immediately, got %s", wait)
+	}
+	snapshot := bucket.snapshot()
+	if snapshot["throttled_requests"] != 2 || snapshot["throttled_wait_seconds"] != 3.0 {
+		t.Fatalf("unexpected snapshot: %v", snapshot)
+	}
+}
+
+func TestTokenBucketDisabledWhenRateIsZero(t *testing.T) {
+	bucket := newTokenBucket(0, 1, time.Now)
+	for i := 0; i < 10; i++ {
+		if wait := bucket.reserve(); wait != 0 {
+			t.Fatalf("expected disabled limiter never to wait, got %s", wait)
+		}
+	}
+	if boolOrFalse(bucket.snapshot()["enabled"]) {
+		t.Fatalf("expected disabled snapshot")
+	}
+}
+
+func TestSharedUpstreamLimiterIsSharedAndFollowsEnv(t *testing.T) {
+	t.Setenv("VISA_UPSTREAM_REQUESTS_PER_MINUTE", "120")
+	t.Setenv("VISA_UPSTREAM_BURST", "3")
+	first := sharedUpstreamLimiter()
+	if first != sharedUpstreamLimiter() {
+		t.Fatalf("expected the same limiter across callers")
+	}
+	if first.perMinute != 120 || first.burst != 3 {
+		t.Fatalf("unexpected limiter config: %d/min burst %d", first.perMinute, first.burst)
+	}
+	t.Setenv("VISA_UPSTREAM_REQUESTS_PER_MINUTE", "30")
+	if sharedUpstreamLimiter().perMinute != 30 {
+		t.Fatalf("expected limiter to be rebuilt after env change")
+	}
+}
+
+func TestTokenBucketIsSafeForConcurrentCallers(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := newTokenBucket(60, 1, func() time.Time { return clock })
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	total := time.Duration(0)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait := bucket.reserve()
+			mu.Lock()
+			total += wait
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if total != 10*time.Second {
+		t.Fatalf("expected queued waits of 0+1+2+3+4s, got %s", total)
+	}
+}
@@ -0,0 +1,231 @@
+package user
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	defaultReportsDir     = "data/reports"
+	defaultReportMaxJobs  = 200
+	maxReportMaxJobsLimit = 1000
+)
+
+//go:embed report_templates/results_report.html
+var reportTemplates embed.FS
+
+var resultsReportTemplate = template.Must(template.ParseFS(reportTemplates, "report_templates/results_report.html"))
+
+type reportJobRow struct {
+	Title         string
+	URL           template.URL
+	Company       string
+	Location      string
+	Posted        string
+	Salary        string
+	Visas         []string
+	ConfidencePct int
+}
+
+type resultsReportView struct {
+	Title       string
+	JobTitle    string
+	Location    string
+	VisaLabels  string
+	SessionID   string
+	GeneratedAt string
+	JobCount    int
+	Jobs        []reportJobRow
+}
+
+func reportsDir() string {
+	return envOrDefault("VISA_REPORTS_DIR", defaultReportsDir)
+}
+
+func resolveResultSession(args map[string]any) (string, map[string]any, error) {
+	userID := getString(args, "user_id")
+	if userID == "" {
+		return "", nil, fmt.Errorf("user_id is required")
+	}
+	sessionID := getString(args, "session_id")
+	runID := getString(args, "run_id")
+	if sessionID == "" && runID == "" {
+		return "", nil, fmt.Errorf("run_id or session_id is required")
+	}
+	if sessionID == "" {
+		run, err := loadRunForUser(runID, userID)
+		if err != nil {
+			return "", nil, err
+		}
+		sessionID = getString(run, "search_session_id")
+		if sessionID == "" {
+			return "", nil, fmt.Errorf("search run has no result session yet; poll its status until it completes")
+		}
+	}
+	session, err := loadSearchSessionForUser(sessionID, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	return sessionID, session, nil
+}
+
+func sessionAcceptedJobs(session map[string]any) []map[string]any {
+	jobs := []map[string]any{}
+	for _, raw := range listOrEmpty(session["accepted_jobs"]) {
+		if row := mapOrNil(raw); row != nil {
+			jobs = append(jobs, row)
+		}
+	}
+	return jobs
+}
+
+func reportSafeURL(raw string) template.URL {
+	clean := strings.TrimSpace(raw)
+	lower := strings.ToLower(clean)
+	if strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") {
+		return template.URL(clean)
+	}
+	return ""
+}
+
+func buildReportRow(job map[string]any) reportJobRow {
+	visas := []string{}
+	for _, raw := range listOrEmpty(job["visas_sponsored"]) {
+		if text := stringFromAny(raw); text != "" {
+			visas = append(visas, text)
+		}
+	}
+	confidence := 0.0
+	if value, ok := job["confidence_score"].(float64); ok {
+		confidence = value
+	}
+	pct := int(math.Round(math.Max(0, math.Min(1, confidence)) * 100))
+	return reportJobRow{
+		Title:         getString(job, "title"),
+		URL:           reportSafeURL(getString(job, "job_url")),
+		Company:       getString(job, "company"),
+		Location:      getString(job, "location"),
+		Posted:        getString(job, "date_posted"),
+		Salary:        getString(job, "salary_text"),
+		Visas:         visas,
+		ConfidencePct: pct,
+	}
+}
+
+func fileURL(absPath string) string {
+	slashed := filepath.ToSlash(absPath)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed
+	}
+	return "file://" + slashed
+}
+
+// writeReportFile writes content to path, refusing to replace an existing
+// file unless overwrite is set.
+func writeReportFile(path string, content []byte, overwrite bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	}
+	file, err := os.OpenFile(path, flags, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("output_path %s already exists; pass overwrite=true to replace it", path)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func RenderResultsReport(args map[string]any) (map[string]any, error) {
+	sessionID, session, err := resolveResultSession(args)
+	if err != nil {
+		return nil, err
+	}
+	maxJobs := defaultReportMaxJobs
+	if parsed, has, err := getOptionalInt(args, "max_jobs"); has {
+		if err != nil {
+			return nil, fmt.Errorf("max_jobs must be an integer when provided")
+		}
+		if parsed < 1 {
+			return nil, fmt.Errorf("max_jobs must be >= 1")
+		}
+		maxJobs = min(parsed, maxReportMaxJobsLimit)
+	}
+
+	query := asMap(session["query"])
+	jobs := sessionAcceptedJobs(session)
+	if len(jobs) > maxJobs {
+		jobs = jobs[:maxJobs]
+	}
+	rows := make([]reportJobRow, 0, len(jobs))
+	for _, job := range jobs {
+		rows = append(rows, buildReportRow(job))
+	}
+	visaLabels := labelsForDesiredVisas(getStringList(query, "preferred_visa_types"))
+	title := getString(args, "title")
+	if title == "" {
+		title = fmt.Sprintf("%s jobs in %s", getString(query, "job_title"), getString(query, "location"))
+	}
+	view := resultsReportView{
+		Title:       title,
+		JobTitle:    getString(query, "job_title"),
+		Location:    getString(query, "location"),
+		VisaLabels:  strings.Join(visaLabels, ", "),
+		SessionID:   sessionID,
+		GeneratedAt: utcNowISO(),
+		JobCount:    len(rows),
+		Jobs:        rows,
+	}
+
+	var buf bytes.Buffer
+	if err := resultsReportTemplate.Execute(&buf, view); err != nil {
+		return nil, fmt.Errorf("render results report: %w", err)
+	}
+	overwrite, has, err := getOptionalBool(args, "overwrite")
+	if has && err != nil {
+		return nil, fmt.Errorf("overwrite must be a boolean when provided")
+	}
+	outputPath := getString(args, "output_path")
+	if outputPath == "" {
+		// The default path belongs to this session's report, so re-rendering
+		// replaces it.
+		outputPath = filepath.Join(reportsDir(), fmt.Sprintf("results_%s.html", sessionID))
+		overwrite = true
+	}
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".html") {
+		return nil, fmt.Errorf("output_path must end with .html")
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return nil, err
+	}
+	if err := writeReportFile(outputPath, buf.Bytes(), overwrite); err != nil {
+		return nil, err
+	}
+	absPath, err := filepath.Abs(outputPath)
+	if err != nil {
+		absPath = outputPath
+	}
+
+	return map[string]any{
+		"user_id":           getString(args, "user_id"),
+		"search_session_id": sessionID,
+		"output_path":       absPath,
+		"file_url":          fileURL(absPath),
+		"jobs_rendered":     len(rows),
+		"jobs_available":    len(sessionAcceptedJobs(session)),
+		"bytes_written":     buf.Len(),
+	}, nil
+}
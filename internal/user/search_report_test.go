@@ -0,0 +1,97 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderResultsReportWritesEscapedHTML(t *testing.T) {
+	setupUserToolPaths(t)
+	root := t.TempDir()
+	t.Setenv("VISA_REPORTS_DIR", filepath.Join(root, "reports"))
+	datasetPath := filepath.Join(root, "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{
+					JobURL:   "https://www.linkedin.com/jobs/view/1/",
+					Title:    "Software Engineer",
+					Company:  "Acme Inc",
+					Location: "New York, NY",
+				},
+				{
+					JobURL:   "javascript:alert(1)",
+					Title:    "Software Engineer <script>alert(1)</script>",
+					Company:  "Beta LLC",
+					Location: "New York, NY",
+				},
+			},
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	})
+
+	report, err := RenderResultsReport(map[string]any{
+		"user_id": "u1",
+		"run_id":  getString(results, "run_id"),
+	})
+	if err != nil {
+		t.Fatalf("RenderResultsReport failed: %v", err)
+	}
+	if got := intOrZero(report["jobs_rendered"]); got != 2 {
+		t.Fatalf("expected 2 rendered jobs, got %d", got)
+	}
+	outputPath := getString(report, "output_path")
+	if !strings.HasPrefix(outputPath, filepath.Join(root, "reports")) {
+		t.Fatalf("expected report under VISA_REPORTS_DIR, got %q", outputPath)
+	}
+	raw, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	html := string(raw)
+	if !strings.Contains(html, `href="https://www.linkedin.com/jobs/view/1/"`) {
+		t.Fatal("expected job link in report")
+	}
+	if strings.Contains(html, "<script>alert(1)</script>") || strings.Contains(html, "javascript:alert") {
+		t.Fatal("expected report to escape untrusted job fields")
+	}
+
+	if _, err := RenderResultsReport(map[string]any{"user_id": "u1"}); err == nil {
+		t.Fatal("expected missing run_id/session_id to fail")
+	}
+	if _, err := RenderResultsReport(map[string]any{
+		"user_id":     "u1",
+		"session_id":  getString(asMap(asMap(results["status"])["search_session"]), "session_id"),
+		"output_path": filepath.Join(root, "report.txt"),
+	}); err == nil {
+		t.Fatal("expected non-html output_path to fail")
+	}
+
+	existing := filepath.Join(root, "existing.html")
+	if err := os.WriteFile(existing, []byte("keep me"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reportArgs := map[string]any{"user_id": "u1", "run_id": getString(results, "run_id"), "output_path": existing}
+	if _, err := RenderResultsReport(reportArgs); err == nil {
+		t.Fatal("expected an existing output_path to be refused without overwrite")
+	}
+	if raw, _ := os.ReadFile(existing); string(raw) != "keep me" {
+		t.Fatalf("expected the existing file to be left alone, got %q", raw)
+	}
+	reportArgs["overwrite"] = true
+	if _, err := RenderResultsReport(reportArgs); err != nil {
+		t.Fatalf("expected overwrite=true to replace the file, got %v", err)
+	}
+	if _, err := RenderResultsReport(map[string]any{"user_id": "u1", "run_id": getString(results, "run_id")}); err != nil {
+		t.Fatalf("expected the default report path to be re-rendered, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package user
+
+import "fmt"
+
+const (
+	maxRequestTimeoutSeconds       = 120
+	maxRateLimitRetryWindowSeconds = 3600
+	maxRateLimitBackoffSeconds     = 300
+	requestPolicyTimeoutArg        = "request_timeout_seconds"
+	requestPolicyRetryWindowArg    = "rate_limit_retry_window_seconds"
+	requestPolicyInitialBackoffArg = "rate_limit_initial_backoff_seconds"
+	requestPolicyMaxBackoffArg     = "rate_limit_max_backoff_seconds"
+)
+
+// upstreamRequestPolicy controls per-request timeouts and how long a run
+// waits out 429s. Runs default to the server env settings and may override
+// them within the server maxima: short values fail fast for interactive use,
+// long ones suit patient overnight runs.
+type upstreamRequestPolicy struct {
+	TimeoutSeconds        int
+	RetryWindowSeconds    int
+	InitialBackoffSeconds int
+	MaxBackoffSeconds     int
+}
+
+func defaultUpstreamRequestPolicy() upstreamRequestPolicy {
+	return upstreamRequestPolicy{
+		TimeoutSeconds:        linkedInRequestTimeoutSeconds(),
+		RetryWindowSeconds:    rateLimitRetryWindowSeconds(),
+		InitialBackoffSeconds: rateLimitInitialBackoffSeconds(),
+		MaxBackoffSeconds:     rateLimitMaxBackoffSeconds(),
+	}
+}
+
+func (p upstreamRequestPolicy) toMap() map[string]any {
+	return map[string]any{
+		requestPolicyTimeoutArg:        p.TimeoutSeconds,
+		requestPolicyRetryWindowArg:    p.RetryWindowSeconds,
+		requestPolicyInitialBackoffArg: p.InitialBackoffSeconds,
+		requestPolicyMaxBackoffArg:     p.MaxBackoffSeconds,
+	}
+}
+
+// parseRequestPolicyOptions validates the per-run timeout and backoff args.
+// A zero retry window disables 429 retries entirely.
+func parseRequestPolicyOptions(args map[string]any, query map[string]any) error {
+	bounds := []struct {
+		name     string
+		minValue int
+		maxValue int
+	}{
+		{requestPolicyTimeoutArg, 1, maxRequestTimeoutSeconds},
+		{requestPolicyRetryWindowArg, 0, maxRateLimitRetryWindowSeconds},
+		{requestPolicyInitialBackoffArg, 1, maxRateLimitBackoffSeconds},
+		{requestPolicyMaxBackoffArg, 1, maxRateLimitBackoffSeconds},
+	}
+	for _, bound := range bounds {
+		parsed, has, err := getOptionalInt(args, bound.name)
+		if !has {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s must be an integer when provided", bound.name)
+		}
+		if parsed < bound.minValue {
+			return fmt.Errorf("%s must be >= %d", bound.name, bound.minValue)
+		}
+		if parsed > bound.maxValue {
+			return fmt.Errorf("%s must be <= %d", bound.name, bound.maxValue)
+		}
+		query[bound.name] = parsed
+	}
+	initial, hasInitial := intFromAny(query[requestPolicyInitialBackoffArg])
+	maxBackoff, hasMax := intFromAny(query[requestPolicyMaxBackoffArg])
+	if hasInitial && hasMax && initial > maxBackoff {
+		return fmt.Errorf("%s must be <= %s", requestPolicyInitialBackoffArg, requestPolicyMaxBackoffArg)
+	}
+	return nil
+}
+
+func requestPolicyFromQuery(queryMap map[string]any) upstreamRequestPolicy {
+	policy := defaultUpstreamRequestPolicy()
+	if value, ok := intFromAny(queryMap[requestPolicyTimeoutArg]); ok {
+		policy.TimeoutSeconds = value
+	}
+	if value, ok := intFromAny(queryMap[requestPolicyRetryWindowArg]); ok {
+		policy.RetryWindowSeconds = value
+	}
+	if value, ok := intFromAny(queryMap[requestPolicyInitialBackoffArg]); ok {
+		policy.InitialBackoffSeconds = value
+	}
+	if value, ok := intFromAny(queryMap[requestPolicyMaxBackoffArg]); ok {
+		policy.MaxBackoffSeconds = value
+	}
+	return policy
+}
+
+// requestPolicySetter is implemented by clients whose timeouts and retry
+// windows can be tuned per run.
+type requestPolicySetter interface {
+	setRequestPolicy(policy upstreamRequestPolicy)
+}
+
+func applyRequestPolicy(client linkedInClient, policy upstreamRequestPolicy) {
+	if setter, ok := client.(requestPolicySetter); ok {
+		setter.setRequestPolicy(policy)
+	}
+}
@@ -0,0 +1,71 @@
+package user
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRequestPolicyOptionsBounds(t *testing.T) {
+	query := map[string]any{}
+	err := parseRequestPolicyOptions(map[string]any{
+		"request_timeout_seconds":            5,
+		"rate_limit_retry_window_seconds":    0,
+		"rate_limit_initial_backoff_seconds": 1,
+		"rate_limit_max_backoff_seconds":     4,
+	}, query)
+	if err != nil {
+		t.Fatalf("parseRequestPolicyOptions failed: %v", err)
+	}
+	policy := requestPolicyFromQuery(query)
+	want := upstreamRequestPolicy{TimeoutSeconds: 5, RetryWindowSeconds: 0, InitialBackoffSeconds: 1, MaxBackoffSeconds: 4}
+	if policy != want {
+		t.Fatalf("requestPolicyFromQuery() = %+v, want %+v", policy, want)
+	}
+
+	for _, args := range []map[string]any{
+		{"request_timeout_seconds": 0},
+		{"request_timeout_seconds": maxRequestTimeoutSeconds + 1},
+		{"rate_limit_retry_window_seconds": -1},
+		{"rate_limit_max_backoff_seconds": "slow"},
+		{"rate_limit_initial_backoff_seconds": 10, "rate_limit_max_backoff_seconds": 5},
+	} {
+		if err := parseRequestPolicyOptions(args, map[string]any{}); err == nil {
+			t.Fatalf("expected %v to be rejected", args)
+		}
+	}
+}
+
+func TestRequestPolicyDefaultsToServerEnv(t *testing.T) {
+	t.Setenv("VISA_LINKEDIN_TIMEOUT_SECONDS", "7")
+	t.Setenv("VISA_RATE_LIMIT_RETRY_WINDOW_SECONDS", "90")
+	policy := requestPolicyFromQuery(map[string]any{"rate_limit_max_backoff_seconds": 10})
+	if policy.TimeoutSeconds != 7 || policy.RetryWindowSeconds != 90 || policy.MaxBackoffSeconds != 10 {
+		t.Fatalf("expected env defaults with the run override, got %+v", policy)
+	}
+}
+
+func TestZeroRetryWindowFailsFastOnRateLimit(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_UPSTREAM_REQUESTS_PER_MINUTE", "0")
+	t.Cleanup(sharedCircuitBreaker.recordSuccess)
+	calls := 0
+	client := liveClientWithTransport(t, func(req *http.Request) *http.Response {
+		calls++
+		return stubResponse(req, 429, "")
+	})
+	client.setRequestPolicy(upstreamRequestPolicy{TimeoutSeconds: 2, RetryWindowSeconds: 0, InitialBackoffSeconds: 1, MaxBackoffSeconds: 1})
+
+	started := time.Now()
+	_, err := client.FetchSearchPage(linkedInSearchQuery{JobTitle: "Fail Fast Engineer", Location: "Denver, CO"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "Retried for 0 seconds") {
+		t.Fatalf("expected an immediate rate-limit failure, got %v", err)
+	}
+	if calls != 1 || time.Since(started) > time.Second {
+		t.Fatalf("expected a single request without backoff, got %d calls in %s", calls, time.Since(started))
+	}
+	if client.httpClient.GetClient().Timeout != 2*time.Second {
+		t.Fatalf("expected per-run timeout on the HTTP client, got %s", client.httpClient.GetClient().Timeout)
+	}
+}
@@ -0,0 +1,332 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxConcurrentRunsPerUser = 2
+	defaultSearchWorkers            = 4
+	defaultEstimatedRunSeconds      = 60
+	estimatedRunSampleSize          = 20
+	searchRunPriorityNormal         = "normal"
+)
+
+// errNoRunsDispatched aborts the scheduler's store transaction so an idle
+// scheduling pass does not rewrite the run store.
+var errNoRunsDispatched = errors.New("no queued search runs to dispatch")
+
+// errNoRunsInterrupted aborts the startup reconciliation's store transaction
+// when no run was left holding a worker slot.
+var errNoRunsInterrupted = errors.New("no interrupted search runs")
+
+var searchRunPriorityRank = map[string]int{
+	"low":    0,
+	"normal": 1,
+	"high":   2,
+}
+
+func maxConcurrentRunsPerUser() int {
+	value := envInt("VISA_MAX_CONCURRENT_RUNS_PER_USER", defaultMaxConcurrentRunsPerUser)
+	if value < 1 {
+		return 1
+	}
+	return value
+}
+
+func searchWorkerCount() int {
+	value := envInt("VISA_SEARCH_WORKERS", defaultSearchWorkers)
+	if value < 1 {
+		return 1
+	}
+	return value
+}
+
+func normalizeSearchRunPriority(raw string) (string, error) {
+	clean := strings.ToLower(strings.TrimSpace(raw))
+	if clean == "" {
+		return searchRunPriorityNormal, nil
+	}
+	if _, ok := searchRunPriorityRank[clean]; !ok {
+		return "", fmt.Errorf("priority must be one of [high low normal]")
+	}
+	return clean, nil
+}
+
+func runPriorityRank(run map[string]any) int {
+	if rank, ok := searchRunPriorityRank[getString(run, "priority")]; ok {
+		return rank
+	}
+	return searchRunPriorityRank[searchRunPriorityNormal]
+}
+
+func runUserID(run map[string]any) string {
+	return getString(mapOrNil(run["query"]), "user_id")
+}
+
+func runOccupiesSlot(run map[string]any) bool {
+	switch strings.ToLower(getString(run, "status")) {
+	case "running":
+		return true
+	case "pending", "cancelling":
+		return boolOrFalse(run["dispatched"])
+	}
+	return false
+}
+
+func runIsQueued(run map[string]any) bool {
+	return strings.ToLower(getString(run, "status")) == "pending" && !boolOrFalse(run["dispatched"])
+}
+
+func queuedRunIDsLocked(runs map[string]any) []string {
+	type queuedRun struct {
+		ID       string
+		Rank     int
+		QueuedAt string
+	}
+	queued := []queuedRun{}
+	for runID, raw := range runs {
+		run := mapOrNil(raw)
+		if run == nil || !runIsQueued(run) {
+			continue
+		}
+		queued = append(queued, queuedRun{
+			ID:       runID,
+			Rank:     runPriorityRank(run),
+			QueuedAt: getString(run, "queued_at_utc"),
+		})
+	}
+	slices.SortFunc(queued, func(a, b queuedRun) int {
+		if a.Rank != b.Rank {
+			return b.Rank - a.Rank
+		}
+		if a.QueuedAt != b.QueuedAt {
+			return strings.Compare(a.QueuedAt, b.QueuedAt)
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	out := make([]string, 0, len(queued))
+	for _, item := range queued {
+		out = append(out, item.ID)
+	}
+	return out
+}
+
+func markRunDispatched(run map[string]any) {
+	run["dispatched"] = true
+	run["dispatched_at_utc"] = utcNowISO()
+	run["updated_at_utc"] = utcNowISO()
+	if boolOrFalse(run["was_queued"]) {
+		appendRunEvent(run, "dequeued", "Worker slot available; starting queued search.", 1, nil)
+	}
+}
+
+func dispatchQueuedRunsLocked(runs map[string]any) []string {
+	globalActive := 0
+	perUser := map[string]int{}
+	for _, raw := range runs {
+		run := mapOrNil(raw)
+		if run != nil && runOccupiesSlot(run) {
+			globalActive++
+			perUser[runUserID(run)]++
+		}
+	}
+	dispatched := []string{}
+	for _, runID := range queuedRunIDsLocked(runs) {
+		if globalActive >= searchWorkerCount() {
+			break
+		}
+		run := mapOrNil(runs[runID])
+		if runAwaitingRetry(run) {
+			continue
+		}
+		userID := runUserID(run)
+		if perUser[userID] >= maxConcurrentRunsPerUser() {
+			continue
+		}
+		markRunDispatched(run)
+		runs[runID] = run
+		globalActive++
+		perUser[userID]++
+		dispatched = append(dispatched, runID)
+	}
+	return dispatched
+}
+
+func scheduleSearchRuns() {
+	dispatched := []string{}
+	_ = withSearchRunStore(true, func(store map[string]any) error {
+		runs := mapOrNil(store["runs"])
+		if runs == nil {
+			return nil
+		}
+		dispatched = dispatchQueuedRunsLocked(runs)
+		if len(dispatched) == 0 {
+			return errNoRunsDispatched
+		}
+		store["runs"] = runs
+		return nil
+	})
+	for _, runID := range dispatched {
+		launchSearchRun(runID)
+	}
+}
+
+// interruptRunLocked ends a run that held a worker slot when the previous
+// server process stopped. A run that was being cancelled is cancelled; any
+// other is failed as interrupted.
+func interruptRunLocked(run map[string]any) {
+	delete(run, "partial_response")
+	run["dispatched"] = false
+	run["completed_at_utc"] = utcNowISO()
+	if strings.ToLower(getString(run, "status")) == "cancelling" || boolOrFalse(run["cancel_requested"]) {
+		run["status"] = "cancelled"
+		run["error"] = ""
+		run["error_code"] = searchErrorCancelled
+		appendRunEvent(run, "cancelled", "Search run cancelled.", 100, nil)
+		return
+	}
+	message := "Search run interrupted by a server restart."
+	run["status"] = "failed"
+	run["error"] = message
+	run["error_code"] = searchErrorInterrupted
+	appendRunEvent(run, "failed", message, 100, map[string]any{
+		"attempt_count": intOrZero(run["attempt_count"]),
+		"error_code":    searchErrorInterrupted,
+	})
+}
+
+// ResumeSearchRuns reconciles the run store when the server starts. Runs
+// that held a worker slot in the previous process can never finish, so they
+// are ended to free their slots. Queued runs are dispatched, and runs still
+// backing off before a retry get their wake-up timer back.
+func ResumeSearchRuns() {
+	retryDelays := []time.Duration{}
+	_ = withSearchRunStore(true, func(store map[string]any) error {
+		runs := mapOrNil(store["runs"])
+		interrupted := 0
+		for runID, raw := range runs {
+			run := mapOrNil(raw)
+			if run == nil {
+				continue
+			}
+			if runIsQueued(run) && runAwaitingRetry(run) {
+				retryDelays = append(retryDelays, time.Until(parseISOTime(run["retry_not_before_utc"])))
+				continue
+			}
+			if !runOccupiesSlot(run) {
+				continue
+			}
+			interruptRunLocked(run)
+			runs[runID] = run
+			interrupted++
+		}
+		if interrupted == 0 {
+			return errNoRunsInterrupted
+		}
+		store["runs"] = runs
+		return nil
+	})
+	for _, delay := range retryDelays {
+		wakeSchedulerAfter(delay)
+	}
+	scheduleSearchRuns()
+}
+
+func launchSearchRun(runID string) {
+	go func() {
+		executeSearchRun(runID)
+		scheduleSearchRuns()
+	}()
+}
+
+func enqueueSearchRun(runID string, run map[string]any) (bool, error) {
+	run["dispatched"] = false
+	run["queued_at_utc"] = time.Now().UTC().Format("2006-01-02T15:04:05.000000000Z")
+	dispatched := []string{}
+	err := withSearchRunStore(true, func(store map[string]any) error {
+		runs := mapOrNil(store["runs"])
+		if runs == nil {
+			runs = map[string]any{}
+		}
+		runs[runID] = run
+		dispatched = dispatchQueuedRunsLocked(runs)
+		if !slices.Contains(dispatched, runID) {
+			run["was_queued"] = true
+			appendRunEvent(run, "queued", "Search queued until a worker slot frees up.", 0, map[string]any{
+				"priority":                     getString(run, "priority"),
+				"search_workers":               searchWorkerCount(),
+				"max_concurrent_runs_per_user": maxConcurrentRunsPerUser(),
+			})
+		}
+		store["runs"] = runs
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, id := range dispatched {
+		launchSearchRun(id)
+	}
+	return slices.Contains(dispatched, runID), nil
+}
+
+func estimatedRunSecondsLocked(runs map[string]any) float64 {
+	type sample struct {
+		CompletedAt time.Time
+		Seconds     float64
+	}
+	samples := []sample{}
+	for _, raw := range runs {
+		run := mapOrNil(raw)
+		if run == nil || getString(run, "status") != "completed" {
+			continue
+		}
+		started := parseISOTime(run["dispatched_at_utc"])
+		completed := parseISOTime(run["completed_at_utc"])
+		if started.IsZero() || completed.IsZero() || completed.Before(started) {
+			continue
+		}
+		samples = append(samples, sample{CompletedAt: completed, Seconds: completed.Sub(started).Seconds()})
+	}
+	if len(samples) == 0 {
+		return defaultEstimatedRunSeconds
+	}
+	slices.SortFunc(samples, func(a, b sample) int {
+		return b.CompletedAt.Compare(a.CompletedAt)
+	})
+	if len(samples) > estimatedRunSampleSize {
+		samples = samples[:estimatedRunSampleSize]
+	}
+	total := 0.0
+	for _, item := range samples {
+		total += item.Seconds
+	}
+	return max(1, total/float64(len(samples)))
+}
+
+func searchRunQueueStatus(runID string) map[string]any {
+	out := map[string]any{
+		"queue_position":         0,
+		"estimated_wait_seconds": nil,
+		"estimated_start_at_utc": nil,
+	}
+	_ = withSearchRunStore(false, func(store map[string]any) error {
+		runs := mapOrNil(store["runs"])
+		position := slices.Index(queuedRunIDsLocked(runs), runID) + 1
+		if position < 1 {
+			return nil
+		}
+		waves := (position + searchWorkerCount() - 1) / searchWorkerCount()
+		wait := int(float64(waves) * estimatedRunSecondsLocked(runs))
+		out["queue_position"] = position
+		out["estimated_wait_seconds"] = wait
+		out["estimated_start_at_utc"] = futureISO(wait)
+		return nil
+	})
+	return out
+}
@@ -0,0 +1,196 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConcurrentRunLimitQueuesExcessRuns(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_MAX_CONCURRENT_RUNS_PER_USER", "1")
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	originalFactory := linkedInClientFactory
+	defer func() {
+		linkedInClientFactory = originalFactory
+	}()
+	linkedInClientFactory = func() linkedInClient {
+		return &fakeLinkedInClient{
+			pages: map[int][]linkedInJob{
+				0: {{
+					JobURL:   "https://www.linkedin.com/jobs/view/1/",
+					Title:    "Software Engineer",
+					Company:  "Acme Inc",
+					Location: "New York, NY",
+				}},
+			},
+			pageDelay: 150 * time.Millisecond,
+		}
+	}
+
+	args := map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	}
+	first, err := StartJobSearch(args)
+	if err != nil {
+		t.Fatalf("first StartJobSearch failed: %v", err)
+	}
+	if queued, _ := first["queued"].(bool); queued {
+		t.Fatalf("expected first run to start immediately, got %#v", first)
+	}
+	second, err := StartJobSearch(args)
+	if err != nil {
+		t.Fatalf("second StartJobSearch failed: %v", err)
+	}
+	if queued, _ := second["queued"].(bool); !queued {
+		t.Fatalf("expected second run to be queued, got %#v", second)
+	}
+	if got := intOrZero(second["queue_position"]); got != 1 {
+		t.Fatalf("expected queue_position=1, got %d", got)
+	}
+	third, err := StartJobSearch(args)
+	if err != nil {
+		t.Fatalf("third StartJobSearch failed: %v", err)
+	}
+	cancelled, err := CancelJobSearch(map[string]any{"user_id": "u1", "run_id": getString(third, "run_id")})
+	if err != nil {
+		t.Fatalf("CancelJobSearch failed: %v", err)
+	}
+	if got := getString(cancelled, "status"); got != "cancelled" {
+		t.Fatalf("expected queued run to cancel immediately, got %q", got)
+	}
+
+	waitForTerminalRunStatus(t, "u1", getString(first, "run_id"), 3*time.Second)
+	final := waitForTerminalRunStatus(t, "u1", getString(second, "run_id"), 3*time.Second)
+	if got := getString(final, "status"); got != "completed" {
+		t.Fatalf("expected queued run to complete, got %q", got)
+	}
+	phases := map[string]bool{}
+	for _, raw := range listOrEmpty(final["events"]) {
+		phases[getString(mapOrNil(raw), "phase")] = true
+	}
+	if !phases["queued"] || !phases["dequeued"] {
+		t.Fatalf("expected queued and dequeued events, got %#v", final["events"])
+	}
+}
+
+func TestSearchRunQueueOrdersByPriority(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_SEARCH_WORKERS", "1")
+	t.Setenv("VISA_MAX_CONCURRENT_RUNS_PER_USER", "5")
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	originalFactory := linkedInClientFactory
+	defer func() {
+		linkedInClientFactory = originalFactory
+	}()
+	linkedInClientFactory = func() linkedInClient {
+		return &fakeLinkedInClient{
+			pages:     map[int][]linkedInJob{0: {}},
+			pageDelay: 200 * time.Millisecond,
+		}
+	}
+
+	start := func(userID, priority string) map[string]any {
+		t.Helper()
+		args := map[string]any{
+			"user_id":      userID,
+			"location":     "New York, NY",
+			"job_title":    "Software Engineer",
+			"dataset_path": datasetPath,
+		}
+		if priority != "" {
+			args["priority"] = priority
+		}
+		started, err := StartJobSearch(args)
+		if err != nil {
+			t.Fatalf("StartJobSearch failed: %v", err)
+		}
+		return started
+	}
+
+	running := start("u1", "")
+	low := start("u2", "low")
+	high := start("u3", "high")
+	if got := intOrZero(high["queue_position"]); got != 1 {
+		t.Fatalf("expected high priority run at queue_position=1, got %d", got)
+	}
+	status, err := GetJobSearchStatus(map[string]any{"user_id": "u2", "run_id": getString(low, "run_id")})
+	if err != nil {
+		t.Fatalf("GetJobSearchStatus failed: %v", err)
+	}
+	if got := intOrZero(status["queue_position"]); got != 2 {
+		t.Fatalf("expected low priority run at queue_position=2, got %d", got)
+	}
+	if getString(status, "estimated_start_at_utc") == "" {
+		t.Fatalf("expected estimated_start_at_utc for queued run, got %#v", status)
+	}
+
+	waitForTerminalRunStatus(t, "u1", getString(running, "run_id"), 3*time.Second)
+	waitForTerminalRunStatus(t, "u3", getString(high, "run_id"), 3*time.Second)
+	waitForTerminalRunStatus(t, "u2", getString(low, "run_id"), 3*time.Second)
+
+	if _, err := StartJobSearch(map[string]any{
+		"user_id":   "u1",
+		"location":  "New York, NY",
+		"job_title": "Software Engineer",
+		"priority":  "urgent",
+	}); err == nil {
+		t.Fatal("expected invalid priority to fail")
+	}
+}
+
+func TestResumeSearchRunsFreesSlotsHeldBeforeRestart(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_MAX_CONCURRENT_RUNS_PER_USER", "1")
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	originalFactory := linkedInClientFactory
+	defer func() {
+		linkedInClientFactory = originalFactory
+	}()
+	linkedInClientFactory = func() linkedInClient {
+		return &fakeLinkedInClient{pages: map[int][]linkedInJob{0: {}}}
+	}
+
+	if err := saveSearchRuns(map[string]any{"runs": map[string]any{
+		"stale-run": map[string]any{
+			"status":         "running",
+			"dispatched":     true,
+			"query":          map[string]any{"user_id": "u1"},
+			"expires_at_utc": futureISO(3600),
+		},
+	}}); err != nil {
+		t.Fatalf("saveSearchRuns failed: %v", err)
+	}
+
+	ResumeSearchRuns()
+	stale, err := loadRunByID("stale-run")
+	if err != nil {
+		t.Fatalf("loadRunByID failed: %v", err)
+	}
+	if getString(stale, "status") != "failed" || getString(stale, "error_code") != searchErrorInterrupted {
+		t.Fatalf("expected stale run to fail as interrupted, got %#v", stale)
+	}
+
+	started, err := StartJobSearch(map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	})
+	if err != nil {
+		t.Fatalf("StartJobSearch failed: %v", err)
+	}
+	if queued, _ := started["queued"].(bool); queued {
+		t.Fatalf("expected the new run to start immediately, got %#v", started)
+	}
+	waitForTerminalRunStatus(t, "u1", getString(started, "run_id"), 3*time.Second)
+}
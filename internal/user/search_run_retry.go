@@ -0,0 +1,117 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSearchRunMaxAttempts         = 3
+	defaultSearchRunRetryBackoffSeconds = 30
+	maxSearchRunRetryBackoffSeconds     = 600
+)
+
+var transientSearchErrorMarkers = []string{
+	"timeout",
+	"timed out",
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"eof",
+	"temporary failure",
+	"status 500",
+	"status 502",
+	"status 503",
+	"status 504",
+}
+
+func searchRunMaxAttempts() int {
+	value := envInt("VISA_SEARCH_RUN_MAX_ATTEMPTS", defaultSearchRunMaxAttempts)
+	if value < 1 {
+		return 1
+	}
+	return value
+}
+
+// searchRunRetryDelaySeconds doubles the base backoff for every attempt that
+// has already failed, capped so a flaky upstream never parks a run for long.
+func searchRunRetryDelaySeconds(failedAttempts int) int {
+	delay := envInt("VISA_SEARCH_RUN_RETRY_BACKOFF_SECONDS", defaultSearchRunRetryBackoffSeconds)
+	if delay < 0 {
+		delay = 0
+	}
+	for i := 1; i < failedAttempts && delay < maxSearchRunRetryBackoffSeconds; i++ {
+		delay *= 2
+	}
+	return min(delay, maxSearchRunRetryBackoffSeconds)
+}
+
+func isTransientSearchError(err error) bool {
+	if err == nil || errors.Is(err, errSearchRunCancelled) {
+		return false
+	}
+	if isRateLimitError(err) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	text := strings.ToLower(err.Error())
+	for _, marker := range transientSearchErrorMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func runAwaitingRetry(run map[string]any) bool {
+	retryAt := parseISOTime(run["retry_not_before_utc"])
+	return !retryAt.IsZero() && utcNow().Before(retryAt)
+}
+
+// scheduleSearchRunRetryLocked puts a failed run back in the queue when the
+// error looks transient and attempts remain. The caller must hold the run
+// store lock and is responsible for waking the scheduler after the delay.
+func scheduleSearchRunRetryLocked(run map[string]any, err error) (time.Duration, bool) {
+	attempt := intOrZero(run["attempt_count"])
+	maxAttempts := searchRunMaxAttempts()
+	if boolOrFalse(run["cancel_requested"]) || !isTransientSearchError(err) || attempt >= maxAttempts {
+		return 0, false
+	}
+	delay := searchRunRetryDelaySeconds(attempt)
+	retryAt := futureISO(delay)
+	run["status"] = "pending"
+	run["dispatched"] = false
+	run["was_queued"] = true
+	run["error"] = err.Error()
+	run["error_code"] = classifySearchError(err)
+	run["retry_not_before_utc"] = retryAt
+	run["attempt_errors"] = append(listOrEmpty(run["attempt_errors"]), map[string]any{
+		"attempt":       attempt,
+		"error":         err.Error(),
+		"error_code":    run["error_code"],
+		"failed_at_utc": utcNowISO(),
+	})
+	appendRunEvent(run, "retry_scheduled", fmt.Sprintf(
+		"Attempt %d of %d failed with a transient error; retrying in %d seconds.",
+		attempt,
+		maxAttempts,
+		delay,
+	), -1, map[string]any{
+		"attempt":       attempt,
+		"max_attempts":  maxAttempts,
+		"error":         err.Error(),
+		"delay_seconds": delay,
+		"retry_at_utc":  retryAt,
+	})
+	return time.Duration(delay) * time.Second, true
+}
+
+func wakeSchedulerAfter(delay time.Duration) {
+	time.AfterFunc(delay, scheduleSearchRuns)
+}
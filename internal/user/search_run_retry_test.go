@@ -0,0 +1,155 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type flakyLinkedInClient struct {
+	fakeLinkedInClient
+	mu       sync.Mutex
+	failures int
+}
+
+func (f *flakyLinkedInClient) FetchSearchPage(query linkedInSearchQuery, isCancelled func() bool) ([]linkedInJob, error) {
+	f.mu.Lock()
+	if f.failures > 0 {
+		f.failures--
+		f.mu.Unlock()
+		return nil, errors.New("rate limited by upstream job source (429/Too Many Requests)")
+	}
+	f.mu.Unlock()
+	return f.fakeLinkedInClient.FetchSearchPage(query, isCancelled)
+}
+
+func startFlakySearch(t *testing.T, client *flakyLinkedInClient) string {
+	t.Helper()
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient { return client }
+
+	started, err := StartVisaJobSearch(map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	})
+	if err != nil {
+		t.Fatalf("StartVisaJobSearch failed: %v", err)
+	}
+	return getString(started, "run_id")
+}
+
+func TestSearchRunRetriesTransientFailures(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_SEARCH_RUN_RETRY_BACKOFF_SECONDS", "0")
+	t.Setenv("VISA_SEARCH_RUN_MAX_ATTEMPTS", "3")
+
+	client := &flakyLinkedInClient{failures: 1}
+	client.pages = map[int][]linkedInJob{0: acmeListingPage(1, 1)}
+	runID := startFlakySearch(t, client)
+
+	status := waitForTerminalRunStatus(t, "u1", runID, 3*time.Second)
+	if got := getString(status, "status"); got != "completed" {
+		t.Fatalf("expected retry to complete the run, got %#v", status)
+	}
+	if got := intOrZero(status["attempt_count"]); got != 2 {
+		t.Fatalf("expected attempt_count=2, got %d", got)
+	}
+	if got := len(listOrEmpty(status["attempt_errors"])); got != 1 {
+		t.Fatalf("expected 1 recorded attempt error, got %d", got)
+	}
+	retried := false
+	for _, raw := range listOrEmpty(status["events"]) {
+		if getString(mapOrNil(raw), "phase") == "retry_scheduled" {
+			retried = true
+		}
+	}
+	if !retried {
+		t.Fatal("expected a retry_scheduled event")
+	}
+}
+
+func TestSearchRunStopsRetryingAfterMaxAttempts(t *testing.T) {
+	setupUserToolPaths(t)
+	t.Setenv("VISA_SEARCH_RUN_RETRY_BACKOFF_SECONDS", "0")
+	t.Setenv("VISA_SEARCH_RUN_MAX_ATTEMPTS", "2")
+
+	runID := startFlakySearch(t, &flakyLinkedInClient{failures: 10})
+	status := waitForTerminalRunStatus(t, "u1", runID, 3*time.Second)
+	if got := getString(status, "status"); got != "failed" {
+		t.Fatalf("expected failed status, got %#v", status)
+	}
+	if got := intOrZero(status["attempt_count"]); got != 2 {
+		t.Fatalf("expected attempt_count=2, got %d", got)
+	}
+}
+
+func TestIsTransientSearchError(t *testing.T) {
+	cases := map[string]bool{
+		"linkedin request failed with status 503": true,
+		"read tcp: connection reset by peer":      true,
+		"linkedin request failed with status 404": false,
+		"job_title is required":                   false,
+	}
+	for text, want := range cases {
+		if got := isTransientSearchError(fmt.Errorf("%s", text)); got != want {
+			t.Fatalf("isTransientSearchError(%q)=%v, want %v", text, got, want)
+		}
+	}
+	if isTransientSearchError(errSearchRunCancelled) {
+		t.Fatal("cancellation must not be retried")
+	}
+	if got := searchRunRetryDelaySeconds(3); got != 120 {
+		t.Fatalf("expected third attempt backoff of 120s, got %d", got)
+	}
+}
+
+func TestResumeSearchRunsRearmsPendingRetries(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	originalFactory := linkedInClientFactory
+	t.Cleanup(func() { linkedInClientFactory = originalFactory })
+	linkedInClientFactory = func() linkedInClient {
+		return &fakeLinkedInClient{pages: map[int][]linkedInJob{0: acmeListingPage(1, 1)}}
+	}
+
+	parked := func(retryAt string) map[string]any {
+		return map[string]any{
+			"status":               "pending",
+			"dispatched":           false,
+			"was_queued":           true,
+			"attempt_count":        1,
+			"retry_not_before_utc": retryAt,
+			"queued_at_utc":        utcNowISO(),
+			"expires_at_utc":       futureISO(3600),
+			"query": map[string]any{
+				"user_id":      "u1",
+				"location":     "New York, NY",
+				"job_title":    "Software Engineer",
+				"dataset_path": datasetPath,
+			},
+		}
+	}
+	if err := saveSearchRuns(map[string]any{"runs": map[string]any{
+		"retry-due":   parked(futureISO(-60)),
+		"retry-later": parked(futureISO(2)),
+	}}); err != nil {
+		t.Fatalf("saveSearchRuns failed: %v", err)
+	}
+
+	ResumeSearchRuns()
+	for _, runID := range []string{"retry-due", "retry-later"} {
+		status := waitForTerminalRunStatus(t, "u1", runID, 5*time.Second)
+		if got := getString(status, "status"); got != "completed" {
+			t.Fatalf("expected %s to run after the restart, got %#v", runID, status)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"errors"
+	"time"
 )
 
 func runCancelled(runID string) bool {
@@ -20,6 +21,7 @@ func runCancelled(runID string) bool {
 func executeSearchRun(runID string) {
 	_ = updateRun(runID, func(run map[string]any) error {
 		run["status"] = "running"
+		run["attempt_count"] = intOrZero(run["attempt_count"]) + 1
 		appendRunEvent(run, "running", "Background search is running.", 2, nil)
 		return nil
 	})
@@ -29,6 +31,7 @@ func executeSearchRun(runID string) {
 		_ = updateRun(runID, func(record map[string]any) error {
 			record["status"] = "failed"
 			record["error"] = err.Error()
+			record["error_code"] = classifySearchError(err)
 			record["completed_at_utc"] = utcNowISO()
 			appendRunEvent(record, "failed", err.Error(), 100, nil)
 			return nil
@@ -54,9 +57,11 @@ func executeSearchRun(runID string) {
 		ScanMultiplier:           intOrZero(queryMap["scan_multiplier"]),
 		MaxScanResults:           intOrZero(queryMap["max_scan_results"]),
 	}
+	applySearchOptions(queryMap, &query)
 	if query.HoursOld < 1 {
 		query.HoursOld = defaultSearchHoursOld
 	}
+	query.HoursOld = postedRangeHoursOld(query.HoursOld, query.PostedAfter, utcNow())
 	if query.DatasetPath == "" {
 		query.DatasetPath = datasetPathOrDefault("")
 	}
@@ -79,6 +84,12 @@ func executeSearchRun(runID string) {
 		query.MaxScanResults = max(defaultSearchMaxScanResults, query.ResultsWanted)
 	}
 
+	query.OnPartialResults = func(jobs []map[string]any) {
+		_ = updateRun(runID, func(run map[string]any) error {
+			run["partial_response"] = partialResultsSnapshot(jobs)
+			return nil
+		})
+	}
 	progress := func(phase, detail string, pct float64, payload map[string]any) {
 		_ = updateRun(runID, func(run map[string]any) error {
 			appendRunEvent(run, phase, detail, pct, payload)
@@ -86,24 +97,45 @@ func executeSearchRun(runID string) {
 		})
 	}
 
-	response, stats, sessionID, err := executeSearchQuery(query, progress, func() bool {
-		return runCancelled(runID)
-	})
+	var response, stats map[string]any
+	var sessionID string
+	// An open circuit fails the run before it sends anything upstream.
+	err = upstreamUnavailableError()
+	if err == nil {
+		response, stats, sessionID, err = executeSearchQuery(query, progress, func() bool {
+			return runCancelled(runID)
+		})
+	}
 	if err != nil {
+		var retryDelay time.Duration
+		retrying := false
 		_ = updateRun(runID, func(run map[string]any) error {
+			delete(run, "partial_response")
 			if errors.Is(err, errSearchRunCancelled) || boolOrFalse(run["cancel_requested"]) {
 				run["status"] = "cancelled"
 				run["error"] = ""
+				run["error_code"] = searchErrorCancelled
 				run["completed_at_utc"] = utcNowISO()
 				appendRunEvent(run, "cancelled", "Search run cancelled.", 100, nil)
 				return nil
 			}
+			if retryDelay, retrying = scheduleSearchRunRetryLocked(run, err); retrying {
+				return nil
+			}
 			run["status"] = "failed"
 			run["error"] = err.Error()
+			run["error_code"] = classifySearchError(err)
 			run["completed_at_utc"] = utcNowISO()
-			appendRunEvent(run, "failed", err.Error(), 100, nil)
+			appendRunEvent(run, "failed", err.Error(), 100, map[string]any{
+				"attempt_count": intOrZero(run["attempt_count"]),
+				"transient":     isTransientSearchError(err),
+				"error_code":    run["error_code"],
+			})
 			return nil
 		})
+		if retrying {
+			wakeSchedulerAfter(retryDelay)
+		}
 		return
 	}
 	_ = updateRun(runID, func(run map[string]any) error {
@@ -113,6 +145,9 @@ func executeSearchRun(runID string) {
 		run["latest_stats"] = stats
 		run["completed_at_utc"] = utcNowISO()
 		run["error"] = ""
+		delete(run, "error_code")
+		delete(run, "retry_not_before_utc")
+		delete(run, "partial_response")
 		return nil
 	})
 }
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"fmt"
+	"time"
+)
+
+func parseRuntimeBudgetOption(args map[string]any, query map[string]any) error {
+	parsed, has, err := getOptionalInt(args, "max_runtime_seconds")
+	if !has {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("max_runtime_seconds must be an integer when provided")
+	}
+	if parsed < 1 {
+		return fmt.Errorf("max_runtime_seconds must be >= 1")
+	}
+	query["max_runtime_seconds"] = parsed
+	return nil
+}
+
+func runtimeDeadline(started time.Time, maxRuntimeSeconds int) time.Time {
+	if maxRuntimeSeconds < 1 {
+		return time.Time{}
+	}
+	return started.Add(time.Duration(maxRuntimeSeconds) * time.Second)
+}
+
+func runtimeBudgetExceeded(deadline time.Time) bool {
+	return !deadline.IsZero() && !time.Now().Before(deadline)
+}
+
+// earliestDeadline returns the sooner of two deadlines, treating zero as none.
+func earliestDeadline(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// runtimeCheckpoint records where a run stopped when it ran out of wall-clock
+// budget, so continue_job_search can resume from the same scan position.
+func runtimeCheckpoint(query searchQuery, started time.Time, scan listingScan, descriptionFetches, acceptedJobs int) map[string]any {
+	return map[string]any{
+		"max_runtime_seconds":  optionalPositiveInt(query.MaxRuntimeSeconds),
+		"elapsed_seconds":      int(time.Since(started).Seconds()),
+		"budget_hit":           scan.RuntimeBudgetHit || runtimeBudgetExceeded(query.RuntimeDeadline),
+		"scan_resume":          scan.Resume.toMap(),
+		"raw_jobs_scanned":     len(scan.Jobs),
+		"description_fetches":  descriptionFetches,
+		"accepted_jobs_so_far": acceptedJobs,
+	}
+}
@@ -0,0 +1,62 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMaxRuntimeSecondsCompletesWithCheckpoint(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	pages := map[int][]linkedInJob{}
+	for start := 0; start < 40; start += 2 {
+		pages[start] = acmeListingPage(start+1, 2)
+	}
+	client := &fakeLinkedInClient{pages: pages, pageDelay: 400 * time.Millisecond}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":             "u1",
+		"location":            "New York, NY",
+		"job_title":           "Software Engineer",
+		"dataset_path":        datasetPath,
+		"results_wanted":      40,
+		"max_runtime_seconds": 1,
+	})
+
+	stats := asMap(results["stats"])
+	checkpoint := asMap(stats["runtime_checkpoint"])
+	if !boolOrFalse(checkpoint["budget_hit"]) {
+		t.Fatalf("expected the runtime budget to be hit, got %#v", checkpoint)
+	}
+	resumeStart := intOrZero(asMap(checkpoint["scan_resume"])["start"])
+	if resumeStart < 2 || resumeStart >= 40 {
+		t.Fatalf("expected a mid-scan resume point, got %d", resumeStart)
+	}
+	if got := len(listOrEmpty(results["jobs"])); got == 0 || got >= 40 {
+		t.Fatalf("expected a partial set of accepted jobs, got %d", got)
+	}
+	suggested := false
+	for _, raw := range listOrEmpty(results["recovery_suggestions"]) {
+		if getString(mapOrNil(raw), "type") == "runtime_budget_reached" {
+			suggested = true
+		}
+	}
+	if !suggested {
+		t.Fatalf("expected a runtime_budget_reached suggestion, got %#v", results["recovery_suggestions"])
+	}
+}
+
+func TestParseRuntimeBudgetOption(t *testing.T) {
+	if err := parseRuntimeBudgetOption(map[string]any{"max_runtime_seconds": 0}, map[string]any{}); err == nil {
+		t.Fatal("expected max_runtime_seconds=0 to be rejected")
+	}
+	query := map[string]any{}
+	if err := parseRuntimeBudgetOption(map[string]any{"max_runtime_seconds": 90}, query); err != nil || query["max_runtime_seconds"] != 90 {
+		t.Fatalf("unexpected parse result %#v (%v)", query, err)
+	}
+	if earliestDeadline(time.Time{}, time.Unix(10, 0)) != time.Unix(10, 0) {
+		t.Fatal("expected a zero deadline to defer to the other")
+	}
+}
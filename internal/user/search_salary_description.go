@@ -0,0 +1,66 @@
+package user
+
+import "regexp"
+
+const (
+	salarySourceListingCard     = "listing_card"
+	salarySourceDescriptionText = "description_text"
+)
+
+const (
+	salaryCurrencyPrefix = `(?:[$€£₹]|\b(?:usd|eur|gbp|cad|aud|inr)\s?)\s?`
+	salaryAmount         = `\d[\d,]*(?:\.\d+)?\s*[kK]?`
+	salaryRangeJoin      = `\s*(?:-|–|—|to)\s*`
+	salaryCurrencyCode   = `\s*(?:usd|eur|gbp|cad|aud|inr)\b`
+	salaryIntervalSuffix = `(?:\s*(?:/\s*|per\s+|an\s+|a\s+)(?:hour|hr|year|yr|annum|month|mo|week|wk|day)|\s+(?:annually|hourly|yearly|monthly))?`
+)
+
+// descriptionSalaryPattern only matches amounts tied to a currency symbol or
+// code, so "401k" or "5+ years" in a description are never read as pay.
+var descriptionSalaryPattern = regexp.MustCompile(`(?i)` +
+	salaryCurrencyPrefix + salaryAmount + `(?:` + salaryRangeJoin + `(?:` + salaryCurrencyPrefix + `)?` + salaryAmount + `)?(?:` + salaryCurrencyCode + `)?` + salaryIntervalSuffix +
+	`|` + `\b` + salaryAmount + `(?:` + salaryRangeJoin + salaryAmount + `)?` + salaryCurrencyCode + salaryIntervalSuffix,
+)
+
+// salaryFromDescription finds the first plausible pay range in a fetched
+// description. Amounts without an interval are treated as yearly, so small
+// untagged figures such as "$50 gift card" fall under the yearly floor.
+func salaryFromDescription(description string) (jobCompensation, bool) {
+	for _, snippet := range descriptionSalaryPattern.FindAllString(description, -1) {
+		compensation, ok := parseCompensation(snippet)
+		if !ok || compensation.MinAmount == nil {
+			continue
+		}
+		top := *compensation.MinAmount
+		if compensation.MaxAmount != nil {
+			top = *compensation.MaxAmount
+		}
+		if compensation.Interval == "" {
+			compensation.Interval = "yearly"
+		}
+		if annualizeSalary(top, compensation.Interval) < 10000 {
+			continue
+		}
+		return compensation, true
+	}
+	return jobCompensation{}, false
+}
+
+// withDescriptionSalary fills compensation from the description when the
+// listing card had none.
+func withDescriptionSalary(job linkedInJob, description string) (linkedInJob, bool) {
+	if job.SalaryMin != nil || job.SalaryMax != nil {
+		return job, false
+	}
+	compensation, ok := salaryFromDescription(description)
+	if !ok {
+		return job, false
+	}
+	job.SalaryText = compensation.Text
+	job.SalaryCurrency = compensation.Currency
+	job.SalaryInterval = compensation.Interval
+	job.SalaryMin = compensation.MinAmount
+	job.SalaryMax = compensation.MaxAmount
+	job.SalarySource = salarySourceDescriptionText
+	return job, true
+}
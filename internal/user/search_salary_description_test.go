@@ -0,0 +1,96 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSalaryFromDescription(t *testing.T) {
+	cases := []struct {
+		text               string
+		min, max           int
+		interval, currency string
+	}{
+		{"The base salary range is $140,000 - $170,000 per year plus equity.", 140000, 170000, "yearly", "USD"},
+		{"Compensation: $55 to $65 per hour.", 55, 65, "hourly", "USD"},
+		{"Pay band 120k-150k USD depending on experience.", 120000, 150000, "yearly", "USD"},
+		{"Salary £60,000 annually.", 60000, 0, "yearly", "GBP"},
+	}
+	for _, tc := range cases {
+		got, ok := salaryFromDescription(tc.text)
+		if !ok {
+			t.Fatalf("expected salary in %q", tc.text)
+		}
+		if got.MinAmount == nil || *got.MinAmount != tc.min || got.Interval != tc.interval || got.Currency != tc.currency {
+			t.Fatalf("unexpected salary for %q: %+v", tc.text, got)
+		}
+		if tc.max > 0 && (got.MaxAmount == nil || *got.MaxAmount != tc.max) {
+			t.Fatalf("unexpected max for %q: %+v", tc.text, got)
+		}
+	}
+	for _, text := range []string{
+		"5+ years of experience and a 401k match.",
+		"We give every new hire a $50 gift card.",
+		"",
+	} {
+		if got, ok := salaryFromDescription(text); ok {
+			t.Fatalf("expected no salary in %q, got %+v", text, got)
+		}
+	}
+}
+
+func TestWithDescriptionSalaryKeepsListingCard(t *testing.T) {
+	card := linkedInJob{SalaryMin: intPtr(100000), SalarySource: salarySourceListingCard}
+	if _, found := withDescriptionSalary(card, "Salary $200,000 per year."); found {
+		t.Fatalf("expected listing card salary to win")
+	}
+	job, found := withDescriptionSalary(linkedInJob{}, "Salary $200,000 per year.")
+	if !found || job.SalarySource != salarySourceDescriptionText || *job.SalaryMin != 200000 {
+		t.Fatalf("expected description salary, got %+v", job)
+	}
+}
+
+func TestSearchUsesDescriptionSalaryForFilteringAndOutput(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	if _, err := SetUserPreferences(map[string]any{
+		"user_id":              "u1",
+		"preferred_visa_types": []any{"h1b"},
+	}); err != nil {
+		t.Fatalf("SetUserPreferences failed: %v", err)
+	}
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/gamma-high/", Title: "Software Engineer", Company: "Gamma Corp", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/gamma-low/", Title: "Software Engineer", Company: "Gamma Corp", Location: "New York, NY"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/gamma-high/": "We offer H-1B visa sponsorship. The salary range is $140,000 - $170,000 per year.",
+			"https://www.linkedin.com/jobs/view/gamma-low/":  "We offer H-1B visa sponsorship. The salary range is $60,000 - $80,000 per year.",
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":        "u1",
+		"location":       "New York, NY",
+		"job_title":      "Software Engineer",
+		"dataset_path":   datasetPath,
+		"results_wanted": 5,
+		"min_salary":     120000,
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 {
+		t.Fatalf("expected only the high-paying job, got %d", len(jobs))
+	}
+	job := mapOrNil(jobs[0])
+	if getString(job, "salary_source") != salarySourceDescriptionText || intOrZero(job["salary_min_amount"]) != 140000 {
+		t.Fatalf("expected description salary on job, got %v", job)
+	}
+	stats := asMap(results["stats"])
+	if intOrZero(stats["description_salary_found"]) != 2 || intOrZero(stats["salary_filtered_out"]) != 1 {
+		t.Fatalf("unexpected salary stats: %v", stats)
+	}
+}
@@ -0,0 +1,62 @@
+package user
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// salaryIntervalsPerYear converts a salary interval to yearly pay assuming a
+// standard 40-hour, 52-week year.
+var salaryIntervalsPerYear = map[string]float64{
+	"hourly":  2080,
+	"daily":   260,
+	"weekly":  52,
+	"monthly": 12,
+	"yearly":  1,
+}
+
+func normalizeSalaryInterval(raw string) (string, error) {
+	clean := strings.ToLower(strings.TrimSpace(raw))
+	if clean == "" {
+		return "yearly", nil
+	}
+	if _, ok := salaryIntervalsPerYear[clean]; !ok {
+		return "", fmt.Errorf("salary_interval must be one of [daily hourly monthly weekly yearly]")
+	}
+	return clean, nil
+}
+
+func annualizeSalary(amount int, interval string) int {
+	factor, ok := salaryIntervalsPerYear[interval]
+	if !ok {
+		factor = 1
+	}
+	return int(math.Round(float64(amount) * factor))
+}
+
+// jobYearlySalaryCeiling returns the highest yearly pay a listing advertises,
+// or false when the card carried no parseable compensation.
+func jobYearlySalaryCeiling(job linkedInJob) (int, bool) {
+	amount := job.SalaryMax
+	if amount == nil {
+		amount = job.SalaryMin
+	}
+	if amount == nil {
+		return 0, false
+	}
+	return annualizeSalary(*amount, job.SalaryInterval), true
+}
+
+// salaryBelowMinimum reports whether a listing's advertised range tops out
+// below the requested minimum. Listings without compensation are kept.
+func salaryBelowMinimum(job linkedInJob, minSalary int, interval string) bool {
+	if minSalary < 1 {
+		return false
+	}
+	ceiling, ok := jobYearlySalaryCeiling(job)
+	if !ok {
+		return false
+	}
+	return ceiling < annualizeSalary(minSalary, interval)
+}
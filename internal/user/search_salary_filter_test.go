@@ -0,0 +1,61 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSalaryBelowMinimumAnnualizesIntervals(t *testing.T) {
+	hourly := linkedInJob{SalaryMin: intPtr(40), SalaryMax: intPtr(55), SalaryInterval: "hourly"}
+	if salaryBelowMinimum(hourly, 100000, "yearly") {
+		t.Fatalf("expected $55/hr (~$114k/yr) to clear a $100k minimum")
+	}
+	if !salaryBelowMinimum(hourly, 60, "hourly") {
+		t.Fatalf("expected $55/hr to fall below a $60/hr minimum")
+	}
+	if salaryBelowMinimum(linkedInJob{}, 100000, "yearly") {
+		t.Fatalf("expected listings without compensation to be kept")
+	}
+	if _, err := normalizeSalaryInterval("fortnightly"); err == nil {
+		t.Fatalf("expected unknown salary_interval to be rejected")
+	}
+}
+
+func TestMinSalaryFiltersLowPayingJobs(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/high-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY", SalaryMin: intPtr(150000), SalaryMax: intPtr(180000), SalaryInterval: "yearly"},
+				{JobURL: "https://www.linkedin.com/jobs/view/low-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY", SalaryMin: intPtr(70000), SalaryMax: intPtr(90000), SalaryInterval: "yearly"},
+				{JobURL: "https://www.linkedin.com/jobs/view/none-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+			},
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":        "u1",
+		"location":       "New York, NY",
+		"job_title":      "Software Engineer",
+		"dataset_path":   datasetPath,
+		"results_wanted": 5,
+		"min_salary":     120000,
+	})
+	if got := len(listOrEmpty(results["jobs"])); got != 2 {
+		t.Fatalf("expected high-paying and unknown-salary jobs, got %d", got)
+	}
+	if got := intOrZero(asMap(results["stats"])["salary_filtered_out"]); got != 1 {
+		t.Fatalf("expected salary_filtered_out=1, got %d", got)
+	}
+
+	if _, err := StartVisaJobSearch(map[string]any{
+		"user_id":         "u1",
+		"location":        "New York, NY",
+		"job_title":       "Software Engineer",
+		"salary_interval": "hourly",
+	}); err == nil {
+		t.Fatalf("expected salary_interval without min_salary to fail")
+	}
+}
@@ -0,0 +1,206 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+)
+
+const maxLinkedInStart = 1000
+
+// linkedInScanSliceHours are the narrower posting-age windows used to re-run a
+// query once LinkedIn's 1000-result start cap truncates the full scan.
+var linkedInScanSliceHours = []int{24, 72, 168, 336, 720}
+
+type listingScan struct {
+	Jobs           []linkedInJob
+	PageCardCounts []int
+	Exhausted      bool
+	CapHit         bool
+	Slices         []map[string]any
+	Resume         scanResume
+	PageRetries    int
+	PagesFailed    int
+	PageFailure    string
+	// RuntimeBudgetHit is set when max_runtime_seconds stopped the scan.
+	RuntimeBudgetHit bool
+}
+
+// stoppedEarly reports whether the scan ended before running out of listings
+// for a reason other than reaching the target.
+func (s listingScan) stoppedEarly() bool {
+	return s.PagesFailed > 0 || s.RuntimeBudgetHit
+}
+
+// scanResume is where a later continue_job_search picks the scan back up.
+type scanResume struct {
+	HoursOld int
+	Start    int
+}
+
+func (r scanResume) toMap() map[string]any {
+	return map[string]any{"hours_old": r.HoursOld, "start": r.Start}
+}
+
+func scanResumeFromMap(raw map[string]any) scanResume {
+	return scanResume{HoursOld: intOrZero(raw["hours_old"]), Start: intOrZero(raw["start"])}
+}
+
+func scanSliceHours(hoursOld int) []int {
+	out := []int{}
+	for _, hours := range linkedInScanSliceHours {
+		if hours < hoursOld {
+			out = append(out, hours)
+		}
+	}
+	return out
+}
+
+func scanSliceLabel(hoursOld int, primary bool) string {
+	if primary {
+		return "full"
+	}
+	return fmt.Sprintf("last_%dh", hoursOld)
+}
+
+// scanLinkedInListings pages through LinkedIn results for the query. When the
+// full query hits the start cap before reaching the scan target it re-runs the
+// query over narrower hours_old windows, deduping by job URL across slices.
+// A non-zero resume point continues an earlier scan, and seenURLs lets the
+// caller skip jobs it already has. Pages are retried on transient errors; if
+// one still fails after some listings were collected, the scan stops there
+// and keeps them.
+func scanLinkedInListings(
+	client linkedInClient,
+	query searchQuery,
+	target int,
+	resume scanResume,
+	seenURLs map[string]struct{},
+	onProgress func(phase, detail string, progress float64, payload map[string]any),
+	isCancelled func() bool,
+) (listingScan, error) {
+	scan := listingScan{Jobs: []linkedInJob{}, PageCardCounts: []int{}, Slices: []map[string]any{}}
+	if seenURLs == nil {
+		seenURLs = map[string]struct{}{}
+	}
+	if resume.HoursOld < 1 {
+		resume.HoursOld = query.HoursOld
+	}
+
+	runSlice := func(hoursOld, start int, primary bool) (bool, error) {
+		slice := map[string]any{
+			"slice":     scanSliceLabel(hoursOld, primary && hoursOld == query.HoursOld),
+			"hours_old": hoursOld,
+			"start":     start,
+			"pages":     0,
+			"raw_added": 0,
+			"cap_hit":   false,
+			"exhausted": false,
+		}
+		scan.Slices = append(scan.Slices, slice)
+		defer func() {
+			scan.Resume = scanResume{HoursOld: hoursOld, Start: start}
+		}()
+		for len(scan.Jobs) < target {
+			if start > maxLinkedInStart {
+				slice["cap_hit"] = true
+				return true, nil
+			}
+			if isCancelled() {
+				return false, errSearchRunCancelled
+			}
+			if runtimeBudgetExceeded(query.RuntimeDeadline) {
+				scan.RuntimeBudgetHit = true
+				slice["runtime_budget_hit"] = true
+				return false, nil
+			}
+			pageJobs, retries, err := fetchSearchPageWithRetry(client, linkedInSearchQuery{
+				JobTitle:       query.JobTitle,
+				Location:       query.Location,
+				GeoID:          query.GeoID,
+				HoursOld:       hoursOld,
+				Start:          start,
+				WorkplaceTypes: query.WorkplaceTypes,
+				JobTypes:       query.JobTypes,
+				JobLevels:      query.JobLevels,
+				BypassCache:    query.RefreshSession,
+			}, isCancelled)
+			scan.PageRetries += retries
+			if err != nil {
+				if !tolerablePageFailure(err, len(scan.Jobs)) {
+					return false, err
+				}
+				scan.PagesFailed++
+				scan.PageFailure = err.Error()
+				slice["page_failed"] = true
+				return false, nil
+			}
+			if len(pageJobs) == 0 {
+				slice["exhausted"] = true
+				return false, nil
+			}
+			scan.PageCardCounts = append(scan.PageCardCounts, len(pageJobs))
+			slice["pages"] = intOrZero(slice["pages"]) + 1
+			added := 0
+			for _, job := range pageJobs {
+				key := strings.ToLower(strings.TrimSpace(job.JobURL))
+				if key == "" {
+					continue
+				}
+				if _, exists := seenURLs[key]; exists {
+					continue
+				}
+				seenURLs[key] = struct{}{}
+				scan.Jobs = append(scan.Jobs, job)
+				added++
+				if len(scan.Jobs) >= target {
+					break
+				}
+			}
+			slice["raw_added"] = intOrZero(slice["raw_added"]) + added
+			// Narrower slices overlap results already collected, so only the
+			// primary query treats an all-duplicate page as the end of results.
+			if added == 0 && primary {
+				slice["exhausted"] = true
+				return false, nil
+			}
+			start += len(pageJobs)
+			progress := 15.0 + (60.0 * float64(len(scan.Jobs)) / float64(max(1, target)))
+			onProgress("scrape", "Collected LinkedIn pages.", progress, map[string]any{
+				"raw_jobs_scanned": len(scan.Jobs),
+			})
+		}
+		return false, nil
+	}
+
+	capHit, err := runSlice(resume.HoursOld, resume.Start, true)
+	if err != nil {
+		return scan, err
+	}
+	if capHit && !scan.stoppedEarly() {
+		scan.CapHit = true
+		for _, hours := range scanSliceHours(query.HoursOld) {
+			if resume.HoursOld < query.HoursOld && hours <= resume.HoursOld {
+				continue
+			}
+			if len(scan.Jobs) >= target {
+				break
+			}
+			progress := 15.0 + (60.0 * float64(len(scan.Jobs)) / float64(max(1, target)))
+			onProgress("scrape", "LinkedIn result cap reached; slicing the query by posting age.", progress, map[string]any{
+				"slice":            scanSliceLabel(hours, false),
+				"raw_jobs_scanned": len(scan.Jobs),
+			})
+			if _, err := runSlice(hours, 0, false); err != nil {
+				return scan, err
+			}
+			if scan.stoppedEarly() {
+				break
+			}
+		}
+	}
+	// A failed page or spent runtime budget leaves the resume point where the
+	// scan stopped, so it is not exhausted and continue_job_search can pick it
+	// up again.
+	scan.Exhausted = len(scan.Jobs) < target && !scan.stoppedEarly()
+	return scan, nil
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"fmt"
+	"testing"
+)
+
+// slicedLinkedInClient serves a distinct result set per hours_old window so
+// narrower slices surface jobs beyond the full query's start cap.
+type slicedLinkedInClient struct {
+	pageSize int
+	calls    map[int]int
+}
+
+func (c *slicedLinkedInClient) FetchSearchPage(query linkedInSearchQuery, _ func() bool) ([]linkedInJob, error) {
+	c.calls[query.HoursOld]++
+	if query.HoursOld != 336 && query.Start >= 2*c.pageSize {
+		return []linkedInJob{}, nil
+	}
+	out := []linkedInJob{}
+	for idx := 0; idx < c.pageSize; idx++ {
+		out = append(out, linkedInJob{
+			JobURL:  fmt.Sprintf("https://www.linkedin.com/jobs/view/h%d-%d/", query.HoursOld, query.Start+idx),
+			Title:   "Software Engineer",
+			Company: "Acme Inc",
+		})
+	}
+	return out, nil
+}
+
+func (c *slicedLinkedInClient) FetchJobDetails(string, string, string, func() bool) (linkedInJobDetails, error) {
+	return linkedInJobDetails{}, nil
+}
+
+func TestScanSlicesPastLinkedInStartCap(t *testing.T) {
+	client := &slicedLinkedInClient{pageSize: 100, calls: map[int]int{}}
+	query := searchQuery{JobTitle: "Software Engineer", Location: "United States", HoursOld: 336}
+	noProgress := func(string, string, float64, map[string]any) {}
+
+	scan, err := scanLinkedInListings(client, query, 1350, scanResume{}, nil, noProgress, func() bool { return false })
+	if err != nil {
+		t.Fatalf("scanLinkedInListings failed: %v", err)
+	}
+	if !scan.CapHit {
+		t.Fatalf("expected start cap to be hit")
+	}
+	if len(scan.Jobs) != 1350 || scan.Exhausted {
+		t.Fatalf("expected slices to reach 1350 jobs, got %d (exhausted=%v)", len(scan.Jobs), scan.Exhausted)
+	}
+	if len(scan.Slices) != 3 {
+		t.Fatalf("expected full + 24h + 72h slices, got %#v", scan.Slices)
+	}
+	if got := getString(scan.Slices[1], "slice"); got != "last_24h" {
+		t.Fatalf("expected first narrower slice last_24h, got %q", got)
+	}
+	if got := intOrZero(scan.Slices[0]["raw_added"]); got != 1100 {
+		t.Fatalf("expected full slice to stop at the cap with 1100 jobs, got %d", got)
+	}
+	if client.calls[168] != 0 {
+		t.Fatalf("expected scan to stop once the target was met")
+	}
+}
+
+func TestScanDoesNotSliceWhenResultsExhausted(t *testing.T) {
+	client := &fakeLinkedInClient{pages: map[int][]linkedInJob{
+		0: {{JobURL: "https://www.linkedin.com/jobs/view/only-1/", Title: "Software Engineer"}},
+	}}
+	query := searchQuery{JobTitle: "Software Engineer", Location: "United States", HoursOld: 336}
+	scan, err := scanLinkedInListings(client, query, 50, scanResume{}, nil, func(string, string, float64, map[string]any) {}, func() bool { return false })
+	if err != nil {
+		t.Fatalf("scanLinkedInListings failed: %v", err)
+	}
+	if scan.CapHit || len(scan.Slices) != 1 || !scan.Exhausted {
+		t.Fatalf("expected a single exhausted slice, got %#v", scan)
+	}
+}
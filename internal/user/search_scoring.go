@@ -0,0 +1,126 @@
+package user
+
+import "math"
+
+// confidenceFeatures are the inputs of the v2 confidence model. Recency,
+// ApprovalRate, LocationMatch, and TitleMatch are already scaled to 0..1,
+// with 1 meaning full credit or no data to judge by. DatasetFactor scales
+// the dataset-derived terms (company tier and visa priority weights).
+type confidenceFeatures struct {
+	DesiredCount        int
+	TotalCount          int
+	Recency             float64
+	ApprovalRate        float64
+	LocationMatch       float64
+	TitleMatch          float64
+	DescriptionPositive bool
+	DescriptionNegative bool
+	DesiredMention      bool
+	MobilityBenefit     bool
+	HeuristicMatch      bool
+	DatasetFactor       float64
+}
+
+type confidenceContribution struct {
+	Feature      string
+	Value        any
+	Weight       float64
+	Contribution float64
+}
+
+// confidenceBreakdown is the v2 score with the contribution of each
+// feature; the contributions add up to the score before it is clamped.
+type confidenceBreakdown struct {
+	Score         float64
+	DatasetFactor float64
+	Features      []confidenceContribution
+}
+
+func (b confidenceBreakdown) toMap() map[string]any {
+	features := make([]map[string]any, 0, len(b.Features))
+	for _, feature := range b.Features {
+		features = append(features, map[string]any{
+			"feature":      feature.Feature,
+			"value":        feature.Value,
+			"weight":       feature.Weight,
+			"contribution": feature.Contribution,
+		})
+	}
+	return map[string]any{
+		"score":          b.Score,
+		"dataset_factor": b.DatasetFactor,
+		"features":       features,
+	}
+}
+
+func roundScore(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// titleMatchValue is full credit when every requested title token is in the
+// job title (or no title was requested) and half credit for a partial match.
+func titleMatchValue(requestedTitle, jobTitle string) float64 {
+	switch {
+	case normalizeWhitespace(requestedTitle) == "" || strongTitleMatch(requestedTitle, jobTitle):
+		return 1
+	case jobMatchesRequestedTitle(requestedTitle, jobTitle):
+		return 0.5
+	}
+	return 0
+}
+
+// scoreConfidence is the v2 weighted-feature model behind confidence_score.
+// The dataset terms only count when the employer filed for a desired visa;
+// otherwise other filings earn other_visa_weight.
+func scoreConfidence(f confidenceFeatures, weights rankingWeights) confidenceBreakdown {
+	out := confidenceBreakdown{DatasetFactor: f.DatasetFactor}
+	add := func(feature string, value any, weight, credit float64) {
+		out.Features = append(out.Features, confidenceContribution{
+			Feature:      feature,
+			Value:        value,
+			Weight:       weight,
+			Contribution: roundScore(weight * credit),
+		})
+	}
+	sponsor := f.DesiredCount > 0
+	datasetCredit := func(value float64) float64 {
+		if !sponsor {
+			return 0
+		}
+		return value * f.DatasetFactor
+	}
+	datasetPresence := 0.0
+	if sponsor {
+		datasetPresence = 1
+	}
+	add("dataset_count", f.DesiredCount, weights.DatasetWeight, datasetCredit(datasetPresence))
+	add("dataset_volume", f.DesiredCount, weights.DatasetVolumeWeight, datasetCredit(math.Min(1, float64(f.DesiredCount)/50.0)))
+	add("recency", f.Recency, weights.RecencyWeight, datasetCredit(f.Recency))
+	add("approval_rate", f.ApprovalRate, weights.ApprovalRateWeight, datasetCredit(f.ApprovalRate))
+	add("location_match", f.LocationMatch, weights.LocationMatchWeight, datasetCredit(f.LocationMatch))
+	otherVisa := 0.0
+	if !sponsor && f.TotalCount > 0 {
+		otherVisa = f.DatasetFactor
+	}
+	add("other_visa_filings", f.TotalCount, weights.OtherVisaWeight, otherVisa)
+	add("title_match", f.TitleMatch, weights.TitleMatchWeight, f.TitleMatch)
+	add("description_positive", f.DescriptionPositive, weights.DescriptionWeight, boolCredit(f.DescriptionPositive))
+	add("description_desired_mention", f.DesiredMention, weights.DesiredMentionWeight, boolCredit(f.DesiredMention))
+	add("description_negative", f.DescriptionNegative, -weights.NegativePenalty, boolCredit(f.DescriptionNegative))
+	add("mobility_benefit", f.MobilityBenefit, weights.BenefitsWeight, boolCredit(f.MobilityBenefit))
+	add("visa_heuristics", f.HeuristicMatch, heuristicVisaConfidence, boolCredit(f.HeuristicMatch))
+
+	score := 0.0
+	for _, feature := range out.Features {
+		score += feature.Contribution
+	}
+	out.Score = roundScore(math.Max(0, math.Min(1, score)))
+	return out
+}
+
+func boolCredit(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,32 @@
+package user
+
+import "strings"
+
+// previouslySeenJobURLs collects job URLs the user has already been shown in
+// other live search sessions or is tracking in the job pipeline.
+func previouslySeenJobURLs(userID, excludeSessionID string) map[string]struct{} {
+	seen := map[string]struct{}{}
+	add := func(jobURL string) {
+		if key := strings.ToLower(strings.TrimSpace(jobURL)); key != "" {
+			seen[key] = struct{}{}
+		}
+	}
+	_ = withSearchSessionStore(false, func(store map[string]any) error {
+		for sessionID, raw := range mapOrNil(store["sessions"]) {
+			session := mapOrNil(raw)
+			if sessionID == excludeSessionID || getString(mapOrNil(session["query"]), "user_id") != userID {
+				continue
+			}
+			for _, entry := range asMap(session["result_id_index"]) {
+				add(getString(mapOrNil(entry), "job_url"))
+			}
+		}
+		return nil
+	})
+	if entry := getPipelineEntry(loadJobPipeline(), userID); entry != nil {
+		for _, row := range entry["jobs"].([]map[string]any) {
+			add(getString(row, "job_url"))
+		}
+	}
+	return seen
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHidePreviouslySeenSkipsEarlierResultsAndPipelineJobs(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	args := map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	}
+
+	client := &fakeLinkedInClient{pages: map[int][]linkedInJob{0: acmeListingPage(1, 2)}}
+	first := runFakeVisaSearch(t, client, args)
+	for _, raw := range listOrEmpty(first["jobs"]) {
+		if boolOrFalse(mapOrNil(raw)["previously_seen"]) {
+			t.Fatalf("expected nothing previously seen on the first run, got %#v", raw)
+		}
+	}
+	if _, err := MarkJobApplied(map[string]any{
+		"user_id": "u1",
+		"job_url": "https://www.linkedin.com/jobs/view/acme-3/",
+	}); err != nil {
+		t.Fatalf("MarkJobApplied failed: %v", err)
+	}
+
+	client.pages[0] = acmeListingPage(1, 4)
+	args["hide_previously_seen"] = true
+	hidden := runFakeVisaSearch(t, client, args)
+	jobs := listOrEmpty(hidden["jobs"])
+	if len(jobs) != 1 || getString(mapOrNil(jobs[0]), "job_url") != "https://www.linkedin.com/jobs/view/acme-4/" {
+		t.Fatalf("expected only the unseen acme-4 listing, got %#v", jobs)
+	}
+	if got := intOrZero(asMap(hidden["stats"])["previously_seen_skipped"]); got != 3 {
+		t.Fatalf("expected previously_seen_skipped=3, got %d", got)
+	}
+
+	args["hide_previously_seen"] = false
+	flagged := runFakeVisaSearch(t, client, args)
+	seenCount := 0
+	for _, raw := range listOrEmpty(flagged["jobs"]) {
+		if boolOrFalse(mapOrNil(raw)["previously_seen"]) {
+			seenCount++
+		}
+	}
+	if seenCount != 4 {
+		t.Fatalf("expected all 4 jobs flagged previously_seen, got %d", seenCount)
+	}
+
+	if _, err := StartVisaJobSearch(map[string]any{
+		"user_id":              "u1",
+		"location":             "New York, NY",
+		"job_title":            "Software Engineer",
+		"hide_previously_seen": "yes",
+	}); err == nil {
+		t.Fatal("expected non-boolean hide_previously_seen to fail")
+	}
+}
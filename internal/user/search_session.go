@@ -80,7 +80,11 @@ func buildResultIndex(jobs []map[string]any) map[string]any {
 			"job_function":             getString(job, "job_function"),
 			"job_url_direct":           getString(job, "job_url_direct"),
 			"is_remote":                job["is_remote"],
+			"workplace_type":           job["workplace_type"],
+			"constraint_effects":       listOrEmpty(job["constraint_effects"]),
+			"constraint_mismatch":      boolOrFalse(job["constraint_mismatch"]),
 			"employer_contacts":        listOrEmpty(job["employer_contacts"]),
+			"company_facts":            listOrEmpty(job["company_facts"]),
 			"visa_counts":              asMap(job["visa_counts"]),
 			"visas_sponsored":          listOrEmpty(job["visas_sponsored"]),
 			"visa_match_strength":      getString(job, "visa_match_strength"),
@@ -98,12 +102,14 @@ func saveSearchSessionRecord(
 	acceptedJobs []map[string]any,
 	scanExhausted bool,
 	rawScanTarget int,
+	resume scanResume,
 ) (map[string]any, error) {
 	sessionID := newRunID()
 	now := utcNowISO()
 	expiresAt := futureISO(searchSessionTTLSeconds())
-	accepted := attachResultIDs(sessionID, acceptedJobs)
-	index := buildResultIndex(accepted)
+	withIDs := attachResultIDs(sessionID, acceptedJobs)
+	index := buildResultIndex(withIDs)
+	accepted, collapsed := collapseJobsByCompany(withIDs, query.MaxResultsPerCompany)
 
 	record := map[string]any{
 		"created_at_utc": now,
@@ -122,6 +128,7 @@ func saveSearchSessionRecord(
 			"require_description_signal": query.RequireDescriptionSignal,
 			"strictness_mode":            query.StrictnessMode,
 			"preferred_visa_types":       desiredVisaTypes,
+			"max_results_per_company":    query.MaxResultsPerCompany,
 		},
 		"accepted_jobs": func() []any {
 			out := []any{}
@@ -130,10 +137,12 @@ func saveSearchSessionRecord(
 			}
 			return out
 		}(),
-		"result_id_index":     index,
-		"accepted_jobs_total": len(accepted),
-		"latest_scan_target":  rawScanTarget,
-		"scan_exhausted":      scanExhausted,
+		"result_id_index":      index,
+		"accepted_jobs_total":  len(accepted),
+		"collapsed_jobs_total": collapsed,
+		"latest_scan_target":   rawScanTarget,
+		"scan_exhausted":       scanExhausted,
+		"scan_resume":          resume.toMap(),
 	}
 
 	err := withSearchSessionStore(true, func(store map[string]any) error {
@@ -150,10 +159,11 @@ func saveSearchSessionRecord(
 		return nil, err
 	}
 	return map[string]any{
-		"session_id":      sessionID,
-		"expires_at_utc":  expiresAt,
-		"accepted_jobs":   accepted,
-		"result_id_index": index,
+		"session_id":           sessionID,
+		"expires_at_utc":       expiresAt,
+		"accepted_jobs":        accepted,
+		"result_id_index":      index,
+		"collapsed_jobs_total": collapsed,
 	}, nil
 }
 
@@ -188,7 +198,9 @@ func sliceAcceptedJobs(
 	rawScanTarget int,
 	maxScanResults int,
 	scanExhausted bool,
+	sortBy string,
 ) (page []map[string]any, pagination map[string]any) {
+	accepted = sortAcceptedJobs(accepted, sortBy)
 	safeOffset := offset
 	if safeOffset < 0 {
 		safeOffset = 0
@@ -223,6 +235,7 @@ func sliceAcceptedJobs(
 		"requested_scan_target":         rawScanTarget,
 		"max_scan_results":              maxScanResults,
 		"scan_exhausted":                scanExhausted,
+		"sort_by":                       optionalString(sortBy),
 	}
 	return page, pagination
 }
@@ -0,0 +1,143 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxVisaSignalPatterns      = 25
+	maxVisaSignalPatternLength = 200
+)
+
+// visaSignalPatterns are a user's own description patterns, checked after
+// the built-in sets in search_eval.go. They only add signals: a custom
+// positive cannot cancel built-in negative language, and the reverse.
+type visaSignalPatterns struct {
+	Positive []*regexp.Regexp
+	Negative []*regexp.Regexp
+}
+
+// customSignals is what the user's patterns found in one description.
+// The user wrote the positive patterns for their own visa, so a positive
+// match also counts as a mention of a desired visa type.
+type customSignals struct {
+	Positive bool
+	Negative bool
+	Matched  []string
+}
+
+func (p visaSignalPatterns) match(description string) customSignals {
+	out := customSignals{Matched: []string{}}
+	if normalizeWhitespace(description) == "" {
+		return out
+	}
+	for _, rx := range p.Positive {
+		if rx.MatchString(description) {
+			out.Positive = true
+			out.Matched = append(out.Matched, "positive: "+strings.TrimPrefix(rx.String(), "(?i)"))
+		}
+	}
+	for _, rx := range p.Negative {
+		if rx.MatchString(description) {
+			out.Negative = true
+			out.Matched = append(out.Matched, "negative: "+strings.TrimPrefix(rx.String(), "(?i)"))
+		}
+	}
+	return out
+}
+
+// compileVisaSignalPatterns validates patterns as Go regular expressions;
+// matching is case-insensitive like the built-in sets.
+func compileVisaSignalPatterns(field string, values []string) ([]string, []*regexp.Regexp, error) {
+	if len(values) > maxVisaSignalPatterns {
+		return nil, nil, fmt.Errorf("%s accepts at most %d patterns", field, maxVisaSignalPatterns)
+	}
+	patterns := []string{}
+	compiled := []*regexp.Regexp{}
+	for _, value := range values {
+		pattern := strings.TrimSpace(value)
+		if pattern == "" {
+			continue
+		}
+		if len(pattern) > maxVisaSignalPatternLength {
+			return nil, nil, fmt.Errorf("%s patterns must be at most %d characters", field, maxVisaSignalPatternLength)
+		}
+		rx, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s pattern %q is not a valid regular expression: %w", field, pattern, err)
+		}
+		patterns = append(patterns, pattern)
+		compiled = append(compiled, rx)
+	}
+	return patterns, compiled, nil
+}
+
+// loadVisaSignalPatterns reads the patterns stored by set_visa_signal_patterns.
+// Stored patterns were validated on save; any that no longer compile are
+// skipped.
+func loadVisaSignalPatterns(userID string) visaSignalPatterns {
+	out := visaSignalPatterns{}
+	if strings.TrimSpace(userID) == "" {
+		return out
+	}
+	prefs, err := loadPrefs()
+	if err != nil {
+		return out
+	}
+	stored := asMap(asMap(prefs[userID])["visa_signal_patterns"])
+	for _, pattern := range getStringList(stored, "positive_patterns") {
+		if _, compiled, err := compileVisaSignalPatterns("positive_patterns", []string{pattern}); err == nil {
+			out.Positive = append(out.Positive, compiled...)
+		}
+	}
+	for _, pattern := range getStringList(stored, "negative_patterns") {
+		if _, compiled, err := compileVisaSignalPatterns("negative_patterns", []string{pattern}); err == nil {
+			out.Negative = append(out.Negative, compiled...)
+		}
+	}
+	return out
+}
+
+func SetVisaSignalPatterns(args map[string]any) (map[string]any, error) {
+	uid := getString(args, "user_id")
+	if uid == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if !hasKey(args, "positive_patterns") && !hasKey(args, "negative_patterns") {
+		return nil, fmt.Errorf("provide positive_patterns, negative_patterns, or both")
+	}
+
+	prefs, err := loadPrefs()
+	if err != nil {
+		return nil, err
+	}
+	user := prefs[uid]
+	if user == nil {
+		user = map[string]any{}
+	}
+	stored := asMap(user["visa_signal_patterns"])
+	for _, field := range []string{"positive_patterns", "negative_patterns"} {
+		if !hasKey(args, field) {
+			continue
+		}
+		patterns, _, err := compileVisaSignalPatterns(field, getStringList(args, field))
+		if err != nil {
+			return nil, err
+		}
+		stored[field] = patterns
+	}
+	stored["updated_at_utc"] = utcNowISO()
+	user["visa_signal_patterns"] = stored
+	prefs[uid] = user
+	if err := savePrefs(prefs); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"user_id":              uid,
+		"visa_signal_patterns": stored,
+		"path":                 prefsPath(),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package user
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSetVisaSignalPatternsValidatesAndReplacesLists(t *testing.T) {
+	setupUserToolPaths(t)
+	if _, err := SetVisaSignalPatterns(map[string]any{"user_id": "u1", "positive_patterns": []any{"(unclosed"}}); err == nil {
+		t.Fatalf("expected an invalid regular expression to be rejected")
+	}
+	if _, err := SetVisaSignalPatterns(map[string]any{"user_id": "u1"}); err == nil {
+		t.Fatalf("expected a call without patterns to be rejected")
+	}
+	if _, err := SetVisaSignalPatterns(map[string]any{
+		"user_id":           "u1",
+		"positive_patterns": []any{"will transfer h-?1b", "global mobility program"},
+		"negative_patterns": []any{"us citizens only"},
+	}); err != nil {
+		t.Fatalf("SetVisaSignalPatterns failed: %v", err)
+	}
+	result, err := SetVisaSignalPatterns(map[string]any{"user_id": "u1", "negative_patterns": []any{}})
+	if err != nil {
+		t.Fatalf("SetVisaSignalPatterns failed: %v", err)
+	}
+	stored := asMap(result["visa_signal_patterns"])
+	if len(getStringList(stored, "positive_patterns")) != 2 || len(getStringList(stored, "negative_patterns")) != 0 {
+		t.Fatalf("expected only the negative list to be cleared, got %#v", stored)
+	}
+	patterns := loadVisaSignalPatterns("u1")
+	got := patterns.match("We WILL TRANSFER H1B visas through our Global Mobility Program.")
+	if !got.Positive || got.Negative || len(got.Matched) != 2 {
+		t.Fatalf("unexpected custom signals: %#v", got)
+	}
+}
+
+func TestCustomSignalPatternsAffectSearchAcceptance(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	if _, err := SetVisaSignalPatterns(map[string]any{
+		"user_id":           "u1",
+		"positive_patterns": []any{"global mobility program"},
+		"negative_patterns": []any{"clearance required"},
+	}); err != nil {
+		t.Fatalf("SetVisaSignalPatterns failed: %v", err)
+	}
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-1/", Title: "Software Engineer", Company: "Beta LLC", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-2/", Title: "Software Engineer", Company: "Beta LLC", Location: "New York, NY"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/beta-1/": "New hires from abroad join our Global Mobility Program.",
+			"https://www.linkedin.com/jobs/view/beta-2/": "Our Global Mobility Program is available. Clearance required.",
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":              "u1",
+		"location":             "United States",
+		"job_title":            "Software Engineer",
+		"dataset_path":         datasetPath,
+		"results_wanted":       5,
+		"preferred_visa_types": []any{"h1b"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 {
+		t.Fatalf("expected only the program listing without the custom negative, got %#v", jobs)
+	}
+	job := asMap(jobs[0])
+	if getString(job, "job_url") != "https://www.linkedin.com/jobs/view/beta-1/" || !slices.Contains(getStringList(job, "custom_signal_matches"), "positive: global mobility program") {
+		t.Fatalf("expected the custom positive match to be reported, got %#v", job)
+	}
+}
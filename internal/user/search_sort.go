@@ -0,0 +1,73 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const sortByScanOrder = "scan_order"
+
+var searchSortOptions = []string{"company_sponsor_count", "confidence", "date_posted", "salary_max", sortByScanOrder}
+
+func normalizeSortBy(raw string) (string, error) {
+	clean := strings.ToLower(strings.TrimSpace(raw))
+	if clean == "" {
+		return "", nil
+	}
+	if !slices.Contains(searchSortOptions, clean) {
+		return "", fmt.Errorf("sort_by must be one of %v", searchSortOptions)
+	}
+	return clean, nil
+}
+
+// jobSalaryCeiling returns the yearly top of a result's advertised pay range.
+func jobSalaryCeiling(job map[string]any) (int, bool) {
+	amount, ok := intFromAny(job["salary_max_amount"])
+	if !ok {
+		amount, ok = intFromAny(job["salary_min_amount"])
+	}
+	if !ok {
+		return 0, false
+	}
+	return annualizeSalary(amount, getString(job, "salary_interval")), true
+}
+
+func compareDescending(a, b float64, aOK, bOK bool) int {
+	switch {
+	case aOK && !bOK:
+		return -1
+	case !aOK && bOK:
+		return 1
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	}
+	return 0
+}
+
+// sortAcceptedJobs returns a copy of the accepted jobs ordered by sortBy.
+// Ties, and jobs missing the sort field, keep their scan order.
+func sortAcceptedJobs(accepted []map[string]any, sortBy string) []map[string]any {
+	if sortBy == "" || sortBy == sortByScanOrder {
+		return accepted
+	}
+	sorted := slices.Clone(accepted)
+	slices.SortStableFunc(sorted, func(a, b map[string]any) int {
+		switch sortBy {
+		case "confidence":
+			return compareDescending(floatOrZero(a["confidence_score"]), floatOrZero(b["confidence_score"]), true, true)
+		case "date_posted":
+			return strings.Compare(getString(b, "date_posted"), getString(a, "date_posted"))
+		case "salary_max":
+			aSalary, aOK := jobSalaryCeiling(a)
+			bSalary, bOK := jobSalaryCeiling(b)
+			return compareDescending(float64(aSalary), float64(bSalary), aOK, bOK)
+		case "company_sponsor_count":
+			return compareDescending(floatOrZero(asMap(a["visa_counts"])["total_visas"]), floatOrZero(asMap(b["visa_counts"])["total_visas"]), true, true)
+		}
+		return 0
+	})
+	return sorted
+}
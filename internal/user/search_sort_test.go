@@ -0,0 +1,68 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSortAcceptedJobsOrdersByField(t *testing.T) {
+	jobs := []map[string]any{
+		{"job_url": "a", "confidence_score": 0.5, "date_posted": "2026-01-02", "salary_max_amount": 50, "salary_interval": "hourly", "visa_counts": map[string]any{"total_visas": 3}},
+		{"job_url": "b", "confidence_score": 0.9, "date_posted": "", "visa_counts": map[string]any{"total_visas": 40}},
+		{"job_url": "c", "confidence_score": 0.7, "date_posted": "2026-01-05", "salary_max_amount": 150000, "salary_interval": "yearly", "visa_counts": map[string]any{"total_visas": 10}},
+	}
+	cases := map[string][]string{
+		"confidence":            {"b", "c", "a"},
+		"date_posted":           {"c", "a", "b"},
+		"salary_max":            {"c", "a", "b"},
+		"company_sponsor_count": {"b", "c", "a"},
+		"scan_order":            {"a", "b", "c"},
+	}
+	for sortBy, want := range cases {
+		sorted := sortAcceptedJobs(jobs, sortBy)
+		for idx, url := range want {
+			if got := getString(sorted[idx], "job_url"); got != url {
+				t.Fatalf("sort_by=%s position %d: expected %s, got %s", sortBy, idx, url, got)
+			}
+		}
+	}
+	if getString(jobs[0], "job_url") != "a" {
+		t.Fatal("expected sorting to leave the input slice untouched")
+	}
+	if _, err := normalizeSortBy("newest"); err == nil {
+		t.Fatal("expected unknown sort_by to fail")
+	}
+}
+
+func TestGetJobSearchResultsAppliesSortBy(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	rows := acmeListingPage(1, 3)
+	rows[0].DatePosted = "2026-01-01"
+	rows[1].DatePosted = "2026-03-01"
+	rows[2].DatePosted = "2026-02-01"
+	client := &fakeLinkedInClient{pages: map[int][]linkedInJob{0: rows}}
+	first := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":      "u1",
+		"location":     "New York, NY",
+		"job_title":    "Software Engineer",
+		"dataset_path": datasetPath,
+	})
+
+	results, err := GetVisaJobSearchResults(map[string]any{
+		"user_id": "u1",
+		"run_id":  getString(first, "run_id"),
+		"sort_by": "date_posted",
+	})
+	if err != nil {
+		t.Fatalf("GetVisaJobSearchResults failed: %v", err)
+	}
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 3 || getString(mapOrNil(jobs[0]), "date_posted") != "2026-03-01" {
+		t.Fatalf("expected newest posting first, got %#v", jobs)
+	}
+	if got := getString(asMap(results["pagination"]), "sort_by"); got != "date_posted" {
+		t.Fatalf("expected pagination.sort_by=date_posted, got %q", got)
+	}
+}
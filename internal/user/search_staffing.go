@@ -0,0 +1,70 @@
+package user
+
+import (
+	"os"
+	"slices"
+	"strings"
+)
+
+const maxStaffingAgencySuggestions = 20
+
+// builtinStaffingAgencyPatterns match at the start of a word in the company
+// name, so "recruit" also covers "Recruiting" and "Recruiters".
+var builtinStaffingAgencyPatterns = []string{
+	"staffing",
+	"recruit",
+	"headhunt",
+	"talent solutions",
+	"workforce solutions",
+	"placement",
+	"robert half",
+	"randstad",
+	"adecco",
+	"kforce",
+	"teksystems",
+	"insight global",
+	"aerotek",
+	"manpower",
+	"kelly services",
+	"apex systems",
+	"cybercoders",
+	"jobot",
+	"motion recruitment",
+	"beacon hill",
+	"collabera",
+	"vaco",
+	"hays",
+}
+
+func normalizeStaffingPatterns(values []string) []string {
+	out := []string{}
+	for _, value := range values {
+		clean := strings.ToLower(normalizeWhitespace(value))
+		if clean != "" && !slices.Contains(out, clean) {
+			out = append(out, clean)
+		}
+	}
+	return out
+}
+
+// staffingAgencyPatterns merges the built-in list with patterns from
+// VISA_STAFFING_AGENCY_PATTERNS (comma-separated) and the search args.
+func staffingAgencyPatterns(extra []string) []string {
+	patterns := append([]string{}, builtinStaffingAgencyPatterns...)
+	patterns = append(patterns, strings.Split(os.Getenv("VISA_STAFFING_AGENCY_PATTERNS"), ",")...)
+	patterns = append(patterns, extra...)
+	return normalizeStaffingPatterns(patterns)
+}
+
+func looksLikeStaffingAgency(company string, patterns []string) bool {
+	name := " " + strings.ToLower(normalizeWhitespace(company))
+	if strings.TrimSpace(name) == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if strings.Contains(name, " "+pattern) {
+			return true
+		}
+	}
+	return false
+}
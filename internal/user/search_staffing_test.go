@@ -0,0 +1,71 @@
+package user
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLooksLikeStaffingAgency(t *testing.T) {
+	patterns := staffingAgencyPatterns([]string{"Acme Talent"})
+	cases := map[string]bool{
+		"Robert Half":              true,
+		"Apex Recruiting Partners": true,
+		"TEKsystems":               true,
+		"Acme Talent Group":        true,
+		"Acme Inc":                 false,
+		"Hayes Analytics":          false,
+	}
+	for company, want := range cases {
+		if got := looksLikeStaffingAgency(company, patterns); got != want {
+			t.Fatalf("looksLikeStaffingAgency(%q)=%v, want %v", company, got, want)
+		}
+	}
+
+	t.Setenv("VISA_STAFFING_AGENCY_PATTERNS", "Globex Placements, ")
+	if !looksLikeStaffingAgency("Globex Placements LLC", staffingAgencyPatterns(nil)) {
+		t.Fatalf("expected env patterns to extend the built-in list")
+	}
+}
+
+func TestExcludeStaffingAgenciesSkipsAgencyPosts(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/acme-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/agency-1/", Title: "Software Engineer", Company: "CyberCoders", Location: "New York, NY"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/agency-1/": "Our client will sponsor H-1B visas.",
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":                   "u1",
+		"location":                  "New York, NY",
+		"job_title":                 "Software Engineer",
+		"dataset_path":              datasetPath,
+		"results_wanted":            5,
+		"exclude_staffing_agencies": true,
+	})
+	if got := len(listOrEmpty(results["jobs"])); got != 1 {
+		t.Fatalf("expected agency post to be excluded, got %d jobs", got)
+	}
+	if got := intOrZero(asMap(results["stats"])["staffing_agencies_skipped"]); got != 1 {
+		t.Fatalf("expected staffing_agencies_skipped=1, got %d", got)
+	}
+	found := false
+	for _, raw := range listOrEmpty(results["recovery_suggestions"]) {
+		suggestion := asMap(raw)
+		if getString(suggestion, "type") == "staffing_agencies_excluded" {
+			found = slices.Equal(getStringList(suggestion, "companies"), []string{"CyberCoders"})
+		}
+	}
+	if !found {
+		t.Fatalf("expected staffing_agencies_excluded suggestion, got %#v", results["recovery_suggestions"])
+	}
+}
@@ -17,19 +17,11 @@ func searchRunIsTerminal(status string) bool {
 }
 
 func StartVisaJobSearch(args map[string]any) (map[string]any, error) {
-	return startJobSearchWithMode(args, searchModeVisa, searchToolNames{
-		PollTool:    "get_visa_job_search_status",
-		ResultsTool: "get_visa_job_search_results",
-		CancelTool:  "cancel_visa_job_search",
-	})
+	return startJobSearchWithMode(args, searchModeVisa, searchToolNamesForMode(searchModeVisa))
 }
 
 func StartJobSearch(args map[string]any) (map[string]any, error) {
-	return startJobSearchWithMode(args, searchModeGeneral, searchToolNames{
-		PollTool:    "get_job_search_status",
-		ResultsTool: "get_job_search_results",
-		CancelTool:  "cancel_job_search",
-	})
+	return startJobSearchWithMode(args, searchModeGeneral, searchToolNamesForMode(searchModeGeneral))
 }
 
 func startJobSearchWithMode(args map[string]any, mode string, names searchToolNames) (map[string]any, error) {
@@ -52,8 +44,8 @@ func startJobSearchWithMode(args map[string]any, mode string, names searchToolNa
 	}
 
 	strictness := strictnessOrDefault(getString(args, "strictness_mode"))
-	if strictness != "strict" && strictness != "balanced" {
-		return nil, fmt.Errorf("strictness_mode must be one of [balanced strict]")
+	if err := validateStrictnessMode(strictness); err != nil {
+		return nil, err
 	}
 
 	resultsWanted := defaultSearchResultsWanted
@@ -130,11 +122,8 @@ func startJobSearchWithMode(args map[string]any, mode string, names searchToolNa
 		}
 		maxScanResults = parsed
 	}
-	datasetPath := datasetPathOrDefault(getString(args, "dataset_path"))
+	datasetPath := datasetPathOrDefault(datasetPathFromArgs(args))
 
-	runID := newRunID()
-	createdAt := utcNowISO()
-	expiresAt := futureISO(searchRunTTLSeconds())
 	query := map[string]any{
 		"search_mode":                mode,
 		"location":                   location,
@@ -152,9 +141,42 @@ func startJobSearchWithMode(args map[string]any, mode string, names searchToolNa
 		"scan_multiplier":            scanMultiplier,
 		"max_scan_results":           maxScanResults,
 	}
+	if err := parseSearchOptions(args, query); err != nil {
+		return nil, err
+	}
+	priority, err := normalizeSearchRunPriority(getString(args, "priority"))
+	if err != nil {
+		return nil, err
+	}
+	return createSearchRun(query, priority, names, "Background search started.")
+}
+
+func searchToolNamesForMode(mode string) searchToolNames {
+	if mode == searchModeVisa {
+		return searchToolNames{
+			PollTool:    "get_visa_job_search_status",
+			ResultsTool: "get_visa_job_search_results",
+			CancelTool:  "cancel_visa_job_search",
+		}
+	}
+	return searchToolNames{
+		PollTool:    "get_job_search_status",
+		ResultsTool: "get_job_search_results",
+		CancelTool:  "cancel_job_search",
+	}
+}
+
+func createSearchRun(query map[string]any, priority string, names searchToolNames, startDetail string) (map[string]any, error) {
+	runID := newRunID()
+	createdAt := utcNowISO()
+	expiresAt := futureISO(searchRunTTLSeconds())
+	resultsWanted := intOrZero(query["results_wanted"])
+	offset := intOrZero(query["offset"])
+	maxReturned := intOrZero(query["max_returned"])
 	run := map[string]any{
 		"run_id":              runID,
 		"status":              "pending",
+		"priority":            priority,
 		"created_at_utc":      createdAt,
 		"updated_at_utc":      createdAt,
 		"completed_at_utc":    "",
@@ -170,33 +192,29 @@ func startJobSearchWithMode(args map[string]any, mode string, names searchToolNa
 		"events":              []any{},
 		"query":               query,
 	}
-	appendRunEvent(run, "started", "Background search started.", 0, nil)
+	appendRunEvent(run, "started", startDetail, 0, nil)
 
-	if err := withSearchRunStore(true, func(store map[string]any) error {
-		runs := mapOrNil(store["runs"])
-		if runs == nil {
-			runs = map[string]any{}
-		}
-		runs[runID] = run
-		store["runs"] = runs
-		return nil
-	}); err != nil {
+	startNow, err := enqueueSearchRun(runID, run)
+	if err != nil {
 		return nil, err
 	}
-
-	go executeSearchRun(runID)
+	queueStatus := searchRunQueueStatus(runID)
 	return map[string]any{
-		"run_id":           runID,
-		"status":           "pending",
-		"user_id":          userID,
-		"search_mode":      mode,
-		"created_at_utc":   createdAt,
-		"expires_at_utc":   expiresAt,
-		"next_cursor":      intOrZero(run["next_event_id"]),
-		"search_runs_path": searchRunsPath(),
-		"poll_tool":        names.PollTool,
-		"results_tool":     names.ResultsTool,
-		"cancel_tool":      names.CancelTool,
+		"run_id":                 runID,
+		"status":                 "pending",
+		"priority":               priority,
+		"queued":                 !startNow,
+		"queue_position":         queueStatus["queue_position"],
+		"estimated_start_at_utc": queueStatus["estimated_start_at_utc"],
+		"user_id":                getString(query, "user_id"),
+		"search_mode":            getString(query, "search_mode"),
+		"created_at_utc":         createdAt,
+		"expires_at_utc":         expiresAt,
+		"next_cursor":            intOrZero(run["next_event_id"]),
+		"search_runs_path":       searchRunsPath(),
+		"poll_tool":              names.PollTool,
+		"results_tool":           names.ResultsTool,
+		"cancel_tool":            names.CancelTool,
 	}, nil
 }
 
@@ -238,17 +256,28 @@ func getJobSearchStatus(args map[string]any) (map[string]any, error) {
 		safeCursor = len(events)
 	}
 	status := strings.ToLower(getString(run, "status"))
+	queueStatus := searchRunQueueStatus(runID)
 	latestStats := asMap(run["latest_stats"])
 	latestResponse := asMap(run["latest_response"])
+	layoutDrift := boolOrFalse(latestStats["possible_layout_change"]) || runHasLayoutDriftEvent(events)
 	return map[string]any{
-		"run_id":           runID,
-		"user_id":          userID,
-		"status":           status,
-		"is_terminal":      searchRunIsTerminal(status),
-		"cancel_requested": boolOrFalse(run["cancel_requested"]),
-		"attempt_count":    intOrZero(run["attempt_count"]),
-		"created_at_utc":   run["created_at_utc"],
-		"updated_at_utc":   run["updated_at_utc"],
+		"run_id":                 runID,
+		"user_id":                userID,
+		"status":                 status,
+		"is_terminal":            searchRunIsTerminal(status),
+		"possible_layout_change": layoutDrift,
+		"queued":                 runIsQueued(run),
+		"priority":               getString(run, "priority"),
+		"queue_position":         queueStatus["queue_position"],
+		"estimated_wait_seconds": queueStatus["estimated_wait_seconds"],
+		"estimated_start_at_utc": queueStatus["estimated_start_at_utc"],
+		"cancel_requested":       boolOrFalse(run["cancel_requested"]),
+		"attempt_count":          intOrZero(run["attempt_count"]),
+		"max_attempts":           searchRunMaxAttempts(),
+		"retry_at_utc":           optionalString(getString(run, "retry_not_before_utc")),
+		"attempt_errors":         listOrEmpty(run["attempt_errors"]),
+		"created_at_utc":         run["created_at_utc"],
+		"updated_at_utc":         run["updated_at_utc"],
 		"completed_at_utc": func() any {
 			text := getString(run, "completed_at_utc")
 			if text == "" {
@@ -260,6 +289,8 @@ func getJobSearchStatus(args map[string]any) (map[string]any, error) {
 		"search_session_id":    getString(run, "search_session_id"),
 		"current_scan_target":  intOrZero(run["current_scan_target"]),
 		"error":                getString(run, "error"),
+		"error_code":           optionalString(getString(run, "error_code")),
+		"recovery_guidance":    searchErrorRecoveryGuidance(getString(run, "error_code")),
 		"events":               events[safeCursor:],
 		"cursor":               safeCursor,
 		"next_cursor":          len(events),
@@ -298,9 +329,6 @@ func getJobSearchResults(args map[string]any, statusToolName string) (map[string
 		return nil, fmt.Errorf("search run query payload is unavailable")
 	}
 	latestResponse := asMap(run["latest_response"])
-	if len(latestResponse) == 0 {
-		return nil, fmt.Errorf("no result snapshot yet; poll %s until results are available", statusToolName)
-	}
 
 	requestedOffset := intOrZero(query["offset"])
 	if parsed, has, err := getOptionalInt(args, "offset"); has {
@@ -326,13 +354,30 @@ func getJobSearchResults(args map[string]any, statusToolName string) (map[string
 		requestedMax = parsed
 	}
 
+	sortBy, err := normalizeSortBy(getString(args, "sort_by"))
+	if err != nil {
+		return nil, err
+	}
+	if len(latestResponse) == 0 {
+		partialOffset := requestedOffset
+		if getString(query, "continue_session_id") != "" && !hasKey(args, "offset") {
+			// A continuation's default offset points past the prior session's
+			// jobs, but the partial snapshot only holds this run's new ones.
+			partialOffset = 0
+		}
+		if partial, ok := partialResultsResponse(run, partialOffset, requestedMax, sortBy); ok {
+			return partial, nil
+		}
+		return nil, fmt.Errorf("no result snapshot yet; poll %s until results are available", statusToolName)
+	}
+
 	defaultOffset := intOrZero(query["offset"])
 	defaultMax := intOrZero(query["max_returned"])
 	if defaultMax < 1 {
 		defaultMax = defaultSearchMaxReturned
 	}
 	response := latestResponse
-	if requestedOffset != defaultOffset || requestedMax != defaultMax {
+	if requestedOffset != defaultOffset || requestedMax != defaultMax || sortBy != "" {
 		sessionID := getString(run, "search_session_id")
 		if sessionID == "" {
 			return nil, fmt.Errorf("search_session_id is unavailable for this run")
@@ -355,10 +400,11 @@ func getJobSearchResults(args map[string]any, statusToolName string) (map[string
 			intOrZero(session["latest_scan_target"]),
 			max(defaultSearchMaxScanResults, intOrZero(query["max_scan_results"])),
 			boolOrFalse(session["scan_exhausted"]),
+			sortBy,
 		)
 		response = rebuildResponsePage(latestResponse, page, pagination)
 	}
-	return map[string]any{
+	out := map[string]any{
 		"run": map[string]any{
 			"run_id":           runID,
 			"status":           getString(run, "status"),
@@ -372,7 +418,11 @@ func getJobSearchResults(args map[string]any, statusToolName string) (map[string
 		"pagination":           asMap(response["pagination"]),
 		"recovery_suggestions": listOrEmpty(response["recovery_suggestions"]),
 		"jobs":                 listOrEmpty(response["jobs"]),
-	}, nil
+	}
+	if diff := asMap(response["diff"]); len(diff) > 0 {
+		out["diff"] = diff
+	}
+	return out, nil
 }
 
 func CancelVisaJobSearch(args map[string]any) (map[string]any, error) {
@@ -413,6 +463,18 @@ func cancelJobSearch(args map[string]any) (map[string]any, error) {
 			cancelRequested = false
 			return nil
 		}
+		if runIsQueued(run) {
+			run["cancel_requested"] = true
+			run["status"] = "cancelled"
+			run["error_code"] = searchErrorCancelled
+			run["completed_at_utc"] = utcNowISO()
+			appendRunEvent(run, "cancelled", "Queued search run cancelled before it started.", 100, nil)
+			runs[runID] = run
+			store["runs"] = runs
+			status = "cancelled"
+			cancelRequested = true
+			return nil
+		}
 		run["cancel_requested"] = true
 		run["status"] = "cancelling"
 		appendRunEvent(run, "cancelling", "Cancellation requested. The run will stop after the current chunk.", -1, nil)
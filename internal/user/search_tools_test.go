@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -12,7 +13,9 @@ import (
 type fakeLinkedInClient struct {
 	pages        map[int][]linkedInJob
 	descriptions map[string]string
+	closed       map[string]string
 	pageDelay    time.Duration
+	mu           sync.Mutex
 	descCalls    int
 }
 
@@ -27,7 +30,12 @@ func (f *fakeLinkedInClient) FetchSearchPage(query linkedInSearchQuery, _ func()
 }
 
 func (f *fakeLinkedInClient) FetchJobDetails(jobURL, _, _ string, _ func() bool) (linkedInJobDetails, error) {
+	f.mu.Lock()
 	f.descCalls++
+	f.mu.Unlock()
+	if reason, ok := f.closed[jobURL]; ok {
+		return linkedInJobDetails{ClosedReason: reason}, nil
+	}
 	if text, ok := f.descriptions[jobURL]; ok {
 		return linkedInJobDetails{
 			Description: text,
@@ -0,0 +1,104 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// urgentDaysThreshold is the days_remaining below which searches favor
+// employers that can file quickly.
+const urgentDaysThreshold = 60
+
+var immediateSponsorshipRegex = regexp.MustCompile(`(?i)\b(?:immediate(?:ly)?|day one|day 1|right away)\b[^.]{0,40}\bsponsor\w*|\bsponsor\w*[^.]{0,40}\b(?:immediately|from day one|from day 1|right away)\b|\bpremium processing\b|\bh-?1b transfers?\b`)
+
+var urgencySignalPhrases = map[string]string{
+	"cap_exempt_employer":            "it is cap-exempt, so it can file H-1B petitions year-round",
+	"recent_high_volume_sponsor":     "it files many petitions every year, so its immigration counsel moves quickly",
+	"immediate_sponsorship_language": "the listing offers sponsorship right away",
+}
+
+// effectiveDaysRemaining counts the stored days_remaining down from when
+// set_user_constraints saved it; nil when none is stored.
+func effectiveDaysRemaining(constraints map[string]any, now time.Time) *int {
+	days, has, err := getOptionalInt(constraints, "days_remaining")
+	if !has || err != nil {
+		return nil
+	}
+	if updated, err := time.Parse(time.RFC3339, getString(constraints, "updated_at_utc")); err == nil && now.After(updated) {
+		days -= int(now.Sub(updated).Hours() / 24)
+	}
+	days = max(days, 0)
+	return &days
+}
+
+func (c searchConstraints) urgent() bool {
+	return c.DaysRemaining != nil && *c.DaysRemaining < urgentDaysThreshold
+}
+
+// urgencySignals lists why a job suits a user who is short on time. The
+// dataset has no premium-processing history, so recent high-volume sponsors
+// stand in for employers with fast filing practices. Immediate-sponsorship
+// language only counts when the description was fetched.
+func urgencySignals(c searchConstraints, capExempt bool, record companyDatasetRecord, hasCompany bool, description string) []string {
+	signals := []string{}
+	if !c.urgent() {
+		return signals
+	}
+	if capExempt {
+		signals = append(signals, "cap_exempt_employer")
+	}
+	if hasCompany && record.CompanyTier == companyTierHighVolumeRecent {
+		signals = append(signals, "recent_high_volume_sponsor")
+	}
+	if immediateSponsorshipRegex.MatchString(description) {
+		signals = append(signals, "immediate_sponsorship_language")
+	}
+	return signals
+}
+
+// urgencyGuidance is appended to agent_guidance for urgent users.
+func urgencyGuidance(c searchConstraints, signals []string) string {
+	if !c.urgent() {
+		return ""
+	}
+	if len(signals) == 0 {
+		return fmt.Sprintf(" With %d days of status left, confirm the employer can file quickly before investing time here.", *c.DaysRemaining)
+	}
+	reasons := []string{}
+	for _, signal := range signals {
+		reasons = append(reasons, urgencySignalPhrases[signal])
+	}
+	return fmt.Sprintf(" With %d days of status left, move on this one first: %s.", *c.DaysRemaining, strings.Join(reasons, "; "))
+}
+
+// promoteUrgentMatches keeps scan order but moves jobs with urgency signals
+// ahead of the rest. It runs before demoteConstraintMismatches, so jobs that
+// fit the constraints still come first.
+func promoteUrgentMatches(jobs []map[string]any) int {
+	promoted := 0
+	hasSignals := func(job map[string]any) bool {
+		signals, _ := job["urgency_signals"].([]string)
+		return len(signals) > 0
+	}
+	for _, job := range jobs {
+		if hasSignals(job) {
+			promoted++
+		}
+	}
+	if promoted > 0 {
+		slices.SortStableFunc(jobs, func(a, b map[string]any) int {
+			aUrgent, bUrgent := hasSignals(a), hasSignals(b)
+			switch {
+			case aUrgent == bUrgent:
+				return 0
+			case aUrgent:
+				return -1
+			}
+			return 1
+		})
+	}
+	return promoted
+}
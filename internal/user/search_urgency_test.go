@@ -0,0 +1,86 @@
+package user
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEffectiveDaysRemainingCountsDownFromUpdate(t *testing.T) {
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+	stored := map[string]any{"days_remaining": 45, "updated_at_utc": "2026-03-01T12:00:00Z"}
+	if got := effectiveDaysRemaining(stored, now); got == nil || *got != 35 {
+		t.Fatalf("expected 35 days left, got %v", got)
+	}
+	stored["days_remaining"] = 5
+	if got := effectiveDaysRemaining(stored, now); got == nil || *got != 0 {
+		t.Fatalf("expected the countdown to stop at 0, got %v", got)
+	}
+	if got := effectiveDaysRemaining(map[string]any{}, now); got != nil {
+		t.Fatalf("expected nil without a stored value, got %v", *got)
+	}
+	if immediateSponsorshipRegex.MatchString("We do not sponsor visas.") || !immediateSponsorshipRegex.MatchString("We can sponsor your H-1B transfer immediately.") {
+		t.Fatalf("unexpected immediate sponsorship matching")
+	}
+}
+
+func runUrgencySearch(t *testing.T, days int) map[string]any {
+	t.Helper()
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	if _, err := SetUserConstraints(map[string]any{"user_id": "u1", "days_remaining": days}); err != nil {
+		t.Fatalf("SetUserConstraints failed: %v", err)
+	}
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/acme-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-1/", Title: "Software Engineer", Company: "Beta LLC", Location: "New York, NY"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/beta-1/": "We sponsor visas and offer premium processing for H-1B transfers.",
+		},
+	}
+	return runFakeVisaSearch(t, client, map[string]any{
+		"user_id":              "u1",
+		"location":             "United States",
+		"job_title":            "Software Engineer",
+		"dataset_path":         datasetPath,
+		"results_wanted":       5,
+		"preferred_visa_types": []any{"h1b"},
+	})
+}
+
+func TestUrgentUsersSeeFastFilingJobsFirst(t *testing.T) {
+	results := runUrgencySearch(t, 30)
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 2 {
+		t.Fatalf("expected the Acme and Beta jobs, got %#v", jobs)
+	}
+	first := asMap(jobs[0])
+	if getString(first, "job_url") != "https://www.linkedin.com/jobs/view/beta-1/" || !slices.Contains(getStringList(first, "urgency_signals"), "immediate_sponsorship_language") {
+		t.Fatalf("expected the premium-processing job first, got %#v", first)
+	}
+	if !strings.Contains(getString(first, "agent_guidance"), "30 days of status left") {
+		t.Fatalf("expected urgency guidance, got %q", getString(first, "agent_guidance"))
+	}
+	stats := asMap(results["stats"])
+	if intOrZero(stats["urgency_promoted"]) != 1 || intOrZero(asMap(stats["constraints_applied"])["days_remaining"]) != 30 {
+		t.Fatalf("unexpected urgency stats: %#v", stats)
+	}
+}
+
+func TestRelaxedTimelineKeepsScanOrder(t *testing.T) {
+	results := runUrgencySearch(t, 120)
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 2 || getString(asMap(jobs[0]), "job_url") != "https://www.linkedin.com/jobs/view/acme-1/" {
+		t.Fatalf("expected scan order without urgency, got %#v", jobs)
+	}
+	if len(getStringList(asMap(jobs[1]), "urgency_signals")) != 0 || strings.Contains(getString(asMap(jobs[1]), "agent_guidance"), "days of status left") {
+		t.Fatalf("expected no urgency output at 120 days, got %#v", jobs[1])
+	}
+}
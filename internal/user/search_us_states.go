@@ -0,0 +1,60 @@
+package user
+
+import "strings"
+
+var usStateCodes = map[string]string{
+	"alabama": "AL", "alaska": "AK", "arizona": "AZ", "arkansas": "AR", "california": "CA",
+	"colorado": "CO", "connecticut": "CT", "delaware": "DE", "district of columbia": "DC", "florida": "FL",
+	"georgia": "GA", "hawaii": "HI", "idaho": "ID", "illinois": "IL", "indiana": "IN",
+	"iowa": "IA", "kansas": "KS", "kentucky": "KY", "louisiana": "LA", "maine": "ME",
+	"maryland": "MD", "massachusetts": "MA", "michigan": "MI", "minnesota": "MN", "mississippi": "MS",
+	"missouri": "MO", "montana": "MT", "nebraska": "NE", "nevada": "NV", "new hampshire": "NH",
+	"new jersey": "NJ", "new mexico": "NM", "new york": "NY", "north carolina": "NC", "north dakota": "ND",
+	"ohio": "OH", "oklahoma": "OK", "oregon": "OR", "pennsylvania": "PA", "puerto rico": "PR",
+	"rhode island": "RI", "south carolina": "SC", "south dakota": "SD", "tennessee": "TN", "texas": "TX",
+	"utah": "UT", "vermont": "VT", "virginia": "VA", "washington": "WA", "west virginia": "WV",
+	"wisconsin": "WI", "wyoming": "WY",
+}
+
+// normalizeUSState returns the two-letter code for a state name or code.
+func normalizeUSState(raw string) string {
+	text := strings.ToLower(normalizeWhitespace(strings.TrimSuffix(strings.TrimSpace(raw), ".")))
+	if code, ok := usStateCodes[text]; ok {
+		return code
+	}
+	upper := strings.ToUpper(text)
+	for _, code := range usStateCodes {
+		if code == upper {
+			return code
+		}
+	}
+	return ""
+}
+
+// parseUSLocation splits LinkedIn-style locations such as "Austin, TX" or
+// "Seattle, Washington, United States" into a lowercase city and a state code.
+// Metro labels without a state ("San Francisco Bay Area") keep only a city.
+func parseUSLocation(location string) (string, string) {
+	parts := []string{}
+	for _, part := range strings.Split(location, ",") {
+		if part = normalizeWhitespace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) > 1 {
+		switch strings.ToLower(parts[len(parts)-1]) {
+		case "united states", "usa", "us":
+			parts = parts[:len(parts)-1]
+		}
+	}
+	if len(parts) == 0 {
+		return "", ""
+	}
+	if len(parts) == 1 {
+		if state := normalizeUSState(parts[0]); state != "" {
+			return "", state
+		}
+		return strings.ToLower(parts[0]), ""
+	}
+	return strings.ToLower(parts[0]), normalizeUSState(parts[1])
+}
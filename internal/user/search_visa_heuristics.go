@@ -0,0 +1,121 @@
+package user
+
+import (
+	"regexp"
+	"slices"
+)
+
+// heuristicVisaConfidence is the confidence weight of a heuristic match;
+// it sits below the dataset weight because no filing proves the employer
+// will support the visa.
+const heuristicVisaConfidence = 0.3
+
+// l1HeavyCompanyPetitions is the L-1 petition count from which an employer
+// counts as a heavy L-1 user.
+const l1HeavyCompanyPetitions = 50
+
+// tnProfessionRegex matches job titles in the USMCA professions list
+// (Appendix 1603.D.1). TN status needs no employer filing with DOL, so the
+// occupation, not the company's history, decides eligibility.
+var tnProfessionRegex = regexp.MustCompile(`(?i)\b(?:accountant|architect|engineer|(?:computer )?systems analyst|economist|graphic designer|industrial designer|interior designer|land surveyor|lawyer|librarian|management consultant|mathematician|statistician|actuary|scientist|chemist|biologist|physicist|geologist|geophysicist|astronomer|agronomist|epidemiologist|forester|technical writer|technical publications writer|urban planner|social worker|research assistant|scientific technician|pharmacist|physician|dentist|dietitian|nutritionist|medical technologist|occupational therapist|physical therapist|physiotherapist|psychologist|recreational therapist|registered nurse|veterinarian|professor|lecturer|hotel manager|vocational counselor)s?\b`)
+
+// deferredSponsorshipRegex matches listings that decline sponsorship only
+// for now; futureSponsorshipClosedRegex matches the ones that rule it out
+// later too ("now or in the future").
+var (
+	deferredSponsorshipRegex     = regexp.MustCompile(`(?i)\b(?:not|unable to|cannot|can't|do not|don't|does not)\s+(?:currently\s+)?(?:able to\s+)?(?:provide\s+|offer\s+)?(?:visa\s+)?sponsor\w*[^.]{0,40}\b(?:at this time|currently|at present)\b|\bnot currently (?:able to )?(?:provide |offer )?(?:visa )?sponsor\w*|\b(?:future|eventual) (?:visa |h-?1b )?sponsorship (?:is )?(?:possible|available|considered)\b|\bmay sponsor in the future\b`)
+	futureSponsorshipClosedRegex = regexp.MustCompile(`(?i)\bnow or (?:at any (?:point|time) )?in the future\b|\bnor in the future\b|\b(?:no|not|without)\b[^.]{0,30}\bfuture (?:visa )?sponsorship\b`)
+	eVerifyRegex                 = regexp.MustCompile(`(?i)\be-?verify\b`)
+)
+
+// visaHeuristics is the evidence for visa types that companies.csv cannot
+// count, such as TN and OPT. WaivesNegative means the listing's negative
+// sponsorship language does not apply to the requested status.
+type visaHeuristics struct {
+	Eligible       []string
+	Flags          []string
+	Reasons        []string
+	WaivesNegative bool
+}
+
+func (h visaHeuristics) accepts(descriptionNegative bool) bool {
+	return len(h.Eligible) > 0 && !descriptionNegative
+}
+
+// strength reports occupation_heuristic for jobs that only the heuristics
+// support.
+func (h visaHeuristics) strength(base string) string {
+	if base == "weak" && len(h.Eligible) > 0 {
+		return "occupation_heuristic"
+	}
+	return base
+}
+
+func (h *visaHeuristics) add(visa, flag, reason string) {
+	if !slices.Contains(h.Eligible, visa) {
+		h.Eligible = append(h.Eligible, visa)
+	}
+	h.Flags = append(h.Flags, flag)
+	h.Reasons = append(h.Reasons, reason)
+}
+
+func tnEligibleOccupation(title string) bool {
+	return tnProfessionRegex.MatchString(title)
+}
+
+// l1HeavyCompany reports employers that move many staff to the US on L-1,
+// which is the route for users already working at their offices abroad.
+func l1HeavyCompany(record companyDatasetRecord) bool {
+	return record.L1 >= l1HeavyCompanyPetitions
+}
+
+func wantsOPT(desired []string) bool {
+	return slices.Contains(desired, "f1_opt") || slices.Contains(desired, "stem_opt")
+}
+
+// sponsorshipDeferred reports listings that decline sponsorship for now
+// without ruling it out later, which OPT holders can take.
+func sponsorshipDeferred(description string) bool {
+	return deferredSponsorshipRegex.MatchString(description) && !futureSponsorshipClosedRegex.MatchString(description)
+}
+
+// evaluateVisaHeuristics checks the desired visa types that do not depend on
+// sponsor filings against the job title, the listing, and the employer's
+// filing history.
+func evaluateVisaHeuristics(desired []string, title, description string, record companyDatasetRecord, hasCompany, eVerifyEnrolled bool) visaHeuristics {
+	out := visaHeuristics{Eligible: []string{}, Flags: []string{}, Reasons: []string{}}
+	if slices.Contains(desired, "tn") && tnEligibleOccupation(title) {
+		out.add("tn", "tn_eligible_occupation", "job_title_matches_usmca_tn_profession")
+	}
+	// O-1 is a petition for the person, and an agent can file it, so filing
+	// volume says little; any US filing shows the employer has immigration
+	// counsel, which matters more for the small companies O-1 seekers target.
+	if slices.Contains(desired, "o1") && hasCompany && record.TotalVisas > 0 {
+		out.add("o1", "o1_immigration_experienced_employer", "employer_has_filed_us_work_visa_petitions")
+	}
+	if slices.Contains(desired, "l1") && hasCompany && l1HeavyCompany(record) {
+		out.add("l1", "l1_heavy_company", "employer_files_many_l1_intracompany_transfers")
+	}
+	// OPT and CPT are work authorization the student already holds; the
+	// employer matters for STEM OPT (it must use E-Verify) and for the H-1B
+	// that follows.
+	if wantsOPT(desired) {
+		optType := "f1_opt"
+		if !slices.Contains(desired, "f1_opt") {
+			optType = "stem_opt"
+		}
+		if sponsorshipDeferred(description) {
+			out.WaivesNegative = true
+			out.add(optType, "sponsorship_deferred_not_refused", "listing_declines_sponsorship_only_for_now")
+		}
+		if hasCompany && record.H1B > 0 {
+			out.add(optType, "opt_future_h1b_sponsor", "employer_files_h1b_for_post_opt_employment")
+		}
+	}
+	if slices.Contains(desired, "stem_opt") && eVerifyEnrolled {
+		out.add("stem_opt", "e_verify_participant", "employer_on_e_verify_participant_list")
+	} else if slices.Contains(desired, "stem_opt") && eVerifyRegex.MatchString(description) {
+		out.add("stem_opt", "e_verify_participant", "listing_mentions_e_verify_required_for_stem_opt")
+	}
+	return out
+}
@@ -0,0 +1,191 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestTNVisaAliasesAndDescriptionSignals(t *testing.T) {
+	for _, alias := range []string{"TN", "tn visa", "USMCA", "TN-1"} {
+		if got, err := normalizeVisaType(alias); err != nil || got != "tn" {
+			t.Fatalf("expected %q to normalize to tn, got %q (%v)", alias, got, err)
+		}
+	}
+	positive, _, mentioned := detectDescriptionSignals("We will support TN visas for Canadian and Mexican citizens.")
+	if !positive || !slices.Contains(mentioned, "tn") {
+		t.Fatalf("expected TN support language to be detected, got positive=%v mentioned=%v", positive, mentioned)
+	}
+	if _, _, mentioned := detectDescriptionSignals("Office in Nashville, TN with hybrid schedule."); slices.Contains(mentioned, "tn") {
+		t.Fatalf("expected a Tennessee location not to count as TN, got %v", mentioned)
+	}
+}
+
+func TestEvaluateVisaHeuristicsFlagsTNProfessions(t *testing.T) {
+	engineer := evaluateVisaHeuristics([]string{"tn"}, "Senior Software Engineer", "", companyDatasetRecord{}, false, false)
+	if !slices.Contains(engineer.Eligible, "tn") || !slices.Contains(engineer.Flags, "tn_eligible_occupation") {
+		t.Fatalf("expected engineers to be TN eligible, got %#v", engineer)
+	}
+	if engineer.strength("weak") != "occupation_heuristic" {
+		t.Fatalf("expected heuristic strength, got %q", engineer.strength("weak"))
+	}
+	if sales := evaluateVisaHeuristics([]string{"tn"}, "Account Executive", "", companyDatasetRecord{}, false, false); len(sales.Eligible) != 0 {
+		t.Fatalf("expected sales titles not to be TN eligible, got %#v", sales)
+	}
+	if other := evaluateVisaHeuristics([]string{"h1b"}, "Software Engineer", "", companyDatasetRecord{}, false, false); len(other.Eligible) != 0 {
+		t.Fatalf("expected no heuristics without tn requested, got %#v", other)
+	}
+}
+
+func TestTNSearchAcceptsEligibleOccupationsWithoutFilings(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-1/", Title: "Software Engineer", Company: "Beta LLC", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-2/", Title: "Software Engineer II", Company: "Beta LLC", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-3/", Title: "Account Executive", Company: "Beta LLC", Location: "New York, NY"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/beta-2/": "We do not sponsor work visas.",
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":              "u1",
+		"location":             "United States",
+		"job_title":            "Software Engineer",
+		"dataset_path":         datasetPath,
+		"results_wanted":       5,
+		"preferred_visa_types": []any{"tn"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 {
+		t.Fatalf("expected only the TN-eligible job without negative language, got %#v", jobs)
+	}
+	job := asMap(jobs[0])
+	if getString(job, "job_url") != "https://www.linkedin.com/jobs/view/beta-1/" || getString(job, "visa_match_strength") != "occupation_heuristic" {
+		t.Fatalf("unexpected TN job: %#v", job)
+	}
+	if flags := getStringList(job, "visa_heuristic_flags"); !slices.Contains(flags, "tn_eligible_occupation") {
+		t.Fatalf("expected the TN occupation flag, got %#v", job["visa_heuristic_flags"])
+	}
+}
+
+func TestO1VisaSignalsAndEmployerHeuristic(t *testing.T) {
+	for _, alias := range []string{"O-1", "o1a", "Extraordinary Ability"} {
+		if got, err := normalizeVisaType(alias); err != nil || got != "o1" {
+			t.Fatalf("expected %q to normalize to o1, got %q (%v)", alias, got, err)
+		}
+	}
+	positive, _, mentioned := detectDescriptionSignals("Candidates of extraordinary ability welcome; we have sponsored O-1A petitions.")
+	if !positive || !slices.Contains(mentioned, "o1") {
+		t.Fatalf("expected O-1 language to be detected, got positive=%v mentioned=%v", positive, mentioned)
+	}
+	small := companyDatasetRecord{CompanyName: "Tiny Labs", H1B: 1, TotalVisas: 1}
+	if got := evaluateVisaHeuristics([]string{"o1"}, "Research Scientist", "", small, true, false); !slices.Contains(got.Flags, "o1_immigration_experienced_employer") {
+		t.Fatalf("expected a small sponsor to count for O-1, got %#v", got)
+	}
+	if got := evaluateVisaHeuristics([]string{"o1"}, "Research Scientist", "", companyDatasetRecord{}, false, false); len(got.Eligible) != 0 {
+		t.Fatalf("expected no O-1 heuristic for unknown employers, got %#v", got)
+	}
+}
+
+func TestOPTHeuristicsWaiveDeferredSponsorshipOnly(t *testing.T) {
+	for alias, want := range map[string]string{"OPT": "f1_opt", "CPT": "f1_opt", "STEM OPT": "stem_opt"} {
+		if got, err := normalizeVisaType(alias); err != nil || got != want {
+			t.Fatalf("expected %q to normalize to %s, got %q (%v)", alias, want, got, err)
+		}
+	}
+	deferred := "We are unable to sponsor visas at this time. Candidates on OPT are welcome."
+	got := evaluateVisaHeuristics([]string{"f1_opt"}, "Data Analyst", deferred, companyDatasetRecord{}, false, false)
+	if !got.WaivesNegative || !slices.Contains(got.Flags, "sponsorship_deferred_not_refused") {
+		t.Fatalf("expected deferred sponsorship to be acceptable for OPT, got %#v", got)
+	}
+	closed := "We do not sponsor, now or in the future."
+	if got := evaluateVisaHeuristics([]string{"f1_opt"}, "Data Analyst", closed, companyDatasetRecord{}, false, false); got.WaivesNegative {
+		t.Fatalf("expected a permanent refusal to stay negative, got %#v", got)
+	}
+	if got := evaluateVisaHeuristics([]string{"h1b"}, "Data Analyst", deferred, companyDatasetRecord{}, false, false); got.WaivesNegative {
+		t.Fatalf("expected the waiver only for OPT users, got %#v", got)
+	}
+
+	stem := evaluateVisaHeuristics([]string{"stem_opt"}, "Data Analyst", "This employer participates in E-Verify.", companyDatasetRecord{H1B: 5, TotalVisas: 5}, true, false)
+	if !slices.Contains(stem.Flags, "e_verify_participant") || !slices.Contains(stem.Flags, "opt_future_h1b_sponsor") || !slices.Equal(stem.Eligible, []string{"stem_opt"}) {
+		t.Fatalf("expected E-Verify and H-1B history to support STEM OPT, got %#v", stem)
+	}
+	if _, _, mentioned := detectDescriptionSignals("STEM OPT candidates encouraged to apply."); !slices.Contains(mentioned, "stem_opt") || !slices.Contains(mentioned, "f1_opt") {
+		t.Fatalf("expected STEM OPT to count as both OPT mentions, got %v", mentioned)
+	}
+}
+
+func TestOPTSearchAcceptsDeferredSponsorshipListing(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-1/", Title: "Software Engineer", Company: "Beta LLC", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-2/", Title: "Software Engineer", Company: "Beta LLC", Location: "New York, NY"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/beta-1/": "We are unable to sponsor visas at this time.",
+			"https://www.linkedin.com/jobs/view/beta-2/": "We are unable to sponsor visas now or in the future.",
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":              "u1",
+		"location":             "United States",
+		"job_title":            "Software Engineer",
+		"dataset_path":         datasetPath,
+		"results_wanted":       5,
+		"preferred_visa_types": []any{"opt"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 || getString(asMap(jobs[0]), "job_url") != "https://www.linkedin.com/jobs/view/beta-1/" {
+		t.Fatalf("expected only the deferred-sponsorship listing, got %#v", jobs)
+	}
+}
+
+func TestL1SignalsAndHeavyCompanyFlag(t *testing.T) {
+	if got, err := normalizeVisaType("Intra-company transfer"); err != nil || got != "l1" {
+		t.Fatalf("expected intra-company transfer to normalize to l1, got %q (%v)", got, err)
+	}
+	if _, _, mentioned := detectDescriptionSignals("Open to L-1 transfers from our London office."); !slices.Contains(mentioned, "l1") {
+		t.Fatalf("expected an L-1 transfer mention, got %v", mentioned)
+	}
+	if positive, _, mentioned := detectDescriptionSignals("Optimize L1 cache usage in our kernels."); positive || slices.Contains(mentioned, "l1") {
+		t.Fatalf("expected an L1 cache to be ignored, got positive=%v mentioned=%v", positive, mentioned)
+	}
+	heavy := companyDatasetRecord{CompanyName: "Globex", H1B: 10, TotalVisas: 10, L1: 80}
+	if got := evaluateVisaHeuristics([]string{"l1"}, "Software Engineer", "", heavy, true, false); !slices.Contains(got.Flags, "l1_heavy_company") {
+		t.Fatalf("expected the heavy L-1 flag, got %#v", got)
+	}
+	if desiredVisaCount(heavy, []string{"l1"}) != 80 || visaCountsFromRecord(heavy)["l1"] != 80 {
+		t.Fatalf("expected L-1 petitions to count for l1 users")
+	}
+	if got := evaluateVisaHeuristics([]string{"l1"}, "Software Engineer", "", companyDatasetRecord{L1: 3}, true, false); len(got.Flags) != 0 {
+		t.Fatalf("expected no flag for light L-1 users, got %#v", got)
+	}
+}
+
+func TestLoadCompanyDatasetReadsAuxiliaryL1Column(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	body := "company_name,h1b,h1b1_chile,h1b1_singapore,e3_australian,green_card,l1\nGlobex Corp,10,0,0,0,0,120\n"
+	if err := os.WriteFile(datasetPath, []byte(body), 0o644); err != nil {
+		t.Fatalf("write dataset: %v", err)
+	}
+	profile, err := GetCompanySponsorshipProfile(map[string]any{"company_name": "Globex", "dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("GetCompanySponsorshipProfile failed: %v", err)
+	}
+	if profile["l1_heavy_company"] != true || intOrZero(asMap(profile["visa_counts"])["l1"]) != 120 || intOrZero(asMap(profile["visa_counts"])["total_visas"]) != 10 {
+		t.Fatalf("expected L-1 petitions outside total_visas, got %#v", profile)
+	}
+}
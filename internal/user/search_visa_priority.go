@@ -0,0 +1,148 @@
+package user
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Without explicit visa_type_weights, each step down preferred_visa_types
+// costs visaPriorityStep, never going below minVisaPriorityWeight.
+const (
+	visaPriorityStep      = 0.15
+	minVisaPriorityWeight = 0.4
+)
+
+// visaPriority ranks the desired visa types. Order is preferred_visa_types as
+// the user listed it; Weights scale confidence_score for jobs whose best
+// match is that type.
+type visaPriority struct {
+	Order   []string
+	Weights map[string]float64
+}
+
+func newVisaPriority(order []string, explicit map[string]float64) visaPriority {
+	out := visaPriority{Order: append([]string{}, order...), Weights: map[string]float64{}}
+	for rank, visa := range order {
+		weight := math.Max(1-visaPriorityStep*float64(rank), minVisaPriorityWeight)
+		if value, ok := explicit[visa]; ok {
+			weight = value
+		}
+		out.Weights[visa] = math.Round(weight*100) / 100
+	}
+	return out
+}
+
+func (p visaPriority) ranked() bool {
+	return len(p.Order) > 1
+}
+
+func (p visaPriority) topWeight() float64 {
+	top := 0.0
+	for _, weight := range p.Weights {
+		top = math.Max(top, weight)
+	}
+	return top
+}
+
+// best returns the matched visa type with the highest weight, preferring the
+// earlier type on ties. A job with no matched type keeps weight 1.
+func (p visaPriority) best(matched []string) (string, float64) {
+	bestVisa, bestWeight := "", 0.0
+	for _, visa := range p.Order {
+		if slices.Contains(matched, visa) && (bestVisa == "" || p.Weights[visa] > bestWeight) {
+			bestVisa, bestWeight = visa, p.Weights[visa]
+		}
+	}
+	if bestVisa == "" || !p.ranked() {
+		return bestVisa, 1
+	}
+	return bestVisa, bestWeight
+}
+
+// strength marks matches that only cover a lower-priority visa type, so
+// "company_dataset" for an H-1B fallback reads
+// "company_dataset_secondary_visa" for a user who listed E-3 first.
+func (p visaPriority) strength(base, bestVisa string) string {
+	if !p.ranked() || bestVisa == "" || base == "weak" || p.Weights[bestVisa] >= p.topWeight() {
+		return base
+	}
+	return base + "_secondary_visa"
+}
+
+// sortByVisaPriority stable-sorts jobs by visa_priority_weight, highest
+// first, and returns how many jobs matched only a lower-priority type.
+func sortByVisaPriority(jobs []map[string]any, priority visaPriority) int {
+	if !priority.ranked() {
+		return 0
+	}
+	top := priority.topWeight()
+	secondary := 0
+	for _, job := range jobs {
+		if floatOrZero(job["visa_priority_weight"]) < top {
+			secondary++
+		}
+	}
+	if secondary > 0 {
+		slices.SortStableFunc(jobs, func(a, b map[string]any) int {
+			aWeight, bWeight := floatOrZero(a["visa_priority_weight"]), floatOrZero(b["visa_priority_weight"])
+			switch {
+			case aWeight > bWeight:
+				return -1
+			case aWeight < bWeight:
+				return 1
+			}
+			return 0
+		})
+	}
+	return secondary
+}
+
+func normalizeVisaTypeWeights(raw any) (map[string]any, error) {
+	input, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("visa_type_weights must be an object of visa type to weight")
+	}
+	out := map[string]any{}
+	for key, value := range input {
+		visa, err := normalizeVisaType(key)
+		if err != nil {
+			return nil, fmt.Errorf("visa_type_weights: %w", err)
+		}
+		number, ok := value.(float64)
+		if !ok {
+			if asInt, isInt := value.(int); isInt {
+				number, ok = float64(asInt), true
+			}
+		}
+		if !ok || math.IsNaN(number) || number <= 0 || number > 1 {
+			return nil, fmt.Errorf("visa_type_weights.%s must be a number greater than 0 and at most 1", key)
+		}
+		out[visa] = number
+	}
+	return out, nil
+}
+
+func visaTypeWeightsFromMap(raw map[string]any) map[string]float64 {
+	out := map[string]float64{}
+	for visa, value := range raw {
+		out[visa] = floatOrZero(value)
+	}
+	return out
+}
+
+// visaTypeWeightsFor reads the weights saved with set_user_preferences and
+// lays the search's own visa_type_weights over them.
+func visaTypeWeightsFor(userID string, overrides map[string]float64) map[string]float64 {
+	out := map[string]float64{}
+	if strings.TrimSpace(userID) != "" {
+		if prefs, err := loadPrefs(); err == nil {
+			out = visaTypeWeightsFromMap(asMap(asMap(prefs[userID])["visa_type_weights"]))
+		}
+	}
+	for visa, weight := range overrides {
+		out[visa] = weight
+	}
+	return out
+}
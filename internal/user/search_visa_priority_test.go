@@ -0,0 +1,78 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestVisaPriorityWeightsFollowOrderAndOverrides(t *testing.T) {
+	priority := newVisaPriority([]string{"e3_australian", "h1b", "green_card"}, map[string]float64{"green_card": 0.9})
+	if priority.Weights["e3_australian"] != 1 || priority.Weights["h1b"] != 0.85 || priority.Weights["green_card"] != 0.9 {
+		t.Fatalf("unexpected weights: %#v", priority.Weights)
+	}
+	if visa, weight := priority.best([]string{"h1b", "green_card"}); visa != "green_card" || weight != 0.9 {
+		t.Fatalf("expected the heavier green card match, got %s %v", visa, weight)
+	}
+	if got := priority.strength("company_dataset", "h1b"); got != "company_dataset_secondary_visa" {
+		t.Fatalf("expected a secondary strength, got %q", got)
+	}
+	if got := priority.strength("strong", "e3_australian"); got != "strong" {
+		t.Fatalf("expected the top type to keep its strength, got %q", got)
+	}
+	single := newVisaPriority([]string{"h1b"}, nil)
+	if visa, weight := single.best([]string{"h1b"}); visa != "h1b" || weight != 1 || single.strength("strong", visa) != "strong" {
+		t.Fatalf("expected no priority effect for a single visa type")
+	}
+	if visas, err := normalizeVisaTypeList([]string{"E-3", "H-1B", "e3"}); err != nil || !slices.Equal(visas, []string{"e3_australian", "h1b"}) {
+		t.Fatalf("expected the listed order without repeats, got %v (%v)", visas, err)
+	}
+	if _, err := normalizeVisaTypeWeights(map[string]any{"h1b": 1.5}); err == nil {
+		t.Fatalf("expected weights above 1 to be rejected")
+	}
+}
+
+func TestSearchRanksPreferredVisaMatchesFirst(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	body := "company_name,h1b,h1b1_chile,h1b1_singapore,e3_australian,green_card\nGlobex Corp,20,0,0,0,0\nWombat Pty,0,0,0,4,0\n"
+	if err := os.WriteFile(datasetPath, []byte(body), 0o644); err != nil {
+		t.Fatalf("write dataset: %v", err)
+	}
+	if _, err := SetUserPreferences(map[string]any{"user_id": "u1", "preferred_visa_types": []any{"e3", "h1b"}}); err != nil {
+		t.Fatalf("SetUserPreferences failed: %v", err)
+	}
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/globex-1/", Title: "Software Engineer", Company: "Globex Corp", Location: "New York, NY"},
+				{JobURL: "https://www.linkedin.com/jobs/view/wombat-1/", Title: "Software Engineer", Company: "Wombat Pty", Location: "New York, NY"},
+			},
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":        "u1",
+		"location":       "United States",
+		"job_title":      "Software Engineer",
+		"dataset_path":   datasetPath,
+		"results_wanted": 5,
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 2 {
+		t.Fatalf("expected both sponsors, got %#v", jobs)
+	}
+	first, second := asMap(jobs[0]), asMap(jobs[1])
+	if getString(first, "job_url") != "https://www.linkedin.com/jobs/view/wombat-1/" || getString(first, "visa_priority_match") != "e3_australian" {
+		t.Fatalf("expected the E-3 sponsor first, got %#v", first)
+	}
+	if getString(second, "visa_match_strength") != "company_dataset_secondary_visa" || floatOrZero(second["visa_priority_weight"]) != 0.85 {
+		t.Fatalf("expected the H-1B sponsor as a secondary match, got %#v", second)
+	}
+	if floatOrZero(asMap(second["confidence_breakdown"])["dataset_factor"]) != 0.85 {
+		t.Fatalf("expected the priority weight to scale the dataset terms, got %#v", second["confidence_breakdown"])
+	}
+	if intOrZero(asMap(results["stats"])["visa_priority_secondary"]) != 1 {
+		t.Fatalf("unexpected stats: %#v", results["stats"])
+	}
+}
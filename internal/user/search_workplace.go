@@ -0,0 +1,98 @@
+package user
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	workplaceRemote = "remote"
+	workplaceHybrid = "hybrid"
+	workplaceOnsite = "onsite"
+)
+
+// linkedInWorkplaceCodes maps workplace types to LinkedIn's f_WT values.
+var linkedInWorkplaceCodes = map[string]string{
+	workplaceOnsite: "1",
+	workplaceRemote: "2",
+	workplaceHybrid: "3",
+}
+
+var workplaceTypeAliases = map[string]string{
+	"remote":    workplaceRemote,
+	"hybrid":    workplaceHybrid,
+	"onsite":    workplaceOnsite,
+	"on-site":   workplaceOnsite,
+	"on_site":   workplaceOnsite,
+	"in_office": workplaceOnsite,
+	"in-office": workplaceOnsite,
+	"office":    workplaceOnsite,
+}
+
+// hybridWorkplaceRegex only matches "hybrid" used about where the work happens,
+// so "hybrid cloud" or "hybrid app" do not make a job hybrid.
+var hybridWorkplaceRegex = regexp.MustCompile(`\(hybrid\)|\bhybrid[- ](?:role|position|job|opportunity|work|working|schedule|model|arrangement|setup|environment|workplace|office|remote|on-?site)\b|\b(?:workplace|work|working|location|schedule|role|position)(?: type| model| arrangement)?(?::| is| will be)? hybrid\b`)
+
+// onsiteWorkplaceRegex matches explicit office-based wording.
+var onsiteWorkplaceRegex = regexp.MustCompile(`\bon[- ]?site\b|\bin[- ]office\b|\bin (?:the |our )?(?:\w+ )?office (?:\w+ )?days a week\b|\b(?:fully|100%) in[- ]person\b`)
+
+func normalizeWorkplaceTypes(values []string) ([]string, error) {
+	out := []string{}
+	for _, value := range values {
+		clean := strings.ToLower(strings.TrimSpace(value))
+		if clean == "" {
+			continue
+		}
+		normalized, ok := workplaceTypeAliases[clean]
+		if !ok {
+			return nil, fmt.Errorf("workplace_types entries must be one of [hybrid onsite remote]")
+		}
+		if !slices.Contains(out, normalized) {
+			out = append(out, normalized)
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+func linkedInWorkplaceFilter(workplaceTypes []string) string {
+	codes := []string{}
+	for _, workplace := range workplaceTypes {
+		if code, ok := linkedInWorkplaceCodes[workplace]; ok {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+	return strings.Join(codes, ",")
+}
+
+// classifyWorkplaceType infers remote/hybrid/onsite from listing text and
+// the listing's remote flag, which is preferred over keywords when set. It
+// returns "" without explicit evidence; unknown values pass workplace filters.
+func classifyWorkplaceType(title, location, description string, isRemote *bool) string {
+	text := strings.ToLower(strings.Join([]string{title, location, description}, " "))
+	switch {
+	case hybridWorkplaceRegex.MatchString(text) || slices.Contains(strings.FieldsFunc(strings.ToLower(location), isLocationSeparator), "hybrid"):
+		return workplaceHybrid
+	case isRemote != nil && *isRemote:
+		return workplaceRemote
+	case isRemote == nil && detectLinkedInRemote(title, location, description):
+		return workplaceRemote
+	case onsiteWorkplaceRegex.MatchString(text):
+		return workplaceOnsite
+	}
+	return ""
+}
+
+func isLocationSeparator(r rune) bool {
+	return strings.ContainsRune(" ,()/-", r)
+}
+
+func workplaceTypeAllowed(workplaceTypes []string, workplace string) bool {
+	if len(workplaceTypes) == 0 || workplace == "" {
+		return true
+	}
+	return slices.Contains(workplaceTypes, workplace)
+}
@@ -0,0 +1,113 @@
+package user
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLinkedInSearchParamsIncludesWorkplaceFilter(t *testing.T) {
+	params := linkedInSearchParams(linkedInSearchQuery{
+		JobTitle:       "Software Engineer",
+		Location:       "United States",
+		WorkplaceTypes: []string{workplaceRemote, workplaceHybrid},
+	})
+	if got := params["f_WT"]; got != "2,3" {
+		t.Fatalf("expected f_WT=2,3, got %q", got)
+	}
+	if _, ok := linkedInSearchParams(linkedInSearchQuery{JobTitle: "x"})["f_WT"]; ok {
+		t.Fatalf("expected no f_WT without workplace_types")
+	}
+	if _, err := normalizeWorkplaceTypes([]string{"moon"}); err == nil {
+		t.Fatalf("expected invalid workplace type to be rejected")
+	}
+}
+
+func TestWorkplaceTypesFilterDropsOnsiteJobs(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/remote-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "United States (Remote)"},
+				{JobURL: "https://www.linkedin.com/jobs/view/onsite-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "New York, NY"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/onsite-1/": "Join our team in our Manhattan office five days a week.",
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":         "u1",
+		"location":        "United States",
+		"job_title":       "Software Engineer",
+		"dataset_path":    datasetPath,
+		"workplace_types": []any{"Remote"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 {
+		t.Fatalf("expected only the remote job, got %#v", jobs)
+	}
+	if got := getString(asMap(jobs[0]), "workplace_type"); got != workplaceRemote {
+		t.Fatalf("expected workplace_type=remote, got %q", got)
+	}
+	if got := intOrZero(asMap(results["stats"])["workplace_filtered_out"]); got != 1 {
+		t.Fatalf("expected workplace_filtered_out=1, got %d", got)
+	}
+}
+
+func TestClassifyWorkplaceTypeNeedsExplicitEvidence(t *testing.T) {
+	cases := []struct {
+		name        string
+		location    string
+		description string
+		isRemote    *bool
+		want        string
+	}{
+		{"hybrid cloud is not a workplace", "New York, NY", "Build our hybrid cloud platform and hybrid app.", nil, ""},
+		{"hybrid cloud on a remote listing", "United States (Remote)", "Build our hybrid cloud platform.", nil, workplaceRemote},
+		{"remote flag without remote wording", "United States", "Ship features across the stack.", boolPtr(true), workplaceRemote},
+		{"remote flag false ignores keywords", "United States", "Remote sensing experience preferred.", boolPtr(false), ""},
+		{"hybrid role", "Austin, TX", "This is a hybrid role with two office days.", nil, workplaceHybrid},
+		{"hybrid location", "Austin, TX (Hybrid)", "", nil, workplaceHybrid},
+		{"onsite wording", "Austin, TX", "Work on-site with the hardware team.", nil, workplaceOnsite},
+		{"no evidence", "Austin, TX", "Ship features across the stack.", nil, ""},
+	}
+	for _, tc := range cases {
+		if got := classifyWorkplaceType("Software Engineer", tc.location, tc.description, tc.isRemote); got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestWorkplaceTypesFilterKeepsRemoteJobsWithoutRemoteWording(t *testing.T) {
+	setupUserToolPaths(t)
+	datasetPath := filepath.Join(t.TempDir(), "companies.csv")
+	writeTestDataset(t, datasetPath)
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/plain-1/", Title: "Software Engineer", Company: "Acme Inc", Location: "United States"},
+			},
+		},
+		descriptions: map[string]string{
+			"https://www.linkedin.com/jobs/view/plain-1/": "Build our hybrid cloud platform with a distributed team.",
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":         "u1",
+		"location":        "United States",
+		"job_title":       "Software Engineer",
+		"dataset_path":    datasetPath,
+		"workplace_types": []any{"remote"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 {
+		t.Fatalf("expected the f_WT-filtered job to be kept, got %#v", results["stats"])
+	}
+	if got := getString(asMap(jobs[0]), "workplace_type"); got != workplaceRemote {
+		t.Fatalf("expected workplace_type=remote from the LinkedIn filter, got %q", got)
+	}
+}
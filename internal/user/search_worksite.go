@@ -0,0 +1,90 @@
+package user
+
+import "strings"
+
+var (
+	dolWorksiteCityColumns  = []string{"WORKSITE_CITY", "WORKSITE_CITY_1", "JOB_INFO_WORK_CITY"}
+	dolWorksiteStateColumns = []string{"WORKSITE_STATE", "WORKSITE_STATE_1", "JOB_INFO_WORK_STATE"}
+)
+
+// Locality factors scale a company's dataset evidence by where it files:
+// sponsors with filings in the listing's city count fully, same-state
+// sponsors nearly so, and companies that only file elsewhere about half.
+const (
+	localityFactorCity      = 1.0
+	localityFactorState     = 0.85
+	localityFactorElsewhere = 0.5
+)
+
+// worksiteCityKey is the city_counts key, for example "austin, TX".
+func worksiteCityKey(city, state string) string {
+	city = strings.ToLower(normalizeWhitespace(city))
+	if city == "" || state == "" {
+		return ""
+	}
+	return city + ", " + state
+}
+
+func normalizeWorksiteCityKey(raw string) string {
+	city, state := parseUSLocation(raw)
+	return worksiteCityKey(city, state)
+}
+
+func (t *dolEmployerTally) addKeyed(column, key string) {
+	if key == "" {
+		return
+	}
+	if t.keyed == nil {
+		t.keyed = map[string]map[string]int{}
+	}
+	if t.keyed[column] == nil {
+		t.keyed[column] = map[string]int{}
+	}
+	t.keyed[column][key]++
+}
+
+func mergeKeyedCounts(into, from map[string]map[string]int) {
+	for column, counts := range from {
+		if into[column] == nil {
+			into[column] = map[string]int{}
+		}
+		for key, count := range counts {
+			into[column][key] += count
+		}
+	}
+}
+
+// sponsorshipLocality compares where a company files with the listing's
+// location (or the searched location when the listing has no state). It
+// returns 1 and no detail when either side lacks location data.
+func sponsorshipLocality(record companyDatasetRecord, location, fallbackLocation string) (float64, map[string]any) {
+	city, state := parseUSLocation(location)
+	if state == "" {
+		city, state = parseUSLocation(fallbackLocation)
+	}
+	if state == "" || len(record.StateCounts) == 0 {
+		return 1, nil
+	}
+	total := 0
+	for _, count := range record.StateCounts {
+		total += count
+	}
+	stateFilings := record.StateCounts[state]
+	cityFilings := record.CityCounts[worksiteCityKey(city, state)]
+	match, factor := "elsewhere", localityFactorElsewhere
+	switch {
+	case cityFilings > 0:
+		match, factor = "city", localityFactorCity
+	case stateFilings > 0:
+		match, factor = "state", localityFactorState
+	}
+	return factor, map[string]any{
+		"state":           state,
+		"city":            optionalString(city),
+		"state_filings":   stateFilings,
+		"city_filings":    cityFilings,
+		"company_filings": total,
+		"match":           match,
+		"factor":          factor,
+	}
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSponsorshipLocalityPrefersLocalSponsors(t *testing.T) {
+	record := companyDatasetRecord{
+		StateCounts: map[string]int{"TX": 90, "NY": 10},
+		CityCounts:  map[string]int{"austin, TX": 90},
+	}
+	factor, detail := sponsorshipLocality(record, "Austin, TX", "")
+	if factor != localityFactorCity || getString(detail, "match") != "city" {
+		t.Fatalf("expected city match, got %v %#v", factor, detail)
+	}
+	factor, detail = sponsorshipLocality(record, "New York, NY", "")
+	if factor != localityFactorState || intOrZero(detail["state_filings"]) != 10 {
+		t.Fatalf("expected state match, got %v %#v", factor, detail)
+	}
+	factor, detail = sponsorshipLocality(record, "Seattle, WA", "")
+	if factor != localityFactorElsewhere || getString(detail, "match") != "elsewhere" {
+		t.Fatalf("expected elsewhere match, got %v %#v", factor, detail)
+	}
+	if factor, detail = sponsorshipLocality(record, "Remote", "Texas, United States"); factor != localityFactorState {
+		t.Fatalf("expected searched location fallback, got %v %#v", factor, detail)
+	}
+	if factor, detail = sponsorshipLocality(companyDatasetRecord{}, "Austin, TX", ""); factor != 1 || detail != nil {
+		t.Fatalf("expected neutral factor without worksite data, got %v %#v", factor, detail)
+	}
+	local := scoreConfidence(confidenceFeatures{DesiredCount: 10, TotalCount: 10, Recency: 1, ApprovalRate: 1, LocationMatch: localityFactorCity, DatasetFactor: 1}, defaultRankingWeights).Score
+	remote := scoreConfidence(confidenceFeatures{DesiredCount: 10, TotalCount: 10, Recency: 1, ApprovalRate: 1, LocationMatch: localityFactorElsewhere, DatasetFactor: 1}, defaultRankingWeights).Score
+	if remote >= local {
+		t.Fatalf("expected out-of-area sponsor to score lower, got %v >= %v", remote, local)
+	}
+}
+
+func TestRunInternalDolPipelineWritesWorksiteCounts(t *testing.T) {
+	setupUserToolPaths(t)
+	dir := t.TempDir()
+	lcaPath := filepath.Join(dir, "lca.csv")
+	lcaBody := strings.Join([]string{
+		"EMPLOYER_NAME,VISA_CLASS,WORKSITE_CITY,WORKSITE_STATE",
+		"Acme Inc,H-1B,Austin,TX",
+		"Acme Inc,H-1B,AUSTIN,Texas",
+		"Acme Inc,H-1B,New York,NY",
+	}, "\n")
+	if err := os.WriteFile(lcaPath, []byte(lcaBody), 0o644); err != nil {
+		t.Fatalf("write lca: %v", err)
+	}
+	permPath := filepath.Join(dir, "perm.csv")
+	if err := os.WriteFile(permPath, []byte("EMPLOYER_NAME,WORKSITE_CITY,WORKSITE_STATE\nAcme Inc,Dallas,TX\n"), 0o644); err != nil {
+		t.Fatalf("write perm: %v", err)
+	}
+	datasetPath := filepath.Join(dir, "companies.csv")
+	result, err := RunInternalDolPipeline(map[string]any{
+		"lca_source":        lcaPath,
+		"perm_source":       permPath,
+		"dataset_path":      datasetPath,
+		"manifest_path":     filepath.Join(dir, "last_run.json"),
+		"strict_validation": false,
+	})
+	if err != nil || getString(result, "status") != "completed" {
+		t.Fatalf("RunInternalDolPipeline failed: %v %#v", err, result)
+	}
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("load generated dataset: %v", err)
+	}
+	acme := dataset.ByNormalizedCompany["acme"]
+	if acme.StateCounts["TX"] != 3 || acme.StateCounts["NY"] != 1 || acme.CityCounts["austin, TX"] != 2 || acme.CityCounts["dallas, TX"] != 1 {
+		t.Fatalf("unexpected worksite counts: %#v %#v", acme.StateCounts, acme.CityCounts)
+	}
+}
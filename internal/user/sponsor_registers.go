@@ -0,0 +1,276 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSponsorRegisterDir = "data/sponsor_registers"
+	maxRegisterPreambleRows   = 5
+)
+
+// sponsorRegisterSpec describes a government register of licensed sponsors
+// outside the US DOL disclosures. classify returns the dataset visa columns a
+// register row counts towards, or a skip reason when it counts for none. Rows
+// count once unless the register has one of CountCols (grant or position
+// counts), whose value is used instead.
+type sponsorRegisterSpec struct {
+	Name         string
+	Country      string
+	Columns      []string
+	EmployerCols []string
+	CountCols    []string
+	classify     func(table *disclosureTable, row []string) ([]string, string)
+}
+
+var sponsorRegisters = []sponsorRegisterSpec{
+	{
+		Name:         "uk_skilled_worker",
+		Country:      "United Kingdom",
+		Columns:      []string{"skilled_worker_uk"},
+		EmployerCols: []string{"Organisation Name", "Organization Name", "Organisation name"},
+		classify:     classifyUKSponsorRow,
+	},
+	{
+		Name:         "au_employer_sponsors",
+		Country:      "Australia",
+		Columns:      []string{"au_482", "au_186"},
+		EmployerCols: []string{"Sponsor Name", "Business Name", "Employer Name", "Organisation Name", "Sponsor"},
+		CountCols:    []string{"Count", "Number of visas granted", "Visas Granted", "Grants"},
+		classify:     classifyAUSponsorRow,
+	},
+	{
+		Name:         "ca_positive_lmia",
+		Country:      "Canada",
+		Columns:      []string{"ca_lmia", "ca_lmia_pr"},
+		EmployerCols: []string{"Employer", "Employer Name", "Employeur"},
+		CountCols:    []string{"Approved Positions", "Approved LMIAs", "Positions approved"},
+		classify:     classifyCALMIARow,
+	},
+}
+
+// registerVisaColumns lists every optional dataset visa column fed by a
+// sponsor register, in register order.
+func registerVisaColumns() []string {
+	columns := []string{}
+	for _, spec := range sponsorRegisters {
+		columns = append(columns, spec.Columns...)
+	}
+	return columns
+}
+
+func sponsorRegisterByName(name string) (sponsorRegisterSpec, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for _, spec := range sponsorRegisters {
+		if spec.Name == key {
+			return spec, true
+		}
+	}
+	return sponsorRegisterSpec{}, false
+}
+
+func sponsorRegisterNames() []string {
+	names := []string{}
+	for _, spec := range sponsorRegisters {
+		names = append(names, spec.Name)
+	}
+	return names
+}
+
+// classifyUKSponsorRow counts A-rated sponsors on the Skilled Worker route of
+// the Home Office "Register of worker and temporary worker licensed sponsors".
+// B-rated sponsors cannot assign new certificates of sponsorship.
+func classifyUKSponsorRow(table *disclosureTable, row []string) ([]string, string) {
+	route := strings.ToLower(table.value(row, table.pick([]string{"Route", "route"})))
+	rating := strings.ToLower(table.value(row, table.pick([]string{"Type & Rating", "Type and Rating", "Rating"})))
+	if !strings.Contains(route, "skilled worker") {
+		return nil, "other_route"
+	}
+	if strings.Contains(rating, "b rating") || strings.Contains(rating, "(b)") {
+		return nil, "b_rated"
+	}
+	return []string{"skilled_worker_uk"}, ""
+}
+
+// classifyAUSponsorRow reads Home Affairs sponsor and grant extracts: rows
+// with a subclass column count towards 482 (including the former 457 and the
+// Skills in Demand stream) or 186; lists without one, such as standard and
+// accredited business sponsor lists, count as 482 sponsors.
+func classifyAUSponsorRow(table *disclosureTable, row []string) ([]string, string) {
+	subclassCol := table.pick([]string{"Visa Subclass", "Subclass", "Visa subclass", "Program"})
+	if subclassCol == "" {
+		status := strings.ToLower(table.value(row, table.pick([]string{"Sponsor Status", "Status"})))
+		if strings.Contains(status, "cancel") || strings.Contains(status, "barred") || strings.Contains(status, "expired") {
+			return nil, "inactive_sponsor"
+		}
+		return []string{"au_482"}, ""
+	}
+	subclass := strings.ToLower(table.value(row, subclassCol))
+	switch {
+	case strings.Contains(subclass, "482"), strings.Contains(subclass, "457"), strings.Contains(subclass, "skills in demand"), strings.Contains(subclass, "temporary skill shortage"):
+		return []string{"au_482"}, ""
+	case strings.Contains(subclass, "186"), strings.Contains(subclass, "employer nomination"):
+		return []string{"au_186"}, ""
+	}
+	return nil, "other_subclass"
+}
+
+// classifyCALMIARow reads ESDC positive LMIA employer lists. "Permanent
+// Resident only" stream approvals support permanent residence applications;
+// every other stream (high/low wage, Global Talent, agriculture, caregivers)
+// supports an employer-specific work permit.
+func classifyCALMIARow(table *disclosureTable, row []string) ([]string, string) {
+	stream := strings.ToLower(table.value(row, table.pick([]string{"Program Stream", "Stream", "Program stream"})))
+	if strings.Contains(stream, "permanent resident") || strings.Contains(stream, "pr only") {
+		return []string{"ca_lmia_pr"}, ""
+	}
+	return []string{"ca_lmia"}, ""
+}
+
+// sponsorRegisterRows tallies register rows per normalized employer and
+// builds companies.csv-shaped rows: the US visa columns stay at zero so the
+// file loads as a regular sponsor dataset.
+func sponsorRegisterRows(ctx context.Context, spec sponsorRegisterSpec, sourcePath string) ([]string, [][]string, map[string]any, error) {
+	type employer struct {
+		name   string
+		counts map[string]int
+	}
+	employers := map[string]*employer{}
+	employerCol, countCol := "", ""
+	rowsRead, rowsMatched := 0, 0
+	skipped := map[string]int{}
+	// Some registers (ESDC lists) open with title rows, so the header is the
+	// first row that names an employer column.
+	var header *disclosureTable
+	preamble := 0
+	useHeader := func(table *disclosureTable) error {
+		if employerCol = table.pick(spec.EmployerCols); employerCol != "" {
+			header, countCol = table, table.pick(spec.CountCols)
+			return nil
+		}
+		if preamble++; preamble > maxRegisterPreambleRows {
+			return fmt.Errorf("%s register is missing an employer column (expected one of %s)", spec.Name, strings.Join(spec.EmployerCols, ", "))
+		}
+		return nil
+	}
+	err := streamDisclosureTable(ctx, sourcePath, useHeader, func(_ *disclosureTable, row []string) error {
+		if header == nil {
+			return useHeader(newDisclosureTable(row))
+		}
+		table := header
+		rowsRead++
+		name := table.value(row, employerCol)
+		key := normalizeCompanyName(name)
+		if key == "" {
+			skipped["blank_employer"]++
+			return nil
+		}
+		visas, reason := spec.classify(table, row)
+		if len(visas) == 0 {
+			skipped[reason]++
+			return nil
+		}
+		weight := 1
+		if countCol != "" {
+			if weight = parseIntCSV(table.value(row, countCol)); weight <= 0 {
+				skipped["zero_count"]++
+				return nil
+			}
+		}
+		rowsMatched++
+		entry := employers[key]
+		if entry == nil {
+			entry = &employer{name: name, counts: map[string]int{}}
+			employers[key] = entry
+		}
+		for _, visa := range visas {
+			entry.counts[visa] += weight
+		}
+		return nil
+	})
+	if err == nil && header == nil {
+		err = fmt.Errorf("%s register is missing an employer column (expected one of %s)", spec.Name, strings.Join(spec.EmployerCols, ", "))
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	columns := append([]string{"company_tier", "company_name"}, datasetVisaColumns...)
+	columns = append(columns, spec.Columns...)
+	rows := make([][]string, 0, len(employers))
+	for _, entry := range employers {
+		row := []string{spec.Name, entry.name}
+		for range datasetVisaColumns {
+			row = append(row, "0")
+		}
+		for _, column := range spec.Columns {
+			row = append(row, strconv.Itoa(entry.counts[column]))
+		}
+		rows = append(rows, row)
+	}
+	slices.SortFunc(rows, func(a, b []string) int { return strings.Compare(strings.ToLower(a[1]), strings.ToLower(b[1])) })
+	summary := map[string]any{
+		"employer_col":    employerCol,
+		"count_col":       optionalString(countCol),
+		"rows_read":       rowsRead,
+		"rows_matched":    rowsMatched,
+		"rows_skipped":    skipped,
+		"employers_found": len(rows),
+	}
+	return columns, rows, summary, nil
+}
+
+func ImportSponsorRegister(args map[string]any) (map[string]any, error) {
+	spec, ok := sponsorRegisterByName(getString(args, "register"))
+	if !ok {
+		return nil, fmt.Errorf("register must be one of: %s", strings.Join(sponsorRegisterNames(), ", "))
+	}
+	source := strings.TrimSpace(getString(args, "source"))
+	if source == "" {
+		return nil, fmt.Errorf("source is required (a local CSV/XLSX path or download URL of the %s register)", spec.Country)
+	}
+	if !supportedDisclosureSource(source) {
+		return nil, fmt.Errorf("source must be a .csv or .xlsx file")
+	}
+	datasetPath := strings.TrimSpace(getString(args, "dataset_path"))
+	if datasetPath == "" {
+		datasetPath = filepath.Join(defaultSponsorRegisterDir, spec.Name+".csv")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(dolPipelineTimeoutSeconds())*time.Second)
+	defer cancel()
+	localPath, err := downloadDOLSource(ctx, source, envOrDefault("VISA_DOL_RAW_DIR", defaultDOLRawDir))
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s register: %w", spec.Name, err)
+	}
+	header, rows, summary, err := sponsorRegisterRows(ctx, spec, localPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no sponsors matched in %s; check that source is the %s register", filepath.Base(localPath), spec.Name)
+	}
+	if err := writeCSVAtomic(datasetPath, ".sponsor-register-*.csv", header, rows); err != nil {
+		return nil, err
+	}
+	clearDatasetCache(datasetPath)
+
+	return map[string]any{
+		"register":      spec.Name,
+		"country":       spec.Country,
+		"source":        source,
+		"visa_types":    spec.Columns,
+		"dataset_path":  datasetPath,
+		"rows_written":  len(rows),
+		"import":        summary,
+		"imported_at":   utcNowISO(),
+		"next_step":     fmt.Sprintf("Search with dataset_path=%s and preferred_visa_types=%s.", datasetPath, strings.Join(spec.Columns, ",")),
+		"data_boundary": "Register listings show an employer holds a sponsor licence; they carry no per-year filing counts.",
+	}, nil
+}
@@ -0,0 +1,160 @@
+package user
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportSponsorRegisterUKFeedsVisaSearch(t *testing.T) {
+	setupUserToolPaths(t)
+	dir := t.TempDir()
+	sourcePath := filepath.Join(dir, "worker_and_temporary_worker.csv")
+	body := strings.Join([]string{
+		"\ufeffOrganisation Name,Town/City,County,Type & Rating,Route",
+		"Acme UK Ltd,London,,Worker (A rating),Skilled Worker",
+		"Acme UK Ltd,London,,Worker (A rating),Global Business Mobility: Senior or Specialist Worker",
+		"Beta Foods Ltd,Leeds,West Yorkshire,Worker (B rating),Skilled Worker",
+		"Gamma Care Ltd,Bristol,,Worker (A (SME+)),Skilled Worker",
+	}, "\n")
+	if err := os.WriteFile(sourcePath, []byte(body), 0o644); err != nil {
+		t.Fatalf("write register: %v", err)
+	}
+	datasetPath := filepath.Join(dir, "uk.csv")
+	result, err := ImportSponsorRegister(map[string]any{"register": "uk_skilled_worker", "source": sourcePath, "dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("ImportSponsorRegister failed: %v", err)
+	}
+	summary := asMap(result["import"])
+	skipped := summary["rows_skipped"].(map[string]int)
+	if intOrZero(result["rows_written"]) != 2 || skipped["b_rated"] != 1 || skipped["other_route"] != 1 {
+		t.Fatalf("unexpected import result: %#v", result)
+	}
+
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("load imported dataset: %v", err)
+	}
+	acme, ok := dataset.lookup("Acme UK")
+	if !ok || desiredVisaCount(acme, []string{"skilled_worker_uk"}) != 1 || acme.TotalVisas != 1 {
+		t.Fatalf("unexpected imported record: %#v", acme)
+	}
+
+	client := &fakeLinkedInClient{
+		pages: map[int][]linkedInJob{
+			0: {
+				{JobURL: "https://www.linkedin.com/jobs/view/acme-uk-1/", Title: "Software Engineer", Company: "Acme UK Ltd", Location: "London, England, United Kingdom"},
+				{JobURL: "https://www.linkedin.com/jobs/view/beta-1/", Title: "Software Engineer", Company: "Beta Foods Ltd", Location: "Leeds, England, United Kingdom"},
+			},
+		},
+	}
+	results := runFakeVisaSearch(t, client, map[string]any{
+		"user_id":              "u1",
+		"location":             "London, United Kingdom",
+		"job_title":            "Software Engineer",
+		"dataset_path":         datasetPath,
+		"results_wanted":       5,
+		"preferred_visa_types": []any{"UK Skilled Worker"},
+	})
+	jobs := listOrEmpty(results["jobs"])
+	if len(jobs) != 1 || getString(asMap(jobs[0]), "company") != "Acme UK Ltd" {
+		t.Fatalf("expected only the licensed sponsor, got %#v", jobs)
+	}
+	if sponsored := getStringList(asMap(jobs[0]), "visas_sponsored"); len(sponsored) != 1 || sponsored[0] != "UK Skilled Worker" {
+		t.Fatalf("unexpected visas_sponsored: %#v", sponsored)
+	}
+
+	if _, err := ImportSponsorRegister(map[string]any{"register": "mars", "source": sourcePath}); err == nil {
+		t.Fatalf("expected error for unknown register")
+	}
+}
+
+func TestImportSponsorRegisterAustralia(t *testing.T) {
+	setupUserToolPaths(t)
+	dir := t.TempDir()
+	grantsPath := filepath.Join(dir, "au_grants.csv")
+	grants := strings.Join([]string{
+		"Sponsor Name,Visa Subclass,Count",
+		"Koala Software Pty Ltd,482 Temporary Skill Shortage,12",
+		"Koala Software Pty Ltd,186 Employer Nomination Scheme,3",
+		"Koala Software Pty Ltd,400 Temporary Work (Short Stay),4",
+		"Wombat Mining Pty Ltd,457,0",
+	}, "\n")
+	if err := os.WriteFile(grantsPath, []byte(grants), 0o644); err != nil {
+		t.Fatalf("write grants: %v", err)
+	}
+	datasetPath := filepath.Join(dir, "au.csv")
+	result, err := ImportSponsorRegister(map[string]any{"register": "au_employer_sponsors", "source": grantsPath, "dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("ImportSponsorRegister failed: %v", err)
+	}
+	skipped := asMap(result["import"])["rows_skipped"].(map[string]int)
+	if intOrZero(result["rows_written"]) != 1 || skipped["other_subclass"] != 1 || skipped["zero_count"] != 1 {
+		t.Fatalf("unexpected import result: %#v", result)
+	}
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("load imported dataset: %v", err)
+	}
+	koala, _ := dataset.lookup("Koala Software")
+	counts := visaCountsFromRecord(koala)
+	if counts["au_482"] != 12 || counts["au_186"] != 3 || desiredVisaCount(koala, []string{"au_186"}) != 3 {
+		t.Fatalf("unexpected Australian counts: %#v", counts)
+	}
+	if visas, err := normalizeVisaTypeList([]string{"Subclass 482", "ENS"}); err != nil || len(visas) != 2 {
+		t.Fatalf("unexpected visa type aliases: %#v %v", visas, err)
+	}
+
+	sponsorsPath := filepath.Join(dir, "au_sponsors.csv")
+	sponsors := "Business Name,Sponsor Status\nKoala Software Pty Ltd,Approved\nEmu Labs Pty Ltd,Cancelled\n"
+	if err := os.WriteFile(sponsorsPath, []byte(sponsors), 0o644); err != nil {
+		t.Fatalf("write sponsors: %v", err)
+	}
+	listed, err := ImportSponsorRegister(map[string]any{"register": "au_employer_sponsors", "source": sponsorsPath, "dataset_path": filepath.Join(dir, "au_list.csv")})
+	if err != nil || intOrZero(listed["rows_written"]) != 1 {
+		t.Fatalf("unexpected sponsor list import: %#v %v", listed, err)
+	}
+}
+
+func TestImportSponsorRegisterCanadaLMIA(t *testing.T) {
+	setupUserToolPaths(t)
+	dir := t.TempDir()
+	sourcePath := filepath.Join(dir, "tfwp_2024q4_pos_en.csv")
+	body := strings.Join([]string{
+		"Positive Labour Market Impact Assessment (LMIA) Employers List,,,,,,,",
+		"Province/Territory,Program Stream,Employer,Address,Occupation,Incorporate Status,Approved LMIAs,Approved Positions",
+		"Ontario,Global Talent Stream,Maple Data Inc.,\"Toronto, ON M5V 2T6\",21231-Software engineers and designers,Yes,2,5",
+		"Ontario,Permanent Resident only,Maple Data Inc.,\"Toronto, ON M5V 2T6\",21231-Software engineers and designers,Yes,1,1",
+		"British Columbia,High Wage,Cedar Robotics Ltd,\"Vancouver, BC V6B 1A1\",21301-Mechanical engineers,Yes,1,2",
+		"\"Note: positions approved under the TFWP\",,,,,,,",
+	}, "\n")
+	if err := os.WriteFile(sourcePath, []byte(body), 0o644); err != nil {
+		t.Fatalf("write lmia list: %v", err)
+	}
+	datasetPath := filepath.Join(dir, "ca.csv")
+	result, err := ImportSponsorRegister(map[string]any{"register": "ca_positive_lmia", "source": sourcePath, "dataset_path": datasetPath})
+	if err != nil {
+		t.Fatalf("ImportSponsorRegister failed: %v", err)
+	}
+	summary := asMap(result["import"])
+	if intOrZero(result["rows_written"]) != 2 || getString(summary, "count_col") != "Approved Positions" {
+		t.Fatalf("unexpected import result: %#v", result)
+	}
+	dataset, err := loadCompanyDataset(datasetPath)
+	if err != nil {
+		t.Fatalf("load imported dataset: %v", err)
+	}
+	maple, _ := dataset.lookup("Maple Data")
+	if counts := visaCountsFromRecord(maple); counts["ca_lmia"] != 5 || counts["ca_lmia_pr"] != 1 || counts["total_visas"] != 6 {
+		t.Fatalf("unexpected Canadian counts: %#v", counts)
+	}
+
+	missingHeader := filepath.Join(dir, "other.csv")
+	if err := os.WriteFile(missingHeader, []byte("Name,Value\nA,1\n"), 0o644); err != nil {
+		t.Fatalf("write other: %v", err)
+	}
+	if _, err := ImportSponsorRegister(map[string]any{"register": "ca_positive_lmia", "source": missingHeader, "dataset_path": filepath.Join(dir, "x.csv")}); err == nil {
+		t.Fatalf("expected error when no employer column is present")
+	}
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -66,6 +67,64 @@ func saveJSONMap(path string, data map[string]any) error {
 	return os.WriteFile(path, raw, 0o644)
 }
 
+// saveSecretJSONMap writes data to a 0600 temp file in the target's directory
+// and renames it into place, so the secret is never readable by other users.
+func saveSecretJSONMap(path string, data map[string]any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".secret-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// writeCSVAtomic writes header and rows to a temp file in the target's
+// directory and renames it into place.
+func writeCSVAtomic(target, tempPattern string, header []string, rows [][]string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), tempPattern)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	writer := csv.NewWriter(tmp)
+	if err := writer.Write(header); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
 func cloneOrEmptyMap(value map[string]any) map[string]any {
 	if value == nil {
 		return map[string]any{}
@@ -0,0 +1,253 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+)
+
+const nonLegalDisclaimer = "Guidance is informational only and not legal advice."
+
+// visaTypeInfo is a plain-language summary of one supported visa type. The
+// summaries are general and can lag policy changes, so get_visa_type_info
+// always returns them with the non-legal disclaimer.
+type visaTypeInfo struct {
+	VisaType            string
+	Country             string
+	Category            string
+	WhoQualifies        []string
+	EmployerObligations []string
+	TypicalTimeline     string
+	SubjectToCap        bool
+	CapSummary          string
+	SearchCoverage      string
+}
+
+func (i visaTypeInfo) toMap() map[string]any {
+	return map[string]any{
+		"visa_type":            i.VisaType,
+		"label":                visaTypeLabels[i.VisaType],
+		"country":              i.Country,
+		"category":             i.Category,
+		"who_qualifies":        i.WhoQualifies,
+		"employer_obligations": i.EmployerObligations,
+		"typical_timeline":     i.TypicalTimeline,
+		"subject_to_cap":       i.SubjectToCap,
+		"cap_summary":          i.CapSummary,
+		"search_coverage":      i.SearchCoverage,
+	}
+}
+
+const (
+	coverageDataset   = "Matched from DOL filing counts in companies.csv plus listing language."
+	coverageRegister  = "Matched from the imported government sponsor register plus listing language."
+	coverageHeuristic = "No employer filing data exists for this type; matched from listing language and the heuristics reported in visa_heuristic_flags."
+)
+
+var visaTypeInfos = []visaTypeInfo{
+	{
+		VisaType: "h1b",
+		Country:  "US",
+		Category: "temporary_work",
+		WhoQualifies: []string{
+			"Job is a specialty occupation that normally requires at least a bachelor's degree in a related field.",
+			"Candidate holds that degree or its equivalent in education and experience.",
+		},
+		EmployerObligations: []string{
+			"Certify a Labor Condition Application (LCA) with DOL and pay at least the prevailing or actual wage, whichever is higher.",
+			"File Form I-129 with USCIS and pay the petition fees.",
+			"Pay reasonable return transportation if it ends the employment early.",
+		},
+		TypicalTimeline: "Cap cases: lottery registration in March, petitions filed from April 1, earliest start October 1. Transfers and cap-exempt petitions can be filed any time; premium processing gives a decision in about 15 business days. Initial stay up to 3 years, extendable to 6.",
+		SubjectToCap:    true,
+		CapSummary:      "65,000 new visas a year plus 20,000 for US master's graduates, allocated by lottery. Universities, their affiliated nonprofits, and nonprofit or government research organizations are cap-exempt.",
+		SearchCoverage:  coverageDataset,
+	},
+	{
+		VisaType:            "h1b1_chile",
+		Country:             "US",
+		Category:            "temporary_work",
+		WhoQualifies:        []string{"Chilean citizen.", "Specialty occupation job and a matching degree, as for H-1B."},
+		EmployerObligations: []string{"Certify an LCA with DOL; no USCIS petition is needed when the candidate applies at a US consulate."},
+		TypicalTimeline:     "Consular processing usually takes weeks rather than months. Granted in one-year periods that can be renewed.",
+		SubjectToCap:        true,
+		CapSummary:          "Separate annual quota of 1,400 that has not been filled in practice, so there is no lottery.",
+		SearchCoverage:      coverageDataset,
+	},
+	{
+		VisaType:            "h1b1_singapore",
+		Country:             "US",
+		Category:            "temporary_work",
+		WhoQualifies:        []string{"Singaporean citizen.", "Specialty occupation job and a matching degree, as for H-1B."},
+		EmployerObligations: []string{"Certify an LCA with DOL; no USCIS petition is needed when the candidate applies at a US consulate."},
+		TypicalTimeline:     "Consular processing usually takes weeks rather than months. Granted in one-year periods that can be renewed.",
+		SubjectToCap:        true,
+		CapSummary:          "Separate annual quota of 5,400 that has not been filled in practice, so there is no lottery.",
+		SearchCoverage:      coverageDataset,
+	},
+	{
+		VisaType:            "e3_australian",
+		Country:             "US",
+		Category:            "temporary_work",
+		WhoQualifies:        []string{"Australian citizen.", "Specialty occupation job and a matching degree, as for H-1B."},
+		EmployerObligations: []string{"Certify an LCA with DOL and pay the required wage; the candidate applies at a US consulate without a USCIS petition."},
+		TypicalTimeline:     "Usually a few weeks from LCA certification to visa. Granted for up to 2 years and renewable indefinitely; spouses can work.",
+		SubjectToCap:        true,
+		CapSummary:          "Annual quota of 10,500 that has not been filled in practice, so there is no lottery.",
+		SearchCoverage:      coverageDataset,
+	},
+	{
+		VisaType: "green_card",
+		Country:  "US",
+		Category: "permanent_residence",
+		WhoQualifies: []string{
+			"EB-2: advanced degree, or exceptional ability.",
+			"EB-3: bachelor's degree, or skilled work with at least 2 years of experience.",
+		},
+		EmployerObligations: []string{
+			"Obtain a prevailing wage determination and run a supervised recruitment to show no qualified US worker is available.",
+			"File the PERM labor certification with DOL, then Form I-140 with USCIS.",
+			"Pay the PERM costs; they cannot be passed on to the candidate.",
+		},
+		TypicalTimeline: "PERM alone often takes more than a year; I-140 follows, and the final step (adjustment of status or consular processing) waits for the priority date to become current, which can take many years for India and China.",
+		SubjectToCap:    true,
+		CapSummary:      "Annual limits per employment-based category plus a 7% per-country limit create backlogs for some countries.",
+		SearchCoverage:  coverageDataset,
+	},
+	{
+		VisaType:            "skilled_worker_uk",
+		Country:             "UK",
+		Category:            "temporary_work",
+		WhoQualifies:        []string{"Job offer in an eligible occupation from a Home Office licensed sponsor.", "Salary at or above the applicable threshold and English at the required level."},
+		EmployerObligations: []string{"Hold a sponsor licence and assign a Certificate of Sponsorship.", "Pay the Immigration Skills Charge and meet sponsor reporting duties."},
+		TypicalTimeline:     "Decisions usually arrive within weeks of applying. Can lead to settlement (indefinite leave to remain) after the qualifying period.",
+		SubjectToCap:        false,
+		CapSummary:          "No annual cap.",
+		SearchCoverage:      coverageRegister,
+	},
+	{
+		VisaType:            "au_482",
+		Country:             "AU",
+		Category:            "temporary_work",
+		WhoQualifies:        []string{"Nominated occupation and salary meet the stream's requirements.", "Relevant work experience and any required skills assessment or English test."},
+		EmployerObligations: []string{"Be an approved sponsor and lodge a nomination.", "Pay the Skilling Australians Fund levy and at least the market salary rate."},
+		TypicalTimeline:     "Processing usually takes weeks to a few months. Stays of up to 4 years, and can lead to employer-sponsored permanent residence.",
+		SubjectToCap:        false,
+		CapSummary:          "No annual cap.",
+		SearchCoverage:      coverageRegister,
+	},
+	{
+		VisaType:            "au_186",
+		Country:             "AU",
+		Category:            "permanent_residence",
+		WhoQualifies:        []string{"Usually 2 or more years working for the sponsor on a 482 visa (Temporary Residence Transition stream).", "Or a Direct Entry nomination with a positive skills assessment and relevant experience."},
+		EmployerObligations: []string{"Nominate the position and pay the Skilling Australians Fund levy.", "Offer a genuine full-time position at the market salary rate."},
+		TypicalTimeline:     "Processing often takes several months to more than a year.",
+		SubjectToCap:        false,
+		CapSummary:          "No per-employer cap; numbers follow the annual migration program planning levels.",
+		SearchCoverage:      coverageRegister,
+	},
+	{
+		VisaType:            "ca_lmia",
+		Country:             "CA",
+		Category:            "temporary_work",
+		WhoQualifies:        []string{"Job offer backed by a positive Labour Market Impact Assessment (LMIA).", "Qualifications the job requires."},
+		EmployerObligations: []string{"Advertise the job and show no Canadian worker is available.", "Apply for the LMIA with ESDC, pay the fee, and pay the prevailing wage."},
+		TypicalTimeline:     "LMIA processing varies by stream, from about two weeks (Global Talent Stream) to several months; the work permit application follows.",
+		SubjectToCap:        false,
+		CapSummary:          "No annual cap, though some low-wage streams limit the share of temporary foreign workers.",
+		SearchCoverage:      coverageRegister,
+	},
+	{
+		VisaType:            "ca_lmia_pr",
+		Country:             "CA",
+		Category:            "permanent_residence",
+		WhoQualifies:        []string{"Eligible for an Express Entry program or a provincial nominee program.", "Skilled work experience, language test results, and educational credentials."},
+		EmployerObligations: []string{"Provide a qualifying job offer or support a provincial nomination when the route needs one."},
+		TypicalTimeline:     "Express Entry applications are usually processed within about 6 months of an invitation to apply.",
+		SubjectToCap:        false,
+		CapSummary:          "No employer cap; invitations follow draw sizes set under the annual immigration levels plan, and whether a job offer earns points depends on current IRCC rules.",
+		SearchCoverage:      coverageRegister,
+	},
+	{
+		VisaType:            "tn",
+		Country:             "US",
+		Category:            "temporary_work",
+		WhoQualifies:        []string{"Canadian or Mexican citizen.", "Job in a USMCA profession and the credentials that profession requires."},
+		EmployerObligations: []string{"Provide an offer letter describing the role, duration, and credentials; no LCA or lottery."},
+		TypicalTimeline:     "Canadians can apply at the border or a preclearance port; Mexicans need a consular visa first. Granted in periods of up to 3 years and renewable.",
+		SubjectToCap:        false,
+		CapSummary:          "No annual cap.",
+		SearchCoverage:      coverageHeuristic,
+	},
+	{
+		VisaType:            "o1",
+		Country:             "US",
+		Category:            "temporary_work",
+		WhoQualifies:        []string{"Extraordinary ability shown by sustained national or international acclaim, such as awards, publications, or judging others' work."},
+		EmployerObligations: []string{"A US employer or agent files Form I-129 with evidence and an advisory opinion from a peer group."},
+		TypicalTimeline:     "Premium processing gives a decision in about 15 business days; gathering the evidence usually takes longer. Initial stay up to 3 years with 1-year extensions.",
+		SubjectToCap:        false,
+		CapSummary:          "No annual cap.",
+		SearchCoverage:      coverageHeuristic,
+	},
+	{
+		VisaType:            "f1_opt",
+		Country:             "US",
+		Category:            "student_work_authorization",
+		WhoQualifies:        []string{"F-1 student: CPT during the program when the school authorizes it, or up to 12 months of post-completion OPT in the field of study."},
+		EmployerObligations: []string{"None for OPT or CPT; the student holds the work authorization. Employers that plan to keep the hire usually need to sponsor H-1B later."},
+		TypicalTimeline:     "OPT can be requested up to 90 days before the program ends; the work permit (EAD) often takes a few months to arrive.",
+		SubjectToCap:        false,
+		CapSummary:          "No cap.",
+		SearchCoverage:      coverageHeuristic,
+	},
+	{
+		VisaType:            "stem_opt",
+		Country:             "US",
+		Category:            "student_work_authorization",
+		WhoQualifies:        []string{"F-1 student on OPT with a degree in a designated STEM field."},
+		EmployerObligations: []string{"Be enrolled in E-Verify.", "Sign and follow the Form I-983 training plan and report changes to the school."},
+		TypicalTimeline:     "A 24-month extension requested before the initial OPT ends; employment can continue while the extension is pending.",
+		SubjectToCap:        false,
+		CapSummary:          "No cap.",
+		SearchCoverage:      coverageHeuristic,
+	},
+	{
+		VisaType:            "l1",
+		Country:             "US",
+		Category:            "temporary_work",
+		WhoQualifies:        []string{"At least one continuous year in the last three working abroad for a related company.", "L-1A: manager or executive; L-1B: specialized knowledge."},
+		EmployerObligations: []string{"A qualifying relationship between the US and foreign entities, and a petition on Form I-129 or under a blanket L approval."},
+		TypicalTimeline:     "Premium processing is available. L-1A stays up to 7 years in total, L-1B up to 5.",
+		SubjectToCap:        false,
+		CapSummary:          "No annual cap.",
+		SearchCoverage:      coverageHeuristic,
+	},
+}
+
+func GetVisaTypeInfo(args map[string]any) (map[string]any, error) {
+	requested := strings.TrimSpace(getString(args, "visa_type"))
+	wanted := ""
+	if requested != "" {
+		normalized, err := normalizeVisaType(requested)
+		if err != nil {
+			return nil, err
+		}
+		wanted = normalized
+	}
+	out := []any{}
+	for _, info := range visaTypeInfos {
+		if wanted == "" || info.VisaType == wanted {
+			out = append(out, info.toMap())
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no summary is available for visa_type '%s'", requested)
+	}
+	return map[string]any{
+		"visa_types":           out,
+		"count":                len(out),
+		"non_legal_disclaimer": nonLegalDisclaimer,
+		"verify_with":          "Confirm current rules, fees, and processing times with the government agency or an immigration attorney before acting.",
+	}, nil
+}
@@ -0,0 +1,40 @@
+package user
+
+import "testing"
+
+func TestGetVisaTypeInfoCoversEverySupportedType(t *testing.T) {
+	result, err := GetVisaTypeInfo(map[string]any{})
+	if err != nil {
+		t.Fatalf("GetVisaTypeInfo failed: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, raw := range listOrEmpty(result["visa_types"]) {
+		info := asMap(raw)
+		if getString(info, "label") == "" || getString(info, "cap_summary") == "" || len(getStringList(info, "who_qualifies")) == 0 {
+			t.Fatalf("incomplete summary: %#v", info)
+		}
+		seen[getString(info, "visa_type")] = true
+	}
+	for visa := range visaTypeLabels {
+		if !seen[visa] {
+			t.Fatalf("missing summary for %s", visa)
+		}
+	}
+	if result["non_legal_disclaimer"] != nonLegalDisclaimer {
+		t.Fatalf("expected the disclaimer, got %#v", result["non_legal_disclaimer"])
+	}
+}
+
+func TestGetVisaTypeInfoNormalizesAliases(t *testing.T) {
+	result, err := GetVisaTypeInfo(map[string]any{"visa_type": "E-3"})
+	if err != nil {
+		t.Fatalf("GetVisaTypeInfo failed: %v", err)
+	}
+	infos := listOrEmpty(result["visa_types"])
+	if len(infos) != 1 || getString(asMap(infos[0]), "visa_type") != "e3_australian" || asMap(infos[0])["subject_to_cap"] != true {
+		t.Fatalf("expected the E-3 summary, got %#v", infos)
+	}
+	if _, err := GetVisaTypeInfo(map[string]any{"visa_type": "j1"}); err == nil {
+		t.Fatalf("expected an unsupported visa type to fail")
+	}
+}